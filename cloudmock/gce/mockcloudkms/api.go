@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockcloudkms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	option "google.golang.org/api/option"
+	"k8s.io/klog/v2"
+)
+
+// mockCloudKMSService represents a mocked cloudkms client.
+type mockCloudKMSService struct {
+	svc *cloudkms.Service
+
+	cryptoKeys cryptoKeys
+}
+
+// New creates a new mock cloudkms client.
+func New() *cloudkms.Service {
+	ctx := context.Background()
+
+	s := &mockCloudKMSService{}
+
+	s.cryptoKeys.Init()
+
+	httpClient := &http.Client{Transport: s}
+	svc, err := cloudkms.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		klog.Fatalf("failed to build mock cloudkms service: %v", err)
+	}
+	s.svc = svc
+	return svc
+}
+
+func (s *mockCloudKMSService) RoundTrip(request *http.Request) (*http.Response, error) {
+	url := request.URL
+	if url.Host != "cloudkms.googleapis.com" {
+		return nil, fmt.Errorf("unexpected host in request %#v", request)
+	}
+
+	path := strings.TrimPrefix(url.Path, "/")
+	if strings.HasPrefix(path, "v1/") {
+		resource, verb, _ := strings.Cut(strings.TrimPrefix(path, "v1/"), ":")
+		if strings.Contains(resource, "/cryptoKeys/") {
+			if verb == "getIamPolicy" && request.Method == "GET" {
+				return s.cryptoKeys.getIAMPolicy(resource, request)
+			}
+			if verb == "setIamPolicy" && request.Method == "POST" {
+				return s.cryptoKeys.setIAMPolicy(resource, request)
+			}
+		}
+	}
+
+	klog.Warningf("request: %s %s %#v", request.Method, request.URL, request)
+	return nil, fmt.Errorf("unhandled request %#v", request)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mockcloudkms
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	"k8s.io/kops/cloudmock/gce/gcphttp"
+)
+
+type cryptoKeys struct {
+	mutex sync.Mutex
+
+	policies map[string]*cloudkms.Policy
+}
+
+func (s *cryptoKeys) Init() {
+	s.policies = make(map[string]*cloudkms.Policy)
+}
+
+func (s *cryptoKeys) getIAMPolicy(cryptoKey string, request *http.Request) (*http.Response, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	policy := s.policies[cryptoKey]
+	if policy == nil {
+		policy = &cloudkms.Policy{}
+	}
+
+	return gcphttp.OKResponse(policy)
+}
+
+func (s *cryptoKeys) setIAMPolicy(cryptoKey string, request *http.Request) (*http.Response, error) {
+	b, err := io.ReadAll(request.Body)
+	if err != nil {
+		return gcphttp.ErrorBadRequest("")
+	}
+
+	req := &cloudkms.SetIamPolicyRequest{}
+	if err := json.Unmarshal(b, &req); err != nil {
+		return gcphttp.ErrorBadRequest("")
+	}
+	if req.Policy == nil {
+		return gcphttp.ErrorBadRequest("")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// TODO: etag
+
+	policy := req.Policy
+	s.policies[cryptoKey] = policy
+
+	return gcphttp.OKResponse(policy)
+}
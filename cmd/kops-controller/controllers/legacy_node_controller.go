@@ -37,6 +37,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+// cacheTTL is how long the cluster and instance groups are read from the cache before they are revalidated.
+// Revalidation only downloads files that have changed, on state stores that support conditional reads.
+const cacheTTL = time.Minute
+
 // NewLegacyNodeReconciler is the constructor for a LegacyNodeReconciler
 func NewLegacyNodeReconciler(mgr manager.Manager, vfsContext *vfs.VFSContext, configPath string, identifier nodeidentity.LegacyIdentifier) (*LegacyNodeReconciler, error) {
 	r := &LegacyNodeReconciler{
@@ -46,6 +50,10 @@ func NewLegacyNodeReconciler(mgr manager.Manager, vfsContext *vfs.VFSContext, co
 		cache:      vfs.NewCache(),
 	}
 
+	if err := registerVFSCacheMetrics("legacy_node_controller", r.cache); err != nil {
+		return nil, fmt.Errorf("error registering cache metrics: %w", err)
+	}
+
 	coreClient, err := corev1client.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		return nil, fmt.Errorf("error building corev1 client: %v", err)
@@ -211,7 +219,7 @@ func (r *LegacyNodeReconciler) getInstanceGroupForNode(ctx context.Context, node
 
 // loadCluster loads a api.Cluster object from a vfs.Path
 func (r *LegacyNodeReconciler) loadCluster(p vfs.Path) (*api.Cluster, error) {
-	ttl := time.Hour
+	ttl := cacheTTL
 
 	b, err := r.cache.Read(p, ttl)
 	if err != nil {
@@ -232,7 +240,7 @@ func (r *LegacyNodeReconciler) loadCluster(p vfs.Path) (*api.Cluster, error) {
 func (r *LegacyNodeReconciler) loadNamedInstanceGroup(name string) (*api.InstanceGroup, error) {
 	p := r.configBase.Join("instancegroup", name)
 
-	ttl := time.Hour
+	ttl := cacheTTL
 	b, err := r.cache.Read(p, ttl)
 	if err != nil {
 		return nil, fmt.Errorf("error loading InstanceGroup %q: %v", p, err)
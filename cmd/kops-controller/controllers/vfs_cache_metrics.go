@@ -0,0 +1,62 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// registerVFSCacheMetrics exposes the hit, revalidation, miss and eviction counts of a vfs.Cache
+// on the controller's metrics endpoint, labelled with the name of the cache.
+func registerVFSCacheMetrics(name string, cache *vfs.Cache) error {
+	labels := prometheus.Labels{"cache": name}
+	counter := func(metric string, help string, value func(stats vfs.CacheStats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   "kops_controller",
+			Subsystem:   "vfs_cache",
+			Name:        metric,
+			Help:        help,
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(value(cache.Stats()))
+		})
+	}
+
+	collectors := []prometheus.Collector{
+		counter("hits_total", "Reads of the state store served from the cache.", func(stats vfs.CacheStats) uint64 { return stats.Hits }),
+		counter("revalidations_total", "Reads of expired files that the state store confirmed had not changed.", func(stats vfs.CacheStats) uint64 { return stats.Revalidations }),
+		counter("misses_total", "Reads that downloaded the file from the state store.", func(stats vfs.CacheStats) uint64 { return stats.Misses }),
+		counter("evictions_total", "Files evicted to keep the cache within its size.", func(stats vfs.CacheStats) uint64 { return stats.Evictions }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace:   "kops_controller",
+			Subsystem:   "vfs_cache",
+			Name:        "entries",
+			Help:        "Files held in the cache.",
+			ConstLabels: labels,
+		}, func() float64 {
+			return float64(cache.Stats().Entries)
+		}),
+	}
+	for _, collector := range collectors {
+		if err := metrics.Registry.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
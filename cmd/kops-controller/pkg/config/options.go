@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/azure"
@@ -28,12 +29,17 @@ import (
 )
 
 type Options struct {
-	ClusterName           string         `json:"clusterName,omitempty"`
-	Cloud                 string         `json:"cloud,omitempty"`
-	ConfigBase            string         `json:"configBase,omitempty"`
-	SecretStore           string         `json:"secretStore,omitempty"`
-	Server                *ServerOptions `json:"server,omitempty"`
-	CacheNodeidentityInfo bool           `json:"cacheNodeidentityInfo,omitempty"`
+	ClusterName string `json:"clusterName,omitempty"`
+	Cloud       string `json:"cloud,omitempty"`
+	ConfigBase  string `json:"configBase,omitempty"`
+	SecretStore string `json:"secretStore,omitempty"`
+	// Keystore is the location of the keystore, which is watched for changes to the signing CAs.
+	// It defaults to the pki directory under ConfigBase.
+	Keystore string `json:"keystore,omitempty"`
+	// SecretStoreEncryption configures decryption of the secret store, if it is encrypted.
+	SecretStoreEncryption *kops.ConfigStoreEncryptionSpec `json:"secretStoreEncryption,omitempty"`
+	Server                *ServerOptions                  `json:"server,omitempty"`
+	CacheNodeidentityInfo bool                            `json:"cacheNodeidentityInfo,omitempty"`
 
 	// EnableCloudIPAM enables the cloud IPAM controller.
 	EnableCloudIPAM bool `json:"enableCloudIPAM,omitempty"`
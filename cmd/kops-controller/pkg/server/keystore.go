@@ -21,13 +21,23 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strings"
+	"sync"
 
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
 	"sigs.k8s.io/yaml"
 )
 
 type keystore struct {
-	keys map[string]keystoreEntry
+	// mutex guards keys and keypairIDs, which are replaced when a signing CA is promoted
+	mutex      sync.Mutex
+	keys       map[string]keystoreEntry
+	keypairIDs map[string]string
 }
 
 type keystoreEntry struct {
@@ -35,10 +45,13 @@ type keystoreEntry struct {
 	key         *pki.PrivateKey
 }
 
-var _ pki.Keystore = keystore{}
+var _ pki.Keystore = &keystore{}
 
 // FindPrimaryKeypair implements pki.Keystore
-func (k keystore) FindPrimaryKeypair(ctx context.Context, name string) (*pki.Certificate, *pki.PrivateKey, error) {
+func (k *keystore) FindPrimaryKeypair(ctx context.Context, name string) (*pki.Certificate, *pki.PrivateKey, error) {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
 	entry, ok := k.keys[name]
 	if !ok {
 		return nil, nil, fmt.Errorf("unknown CA %q", name)
@@ -46,27 +59,35 @@ func (k keystore) FindPrimaryKeypair(ctx context.Context, name string) (*pki.Cer
 	return entry.certificate, entry.key, nil
 }
 
-func newKeystore(basePath string, cas []string) (pki.Keystore, map[string]string, error) {
+// keypairID returns the ID of the primary keypair of the named keyset.
+func (k *keystore) keypairID(name string) string {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	return k.keypairIDs[name]
+}
+
+func newKeystore(basePath string, cas []string) (*keystore, error) {
 	keystore := &keystore{
 		keys: map[string]keystoreEntry{},
 	}
 	for _, name := range cas {
 		certBytes, err := os.ReadFile(path.Join(basePath, name+".crt"))
 		if err != nil {
-			return nil, nil, fmt.Errorf("reading %q certificate: %v", name, err)
+			return nil, fmt.Errorf("reading %q certificate: %v", name, err)
 		}
 		certificate, err := pki.ParsePEMCertificate(certBytes)
 		if err != nil {
-			return nil, nil, fmt.Errorf("parsing %q certificate: %v", name, err)
+			return nil, fmt.Errorf("parsing %q certificate: %v", name, err)
 		}
 
 		keyBytes, err := os.ReadFile(path.Join(basePath, name+".key"))
 		if err != nil {
-			return nil, nil, fmt.Errorf("reading %q key: %v", name, err)
+			return nil, fmt.Errorf("reading %q key: %v", name, err)
 		}
 		key, err := pki.ParsePEMPrivateKey(keyBytes)
 		if err != nil {
-			return nil, nil, fmt.Errorf("parsing %q key: %v", name, err)
+			return nil, fmt.Errorf("parsing %q key: %v", name, err)
 		}
 
 		keystore.keys[name] = keystoreEntry{
@@ -78,12 +99,78 @@ func newKeystore(basePath string, cas []string) (pki.Keystore, map[string]string
 	var keypairIDs map[string]string
 	keypairIDsBytes, err := os.ReadFile(path.Join(basePath, "keypair-ids.yaml"))
 	if err != nil {
-		return nil, nil, fmt.Errorf("reading keypair-ids.yaml")
+		return nil, fmt.Errorf("reading keypair-ids.yaml")
 	}
 	err = yaml.Unmarshal(keypairIDsBytes, &keypairIDs)
 	if err != nil {
-		return nil, nil, fmt.Errorf("parsing keypair-ids.yaml")
+		return nil, fmt.Errorf("parsing keypair-ids.yaml")
 	}
+	keystore.keypairIDs = keypairIDs
 
-	return keystore, keypairIDs, nil
+	return keystore, nil
+}
+
+// watch reloads the signing CAs from the keystore in the state store whenever their keysets change,
+// so that a promoted keypair is used without restarting kops-controller. It returns when ctx is done.
+func (k *keystore) watch(ctx context.Context, base vfs.Path, encrypter *envelope.Encrypter, cas []string) error {
+	private := base.Join("private")
+	events, err := vfs.Watch(ctx, private, vfs.DefaultWatchInterval)
+	if err != nil {
+		return err
+	}
+
+	// Watch only reports changes after it started, and a keypair may have been promoted
+	// after the CAs on disk were written but before then
+	for _, name := range cas {
+		if err := k.reload(ctx, base, encrypter, name); err != nil {
+			klog.Warningf("error reloading keyset %q: %v", name, err)
+		}
+	}
+
+	signingCAs := sets.New(cas...)
+	for event := range events {
+		relativePath, err := vfs.RelativePath(private, event.Path)
+		if err != nil {
+			klog.Warningf("ignoring change to %s: %v", event.Path, err)
+			continue
+		}
+		name, file, _ := strings.Cut(relativePath, "/")
+		if file != "keyset.yaml" || !signingCAs.Has(name) || event.Type == vfs.WatchEventDeleted {
+			continue
+		}
+		if err := k.reload(ctx, base, encrypter, name); err != nil {
+			klog.Warningf("error reloading keyset %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// reload replaces the named CA with the primary keypair of its keyset in the state store.
+func (k *keystore) reload(ctx context.Context, base vfs.Path, encrypter *envelope.Encrypter, name string) error {
+	// A new reader each time, as readers cache the CA
+	keyset, err := fi.NewVFSKeystoreReader(base, encrypter).FindKeyset(ctx, name)
+	if err != nil {
+		return err
+	}
+	if keyset == nil || keyset.Primary == nil || keyset.Primary.PrivateKey == nil {
+		return fmt.Errorf("keyset has no primary keypair with a private key")
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if k.keypairIDs[name] == keyset.Primary.Id {
+		return nil
+	}
+	klog.Infof("primary keypair of %q changed from %q to %q", name, k.keypairIDs[name], keyset.Primary.Id)
+
+	k.keys[name] = keystoreEntry{
+		certificate: keyset.Primary.Certificate,
+		key:         keyset.Primary.PrivateKey,
+	}
+	if k.keypairIDs == nil {
+		k.keypairIDs = make(map[string]string)
+	}
+	k.keypairIDs[name] = keyset.Primary.Id
+	return nil
 }
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func issueTestCA(t *testing.T, serial int64) (*pki.Certificate, *pki.PrivateKey) {
+	t.Helper()
+
+	cert, key, _, err := pki.IssueCert(context.TODO(), &pki.IssueCertRequest{
+		Type:    "ca",
+		Subject: pkix.Name{CommonName: "kubernetes-ca"},
+		Serial:  big.NewInt(serial),
+	}, nil)
+	if err != nil {
+		t.Fatalf("error issuing certificate: %v", err)
+	}
+	return cert, key
+}
+
+func TestKeystoreWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	base := vfs.NewFSPath(t.TempDir())
+	store := fi.NewVFSCAStore(nil, base, nil)
+
+	cert, key := issueTestCA(t, 1)
+	keyset, err := fi.NewKeyset(cert, key)
+	if err != nil {
+		t.Fatalf("error building keyset: %v", err)
+	}
+	if err := store.StoreKeyset(ctx, fi.CertificateIDCA, keyset); err != nil {
+		t.Fatalf("error storing keyset: %v", err)
+	}
+	originalID := keyset.Primary.Id
+
+	k := &keystore{
+		keys:       map[string]keystoreEntry{fi.CertificateIDCA: {certificate: cert, key: key}},
+		keypairIDs: map[string]string{fi.CertificateIDCA: originalID},
+	}
+
+	watchDone := make(chan error)
+	go func() {
+		watchDone <- k.watch(ctx, base, nil, []string{fi.CertificateIDCA})
+	}()
+
+	// Give the watch time to take its initial snapshot, so that the promotion is seen as a change
+	time.Sleep(500 * time.Millisecond)
+
+	// Promote a new keypair, as "kops promote keypair" does
+	newCert, newKey := issueTestCA(t, 2)
+	item, err := keyset.AddItem(newCert, newKey, true)
+	if err != nil {
+		t.Fatalf("error adding keypair: %v", err)
+	}
+	if err := store.StoreKeyset(ctx, fi.CertificateIDCA, keyset); err != nil {
+		t.Fatalf("error storing keyset: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for k.keypairID(fi.CertificateIDCA) != item.Id {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for keypair %q to be promoted, still using %q", item.Id, k.keypairID(fi.CertificateIDCA))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	found, _, err := k.FindPrimaryKeypair(ctx, fi.CertificateIDCA)
+	if err != nil {
+		t.Fatalf("error finding primary keypair: %v", err)
+	}
+	if !found.Certificate.Equal(newCert.Certificate) {
+		t.Errorf("expected the promoted certificate to be the primary")
+	}
+
+	cancel()
+	if err := <-watchDone; err != nil {
+		t.Errorf("unexpected error from watch: %v", err)
+	}
+}
+
+func TestKeystoreWatchInitialReload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	base := vfs.NewFSPath(t.TempDir())
+	store := fi.NewVFSCAStore(nil, base, nil)
+
+	cert, key := issueTestCA(t, 1)
+	keyset, err := fi.NewKeyset(cert, key)
+	if err != nil {
+		t.Fatalf("error building keyset: %v", err)
+	}
+	originalID := keyset.Primary.Id
+
+	// The keypair was promoted before kops-controller started watching
+	newCert, newKey := issueTestCA(t, 2)
+	item, err := keyset.AddItem(newCert, newKey, true)
+	if err != nil {
+		t.Fatalf("error adding keypair: %v", err)
+	}
+	if err := store.StoreKeyset(ctx, fi.CertificateIDCA, keyset); err != nil {
+		t.Fatalf("error storing keyset: %v", err)
+	}
+
+	k := &keystore{
+		keys:       map[string]keystoreEntry{fi.CertificateIDCA: {certificate: cert, key: key}},
+		keypairIDs: map[string]string{fi.CertificateIDCA: originalID},
+	}
+
+	watchDone := make(chan error)
+	go func() {
+		watchDone <- k.watch(ctx, base, nil, []string{fi.CertificateIDCA})
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for k.keypairID(fi.CertificateIDCA) != item.Id {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for keypair %q to be loaded, still using %q", item.Id, k.keypairID(fi.CertificateIDCA))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-watchDone; err != nil {
+		t.Errorf("unexpected error from watch: %v", err)
+	}
+}
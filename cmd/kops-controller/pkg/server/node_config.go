@@ -21,8 +21,10 @@ import (
 	"fmt"
 
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/upup/pkg/fi/utils"
 )
 
 func (s *Server) getNodeConfig(ctx context.Context, req *nodeup.BootstrapRequest, identity *bootstrap.VerifyResult) (*nodeup.NodeConfig, error) {
@@ -52,6 +54,14 @@ func (s *Server) getNodeConfig(ctx context.Context, req *nodeup.BootstrapRequest
 		secretIDs := []string{
 			"dockerconfig",
 		}
+
+		// The keys for the instance group's encrypted volume mounts
+		var nodeupConfig nodeup.Config
+		if err := utils.YamlUnmarshal([]byte(nodeConfig.NodeupConfig), &nodeupConfig); err != nil {
+			return nil, fmt.Errorf("error parsing NodeupConfig: %w", err)
+		}
+		secretIDs = append(secretIDs, volumeEncryptionSecrets(&nodeupConfig)...)
+
 		nodeConfig.NodeSecrets = make(map[string][]byte)
 		for _, id := range secretIDs {
 			secret, err := s.secretStore.FindSecret(id)
@@ -66,3 +76,14 @@ func (s *Server) getNodeConfig(ctx context.Context, req *nodeup.BootstrapRequest
 
 	return nodeConfig, nil
 }
+
+// volumeEncryptionSecrets returns the secrets holding the keys for the encrypted volume mounts in the nodeup config.
+func volumeEncryptionSecrets(nodeupConfig *nodeup.Config) []string {
+	var secretIDs []string
+	for _, volumeMount := range nodeupConfig.VolumeMounts {
+		if e := volumeMount.Encryption; e != nil && e.KeySource == kops.VolumeEncryptionKeySourceSecret && e.SecretName != "" {
+			secretIDs = append(secretIDs, e.SecretName)
+		}
+	}
+	return secretIDs
+}
@@ -40,6 +40,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/bootstrap"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/pkg/rbac"
 	"k8s.io/kops/upup/pkg/fi"
@@ -52,12 +53,16 @@ import (
 type Server struct {
 	opt         *config.Options
 	certNames   sets.Set[string]
-	keypairIDs  map[string]string
 	server      *http.Server
 	verifier    bootstrap.Verifier
-	keystore    pki.Keystore
+	keystore    *keystore
 	secretStore fi.SecretStore
 
+	// keystorePath is the keystore in the state store, which is watched for promoted signing CAs.
+	keystorePath vfs.Path
+	// encrypter decrypts the secret store and the keystore.
+	encrypter *envelope.Encrypter
+
 	// configBase is the base of the configuration storage.
 	configBase vfs.Path
 
@@ -96,9 +101,23 @@ func NewServer(vfsContext *vfs.VFSContext, opt *config.Options, verifier bootstr
 	if err != nil {
 		return nil, fmt.Errorf("cannot parse SecretStore %q: %w", opt.SecretStore, err)
 	}
-	s.secretStore = secrets.NewVFSSecretStore(nil, p)
+	encrypter, err := envelope.New(opt.SecretStoreEncryption)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build SecretStore encryption: %w", err)
+	}
+	s.secretStore = secrets.NewVFSSecretStore(nil, p, encrypter)
+	s.encrypter = encrypter
 
-	s.keystore, s.keypairIDs, err = newKeystore(opt.Server.CABasePath, opt.Server.SigningCAs)
+	if opt.Keystore != "" {
+		s.keystorePath, err = vfsContext.BuildVfsPath(opt.Keystore)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse Keystore %q: %w", opt.Keystore, err)
+		}
+	} else {
+		s.keystorePath = configBase.Join("pki")
+	}
+
+	s.keystore, err = newKeystore(opt.Server.CABasePath, opt.Server.SigningCAs)
 	if err != nil {
 		return nil, err
 	}
@@ -136,6 +155,12 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}()
 
+	go func() {
+		if err := s.keystore.watch(ctx, s.keystorePath, s.encrypter, s.opt.Server.SigningCAs); err != nil {
+			klog.Warningf("not watching %s for promoted keypairs: %v", s.keystorePath, err)
+		}
+	}()
+
 	klog.Infof("kops-controller listening on %s", s.opt.Server.Listen)
 	return s.server.ListenAndServeTLS(s.opt.Server.ServerCertificatePath, s.opt.Server.ServerKeyPath)
 }
@@ -310,8 +335,8 @@ func (s *Server) issueCert(ctx context.Context, name string, pubKey string, id *
 
 	// This field was added to the protocol in kOps 1.22.
 	if len(keypairIDs) > 0 {
-		if keypairIDs[issueReq.Signer] != s.keypairIDs[issueReq.Signer] {
-			return "", fmt.Errorf("request's keypair ID %q for %s didn't match server's %q", keypairIDs[issueReq.Signer], issueReq.Signer, s.keypairIDs[issueReq.Signer])
+		if serverKeypairID := s.keystore.keypairID(issueReq.Signer); keypairIDs[issueReq.Signer] != serverKeypairID {
+			return "", fmt.Errorf("request's keypair ID %q for %s didn't match server's %q", keypairIDs[issueReq.Signer], issueReq.Signer, serverKeypairID)
 		}
 	}
 
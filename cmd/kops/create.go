@@ -100,6 +100,11 @@ func RunCreate(ctx context.Context, f *util.Factory, out io.Writer, c *CreateOpt
 
 	vfsContext := f.VFSContext()
 
+	clusterPolicy, err := f.Policy(ctx)
+	if err != nil {
+		return err
+	}
+
 	clusterName := ""
 	// var cSpec = false
 	var sb bytes.Buffer
@@ -142,6 +147,9 @@ func RunCreate(ctx context.Context, f *util.Factory, out io.Writer, c *CreateOpt
 				if err != nil {
 					return fmt.Errorf("error populating configuration: %v", err)
 				}
+				if err := validateClusterPolicy(ctx, clientset, clusterPolicy, v, nil, cloud); err != nil {
+					return err
+				}
 				_, err = clientset.CreateCluster(ctx, v)
 				if err != nil {
 					if apierrors.IsAlreadyExists(err) {
@@ -167,6 +175,10 @@ func RunCreate(ctx context.Context, f *util.Factory, out io.Writer, c *CreateOpt
 					return fmt.Errorf("cluster %q not found", clusterName)
 				}
 
+				if err := validateInstanceGroupPolicy(ctx, clientset, clusterPolicy, cluster, v); err != nil {
+					return err
+				}
+
 				_, err = clientset.InstanceGroupsFor(cluster).Create(ctx, v, metav1.CreateOptions{})
 				if err != nil {
 					if apierrors.IsAlreadyExists(err) {
@@ -251,10 +251,9 @@ func NewCmdCreateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringSliceVar(&options.KubernetesFeatureGates, "kubernetes-feature-gates", options.KubernetesFeatureGates, "List of Kubernetes feature gates to enable/disable")
 	cmd.RegisterFlagCompletionFunc("kubernetes-version", completeKubernetesFeatureGates)
 
-	cmd.Flags().StringVar(&options.ContainerRuntime, "container-runtime", options.ContainerRuntime, "Container runtime to use: containerd")
-	cmd.Flags().MarkDeprecated("container-runtime", "containerd is the only supported value")
+	cmd.Flags().StringVar(&options.ContainerRuntime, "container-runtime", options.ContainerRuntime, "Container runtime to use: containerd, crio")
 	cmd.RegisterFlagCompletionFunc("container-runtime", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
-		return []string{"containerd"}, cobra.ShellCompDirectiveNoFileComp
+		return []string{api.ContainerRuntimeContainerd, api.ContainerRuntimeCrio}, cobra.ShellCompDirectiveNoFileComp
 	})
 
 	cmd.Flags().StringVar(&sshPublicKey, "ssh-public-key", sshPublicKey, "SSH public key to use")
@@ -608,6 +607,10 @@ func RunCreateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Cr
 		}
 	}
 
+	if c.ContainerRuntime != "" {
+		cluster.Spec.ContainerRuntime = c.ContainerRuntime
+	}
+
 	if c.DNSZone != "" {
 		cluster.Spec.DNSZone = c.DNSZone
 	}
@@ -690,6 +693,14 @@ func RunCreateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Cr
 		if err != nil {
 			return fmt.Errorf("validation of the full cluster and instance group specs failed: %w", err)
 		}
+
+		clusterPolicy, err := f.Policy(ctx)
+		if err != nil {
+			return err
+		}
+		if errs := clusterPolicy.Validate(fullCluster, fullInstanceGroups); len(errs) != 0 {
+			return fmt.Errorf("cluster and instance group specs do not comply with policy: %w", errs.ToAggregate())
+		}
 	}
 
 	if c.DryRun {
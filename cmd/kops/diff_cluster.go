@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/commands"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	diffClusterLong = templates.LongDesc(i18n.T(`
+	Show the differences between a revision of the cluster and instance group specs and the current specs.
+	Revisions are listed by kops get cluster --history.`))
+
+	diffClusterExample = templates.Examples(i18n.T(`
+	# Show what has changed since revision 3
+	kops diff cluster k8s-cluster.example.com --revision 3
+	`))
+
+	diffClusterShort = i18n.T(`Show differences from a previous revision of a cluster.`)
+)
+
+type DiffClusterOptions struct {
+	ClusterName string
+	// Revision is the number of the revision to compare against the current specs
+	Revision int
+}
+
+func NewCmdDiffCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &DiffClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             diffClusterShort,
+		Long:              diffClusterLong,
+		Example:           diffClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunDiffCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().IntVar(&options.Revision, "revision", options.Revision, "Revision to compare against the current specs")
+	cmd.MarkFlagRequired("revision")
+
+	return cmd
+}
+
+func RunDiffCluster(ctx context.Context, f *util.Factory, out io.Writer, options *DiffClusterOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	revision, err := clientset.RevisionsFor(cluster).Get(ctx, options.Revision)
+	if err != nil {
+		return err
+	}
+	previous, err := specsYAML(revision.Cluster, revision.InstanceGroups)
+	if err != nil {
+		return err
+	}
+
+	instanceGroups, err := commands.ReadAllInstanceGroups(ctx, clientset, cluster)
+	if err != nil {
+		return err
+	}
+	current, err := specsYAML(cluster, instanceGroups)
+	if err != nil {
+		return err
+	}
+
+	if previous == current {
+		fmt.Fprintf(out, "No changes since revision %d\n", options.Revision)
+		return nil
+	}
+	_, err = fmt.Fprint(out, diff.FormatDiff(previous, current))
+	return err
+}
+
+// specsYAML serializes the cluster and instance group specs as YAML documents, for comparison.
+func specsYAML(cluster *kopsapi.Cluster, instanceGroups []*kopsapi.InstanceGroup) (string, error) {
+	cluster = cluster.DeepCopy()
+	cluster.ResourceVersion = ""
+	b, err := kopscodecs.ToVersionedYaml(cluster)
+	if err != nil {
+		return "", fmt.Errorf("error serializing cluster: %w", err)
+	}
+	sections := []string{string(b)}
+
+	for _, ig := range instanceGroups {
+		ig = ig.DeepCopy()
+		ig.ResourceVersion = ""
+		b, err := kopscodecs.ToVersionedYaml(ig)
+		if err != nil {
+			return "", fmt.Errorf("error serializing instance group %q: %w", ig.Name, err)
+		}
+		sections = append(sections, string(b))
+	}
+	return strings.Join(sections, "---\n"), nil
+}
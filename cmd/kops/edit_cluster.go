@@ -27,6 +27,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
 	"k8s.io/kops/cmd/kops/util"
 	api "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/validation"
@@ -36,6 +37,7 @@ import (
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/edit"
 	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/policy"
 	"k8s.io/kops/pkg/pretty"
 	"k8s.io/kops/pkg/try"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
@@ -120,6 +122,11 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 		return err
 	}
 
+	clusterPolicy, err := f.Policy(ctx)
+	if err != nil {
+		return err
+	}
+
 	if len(options.Unsets)+len(options.Sets) > 0 {
 		newCluster := oldCluster.DeepCopy()
 		if err := commands.UnsetClusterFields(options.Unsets, newCluster); err != nil {
@@ -129,7 +136,7 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 			return err
 		}
 
-		failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+		failure, err := updateCluster(ctx, clientset, clusterPolicy, oldCluster, newCluster, instanceGroups)
 		if err != nil {
 			return err
 		}
@@ -235,7 +242,7 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 			continue
 		}
 
-		failure, err := updateCluster(ctx, clientset, oldCluster, newCluster, instanceGroups)
+		failure, err := updateCluster(ctx, clientset, clusterPolicy, oldCluster, newCluster, instanceGroups)
 		if err != nil {
 			return preservedFile(err, file, out)
 		}
@@ -252,7 +259,7 @@ func RunEditCluster(ctx context.Context, f *util.Factory, out io.Writer, options
 	}
 }
 
-func updateCluster(ctx context.Context, clientset simple.Clientset, oldCluster, newCluster *api.Cluster, instanceGroups []*api.InstanceGroup) (string, error) {
+func updateCluster(ctx context.Context, clientset simple.Clientset, clusterPolicy *policy.Policy, oldCluster, newCluster *api.Cluster, instanceGroups []*api.InstanceGroup) (string, error) {
 	cloud, err := cloudup.BuildCloud(newCluster)
 	if err != nil {
 		return "", err
@@ -274,6 +281,26 @@ func updateCluster(ctx context.Context, clientset simple.Clientset, oldCluster,
 		return fmt.Sprintf("validation failed: %s", err), nil
 	}
 
+	if clusterPolicy != nil {
+		channel, err := cloudup.ChannelForCluster(clientset.VFSContext(), fullCluster)
+		if err != nil {
+			klog.Warningf("%v", err)
+		}
+
+		var fullGroups []*api.InstanceGroup
+		for _, ig := range instanceGroups {
+			fullGroup, err := cloudup.PopulateInstanceGroupSpec(fullCluster, ig, cloud, channel)
+			if err != nil {
+				return fmt.Sprintf("error populating instance group spec: %s", err), nil
+			}
+			fullGroups = append(fullGroups, fullGroup)
+		}
+
+		if errs := clusterPolicy.Validate(fullCluster, fullGroups); len(errs) != 0 {
+			return fmt.Sprintf("validation failed: %s", errs.ToAggregate()), nil
+		}
+	}
+
 	// Retrieve the current status of the cluster.  This will eventually be part of the cluster object.
 	status, err := cloud.FindClusterStatus(oldCluster)
 	if err != nil {
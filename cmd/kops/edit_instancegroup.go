@@ -37,6 +37,7 @@ import (
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/edit"
 	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/policy"
 	"k8s.io/kops/pkg/pretty"
 	"k8s.io/kops/pkg/try"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
@@ -137,6 +138,11 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 		klog.Warningf("%v", err)
 	}
 
+	clusterPolicy, err := f.Policy(ctx)
+	if err != nil {
+		return err
+	}
+
 	oldGroup, err := clientset.InstanceGroupsFor(cluster).Get(ctx, groupName, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("error reading InstanceGroup %q: %v", groupName, err)
@@ -154,7 +160,7 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 			return err
 		}
 
-		failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, newGroup)
+		failure, err := updateInstanceGroup(ctx, clientset, clusterPolicy, channel, cluster, newGroup)
 		if err != nil {
 			return err
 		}
@@ -260,7 +266,7 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 			continue
 		}
 
-		failure, err := updateInstanceGroup(ctx, clientset, channel, cluster, newGroup)
+		failure, err := updateInstanceGroup(ctx, clientset, clusterPolicy, channel, cluster, newGroup)
 		if err != nil {
 			return preservedFile(err, file, out)
 		}
@@ -277,7 +283,7 @@ func RunEditInstanceGroup(ctx context.Context, f *util.Factory, out io.Writer, o
 	}
 }
 
-func updateInstanceGroup(ctx context.Context, clientset simple.Clientset, channel *api.Channel, cluster *api.Cluster, newGroup *api.InstanceGroup) (string, error) {
+func updateInstanceGroup(ctx context.Context, clientset simple.Clientset, clusterPolicy *policy.Policy, channel *api.Channel, cluster *api.Cluster, newGroup *api.InstanceGroup) (string, error) {
 	cloud, err := cloudup.BuildCloud(cluster)
 	if err != nil {
 		return "", err
@@ -306,6 +312,10 @@ func updateInstanceGroup(ctx context.Context, clientset simple.Clientset, channe
 		return fmt.Sprintf("validation failed: %s", err), nil
 	}
 
+	if errs := clusterPolicy.ValidateInstanceGroups(fullCluster, []*api.InstanceGroup{fullGroup}); len(errs) != 0 {
+		return fmt.Sprintf("validation failed: %s", errs.ToAggregate()), nil
+	}
+
 	// Note we perform as much validation as we can, before writing a bad config
 	_, err = clientset.InstanceGroupsFor(cluster).Update(ctx, newGroup, metav1.UpdateOptions{})
 	return "", err
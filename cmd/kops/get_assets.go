@@ -138,6 +138,16 @@ func RunGetAssets(ctx context.Context, f *util.Factory, out io.Writer, options *
 			result.Files = append(result.Files, &file)
 			seen[file.Canonical] = true
 		}
+		if signature := fileAsset.Signature; signature != nil {
+			file := File{
+				Canonical: signature.CanonicalURL.String(),
+				Download:  signature.DownloadURL.String(),
+			}
+			if !seen[file.Canonical] {
+				result.Files = append(result.Files, &file)
+				seen[file.Canonical] = true
+			}
+		}
 	}
 
 	if options.Copy {
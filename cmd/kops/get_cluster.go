@@ -18,9 +18,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -30,12 +33,14 @@ import (
 	"k8s.io/kops/cmd/kops/util"
 	kopsapi "k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/util/pkg/tables"
 	"k8s.io/kops/util/pkg/vfs"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -54,6 +59,12 @@ var (
 
 	# Save a cluster desired configuration to YAML file
 	kops get cluster k8s-cluster.example.com -o yaml > cluster-desired-config.yaml
+
+	# Get the revision history of a cluster
+	kops get cluster k8s-cluster.example.com --history
+
+	# Get a health summary of the clusters in every state store listed in ~/.kops/fleet.yaml
+	kops get clusters --all-stores -o json
 	`))
 
 	getClusterShort = i18n.T(`Get one or many clusters.`)
@@ -80,8 +91,17 @@ type GetClusterOptions struct {
 	// FullSpec determines if we should output the completed (fully populated) spec
 	FullSpec bool
 
+	// History determines if we should output the revision history of the cluster
+	History bool
+
 	// ClusterNames is a list of cluster names to show; if not specified all clusters will be shown
 	ClusterNames []string
+
+	// AllStores summarizes the clusters in every state store listed in FleetConfig
+	AllStores bool
+
+	// FleetConfig is the file listing the state stores to use with AllStores
+	FleetConfig string
 }
 
 func NewCmdGetCluster(f *util.Factory, out io.Writer, getOptions *GetOptions) *cobra.Command {
@@ -109,11 +129,17 @@ func NewCmdGetCluster(f *util.Factory, out io.Writer, getOptions *GetOptions) *c
 		},
 		ValidArgsFunction: commandutils.CompleteClusterName(f, false, true),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.AllStores {
+				return RunGetFleetClusters(cmd.Context(), f, out, &options)
+			}
 			return RunGetClusters(cmd.Context(), f, out, &options)
 		},
 	}
 
 	cmd.Flags().BoolVar(&options.FullSpec, "full", options.FullSpec, "Show fully populated configuration")
+	cmd.Flags().BoolVar(&options.History, "history", options.History, "Show the revision history of the cluster")
+	cmd.Flags().BoolVar(&options.AllStores, "all-stores", options.AllStores, "Show a health summary of the clusters in every state store listed in the fleet config")
+	cmd.Flags().StringVar(&options.FleetConfig, "fleet-config", options.FleetConfig, "File listing the state stores and kubeconfig contexts to use with --all-stores (default ~/.kops/fleet.yaml)")
 
 	return cmd
 }
@@ -156,6 +182,20 @@ func RunGetClusters(ctx context.Context, f commandutils.Factory, out io.Writer,
 		return fmt.Errorf("no clusters found")
 	}
 
+	if options.History {
+		if !singleClusterSelected {
+			return fmt.Errorf("--history requires a single cluster to be specified")
+		}
+		if options.FullSpec {
+			return fmt.Errorf("cannot use --full with --history")
+		}
+		revisions, err := client.RevisionsFor(clusters[0]).List(ctx)
+		if err != nil {
+			return err
+		}
+		return revisionsOutput(revisions, options.Output, out)
+	}
+
 	if options.FullSpec {
 		var err error
 		clusters, err = fullClusterSpecs(ctx, client.VFSContext(), clusters)
@@ -234,6 +274,42 @@ func clusterOutputTable(clusters []*kopsapi.Cluster, out io.Writer) error {
 	return t.Render(clusters, out, "NAME", "CLOUD", "ZONES")
 }
 
+func revisionsOutput(revisions []*simple.Revision, output string, out io.Writer) error {
+	switch output {
+	case OutputTable:
+		t := &tables.Table{}
+		t.AddColumn("REVISION", func(r *simple.Revision) string {
+			return strconv.Itoa(r.Number)
+		})
+		t.AddColumn("TIMESTAMP", func(r *simple.Revision) string {
+			return r.Timestamp.UTC().Format(time.RFC3339)
+		})
+		t.AddColumn("USER", func(r *simple.Revision) string {
+			return r.User
+		})
+		t.AddColumn("CHANGE", func(r *simple.Revision) string {
+			return r.Change
+		})
+		return t.Render(revisions, out, "REVISION", "TIMESTAMP", "USER", "CHANGE")
+	case OutputYaml:
+		b, err := yaml.Marshal(revisions)
+		if err != nil {
+			return fmt.Errorf("error marshaling yaml: %w", err)
+		}
+		_, err = out.Write(b)
+		return err
+	case OutputJSON:
+		b, err := json.MarshalIndent(revisions, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling json: %w", err)
+		}
+		_, err = fmt.Fprintf(out, "%s\n", b)
+		return err
+	default:
+		return fmt.Errorf("unknown output format: %q", output)
+	}
+}
+
 // fullOutputJSON outputs the marshalled JSON of a list of clusters and instance groups.  It will handle
 // nils for clusters and instanceGroups slices.
 func fullOutputJSON(out io.Writer, singleObject bool, args ...runtime.Object) error {
@@ -0,0 +1,376 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/homedir"
+	"k8s.io/klog/v2"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/validation"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kops/util/pkg/tables"
+	"sigs.k8s.io/yaml"
+)
+
+// fleetParallelism is the number of clusters that are summarized at the same time.
+const fleetParallelism = 8
+
+// fleetValidationTimeout bounds the requests made to each cluster's API server during validation.
+const fleetValidationTimeout = 30 * time.Second
+
+// FleetConfig lists the state stores that make up a fleet, for kops get clusters --all-stores.
+type FleetConfig struct {
+	// StateStores are the state stores to list clusters from.
+	StateStores []FleetStateStore `json:"stateStores"`
+}
+
+// FleetStateStore is a state store in a fleet.
+type FleetStateStore struct {
+	// Path is the location of the state store, such as s3://my-state-store.
+	Path string `json:"path"`
+	// Kubeconfig is the kubeconfig file used to validate the clusters in this state store.
+	// If not set, the default kubeconfig is used.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+	// Contexts maps cluster names to the kubeconfig context used to validate them.
+	// Clusters that are not listed are validated using the context with the same name as the cluster.
+	Contexts map[string]string `json:"contexts,omitempty"`
+}
+
+// FleetClusterSummary is the health summary of a cluster, as output by kops get clusters --all-stores.
+type FleetClusterSummary struct {
+	StateStore        string `json:"stateStore"`
+	Name              string `json:"name,omitempty"`
+	Cloud             string `json:"cloud,omitempty"`
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// KopsVersion is the version of kops that last applied the cluster configuration.
+	KopsVersion string `json:"kopsVersion,omitempty"`
+	// InstanceGroups counts the instance groups of the cluster by role.
+	InstanceGroups map[string]int `json:"instanceGroups,omitempty"`
+	// CertificateExpiry is the earliest expiry of the primary certificates in the keystore.
+	CertificateExpiry *time.Time `json:"certificateExpiry,omitempty"`
+	// CertificateExpiryKeyset is the keyset whose primary certificate expires first.
+	CertificateExpiryKeyset string `json:"certificateExpiryKeyset,omitempty"`
+	// Validation is the result of validating the cluster: Ready, the number of failures, or the error that prevented validation.
+	Validation string `json:"validation,omitempty"`
+	// ValidationFailures are the failures found by validation.
+	ValidationFailures []*validation.ValidationError `json:"validationFailures,omitempty"`
+	// Error is set if the cluster, or the state store, could not be read.
+	Error string `json:"error,omitempty"`
+}
+
+// defaultFleetConfigPath returns the fleet config file used when --fleet-config is not specified.
+func defaultFleetConfigPath() string {
+	home := homedir.HomeDir()
+	if home == "" {
+		return ""
+	}
+	return filepath.Join(home, ".kops", "fleet.yaml")
+}
+
+// expandHome replaces a leading ~/ in p with the home directory.
+func expandHome(p string) string {
+	if strings.HasPrefix(p, "~/") {
+		return filepath.Join(homedir.HomeDir(), p[2:])
+	}
+	return p
+}
+
+// loadFleetConfig reads and validates a fleet config file.
+func loadFleetConfig(p string) (*FleetConfig, error) {
+	if p == "" {
+		p = defaultFleetConfigPath()
+		if p == "" {
+			return nil, fmt.Errorf("--fleet-config is required with --all-stores")
+		}
+	}
+	b, err := os.ReadFile(expandHome(p))
+	if err != nil {
+		return nil, fmt.Errorf("error reading fleet config: %w", err)
+	}
+	config := &FleetConfig{}
+	if err := yaml.UnmarshalStrict(b, config); err != nil {
+		return nil, fmt.Errorf("error parsing fleet config %q: %w", p, err)
+	}
+	if len(config.StateStores) == 0 {
+		return nil, fmt.Errorf("fleet config %q does not list any stateStores", p)
+	}
+	for i, store := range config.StateStores {
+		if store.Path == "" {
+			return nil, fmt.Errorf("fleet config %q: stateStores[%d].path is required", p, i)
+		}
+	}
+	return config, nil
+}
+
+// RunGetFleetClusters summarizes the clusters in every state store of the fleet config.
+// Clusters are summarized in parallel; a cluster or state store that cannot be read is reported in the output
+// rather than stopping the command.
+func RunGetFleetClusters(ctx context.Context, f commandutils.Factory, out io.Writer, options *GetClusterOptions) error {
+	if options.FullSpec || options.History {
+		return fmt.Errorf("cannot use --full or --history with --all-stores")
+	}
+
+	config, err := loadFleetConfig(options.FleetConfig)
+	if err != nil {
+		return err
+	}
+
+	filter := make(map[string]bool)
+	for _, name := range options.ClusterNames {
+		filter[name] = true
+	}
+
+	var mutex sync.Mutex
+	var summaries []*FleetClusterSummary
+	addSummary := func(summary *FleetClusterSummary) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		summaries = append(summaries, summary)
+	}
+
+	// Every state store is listed at once; the clusters they contain share a limited pool,
+	// which the listing goroutines block on without holding anything the cluster goroutines need.
+	var stores, clusters errgroup.Group
+	clusters.SetLimit(fleetParallelism)
+	for i := range config.StateStores {
+		store := &config.StateStores[i]
+		stores.Go(func() error {
+			basePath, err := f.VFSContext().BuildVfsPath(store.Path)
+			if err != nil {
+				addSummary(&FleetClusterSummary{StateStore: store.Path, Error: fmt.Sprintf("error parsing state store path: %v", err)})
+				return nil
+			}
+			clientset := vfsclientset.NewVFSClientset(f.VFSContext(), basePath)
+
+			list, err := clientset.ListClusters(ctx, metav1.ListOptions{})
+			if err != nil {
+				addSummary(&FleetClusterSummary{StateStore: store.Path, Error: fmt.Sprintf("error listing clusters: %v", err)})
+				return nil
+			}
+
+			for j := range list.Items {
+				cluster := &list.Items[j]
+				if len(filter) != 0 && !filter[cluster.ObjectMeta.Name] {
+					continue
+				}
+				clusters.Go(func() error {
+					addSummary(summarizeFleetCluster(ctx, clientset, store, cluster))
+					return nil
+				})
+			}
+			return nil
+		})
+	}
+	if err := stores.Wait(); err != nil {
+		return err
+	}
+	if err := clusters.Wait(); err != nil {
+		return err
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].StateStore != summaries[j].StateStore {
+			return summaries[i].StateStore < summaries[j].StateStore
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	switch options.Output {
+	case OutputTable:
+		return fleetOutputTable(summaries, out)
+	case OutputYaml:
+		b, err := yaml.Marshal(summaries)
+		if err != nil {
+			return fmt.Errorf("error marshaling yaml: %w", err)
+		}
+		_, err = out.Write(b)
+		return err
+	case OutputJSON:
+		b, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling json: %w", err)
+		}
+		_, err = fmt.Fprintf(out, "%s\n", b)
+		return err
+	default:
+		return fmt.Errorf("unknown output format: %q", options.Output)
+	}
+}
+
+// summarizeFleetCluster builds the health summary of a single cluster.
+func summarizeFleetCluster(ctx context.Context, clientset simple.Clientset, store *FleetStateStore, cluster *kopsapi.Cluster) *FleetClusterSummary {
+	summary := &FleetClusterSummary{
+		StateStore:        store.Path,
+		Name:              cluster.ObjectMeta.Name,
+		Cloud:             string(cluster.Spec.GetCloudProvider()),
+		KubernetesVersion: cluster.Spec.KubernetesVersion,
+	}
+
+	configBase, err := registry.ConfigBase(clientset.VFSContext(), cluster)
+	if err != nil {
+		summary.Error = fmt.Sprintf("error reading configBase: %v", err)
+		return summary
+	}
+	if b, err := configBase.Join(registry.PathKopsVersionUpdated).ReadFile(ctx); err == nil {
+		summary.KopsVersion = strings.TrimSpace(string(b))
+	} else if !os.IsNotExist(err) {
+		klog.Warningf("error reading kops version of cluster %q: %v", cluster.ObjectMeta.Name, err)
+	}
+
+	instanceGroups, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		summary.Error = fmt.Sprintf("error listing instance groups: %v", err)
+		return summary
+	}
+	summary.InstanceGroups = make(map[string]int)
+	for _, ig := range instanceGroups.Items {
+		summary.InstanceGroups[string(ig.Spec.Role)]++
+	}
+
+	keyStore, err := clientset.KeyStore(cluster)
+	if err != nil {
+		summary.Error = fmt.Sprintf("error opening keystore: %v", err)
+		return summary
+	}
+	keysets, err := keyStore.ListKeysets()
+	if err != nil {
+		summary.Error = fmt.Sprintf("error listing keysets: %v", err)
+		return summary
+	}
+	for name, keyset := range keysets {
+		if keyset.Primary == nil || keyset.Primary.Certificate == nil {
+			continue
+		}
+		notAfter := keyset.Primary.Certificate.Certificate.NotAfter
+		if summary.CertificateExpiry == nil || notAfter.Before(*summary.CertificateExpiry) {
+			summary.CertificateExpiry = &notAfter
+			summary.CertificateExpiryKeyset = name
+		}
+	}
+
+	result, err := validateFleetCluster(store, cluster, instanceGroups)
+	if err != nil {
+		summary.Validation = fmt.Sprintf("error: %v", err)
+	} else if len(result.Failures) == 0 {
+		summary.Validation = "Ready"
+	} else {
+		summary.Validation = fmt.Sprintf("%d failures", len(result.Failures))
+		summary.ValidationFailures = result.Failures
+	}
+
+	return summary
+}
+
+// validateFleetCluster validates a cluster once, as kops validate cluster does without --wait.
+func validateFleetCluster(store *FleetStateStore, cluster *kopsapi.Cluster, instanceGroups *kopsapi.InstanceGroupList) (*validation.ValidationCluster, error) {
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	contextName := store.Contexts[cluster.ObjectMeta.Name]
+	if contextName == "" {
+		contextName = cluster.ObjectMeta.Name
+	}
+	configLoadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if store.Kubeconfig != "" {
+		configLoadingRules.ExplicitPath = expandHome(store.Kubeconfig)
+	}
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		configLoadingRules,
+		&clientcmd.ConfigOverrides{CurrentContext: contextName}).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load kubecfg settings for %q: %v", contextName, err)
+	}
+	config.Timeout = fleetValidationTimeout
+
+	k8sClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build kubernetes api client for %q: %v", contextName, err)
+	}
+
+	validator, err := validation.NewClusterValidator(cluster, cloud, instanceGroups, config.Host, k8sClient)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected error creating validator: %v", err)
+	}
+	return validator.Validate()
+}
+
+func fleetOutputTable(summaries []*FleetClusterSummary, out io.Writer) error {
+	t := &tables.Table{}
+	t.AddColumn("STATE STORE", func(s *FleetClusterSummary) string {
+		return s.StateStore
+	})
+	t.AddColumn("NAME", func(s *FleetClusterSummary) string {
+		return s.Name
+	})
+	t.AddColumn("CLOUD", func(s *FleetClusterSummary) string {
+		return s.Cloud
+	})
+	t.AddColumn("KUBERNETES", func(s *FleetClusterSummary) string {
+		return s.KubernetesVersion
+	})
+	t.AddColumn("KOPS", func(s *FleetClusterSummary) string {
+		return s.KopsVersion
+	})
+	t.AddColumn("INSTANCE GROUPS", func(s *FleetClusterSummary) string {
+		var roles []string
+		for role := range s.InstanceGroups {
+			roles = append(roles, role)
+		}
+		sort.Strings(roles)
+		var counts []string
+		for _, role := range roles {
+			counts = append(counts, role+"="+strconv.Itoa(s.InstanceGroups[role]))
+		}
+		return strings.Join(counts, ",")
+	})
+	t.AddColumn("CERT EXPIRY", func(s *FleetClusterSummary) string {
+		if s.CertificateExpiry == nil {
+			return ""
+		}
+		return s.CertificateExpiry.UTC().Format("2006-01-02")
+	})
+	t.AddColumn("VALIDATION", func(s *FleetClusterSummary) string {
+		if s.Error != "" {
+			return "error: " + s.Error
+		}
+		return s.Validation
+	})
+
+	return t.Render(summaries, out, "STATE STORE", "NAME", "CLOUD", "KUBERNETES", "KOPS", "INSTANCE GROUPS", "CERT EXPIRY", "VALIDATION")
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestGetFleetClusters(t *testing.T) {
+	t.Setenv("SKIP_REGION_CHECK", "1")
+	// No kubeconfig context exists, so validation is reported as an error rather than contacting a cluster
+	t.Setenv("KUBECONFIG", filepath.Join(t.TempDir(), "kubeconfig"))
+	ctx := context.Background()
+
+	testutils.NewIntegrationTestHarness(t).SetupMockAWS()
+
+	factory := util.NewFactory(&util.FactoryOptions{RegistryPath: "memfs://fleet-a"})
+
+	stores := map[string]string{
+		"memfs://fleet-a": "a.k8s.io",
+		"memfs://fleet-b": "b.k8s.io",
+	}
+	for store, clusterName := range stores {
+		basePath, err := factory.VFSContext().BuildVfsPath(store)
+		if err != nil {
+			t.Fatalf("error building path: %v", err)
+		}
+		clientset := vfsclientset.NewVFSClientset(factory.VFSContext(), basePath)
+
+		cluster := testutils.BuildMinimalCluster(clusterName)
+		cluster.Spec.ConfigStore.Base = store + "/" + clusterName
+		cluster.Spec.ConfigStore.Keypairs = store + "/" + clusterName + "/pki"
+		cluster, err = clientset.CreateCluster(ctx, cluster)
+		if err != nil {
+			t.Fatalf("could not create cluster: %v", err)
+		}
+		controlPlane := testutils.BuildMinimalMasterInstanceGroup("subnet-us-test-1a")
+		nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+		for _, ig := range []*kops.InstanceGroup{&controlPlane, &nodes} {
+			if _, err := clientset.InstanceGroupsFor(cluster).Create(ctx, ig, v1.CreateOptions{}); err != nil {
+				t.Fatalf("could not create instance group: %v", err)
+			}
+		}
+
+		configBase, err := registry.ConfigBase(factory.VFSContext(), cluster)
+		if err != nil {
+			t.Fatalf("error getting configBase: %v", err)
+		}
+		if err := configBase.Join(registry.PathKopsVersionUpdated).WriteFile(ctx, strings.NewReader("1.30.0"), nil); err != nil {
+			t.Fatalf("error writing kops version: %v", err)
+		}
+
+		cert, key, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+			Type:    "ca",
+			Subject: pkix.Name{CommonName: "kubernetes-ca"},
+			Serial:  big.NewInt(1),
+		}, nil)
+		if err != nil {
+			t.Fatalf("error issuing certificate: %v", err)
+		}
+		keyset, err := fi.NewKeyset(cert, key)
+		if err != nil {
+			t.Fatalf("error building keyset: %v", err)
+		}
+		keyStore, err := clientset.KeyStore(cluster)
+		if err != nil {
+			t.Fatalf("error opening keystore: %v", err)
+		}
+		if err := keyStore.StoreKeyset(ctx, fi.CertificateIDCA, keyset); err != nil {
+			t.Fatalf("error storing keyset: %v", err)
+		}
+	}
+
+	fleetConfig := filepath.Join(t.TempDir(), "fleet.yaml")
+	if err := os.WriteFile(fleetConfig, []byte(`
+stateStores:
+- path: memfs://fleet-a
+- path: memfs://fleet-b
+  contexts:
+    b.k8s.io: b-admin
+`), 0o644); err != nil {
+		t.Fatalf("error writing fleet config: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	options := &GetClusterOptions{
+		GetOptions:  &GetOptions{Output: OutputJSON},
+		AllStores:   true,
+		FleetConfig: fleetConfig,
+	}
+	if err := RunGetFleetClusters(ctx, factory, &stdout, options); err != nil {
+		t.Fatalf("error getting fleet clusters: %v", err)
+	}
+
+	var summaries []*FleetClusterSummary
+	if err := json.Unmarshal(stdout.Bytes(), &summaries); err != nil {
+		t.Fatalf("error parsing output: %v\n%s", err, stdout.String())
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 clusters, got %d:\n%s", len(summaries), stdout.String())
+	}
+	for i, store := range []string{"memfs://fleet-a", "memfs://fleet-b"} {
+		s := summaries[i]
+		if s.StateStore != store || s.Name != stores[store] {
+			t.Errorf("unexpected cluster %q in %q, expected %q in %q", s.Name, s.StateStore, stores[store], store)
+		}
+		if s.Error != "" {
+			t.Errorf("unexpected error for %q: %s", s.Name, s.Error)
+		}
+		if s.Cloud != "aws" || s.KopsVersion != "1.30.0" {
+			t.Errorf("unexpected cloud %q or kops version %q for %q", s.Cloud, s.KopsVersion, s.Name)
+		}
+		if s.InstanceGroups["ControlPlane"] != 1 || s.InstanceGroups["Node"] != 1 {
+			t.Errorf("unexpected instance group counts %v for %q", s.InstanceGroups, s.Name)
+		}
+		if s.CertificateExpiry == nil || s.CertificateExpiryKeyset != fi.CertificateIDCA {
+			t.Errorf("expected certificate expiry from %q for %q, got %v from %q", fi.CertificateIDCA, s.Name, s.CertificateExpiry, s.CertificateExpiryKeyset)
+		}
+	}
+	if !strings.Contains(summaries[1].Validation, `"b-admin"`) {
+		t.Errorf("expected validation of b.k8s.io to use the configured context, got %q", summaries[1].Validation)
+	}
+
+	stdout.Reset()
+	options.Output = OutputTable
+	if err := RunGetFleetClusters(ctx, factory, &stdout, options); err != nil {
+		t.Fatalf("error getting fleet clusters: %v", err)
+	}
+	if !strings.Contains(stdout.String(), "ControlPlane=1,Node=1") {
+		t.Errorf("unexpected table output:\n%s", stdout.String())
+	}
+}
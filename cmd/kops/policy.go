@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/klog/v2"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/policy"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+)
+
+// populateClusterForPolicy returns the fully populated spec of the cluster, which is what the policy rules are evaluated against.
+// The cluster itself is not modified.
+func populateClusterForPolicy(ctx context.Context, clientset simple.Clientset, cluster *kopsapi.Cluster, instanceGroups []*kopsapi.InstanceGroup, cloud fi.Cloud) (*kopsapi.Cluster, error) {
+	cluster = cluster.DeepCopy()
+	if err := cloudup.PerformAssignments(cluster, clientset.VFSContext(), cloud); err != nil {
+		return nil, fmt.Errorf("error populating configuration: %w", err)
+	}
+
+	assetBuilder := assets.NewAssetBuilder(clientset.VFSContext(), cluster.Spec.Assets, cluster.Spec.KubernetesVersion, false)
+	fullCluster, err := cloudup.PopulateClusterSpec(ctx, clientset, cluster, instanceGroups, cloud, assetBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("error populating cluster spec: %w", err)
+	}
+	return fullCluster, nil
+}
+
+// validateClusterPolicy evaluates the cluster rules of the policy against the fully populated spec of the cluster.
+func validateClusterPolicy(ctx context.Context, clientset simple.Clientset, clusterPolicy *policy.Policy, cluster *kopsapi.Cluster, instanceGroups []*kopsapi.InstanceGroup, cloud fi.Cloud) error {
+	if clusterPolicy == nil {
+		return nil
+	}
+
+	fullCluster, err := populateClusterForPolicy(ctx, clientset, cluster, instanceGroups, cloud)
+	if err != nil {
+		return err
+	}
+
+	if errs := clusterPolicy.ValidateCluster(fullCluster); len(errs) != 0 {
+		return fmt.Errorf("cluster %q does not comply with policy: %w", cluster.ObjectMeta.Name, errs.ToAggregate())
+	}
+	return nil
+}
+
+// validateInstanceGroupPolicy evaluates the instance group rules of the policy against the fully populated specs of the cluster and the instance group.
+func validateInstanceGroupPolicy(ctx context.Context, clientset simple.Clientset, clusterPolicy *policy.Policy, cluster *kopsapi.Cluster, ig *kopsapi.InstanceGroup) error {
+	if clusterPolicy == nil {
+		return nil
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+
+	channel, err := cloudup.ChannelForCluster(clientset.VFSContext(), cluster)
+	if err != nil {
+		klog.Warningf("%v", err)
+	}
+
+	fullGroup, err := cloudup.PopulateInstanceGroupSpec(cluster, ig, cloud, channel)
+	if err != nil {
+		return fmt.Errorf("error populating instance group spec: %w", err)
+	}
+
+	fullCluster, err := populateClusterForPolicy(ctx, clientset, cluster, []*kopsapi.InstanceGroup{ig}, cloud)
+	if err != nil {
+		return err
+	}
+
+	if errs := clusterPolicy.ValidateInstanceGroups(fullCluster, []*kopsapi.InstanceGroup{fullGroup}); len(errs) != 0 {
+		return fmt.Errorf("instanceGroup %q does not comply with policy: %w", ig.ObjectMeta.Name, errs.ToAggregate())
+	}
+	return nil
+}
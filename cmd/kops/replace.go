@@ -91,6 +91,11 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 
 	vfsContext := f.VFSContext()
 
+	clusterPolicy, err := f.Policy(ctx)
+	if err != nil {
+		return err
+	}
+
 	for _, f := range c.Filenames {
 		var contents []byte
 		if f == "-" {
@@ -135,6 +140,20 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 							return fmt.Errorf("error fetching cluster %q: %v", clusterName, err)
 						}
 					}
+
+					var instanceGroups []*kopsapi.InstanceGroup
+					if cluster != nil {
+						list, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+						if err != nil {
+							return fmt.Errorf("error listing instanceGroups: %v", err)
+						}
+						for i := range list.Items {
+							instanceGroups = append(instanceGroups, &list.Items[i])
+						}
+					}
+					if err := validateClusterPolicy(ctx, clientset, clusterPolicy, v, instanceGroups, cloud); err != nil {
+						return err
+					}
 					if cluster == nil {
 						if !c.Force {
 							return fmt.Errorf("cluster %v does not exist (try adding --force flag)", clusterName)
@@ -169,6 +188,9 @@ func RunReplace(ctx context.Context, f *util.Factory, out io.Writer, c *ReplaceO
 					}
 					return fmt.Errorf("error fetching cluster %q: %v", clusterName, err)
 				}
+				if err := validateInstanceGroupPolicy(ctx, clientset, clusterPolicy, cluster, v); err != nil {
+					return err
+				}
 				// check if the instancegroup exists already
 				igName := v.ObjectMeta.Name
 				ig, err := clientset.InstanceGroupsFor(cluster).Get(ctx, igName, metav1.GetOptions{})
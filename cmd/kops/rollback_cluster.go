@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/cmd/kops/util"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/validation"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/policy"
+	"k8s.io/kops/pkg/pretty"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	rollbackClusterLong = pretty.LongDesc(i18n.T(`
+	Restore the cluster and instance group specs to those recorded in a previous revision.
+	Instance groups that did not exist at that revision are deleted. Revisions are listed by
+	` + pretty.Bash("kops get cluster --history") + `.
+
+	Only the specs in the state store are changed; use ` + pretty.Bash("kops update cluster") + ` to apply them to the cluster.
+	`))
+
+	rollbackClusterExample = templates.Examples(i18n.T(`
+	# Review and roll back to revision 3
+	kops diff cluster k8s-cluster.example.com --revision 3
+	kops rollback cluster k8s-cluster.example.com --to 3
+	kops update cluster k8s-cluster.example.com --yes
+	`))
+
+	rollbackClusterShort = i18n.T(`Roll back a cluster to a previous revision.`)
+)
+
+type RollbackClusterOptions struct {
+	ClusterName string
+	// To is the number of the revision to roll back to
+	To int
+}
+
+func NewCmdRollbackCluster(f *util.Factory, out io.Writer) *cobra.Command {
+	options := &RollbackClusterOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "cluster [CLUSTER]",
+		Short:             rollbackClusterShort,
+		Long:              rollbackClusterLong,
+		Example:           rollbackClusterExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunRollbackCluster(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().IntVar(&options.To, "to", options.To, "Revision to roll back to")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func RunRollbackCluster(ctx context.Context, f *util.Factory, out io.Writer, options *RollbackClusterOptions) error {
+	cluster, err := GetCluster(ctx, f, options.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cloud, err := cloudup.BuildCloud(cluster)
+	if err != nil {
+		return err
+	}
+	// Retrieve the current status of the cluster, so that changes to etcd can be validated
+	status, err := cloud.FindClusterStatus(cluster)
+	if err != nil {
+		return err
+	}
+
+	clusterPolicy, err := f.Policy(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Validate the restored specs as kops edit does, before writing any of them
+	target, err := clientset.RevisionsFor(cluster).Get(ctx, options.To)
+	if err != nil {
+		return err
+	}
+	if err := validateRevision(ctx, clientset, clusterPolicy, target, cloud); err != nil {
+		return fmt.Errorf("cannot roll back to revision %d: %w", options.To, err)
+	}
+
+	revision, err := clientset.RevisionsFor(cluster).Rollback(ctx, options.To, status)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Cluster %q rolled back to revision %d (%s)\n", cluster.Name, revision.Number, revision.Change)
+	fmt.Fprintf(out, "Run \"kops update cluster %s --yes\" to apply the changes\n", cluster.Name)
+	return nil
+}
+
+// validateRevision deep-validates the specs of a revision, cross-validating the instance groups against the restored cluster,
+// and checks that the populated specs comply with the policy.
+func validateRevision(ctx context.Context, clientset simple.Clientset, clusterPolicy *policy.Policy, revision *simple.Revision, cloud fi.Cloud) error {
+	cluster := revision.Cluster.DeepCopy()
+	if err := cloudup.PerformAssignments(cluster, clientset.VFSContext(), cloud); err != nil {
+		return fmt.Errorf("error populating configuration: %w", err)
+	}
+
+	assetBuilder := assets.NewAssetBuilder(clientset.VFSContext(), cluster.Spec.Assets, cluster.Spec.KubernetesVersion, false)
+	fullCluster, err := cloudup.PopulateClusterSpec(ctx, clientset, cluster, revision.InstanceGroups, cloud, assetBuilder)
+	if err != nil {
+		return fmt.Errorf("error populating cluster spec: %w", err)
+	}
+
+	if err := validation.DeepValidate(fullCluster, revision.InstanceGroups, true, clientset.VFSContext(), cloud); err != nil {
+		return err
+	}
+
+	if clusterPolicy == nil {
+		return nil
+	}
+
+	channel, err := cloudup.ChannelForCluster(clientset.VFSContext(), fullCluster)
+	if err != nil {
+		klog.Warningf("%v", err)
+	}
+	var fullGroups []*kopsapi.InstanceGroup
+	for _, ig := range revision.InstanceGroups {
+		fullGroup, err := cloudup.PopulateInstanceGroupSpec(fullCluster, ig, cloud, channel)
+		if err != nil {
+			return fmt.Errorf("error populating instance group spec: %w", err)
+		}
+		fullGroups = append(fullGroups, fullGroup)
+	}
+	if errs := clusterPolicy.Validate(fullCluster, fullGroups); len(errs) != 0 {
+		return fmt.Errorf("specs do not comply with policy: %w", errs.ToAggregate())
+	}
+	return nil
+}
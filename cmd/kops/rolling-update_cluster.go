@@ -18,6 +18,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -89,6 +90,17 @@ var (
 		# Update only the "nodes-1a" instance group of the k8s-cluster.example.com kOps cluster.
 		kops rolling-update cluster k8s-cluster.example.com --yes \
 		  --instance-group nodes-1a
+
+		# Continue a rolling update of the k8s-cluster.example.com kOps cluster
+		# that was interrupted, skipping the instance groups it already completed.
+		kops rolling-update cluster k8s-cluster.example.com --yes --resume
+
+		# Report the drains that PodDisruptionBudgets would block, without updating anything.
+		kops rolling-update cluster k8s-cluster.example.com --plan
+
+		# Update the k8s-cluster.example.com kOps cluster, writing a JSON event
+		# for each step of the rolling update to stdout.
+		kops rolling-update cluster k8s-cluster.example.com --yes -o json
 		`))
 
 	rollingupdateShort = i18n.T(`Rolling update a cluster.`)
@@ -144,6 +156,20 @@ type RollingUpdateOptions struct {
 	// if not specified, all instance groups will be updated
 	InstanceGroupRoles []string
 
+	// Plan simulates the drains of the rolling update and reports any that PodDisruptionBudgets would block.
+	Plan bool
+
+	// FailOnEvictionBlocker aborts the rolling update before replacing any instance
+	// if the plan finds drains that PodDisruptionBudgets would block.
+	FailOnEvictionBlocker bool
+
+	// Output is the output format; one of table or json.
+	// When updating, json writes a stream of events instead of the table.
+	Output string
+
+	// EventsFile is the path of a file to which a JSON event is written for each step of the rolling update.
+	EventsFile string
+
 	// TODO: Move more/all above options to RollingUpdateOptions
 	instancegroups.RollingUpdateOptions
 }
@@ -166,6 +192,8 @@ func (o *RollingUpdateOptions) InitDefaults() {
 
 	o.DrainTimeout = 15 * time.Minute
 
+	o.Output = OutputTable
+
 	o.RollingUpdateOptions.InitDefaults()
 }
 
@@ -213,6 +241,14 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 
 	cmd.Flags().BoolVar(&options.FailOnDrainError, "fail-on-drain-error", true, "Fail if draining a node fails")
 	cmd.Flags().BoolVar(&options.FailOnValidate, "fail-on-validate-error", true, "Fail if the cluster fails to validate")
+	cmd.Flags().BoolVar(&options.Resume, "resume", options.Resume, "Resume an interrupted rolling update from its checkpoint in the state store")
+	cmd.Flags().BoolVar(&options.Plan, "plan", options.Plan, "Report the drains that PodDisruptionBudgets would block, without updating anything")
+	cmd.Flags().BoolVar(&options.FailOnEvictionBlocker, "fail-on-eviction-blocker", options.FailOnEvictionBlocker, "Fail before updating any instance if PodDisruptionBudgets would block a drain")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format. One of table or json. When updating, json writes an event for each step of the rolling update")
+	cmd.Flags().StringVar(&options.EventsFile, "events-file", options.EventsFile, "File to write a JSON event to for each step of the rolling update")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputTable, OutputJSON}, cobra.ShellCompDirectiveNoFileComp
+	})
 
 	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
 		switch name {
@@ -228,6 +264,11 @@ func NewCmdRollingUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 }
 
 func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, options *RollingUpdateOptions) error {
+	humanOut, err := rollingUpdateHumanOut(options.Output, out)
+	if err != nil {
+		return err
+	}
+
 	clientset, err := f.KopsClient()
 	if err != nil {
 		return err
@@ -285,7 +326,7 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		countByRole[instanceGroup.Spec.Role] = countByRole[instanceGroup.Spec.Role] + minSize
 	}
 	if countByRole[kopsapi.InstanceGroupRoleAPIServer]+countByRole[kopsapi.InstanceGroupRoleControlPlane] <= 1 {
-		fmt.Fprintf(out, "Detected single-control-plane cluster; won't detach before draining\n")
+		fmt.Fprintf(humanOut, "Detected single-control-plane cluster; won't detach before draining\n")
 		options.DeregisterControlPlaneNodes = false
 	}
 
@@ -378,56 +419,108 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 		return err
 	}
 
-	{
-		t := &tables.Table{}
-		t.AddColumn("NAME", func(r *cloudinstances.CloudInstanceGroup) string {
-			return r.InstanceGroup.ObjectMeta.Name
-		})
-		t.AddColumn("STATUS", func(r *cloudinstances.CloudInstanceGroup) string {
-			return r.Status()
-		})
-		t.AddColumn("NEEDUPDATE", func(r *cloudinstances.CloudInstanceGroup) string {
-			return strconv.Itoa(len(r.NeedUpdate))
-		})
-		t.AddColumn("READY", func(r *cloudinstances.CloudInstanceGroup) string {
-			return strconv.Itoa(len(r.Ready))
-		})
-		t.AddColumn("MIN", func(r *cloudinstances.CloudInstanceGroup) string {
-			return strconv.Itoa(r.MinSize)
-		})
-		t.AddColumn("TARGET", func(r *cloudinstances.CloudInstanceGroup) string {
-			return strconv.Itoa(r.TargetSize)
-		})
-		t.AddColumn("MAX", func(r *cloudinstances.CloudInstanceGroup) string {
-			return strconv.Itoa(r.MaxSize)
-		})
-		t.AddColumn("NODES", func(r *cloudinstances.CloudInstanceGroup) string {
-			var nodes []*v1.Node
-			for _, i := range r.Ready {
-				if i.Node != nil {
-					nodes = append(nodes, i.Node)
-				}
-			}
-			for _, i := range r.NeedUpdate {
-				if i.Node != nil {
-					nodes = append(nodes, i.Node)
-				}
-			}
-			return strconv.Itoa(len(nodes))
-		})
-		var l []*cloudinstances.CloudInstanceGroup
-		for _, v := range groups {
-			l = append(l, v)
-		}
+	if proceed, err := previewRollingUpdate(d, groups, options, out, humanOut); err != nil || !proceed {
+		return err
+	}
 
-		columns := []string{"NAME", "STATUS", "NEEDUPDATE", "READY", "MIN", "TARGET", "MAX"}
-		if !options.CloudOnly {
-			columns = append(columns, "NODES")
+	var clusterValidator validation.ClusterValidator
+	if !options.CloudOnly {
+		clusterValidator, err = validation.NewClusterValidator(cluster, cloud, list, config.Host, k8sClient)
+		if err != nil {
+			return fmt.Errorf("cannot create cluster validator: %v", err)
 		}
-		err := t.Render(l, out, columns...)
+	}
+	d.ClusterValidator = clusterValidator
+
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		return fmt.Errorf("error building ConfigBase for cluster: %v", err)
+	}
+	d.Checkpointer = instancegroups.NewCheckpointer(configBase, instancegroups.DefaultCheckpointHolder())
+
+	var eventWriters []io.Writer
+	if options.Output == OutputJSON {
+		eventWriters = append(eventWriters, out)
+	}
+	if options.EventsFile != "" {
+		eventsFile, err := os.Create(options.EventsFile)
 		if err != nil {
-			return err
+			return fmt.Errorf("error creating events file: %w", err)
+		}
+		defer eventsFile.Close()
+		eventWriters = append(eventWriters, eventsFile)
+	}
+	if len(eventWriters) != 0 {
+		d.EventSink = instancegroups.NewJSONEventSink(io.MultiWriter(eventWriters...))
+	}
+
+	return d.RollingUpdate(groups, list)
+}
+
+// rollingUpdateHumanOut returns the writer for the output meant for people.
+// With JSON output, stdout holds only the JSON events or plan, so everything else is written to stderr.
+func rollingUpdateHumanOut(output string, out io.Writer) (io.Writer, error) {
+	switch output {
+	case OutputTable:
+		return out, nil
+	case OutputJSON:
+		return os.Stderr, nil
+	default:
+		return nil, fmt.Errorf("unknown output format: %q", output)
+	}
+}
+
+// previewRollingUpdate reports the instance groups that need updating and, if requested, the plan of the rolling update.
+// It returns true if the rolling update should go ahead.
+func previewRollingUpdate(d *instancegroups.RollingUpdateCluster, groups map[string]*cloudinstances.CloudInstanceGroup, options *RollingUpdateOptions, out io.Writer, humanOut io.Writer) (bool, error) {
+	t := &tables.Table{}
+	t.AddColumn("NAME", func(r *cloudinstances.CloudInstanceGroup) string {
+		return r.InstanceGroup.ObjectMeta.Name
+	})
+	t.AddColumn("STATUS", func(r *cloudinstances.CloudInstanceGroup) string {
+		return r.Status()
+	})
+	t.AddColumn("NEEDUPDATE", func(r *cloudinstances.CloudInstanceGroup) string {
+		return strconv.Itoa(len(r.NeedUpdate))
+	})
+	t.AddColumn("READY", func(r *cloudinstances.CloudInstanceGroup) string {
+		return strconv.Itoa(len(r.Ready))
+	})
+	t.AddColumn("MIN", func(r *cloudinstances.CloudInstanceGroup) string {
+		return strconv.Itoa(r.MinSize)
+	})
+	t.AddColumn("TARGET", func(r *cloudinstances.CloudInstanceGroup) string {
+		return strconv.Itoa(r.TargetSize)
+	})
+	t.AddColumn("MAX", func(r *cloudinstances.CloudInstanceGroup) string {
+		return strconv.Itoa(r.MaxSize)
+	})
+	t.AddColumn("NODES", func(r *cloudinstances.CloudInstanceGroup) string {
+		var nodes []*v1.Node
+		for _, i := range r.Ready {
+			if i.Node != nil {
+				nodes = append(nodes, i.Node)
+			}
+		}
+		for _, i := range r.NeedUpdate {
+			if i.Node != nil {
+				nodes = append(nodes, i.Node)
+			}
 		}
+		return strconv.Itoa(len(nodes))
+	})
+	var l []*cloudinstances.CloudInstanceGroup
+	for _, v := range groups {
+		l = append(l, v)
+	}
+
+	columns := []string{"NAME", "STATUS", "NEEDUPDATE", "READY", "MIN", "TARGET", "MAX"}
+	if !options.CloudOnly {
+		columns = append(columns, "NODES")
+	}
+	err := t.Render(l, humanOut, columns...)
+	if err != nil {
+		return false, err
 	}
 
 	needUpdate := false
@@ -438,25 +531,117 @@ func RunRollingUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer
 	}
 
 	if !needUpdate && !options.Force {
-		fmt.Printf("\nNo rolling-update required.\n")
-		return nil
+		fmt.Fprintf(humanOut, "\nNo rolling-update required.\n")
+		if options.Plan && options.Output == OutputJSON {
+			return false, rollingUpdatePlanOutput(&instancegroups.RollingUpdatePlan{Groups: []*instancegroups.GroupPlan{}}, options.Output, out)
+		}
+		return false, nil
+	}
+
+	if options.Plan || (options.Yes && options.FailOnEvictionBlocker && !options.CloudOnly) {
+		if options.CloudOnly {
+			return false, fmt.Errorf("--plan cannot be used with --cloudonly, as it requires access to the kubernetes API")
+		}
+
+		plan, err := d.Plan(groups)
+		if err != nil {
+			return false, fmt.Errorf("error planning rolling update: %w", err)
+		}
+
+		if options.Plan {
+			return false, rollingUpdatePlanOutput(plan, options.Output, out)
+		}
+
+		if blockers := plan.Blockers(); len(blockers) != 0 {
+			fmt.Fprintf(humanOut, "\n")
+			if err := rollingUpdateBlockersOutputTable(blockers, humanOut); err != nil {
+				return false, err
+			}
+			return false, fmt.Errorf("PodDisruptionBudgets would block %d eviction(s); not starting rolling update", len(blockers))
+		}
 	}
 
 	if !options.Yes {
-		fmt.Printf("\nMust specify --yes to rolling-update.\n")
-		return nil
+		fmt.Fprintf(humanOut, "\nMust specify --yes to rolling-update.\n")
+		return false, nil
 	}
 
-	var clusterValidator validation.ClusterValidator
-	if !options.CloudOnly {
-		clusterValidator, err = validation.NewClusterValidator(cluster, cloud, list, config.Host, k8sClient)
+	return true, nil
+}
+
+func rollingUpdatePlanOutput(plan *instancegroups.RollingUpdatePlan, output string, out io.Writer) error {
+	switch output {
+	case OutputTable:
+		var nodes []*instancegroups.NodePlan
+		groupNames := make(map[*instancegroups.NodePlan]string)
+		for _, group := range plan.Groups {
+			for _, node := range group.Nodes {
+				nodes = append(nodes, node)
+				groupNames[node] = group.Name
+			}
+		}
+
+		t := &tables.Table{}
+		t.AddColumn("INSTANCEGROUP", func(r *instancegroups.NodePlan) string {
+			return groupNames[r]
+		})
+		t.AddColumn("INSTANCE", func(r *instancegroups.NodePlan) string {
+			return r.InstanceID
+		})
+		t.AddColumn("NODE", func(r *instancegroups.NodePlan) string {
+			return r.NodeName
+		})
+		t.AddColumn("BATCH", func(r *instancegroups.NodePlan) string {
+			return strconv.Itoa(r.Batch)
+		})
+		t.AddColumn("PODS", func(r *instancegroups.NodePlan) string {
+			return strconv.Itoa(r.EvictedPods)
+		})
+		t.AddColumn("BLOCKED", func(r *instancegroups.NodePlan) string {
+			return strconv.FormatBool(len(r.Blockers) != 0)
+		})
+		fmt.Fprintf(out, "\n")
+		if err := t.Render(nodes, out, "INSTANCEGROUP", "INSTANCE", "NODE", "BATCH", "PODS", "BLOCKED"); err != nil {
+			return err
+		}
+
+		if blockers := plan.Blockers(); len(blockers) != 0 {
+			fmt.Fprintf(out, "\n")
+			return rollingUpdateBlockersOutputTable(blockers, out)
+		}
+		fmt.Fprintf(out, "\nNo drains are expected to be blocked by PodDisruptionBudgets.\n")
+		return nil
+
+	case OutputJSON:
+		j, err := json.MarshalIndent(plan, "", "  ")
 		if err != nil {
-			return fmt.Errorf("cannot create cluster validator: %v", err)
+			return fmt.Errorf("unable to marshal JSON: %v", err)
 		}
+		if _, err := out.Write(j); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown output format: %q", output)
 	}
-	d.ClusterValidator = clusterValidator
+}
 
-	return d.RollingUpdate(groups, list)
+func rollingUpdateBlockersOutputTable(blockers []*instancegroups.EvictionBlocker, out io.Writer) error {
+	t := &tables.Table{}
+	t.AddColumn("NODE", func(r *instancegroups.EvictionBlocker) string {
+		return r.NodeName
+	})
+	t.AddColumn("POD", func(r *instancegroups.EvictionBlocker) string {
+		return r.Pod
+	})
+	t.AddColumn("PDB", func(r *instancegroups.EvictionBlocker) string {
+		return r.PodDisruptionBudget
+	})
+	t.AddColumn("REASON", func(r *instancegroups.EvictionBlocker) string {
+		return r.Reason
+	})
+	return t.Render(blockers, out, "NODE", "POD", "PDB", "REASON")
 }
 
 func completeInstanceGroup(f commandutils.Factory, selectedInstanceGroups *[]string, selectedInstanceGroupRoles *[]string) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
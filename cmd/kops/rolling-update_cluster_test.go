@@ -0,0 +1,93 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/instancegroups"
+)
+
+func TestRollingUpdatePlanJSONOutput(t *testing.T) {
+	for _, needUpdate := range []bool{true, false} {
+		ig := &kopsapi.InstanceGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+			Spec:       kopsapi.InstanceGroupSpec{Role: kopsapi.InstanceGroupRoleNode},
+		}
+		group := &cloudinstances.CloudInstanceGroup{
+			HumanName:     "nodes",
+			InstanceGroup: ig,
+			MinSize:       1,
+			TargetSize:    1,
+			MaxSize:       1,
+		}
+		instance := &cloudinstances.CloudInstance{
+			ID:                 "i-1",
+			CloudInstanceGroup: group,
+			Node:               &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		}
+		if needUpdate {
+			group.NeedUpdate = append(group.NeedUpdate, instance)
+		} else {
+			group.Ready = append(group.Ready, instance)
+		}
+		groups := map[string]*cloudinstances.CloudInstanceGroup{"nodes": group}
+
+		d := &instancegroups.RollingUpdateCluster{
+			Ctx:       context.TODO(),
+			Cluster:   &kopsapi.Cluster{},
+			K8sClient: fake.NewSimpleClientset(),
+		}
+		options := &RollingUpdateOptions{
+			Output: OutputJSON,
+			Plan:   true,
+		}
+
+		var stdout bytes.Buffer
+		humanOut, err := rollingUpdateHumanOut(options.Output, &stdout)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		proceed, err := previewRollingUpdate(d, groups, options, &stdout, humanOut)
+		if err != nil {
+			t.Fatalf("unexpected error planning rolling update: %v", err)
+		}
+		if proceed {
+			t.Errorf("expected --plan not to proceed with the rolling update")
+		}
+
+		var plan instancegroups.RollingUpdatePlan
+		if err := json.Unmarshal(stdout.Bytes(), &plan); err != nil {
+			t.Fatalf("stdout is not valid JSON: %v\n%s", err, stdout.String())
+		}
+		expectedGroups := 0
+		if needUpdate {
+			expectedGroups = 1
+		}
+		if len(plan.Groups) != expectedGroups {
+			t.Errorf("expected %d groups in the plan, got %d", expectedGroups, len(plan.Groups))
+		}
+	}
+}
@@ -157,6 +157,13 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	viper.BindEnv("KOPS_STATE_STORE")
 	// TODO implement completion against VFS
 
+	cmd.PersistentFlags().StringVar(&rootCommand.PolicyPath, "policy", "", "Location of the policy rules that cluster specs must comply with (default is policy.yaml in the state store). Overrides KOPS_POLICY environment variable")
+	viper.BindPFlag("KOPS_POLICY", cmd.PersistentFlags().Lookup("policy"))
+	viper.BindEnv("KOPS_POLICY")
+	cmd.RegisterFlagCompletionFunc("policy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"yaml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+	})
+
 	defaultClusterName := os.Getenv("KOPS_CLUSTER_NAME")
 	cmd.PersistentFlags().StringVarP(&rootCommand.clusterName, "name", "", defaultClusterName, "Name of cluster. Overrides KOPS_CLUSTER_NAME environment variable")
 	cmd.RegisterFlagCompletionFunc("name", commandutils.CompleteClusterName(rootCommand.factory, false, false))
@@ -164,6 +171,7 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	// create subcommands
 	cmd.AddCommand(NewCmdCreate(f, out))
 	cmd.AddCommand(NewCmdDelete(f, out))
+	cmd.AddCommand(NewCmdDiff(f, out))
 	cmd.AddCommand(NewCmdDistrust(f, out))
 	cmd.AddCommand(NewCmdEdit(f, out))
 	cmd.AddCommand(NewCmdExport(f, out))
@@ -172,6 +180,7 @@ func NewCmdRoot(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(commands.NewCmdHelpers(f, out))
 	cmd.AddCommand(NewCmdPromote(f, out))
 	cmd.AddCommand(NewCmdReplace(f, out))
+	cmd.AddCommand(NewCmdRollback(f, out))
 	cmd.AddCommand(NewCmdRollingUpdate(f, out))
 	cmd.AddCommand(NewCmdToolbox(f, out))
 	cmd.AddCommand(NewCmdTrust(f, out))
@@ -217,6 +226,8 @@ func initConfig() {
 
 	// Tolerate multiple slashes at end
 	rootCommand.RegistryPath = strings.TrimSuffix(rootCommand.RegistryPath, "/")
+
+	rootCommand.PolicyPath = viper.GetString("KOPS_POLICY")
 }
 
 func (c *RootCmd) AddCommand(cmd *cobra.Command) {
@@ -36,6 +36,8 @@ func NewCmdToolbox(f commandutils.Factory, out io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdToolboxEnroll(f, out))
 	cmd.AddCommand(NewCmdToolboxTemplate(f, out))
 	cmd.AddCommand(NewCmdToolboxInstanceSelector(f, out))
+	cmd.AddCommand(NewCmdToolboxMigrateStateStore(f, out))
+	cmd.AddCommand(NewCmdToolboxFsck(f, out))
 	cmd.AddCommand(NewCmdToolboxAddons(out))
 
 	return cmd
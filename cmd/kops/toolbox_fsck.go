@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/pkg/fsck"
+	"k8s.io/kops/util/pkg/tables"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxFsckLong = templates.LongDesc(i18n.T(`
+	Checks the integrity of a cluster's files in the state store.
+
+	Every object is validated against its schema, keysets are checked for consistent certificates
+	and private keys, expiring certificates and unusable primary keys, and addon manifests are
+	checked against the hashes in the bootstrap channel.
+
+	Each problem is listed with a suggested fix. With --repair, the problems that can be fixed
+	safely are fixed in the state store.`))
+
+	toolboxFsckExample = templates.Examples(i18n.T(`
+	# Check a cluster's state store
+	kops toolbox fsck --name k8s-cluster.example.com --state s3://my-state-store
+
+	# Check a cluster's state store, and fix the problems that can be fixed safely
+	kops toolbox fsck --name k8s-cluster.example.com --state s3://my-state-store --repair
+	`))
+
+	toolboxFsckShort = i18n.T(`Check the integrity of a cluster's state store`)
+)
+
+type ToolboxFsckOptions struct {
+	ClusterName string
+
+	// Repair fixes the problems that can be fixed safely.
+	Repair bool
+}
+
+func NewCmdToolboxFsck(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxFsckOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "fsck [CLUSTER]",
+		Short:             toolboxFsckShort,
+		Long:              toolboxFsckLong,
+		Example:           toolboxFsckExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxFsck(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().BoolVar(&options.Repair, "repair", options.Repair, "Fix the problems that can be fixed safely")
+
+	return cmd
+}
+
+func RunToolboxFsck(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxFsckOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	checker := &fsck.Checker{
+		Clientset: clientset,
+		Cluster:   cluster,
+	}
+	problems, err := checker.Check(ctx)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Fprintf(out, "No problems found in the state store of cluster %q\n", cluster.Name)
+		return nil
+	}
+
+	repaired := map[*fsck.Problem]bool{}
+	if options.Repair {
+		for _, problem := range problems {
+			if !problem.Repairable() {
+				continue
+			}
+			if err := problem.Repair(ctx); err != nil {
+				return fmt.Errorf("error repairing %s: %w", problem.Path, err)
+			}
+			repaired[problem] = true
+		}
+	}
+
+	t := &tables.Table{}
+	t.AddColumn("PATH", func(p *fsck.Problem) string {
+		return p.Path
+	})
+	t.AddColumn("SEVERITY", func(p *fsck.Problem) string {
+		return string(p.Severity)
+	})
+	t.AddColumn("PROBLEM", func(p *fsck.Problem) string {
+		return p.Message
+	})
+	t.AddColumn("FIX", func(p *fsck.Problem) string {
+		return p.Fix
+	})
+	t.AddColumn("REPAIR", func(p *fsck.Problem) string {
+		switch {
+		case repaired[p]:
+			return "repaired"
+		case p.Repairable():
+			return "--repair"
+		default:
+			return ""
+		}
+	})
+	if err := t.Render(problems, out, "PATH", "SEVERITY", "PROBLEM", "FIX", "REPAIR"); err != nil {
+		return err
+	}
+
+	errors := 0
+	for _, problem := range problems {
+		if problem.Severity == fsck.SeverityError && !repaired[problem] {
+			errors++
+		}
+	}
+	if errors != 0 {
+		return fmt.Errorf("found %d errors in the state store of cluster %q", errors, cluster.Name)
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	apimodel "k8s.io/kops/pkg/apis/kops/model"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/commands/commandutils"
+	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	toolboxMigrateStateStoreLong = templates.LongDesc(i18n.T(`
+	Copies a cluster's state to another state store, which may use a different backend.
+
+	Every file under the cluster's config base is copied and verified against its hash, and
+	the cluster's configStore is rewritten to point at the new location. The original state store
+	is left unchanged; delete it once the cluster has been updated to use the new one.
+
+	Without --yes, only lists the files that would be copied.`))
+
+	toolboxMigrateStateStoreExample = templates.Examples(i18n.T(`
+	# Preview moving a cluster's state from S3 to GCS
+	kops toolbox migrate-state-store --name k8s-cluster.example.com --state s3://old-bucket --to gs://new-bucket
+
+	# Move the cluster's state
+	kops toolbox migrate-state-store --name k8s-cluster.example.com --state s3://old-bucket --to gs://new-bucket --yes
+	`))
+
+	toolboxMigrateStateStoreShort = i18n.T(`Move a cluster's state to another state store`)
+)
+
+type ToolboxMigrateStateStoreOptions struct {
+	ClusterName string
+
+	// To is the state store to move the cluster to.
+	To string
+
+	// Yes must be set to copy the files; otherwise the files are only listed.
+	Yes bool
+}
+
+func NewCmdToolboxMigrateStateStore(f commandutils.Factory, out io.Writer) *cobra.Command {
+	options := &ToolboxMigrateStateStoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:               "migrate-state-store [CLUSTER]",
+		Short:             toolboxMigrateStateStoreShort,
+		Long:              toolboxMigrateStateStoreLong,
+		Example:           toolboxMigrateStateStoreExample,
+		Args:              rootCommand.clusterNameArgs(&options.ClusterName),
+		ValidArgsFunction: commandutils.CompleteClusterName(f, true, false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunToolboxMigrateStateStore(cmd.Context(), f, out, options)
+		},
+	}
+
+	cmd.Flags().StringVar(&options.To, "to", options.To, "The state store to move the cluster to")
+	cmd.MarkFlagRequired("to")
+	cmd.Flags().BoolVarP(&options.Yes, "yes", "y", options.Yes, "Copy the files; otherwise only list them")
+
+	return cmd
+}
+
+func RunToolboxMigrateStateStore(ctx context.Context, f commandutils.Factory, out io.Writer, options *ToolboxMigrateStateStoreOptions) error {
+	clientset, err := f.KopsClient()
+	if err != nil {
+		return err
+	}
+
+	cluster, err := clientset.GetCluster(ctx, options.ClusterName)
+	if err != nil {
+		return err
+	}
+	if cluster == nil {
+		return fmt.Errorf("cluster not found %q", options.ClusterName)
+	}
+
+	destination, err := f.VFSContext().BuildVfsPath(options.To)
+	if err != nil {
+		return fmt.Errorf("error parsing --to %q: %w", options.To, err)
+	}
+
+	return migrateStateStore(ctx, f.VFSContext(), clientset, cluster, destination, options.Yes, out)
+}
+
+// migrateStateStore copies the cluster's state to the destination state store,
+// and rewrites its configStore to point at the copy.
+func migrateStateStore(ctx context.Context, vfsContext *vfs.VFSContext, clientset simple.Clientset, cluster *kops.Cluster, destination vfs.Path, yes bool, out io.Writer) error {
+	sourceBase, err := registry.ConfigBase(vfsContext, cluster)
+	if err != nil {
+		return err
+	}
+	destinationBase := destination.Join(cluster.Name)
+	if sourceBase.Path() == destinationBase.Path() {
+		return fmt.Errorf("cluster %q is already stored in %q", cluster.Name, destination)
+	}
+
+	files, err := sourceBase.ReadTree(ctx)
+	if err != nil {
+		return fmt.Errorf("error listing %q: %w", sourceBase, err)
+	}
+
+	// Compute the new locations of the cluster's stores, so that files are written with the ACLs they will have there
+	migrated := cluster.DeepCopy()
+	migrated.Spec.ConfigStore.Base = destinationBase.Path()
+	migrated.Spec.ConfigStore.Keypairs = rebaseStorePath(cluster.Spec.ConfigStore.Keypairs, sourceBase, destinationBase)
+	migrated.Spec.ConfigStore.Secrets = rebaseStorePath(cluster.Spec.ConfigStore.Secrets, sourceBase, destinationBase)
+
+	for _, location := range []string{cluster.Spec.ConfigStore.Keypairs, cluster.Spec.ConfigStore.Secrets} {
+		if location != "" && !strings.HasPrefix(location, sourceBase.Path()+"/") {
+			klog.Warningf("%q is outside the cluster's config base and will not be copied", location)
+		}
+	}
+
+	if !yes {
+		for _, file := range files {
+			relativePath, err := vfs.RelativePath(sourceBase, file)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Would copy %s to %s\n", file, destinationBase.Join(relativePath))
+		}
+		fmt.Fprintf(out, "\nMust specify --yes to copy %d files\n", len(files))
+		return nil
+	}
+
+	for _, file := range files {
+		relativePath, err := vfs.RelativePath(sourceBase, file)
+		if err != nil {
+			return err
+		}
+		if err := copyStateStoreFile(ctx, file, destinationBase.Join(relativePath), migrated); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(out, "Copied %d files from %s to %s\n", len(files), sourceBase, destinationBase)
+
+	destinationClientset := vfsclientset.NewVFSClientset(vfsContext, destination)
+	copied, err := destinationClientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		return fmt.Errorf("error reading copied cluster: %w", err)
+	}
+	if copied == nil {
+		return fmt.Errorf("cluster %q was not found in %q after copying its config base", cluster.Name, destination)
+	}
+	copied.Spec.ConfigStore = migrated.Spec.ConfigStore
+	if _, err := destinationClientset.UpdateCluster(ctx, copied, nil); err != nil {
+		return fmt.Errorf("error updating configStore of copied cluster: %w", err)
+	}
+
+	igs, err := clientset.InstanceGroupsFor(cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	var rollingUpdate []string
+	for i := range igs.Items {
+		ig := &igs.Items[i]
+		// These instance groups read their configuration from the state store, rather than from kops-controller
+		if ig.HasAPIServer() || !apimodel.UseKopsControllerForNodeConfig(cluster) {
+			rollingUpdate = append(rollingUpdate, ig.Name)
+		}
+	}
+
+	fmt.Fprintf(out, "\nTo complete the migration:\n")
+	fmt.Fprintf(out, "  export KOPS_STATE_STORE=%s\n", destination)
+	fmt.Fprintf(out, "  kops update cluster %s --yes\n", cluster.Name)
+	if len(rollingUpdate) > 0 {
+		fmt.Fprintf(out, "  kops rolling-update cluster %s --yes --force --instance-group %s\n", cluster.Name, strings.Join(rollingUpdate, ","))
+		fmt.Fprintf(out, "\nInstance groups that read the state store, and need a rolling update to use the new location: %s\n", strings.Join(rollingUpdate, ", "))
+	}
+	fmt.Fprintf(out, "\nThen delete %s\n", sourceBase)
+
+	return nil
+}
+
+// rebaseStorePath moves a store location under the source config base to the same location under the destination.
+// Locations outside the config base are not changed.
+func rebaseStorePath(location string, sourceBase, destinationBase vfs.Path) string {
+	if location == sourceBase.Path() {
+		return destinationBase.Path()
+	}
+	if relativePath, ok := strings.CutPrefix(location, sourceBase.Path()+"/"); ok {
+		return destinationBase.Join(relativePath).Path()
+	}
+	return location
+}
+
+// copyStateStoreFile copies a file between state stores, verifying that the copy has the same hash.
+func copyStateStoreFile(ctx context.Context, source, destination vfs.Path, cluster *kops.Cluster) error {
+	data, err := source.ReadFile(ctx)
+	if err != nil {
+		return fmt.Errorf("error reading %q: %w", source, err)
+	}
+
+	acl, err := acls.GetACL(ctx, destination, cluster)
+	if err != nil {
+		return err
+	}
+	if err := destination.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+		return fmt.Errorf("error writing %q: %w", destination, err)
+	}
+
+	// Use the hash reported by the destination where possible, falling back to reading the copy back
+	var actual *hashing.Hash
+	if hasHash, ok := destination.(vfs.HasHash); ok {
+		actual, err = hasHash.PreferredHash()
+		if err != nil {
+			return fmt.Errorf("error getting hash of %q: %w", destination, err)
+		}
+	}
+	if actual == nil {
+		copied, err := destination.ReadFile(ctx)
+		if err != nil {
+			return fmt.Errorf("error reading back %q: %w", destination, err)
+		}
+		actual, err = hashing.HashAlgorithmSHA256.Hash(bytes.NewReader(copied))
+		if err != nil {
+			return err
+		}
+	}
+
+	expected, err := actual.Algorithm.Hash(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if !expected.Equal(actual) {
+		return fmt.Errorf("hash of %q is %s after copying, expected %s", destination, actual, expected)
+	}
+	return nil
+}
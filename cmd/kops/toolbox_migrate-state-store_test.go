@@ -0,0 +1,111 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/cmd/kops/util"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/testutils"
+)
+
+func TestToolboxMigrateStateStore(t *testing.T) {
+	t.Setenv("SKIP_REGION_CHECK", "1")
+	ctx := context.Background()
+
+	clusterName := "test.k8s.io"
+	cluster := testutils.BuildMinimalCluster(clusterName)
+	cluster.Spec.ConfigStore.Base = "memfs://migrate-source/" + clusterName
+	cluster.Spec.ConfigStore.Keypairs = "memfs://migrate-source/" + clusterName + "/pki"
+	controlPlane := testutils.BuildMinimalMasterInstanceGroup("subnet-us-test-1a")
+	nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+
+	testutils.NewIntegrationTestHarness(t).SetupMockAWS()
+
+	factoryOptions := &util.FactoryOptions{}
+	factoryOptions.RegistryPath = "memfs://migrate-source"
+	factory := util.NewFactory(factoryOptions)
+	clientset, err := factory.KopsClient()
+	if err != nil {
+		t.Fatalf("could not create clientset: %v", err)
+	}
+	cluster, err = clientset.CreateCluster(ctx, cluster)
+	if err != nil {
+		t.Fatalf("could not create cluster: %v", err)
+	}
+	for _, ig := range []*kops.InstanceGroup{&controlPlane, &nodes} {
+		if _, err := clientset.InstanceGroupsFor(cluster).Create(ctx, ig, v1.CreateOptions{}); err != nil {
+			t.Fatalf("could not create instance group: %v", err)
+		}
+	}
+
+	// Without --yes nothing is copied
+	{
+		var stdout bytes.Buffer
+		options := &ToolboxMigrateStateStoreOptions{ClusterName: clusterName, To: "memfs://migrate-destination"}
+		if err := RunToolboxMigrateStateStore(ctx, factory, &stdout, options); err != nil {
+			t.Fatalf("error previewing migration: %v", err)
+		}
+		if !strings.Contains(stdout.String(), "Would copy memfs://migrate-source/test.k8s.io/config to memfs://migrate-destination/test.k8s.io/config") {
+			t.Errorf("unexpected preview output:\n%s", stdout.String())
+		}
+		destination, err := factory.VFSContext().BuildVfsPath("memfs://migrate-destination")
+		if err != nil {
+			t.Fatalf("error building path: %v", err)
+		}
+		if _, err := destination.Join(clusterName, "config").ReadFile(ctx); !os.IsNotExist(err) {
+			t.Fatalf("expected no files to be copied without --yes, got error %v", err)
+		}
+	}
+
+	var stdout bytes.Buffer
+	options := &ToolboxMigrateStateStoreOptions{ClusterName: clusterName, To: "memfs://migrate-destination", Yes: true}
+	if err := RunToolboxMigrateStateStore(ctx, factory, &stdout, options); err != nil {
+		t.Fatalf("error migrating state store: %v", err)
+	}
+
+	destination, err := factory.VFSContext().BuildVfsPath("memfs://migrate-destination")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	migrated, err := vfsclientset.NewVFSClientset(factory.VFSContext(), destination).GetCluster(ctx, clusterName)
+	if err != nil {
+		t.Fatalf("error reading migrated cluster: %v", err)
+	}
+	if migrated.Spec.ConfigStore.Base != "memfs://migrate-destination/test.k8s.io" {
+		t.Errorf("unexpected configStore.base %q", migrated.Spec.ConfigStore.Base)
+	}
+	if migrated.Spec.ConfigStore.Keypairs != "memfs://migrate-destination/test.k8s.io/pki" {
+		t.Errorf("unexpected configStore.keypairs %q", migrated.Spec.ConfigStore.Keypairs)
+	}
+
+	ig, err := vfsclientset.NewVFSClientset(factory.VFSContext(), destination).InstanceGroupsFor(migrated).Get(ctx, "nodes", v1.GetOptions{})
+	if err != nil || ig == nil {
+		t.Fatalf("error reading migrated instance group: %v", err)
+	}
+
+	if !strings.Contains(stdout.String(), "need a rolling update to use the new location: master-subnet-us-test-1a\n") {
+		t.Errorf("expected only the control plane to need a rolling update, got:\n%s", stdout.String())
+	}
+}
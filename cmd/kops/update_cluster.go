@@ -19,6 +19,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -34,11 +35,13 @@ import (
 	"k8s.io/kops/pkg/assets"
 	"k8s.io/kops/pkg/commands/commandutils"
 	"k8s.io/kops/pkg/kubeconfig"
+	"k8s.io/kops/pkg/policy"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup"
 	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -53,16 +56,27 @@ var (
 	updateClusterExample = templates.Examples(i18n.T(`
 	# After the cluster has been edited or upgraded, update the cloud resources with:
 	kops update cluster k8s-cluster.example.com --yes --state=s3://my-state-store --yes
+
+	# Write terraform configuration for an existing cluster, with import blocks
+	# to adopt its cloud resources into terraform state:
+	kops update cluster k8s-cluster.example.com --target=terraform --generate-imports --state=s3://my-state-store
+
+	# Print the changes that would be made as JSON, for processing by other tools:
+	kops update cluster k8s-cluster.example.com -o json --state=s3://my-state-store
 	`))
 
 	updateClusterShort = i18n.T("Update a cluster.")
 )
 
 type UpdateClusterOptions struct {
-	Yes                bool
-	Target             string
-	OutDir             string
-	SSHPublicKey       string
+	Yes          bool
+	Target       string
+	OutDir       string
+	SSHPublicKey string
+	// GenerateImports emits terraform import blocks for existing cloud resources.
+	GenerateImports bool
+	// TerraformModule writes the terraform output as a root module that calls a reusable module, with input variables and outputs.
+	TerraformModule    bool
 	RunTasksOptions    fi.RunTasksOptions
 	AllowKopsDowngrade bool
 	// GetAssets is whether this is invoked from the CmdGetAssets.
@@ -86,6 +100,9 @@ type UpdateClusterOptions struct {
 	// The goal is that the cluster can keep running even during more disruptive
 	// infrastructure changes.
 	Prune bool
+
+	// Output is the format of the dry-run plan; one of json or yaml. If empty, a human-readable report is printed.
+	Output string
 }
 
 func (o *UpdateClusterOptions) InitDefaults() {
@@ -125,6 +142,8 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 	cmd.Flags().StringVar(&options.SSHPublicKey, "ssh-public-key", options.SSHPublicKey, "SSH public key to use (deprecated: use kops create secret instead)")
 	cmd.Flags().StringVar(&options.OutDir, "out", options.OutDir, "Path to write any local output")
 	cmd.MarkFlagDirname("out")
+	cmd.Flags().BoolVar(&options.GenerateImports, "generate-imports", options.GenerateImports, "Emit terraform import blocks for existing cloud resources. Requires --target=terraform")
+	cmd.Flags().BoolVar(&options.TerraformModule, "terraform-module", options.TerraformModule, "Write the terraform output as a root module that calls a reusable module, with input variables and outputs. Requires --target=terraform")
 	cmd.Flags().BoolVar(&options.CreateKubecfg, "create-kube-config", options.CreateKubecfg, "Will control automatically creating the kube config file on your local filesystem")
 	cmd.Flags().DurationVar(&options.admin, "admin", options.admin, "Also export a cluster admin user credential with the specified lifetime and add it to the cluster context")
 	cmd.Flags().Lookup("admin").NoOptDefVal = kubeconfig.DefaultKubecfgAdminLifetime.String()
@@ -143,6 +162,11 @@ func NewCmdUpdateCluster(f *util.Factory, out io.Writer) *cobra.Command {
 
 	cmd.Flags().BoolVar(&options.Prune, "prune", options.Prune, "Delete old revisions of cloud resources that were needed during an upgrade")
 
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Output format of the dry-run plan. One of: json, yaml")
+	cmd.RegisterFlagCompletionFunc("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{OutputJSON, OutputYaml}, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	return cmd
 }
 
@@ -186,6 +210,23 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		c.CreateKubecfg = true
 	}
 
+	if c.GenerateImports && c.Target != cloudup.TargetTerraform {
+		return nil, fmt.Errorf("--generate-imports requires --target=%s", cloudup.TargetTerraform)
+	}
+	if c.TerraformModule && c.Target != cloudup.TargetTerraform {
+		return nil, fmt.Errorf("--terraform-module requires --target=%s", cloudup.TargetTerraform)
+	}
+
+	switch c.Output {
+	case "":
+	case OutputJSON, OutputYaml:
+		if c.Yes || c.Target == cloudup.TargetTerraform {
+			return nil, fmt.Errorf("--output can only be used for a dry-run")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported output format: %q", c.Output)
+	}
+
 	// direct requires --yes (others do not, because they don't do anything!)
 	if c.Target == cloudup.TargetDirect {
 		if !c.Yes {
@@ -290,11 +331,21 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		return nil, err
 	}
 
+	var clusterPolicy *policy.Policy
+	if !c.GetAssets {
+		clusterPolicy, err = f.Policy(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	applyCmd := &cloudup.ApplyClusterCmd{
 		Cloud:              cloud,
 		Clientset:          clientset,
 		Cluster:            cluster,
 		DryRun:             isDryrun,
+		GenerateImports:    c.GenerateImports,
+		TerraformModule:    c.TerraformModule,
 		AllowKopsDowngrade: c.AllowKopsDowngrade,
 		RunTasksOptions:    &c.RunTasksOptions,
 		OutDir:             c.OutDir,
@@ -302,6 +353,8 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 		TargetName:         targetName,
 		LifecycleOverrides: lifecycleOverrideMap,
 		GetAssets:          c.GetAssets,
+		QuietDryRun:        c.Output != "",
+		Policy:             clusterPolicy,
 		DeletionProcessing: deletionProcessing,
 	}
 
@@ -318,6 +371,9 @@ func RunUpdateCluster(ctx context.Context, f *util.Factory, out io.Writer, c *Up
 
 	if isDryrun && !c.GetAssets {
 		target := applyCmd.Target.(*fi.CloudupDryRunTarget)
+		if c.Output != "" {
+			return results, updateClusterPlanOutput(target, applyCmd.TaskMap, c.Output, out)
+		}
 		if target.HasChanges() {
 			fmt.Fprintf(out, "Must specify --yes to apply changes\n")
 		} else {
@@ -473,6 +529,36 @@ func clusterIsInKubeConfig(contextName string) (bool, error) {
 	return false, nil
 }
 
+// updateClusterPlanOutput writes the changes found by a dry-run in the given format.
+func updateClusterPlanOutput(target *fi.CloudupDryRunTarget, taskMap map[string]fi.CloudupTask, output string, out io.Writer) error {
+	plan, err := target.Plan(taskMap)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case OutputYaml:
+		y, err := yaml.Marshal(plan)
+		if err != nil {
+			return fmt.Errorf("unable to marshal YAML: %v", err)
+		}
+		if _, err := out.Write(y); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	case OutputJSON:
+		j, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return fmt.Errorf("unable to marshal JSON: %v", err)
+		}
+		if _, err := out.Write(append(j, '\n')); err != nil {
+			return fmt.Errorf("error writing to output: %v", err)
+		}
+	default:
+		return fmt.Errorf("unsupported output format: %q", output)
+	}
+	return nil
+}
+
 func completeUpdateClusterTarget(f commandutils.Factory, options *UpdateClusterOptions) func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		ctx := cmd.Context()
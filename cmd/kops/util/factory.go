@@ -17,6 +17,7 @@ limitations under the License.
 package util
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -36,11 +37,15 @@ import (
 	"k8s.io/kops/pkg/client/simple"
 	"k8s.io/kops/pkg/client/simple/api"
 	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/policy"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
 type FactoryOptions struct {
 	RegistryPath string
+
+	// PolicyPath is the location of the policy rules; if empty, the policy in the root of the state store is used.
+	PolicyPath string
 }
 
 type Factory struct {
@@ -51,6 +56,8 @@ type Factory struct {
 	kubernetesClient  kubernetes.Interface
 	certManagerClient certmanager.Interface
 	vfsContext        *vfs.VFSContext
+	policy            *policy.Policy
+	policyLoaded      bool
 
 	cachedRESTConfig *rest.Config
 	dynamicClient    dynamic.Interface
@@ -138,6 +145,20 @@ func (f *Factory) KopsClient() (simple.Clientset, error) {
 	return f.clientset, nil
 }
 
+// Policy returns the policy that cluster and instance group specs must comply with, or nil if none is configured.
+// The policy is loaded once, from the configured policy path or the state store.
+func (f *Factory) Policy(ctx context.Context) (*policy.Policy, error) {
+	if !f.policyLoaded {
+		p, err := policy.Load(ctx, f.VFSContext(), f.options.PolicyPath, f.options.RegistryPath)
+		if err != nil {
+			return nil, err
+		}
+		f.policy = p
+		f.policyLoaded = true
+	}
+	return f.policy, nil
+}
+
 // KopsStateStore returns the configured KOPS_STATE_STORE in use
 func (f *Factory) KopsStateStore() string {
 	return f.options.RegistryPath
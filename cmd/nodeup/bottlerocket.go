@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// bottlerocketUserData is where Bottlerocket places the user-data of a bootstrap container, which holds the boot config
+	bottlerocketUserData = "/.bottlerocket/bootstrap-containers/current/user-data"
+	// bottlerocketRootFS is where Bottlerocket mounts the root filesystem of the host in bootstrap containers
+	bottlerocketRootFS = "/.bottlerocket/rootfs"
+	// bottlerocketBootConfig is where the boot config is kept on the host
+	bottlerocketBootConfig = "/var/lib/kops/kube_env.yaml"
+)
+
+// isBottlerocketBootstrapContainer returns true if nodeup runs as a Bottlerocket bootstrap container
+func isBottlerocketBootstrapContainer() bool {
+	_, err := os.Stat(bottlerocketUserData)
+	return err == nil
+}
+
+// enterBottlerocketHost copies the boot config to the host and changes root to the host,
+// so that nodeup configures the host rather than the bootstrap container.
+// It returns the location of the boot config on the host.
+func enterBottlerocketHost() (string, error) {
+	bootConfig, err := os.ReadFile(bottlerocketUserData)
+	if err != nil {
+		return "", fmt.Errorf("error reading bootstrap container user-data: %w", err)
+	}
+
+	p := filepath.Join(bottlerocketRootFS, bottlerocketBootConfig)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", fmt.Errorf("error creating directory for %q: %w", p, err)
+	}
+	if err := os.WriteFile(p, bootConfig, 0o600); err != nil {
+		return "", fmt.Errorf("error writing %q: %w", p, err)
+	}
+
+	if err := syscall.Chroot(bottlerocketRootFS); err != nil {
+		return "", fmt.Errorf("error changing root to %q: %w", bottlerocketRootFS, err)
+	}
+	if err := os.Chdir("/"); err != nil {
+		return "", fmt.Errorf("error changing directory to /: %w", err)
+	}
+
+	klog.Infof("running nodeup on the Bottlerocket host")
+	return bottlerocketBootConfig, nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main // import "k8s.io/kops/cmd/nodeup"
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops"
 	"k8s.io/kops/nodeup/pkg/bootstrap"
+	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/pkg/fi/nodeup"
 )
 
@@ -40,6 +42,9 @@ func main() {
 	var flagRetries int
 	var dryrun, installSystemdUnit bool
 	target := "direct"
+	mode := nodeup.ModeApply
+	auditInterval := 10 * time.Minute
+	metricsAddress := fmt.Sprintf(":%d", wellknownports.NodeupAuditMetrics)
 
 	if kops.GitVersion != "" {
 		gitVersion = fmt.Sprintf(" (git-%s)", kops.GitVersion)
@@ -51,6 +56,9 @@ func main() {
 	flag.BoolVar(&dryrun, "dryrun", false, "Don't create cloud resources; just show what would be done")
 	flag.StringVar(&target, "target", target, "Target - direct, dryrun")
 	flag.BoolVar(&installSystemdUnit, "install-systemd-unit", installSystemdUnit, "If true, will install a systemd unit instead of running directly")
+	flag.StringVar(&mode, "mode", mode, "Mode - apply configures the node once; audit periodically reports where the node has drifted from its configuration; reconcile also re-applies the drifted tasks")
+	flag.DurationVar(&auditInterval, "audit-interval", auditInterval, "How often to check the node for drift, in audit and reconcile modes")
+	flag.StringVar(&metricsAddress, "metrics-address", metricsAddress, "Address to serve Prometheus metrics on in audit and reconcile modes; empty to disable")
 
 	if dryrun {
 		target = "dryrun"
@@ -59,10 +67,40 @@ func main() {
 	flag.Set("logtostderr", "true")
 	flag.Parse()
 
+	// Bottlerocket runs the nodeup image as a bootstrap container, without arguments
+	if len(os.Args) == 1 && isBottlerocketBootstrapContainer() {
+		conf, err := enterBottlerocketHost()
+		if err != nil {
+			klog.Exitf("error entering Bottlerocket host: %v", err)
+		}
+		flagConf = conf
+	}
+
 	if flagConf == "" {
 		klog.Exitf("--conf is required")
 	}
 
+	switch mode {
+	case nodeup.ModeApply:
+	case nodeup.ModeAudit, nodeup.ModeReconcile:
+		cmd := &nodeup.NodeUpCommand{
+			ConfigLocation: flagConf,
+			Target:         "direct",
+			CacheDir:       flagCacheDir,
+		}
+		err := cmd.Audit(context.Background(), os.Stdout, nodeup.AuditOptions{
+			Interval:       auditInterval,
+			Reconcile:      mode == nodeup.ModeReconcile,
+			MetricsAddress: metricsAddress,
+		})
+		if err != nil {
+			klog.Exitf("error auditing node: %v", err)
+		}
+		os.Exit(0)
+	default:
+		klog.Exitf("unknown --mode %q", mode)
+	}
+
 	retries := flagRetries
 
 	for {
@@ -29,6 +29,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi/nodeup/install"
 	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
 	"k8s.io/kops/util/pkg/distributions"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 type Installation struct {
@@ -96,6 +97,12 @@ func (i *Installation) buildEnvFile() *nodetasks.InstallFile {
 		envVars["S3_SECRET_ACCESS_KEY"] = os.Getenv("S3_SECRET_ACCESS_KEY")
 	}
 
+	for _, name := range vfs.VaultEnvVars {
+		if os.Getenv(name) != "" {
+			envVars[name] = os.Getenv(name)
+		}
+	}
+
 	// Pass in required credentials when using user-defined swift endpoint
 	if os.Getenv("OS_AUTH_URL") != "" {
 		for _, envVar := range []string{
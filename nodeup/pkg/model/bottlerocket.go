@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"io"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+// BottlerocketKubeletCertificate is where the kubelet of Bottlerocket reads its client certificate and key from,
+// when settings.kubernetes.authentication-mode is tls
+const BottlerocketKubeletCertificate = "/var/lib/kubelet/pki/kubelet-client-current.pem"
+
+// BottlerocketBuilder writes the credentials of the kubelet on Bottlerocket.
+// The kubelet itself is part of the image and configured through the user-data settings.
+type BottlerocketBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &BottlerocketBuilder{}
+
+// Build is responsible for writing the kubelet client certificate that kops-controller issues
+func (b *BottlerocketBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if b.Distribution != distributions.DistributionBottlerocket {
+		return nil
+	}
+
+	cert, key, err := b.GetBootstrapCert("kubelet", fi.CertificateIDCA)
+	if err != nil {
+		return err
+	}
+
+	c.AddTask(&nodetasks.File{
+		Path:     BottlerocketKubeletCertificate,
+		Contents: &concatResource{parts: []fi.Resource{cert, key}},
+		Type:     nodetasks.FileType_File,
+		Mode:     fi.PtrTo("0600"),
+	})
+
+	return nil
+}
+
+// concatResource is the concatenation of resources, which may depend on tasks
+type concatResource struct {
+	parts []fi.Resource
+}
+
+var (
+	_ fi.Resource              = &concatResource{}
+	_ fi.NodeupHasDependencies = &concatResource{}
+	_ fi.HasIsReady            = &concatResource{}
+)
+
+func (r *concatResource) Open() (io.Reader, error) {
+	var readers []io.Reader
+	for _, part := range r.parts {
+		data, err := fi.ResourceAsBytes(part)
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, bytes.NewReader(data))
+	}
+	return io.MultiReader(readers...), nil
+}
+
+// GetDependencies implements HasDependencies::GetDependencies
+func (r *concatResource) GetDependencies(tasks map[string]fi.NodeupTask) []fi.NodeupTask {
+	var deps []fi.NodeupTask
+	for _, part := range r.parts {
+		if hasDep, ok := part.(fi.NodeupHasDependencies); ok {
+			deps = append(deps, hasDep.GetDependencies(tasks)...)
+		}
+	}
+	return deps
+}
+
+// IsReady implements HasIsReady::IsReady
+func (r *concatResource) IsReady() bool {
+	for _, part := range r.parts {
+		if hasIsReady, ok := part.(fi.HasIsReady); ok && !hasIsReady.IsReady() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+func TestBottlerocketBuilder(t *testing.T) {
+	for _, distribution := range []distributions.Distribution{distributions.DistributionUbuntu2204, distributions.DistributionBottlerocket} {
+		modelContext := &NodeupModelContext{
+			Distribution: distribution,
+			BootConfig: &nodeup.BootConfig{
+				InstanceGroupRole: kops.InstanceGroupRoleNode,
+			},
+			NodeupConfig: &nodeup.Config{
+				KubernetesVersion: "1.30.0",
+				KeypairIDs:        map[string]string{fi.CertificateIDCA: "1"},
+			},
+		}
+		if err := modelContext.Init(); err != nil {
+			t.Fatalf("error initializing model context: %v", err)
+		}
+
+		c := &fi.NodeupModelBuilderContext{
+			Tasks: make(map[string]fi.NodeupTask),
+		}
+		builder := &BottlerocketBuilder{NodeupModelContext: modelContext}
+		if err := builder.Build(c); err != nil {
+			t.Fatalf("error building %v: %v", distribution, err)
+		}
+
+		task := c.Tasks["File//var/lib/kubelet/pki/kubelet-client-current.pem"]
+		if distribution != distributions.DistributionBottlerocket {
+			if task != nil {
+				t.Errorf("unexpected kubelet certificate on %v", distribution)
+			}
+			continue
+		}
+		if task == nil {
+			t.Fatalf("kubelet certificate not written on Bottlerocket, tasks: %v", c.Tasks)
+		}
+
+		file := task.(*nodetasks.File)
+		if hasIsReady := file.Contents.(fi.HasIsReady); hasIsReady.IsReady() {
+			t.Errorf("kubelet certificate ready before kops-controller issued it")
+		}
+
+		cert, key, err := modelContext.GetBootstrapCert("kubelet", fi.CertificateIDCA)
+		if err != nil {
+			t.Fatalf("error getting bootstrap cert: %v", err)
+		}
+		cert.(*fi.NodeupTaskDependentResource).Resource = fi.NewStringResource("certificate\n")
+		key.(*fi.NodeupTaskDependentResource).Resource = fi.NewStringResource("key\n")
+
+		actual, err := fi.ResourceAsString(file.Contents)
+		if err != nil {
+			t.Fatalf("error reading kubelet certificate: %v", err)
+		}
+		if actual != "certificate\nkey\n" {
+			t.Errorf("unexpected kubelet certificate %q", actual)
+		}
+	}
+}
@@ -48,6 +48,10 @@ var _ fi.NodeupModelBuilder = &ContainerdBuilder{}
 
 // Build is responsible for configuring the containerd daemon
 func (b *ContainerdBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if b.UsesCrio() {
+		return nil
+	}
+
 	if b.skipInstall() {
 		klog.Infof("SkipInstall is set to true; won't install containerd")
 		return nil
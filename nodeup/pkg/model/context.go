@@ -180,8 +180,14 @@ func (c *NodeupModelContext) IsMounted(m mount.Interface, device, path string) (
 		return false, err
 	}
 
+	// Devices such as /dev/md/<name> are symlinks to the device listed in the mount table
+	resolved := device
+	if target, err := filepath.EvalSymlinks(device); err == nil {
+		resolved = target
+	}
+
 	for _, x := range list {
-		if x.Device == device {
+		if x.Device == device || x.Device == resolved {
 			klog.V(3).Infof("Found mountpoint device: %s, path: %s, type: %s", x.Device, x.Path, x.Type)
 			if strings.TrimSuffix(x.Path, "/") == strings.TrimSuffix(path, "/") {
 				return true, nil
@@ -222,6 +228,17 @@ func (c *NodeupModelContext) KubeletKubeConfig() string {
 	return "/var/lib/kubelet/kubeconfig"
 }
 
+// StaticPodDir is the directory the kubelet reads static pod manifests from
+func (c *NodeupModelContext) StaticPodDir() string {
+	switch c.Distribution {
+	case distributions.DistributionBottlerocket:
+		// The kubelet of Bottlerocket is configured by the image, not by nodeup
+		return "/etc/kubernetes/static-pods"
+	default:
+		return "/etc/kubernetes/manifests"
+	}
+}
+
 // BuildIssuedKubeconfig generates a kubeconfig with a locally issued client certificate.
 func (c *NodeupModelContext) BuildIssuedKubeconfig(name string, subject nodetasks.PKIXName, ctx *fi.NodeupModelBuilderContext) *fi.NodeupTaskDependentResource {
 	issueCert := &nodetasks.IssueCert{
@@ -539,6 +556,22 @@ func (c *NodeupModelContext) CNIConfDir() string {
 	return "/etc/cni/net.d/"
 }
 
+// UsesCrio returns true if the node runs CRI-O rather than containerd
+func (c *NodeupModelContext) UsesCrio() bool {
+	return c.NodeupConfig.ContainerRuntime == kops.ContainerRuntimeCrio
+}
+
+// ContainerRuntimeEndpoint returns the CRI endpoint of the container runtime
+func (c *NodeupModelContext) ContainerRuntimeEndpoint() string {
+	if c.UsesCrio() {
+		return "unix://" + crioSocketPath
+	}
+	if c.NodeupConfig.ContainerdConfig != nil && c.NodeupConfig.ContainerdConfig.Address != nil {
+		return "unix://" + fi.ValueOf(c.NodeupConfig.ContainerdConfig.Address)
+	}
+	return "unix:///run/containerd/containerd.sock"
+}
+
 func (c *NodeupModelContext) InstallNvidiaRuntime() bool {
 	return c.NodeupConfig.NvidiaGPU != nil &&
 		fi.ValueOf(c.NodeupConfig.NvidiaGPU.Enabled) &&
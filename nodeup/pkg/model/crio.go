@@ -0,0 +1,388 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/systemd"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+const (
+	crioConfigFilePath     = "/etc/crio/crio.conf"
+	crioRegistriesFilePath = "/etc/containers/registries.conf.d/10-kops.conf"
+	crioPolicyFilePath     = "/etc/containers/policy.json"
+	// crioRegistriesDFilePath configures where the containers libraries look for the signatures of images
+	crioRegistriesDFilePath = "/etc/containers/registries.d/kops.yaml"
+	crioSocketPath          = "/run/crio/crio.sock"
+	// crioLibexecDir holds the binaries that only CRI-O runs, as in the CRI-O packages
+	crioLibexecDir = "/usr/libexec/crio"
+)
+
+// CrioBuilder installs and configures CRI-O, when it is the container runtime
+type CrioBuilder struct {
+	*NodeupModelContext
+}
+
+var _ fi.NodeupModelBuilder = &CrioBuilder{}
+
+// Build is responsible for configuring the CRI-O daemon
+func (b *CrioBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	if !b.UsesCrio() {
+		return nil
+	}
+
+	crio := b.crioConfig()
+	if crio.SkipInstall {
+		klog.Infof("SkipInstall is set to true; won't install CRI-O")
+		return nil
+	}
+
+	switch b.Distribution {
+	case distributions.DistributionFlatcar, distributions.DistributionContainerOS:
+		return fmt.Errorf("CRI-O is not supported on Flatcar or ContainerOS, which ship containerd")
+	}
+
+	config, err := b.buildCrioConfig()
+	if err != nil {
+		return err
+	}
+	c.AddTask(&nodetasks.File{
+		Path:     crioConfigFilePath,
+		Contents: fi.NewStringResource(config),
+		Type:     nodetasks.FileType_File,
+	})
+
+	registries, err := b.buildRegistriesConfig()
+	if err != nil {
+		return err
+	}
+	c.AddTask(&nodetasks.File{
+		Path:     crioRegistriesFilePath,
+		Contents: fi.NewStringResource(registries),
+		Type:     nodetasks.FileType_File,
+	})
+
+	if verification := b.NodeupConfig.AssetVerification; verification != nil && verification.Images {
+		policy, err := b.buildPolicyConfig(verification)
+		if err != nil {
+			return err
+		}
+		c.AddTask(&nodetasks.File{
+			Path:     crioPolicyFilePath,
+			Contents: fi.NewStringResource(policy),
+			Type:     nodetasks.FileType_File,
+		})
+		// cosign attaches signatures to images in the registry, rather than publishing them on a separate server
+		c.AddTask(&nodetasks.File{
+			Path:     crioRegistriesDFilePath,
+			Contents: fi.NewStringResource("# Built by kOps - do not edit\ndefault-docker:\n  use-sigstore-attachments: true\n"),
+			Type:     nodetasks.FileType_File,
+		})
+	} else {
+		// CRI-O refuses to pull images without a signature policy; keep any policy the image already has
+		c.AddTask(&nodetasks.File{
+			Path:        crioPolicyFilePath,
+			Contents:    fi.NewStringResource(`{"default":[{"type":"insecureAcceptAnything"}]}` + "\n"),
+			Type:        nodetasks.FileType_File,
+			IfNotExists: true,
+		})
+	}
+
+	return b.installCrio(c)
+}
+
+// buildPolicyConfig renders the signature policy that makes CRI-O only pull images signed by one of the public keys
+// of the asset verification spec, so that the images the kubelet pulls are verified as well as those nodeup pulls
+func (b *CrioBuilder) buildPolicyConfig(verification *kops.AssetVerificationSpec) (string, error) {
+	if len(verification.PublicKeys) == 0 {
+		return "", fmt.Errorf("CRI-O can only verify the signatures of images with public keys")
+	}
+
+	var keyDatas []string
+	for _, publicKey := range verification.PublicKeys {
+		keyDatas = append(keyDatas, base64.StdEncoding.EncodeToString([]byte(publicKey)))
+	}
+	requirement := map[string]interface{}{
+		"type":           "sigstoreSigned",
+		"signedIdentity": map[string]interface{}{"type": "matchRepository"},
+	}
+	if len(keyDatas) == 1 {
+		requirement["keyData"] = keyDatas[0]
+	} else {
+		requirement["keyDatas"] = keyDatas
+	}
+
+	policy, err := json.MarshalIndent(map[string]interface{}{
+		"default": []interface{}{requirement},
+	}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("building signature policy: %w", err)
+	}
+	return string(policy) + "\n", nil
+}
+
+// crioConfig returns the CRI-O configuration, which may be empty
+func (b *CrioBuilder) crioConfig() *kops.CrioConfig {
+	if b.NodeupConfig.CrioConfig == nil {
+		return &kops.CrioConfig{}
+	}
+	return b.NodeupConfig.CrioConfig
+}
+
+// installCrio installs the binaries and service from the CRI-O static bundle
+func (b *CrioBuilder) installCrio(c *fi.NodeupModelBuilderContext) error {
+	f := b.Assets.FindMatches(regexp.MustCompile(`^(\./)?cri-o/bin/(crio|pinns)$`))
+	if len(f) == 0 {
+		return fmt.Errorf("unable to find any CRI-O binaries in assets")
+	}
+	for k, v := range f {
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join("/usr/bin", k),
+			Contents: v,
+			Type:     nodetasks.FileType_File,
+			Mode:     fi.PtrTo("0755"),
+		})
+	}
+
+	f = b.Assets.FindMatches(regexp.MustCompile(`^(\./)?cri-o/bin/(conmon|conmonrs|crun|runc)$`))
+	if _, found := f["conmon"]; !found {
+		return fmt.Errorf("unable to find conmon in the CRI-O assets")
+	}
+	for k, v := range f {
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join(crioLibexecDir, k),
+			Contents: v,
+			Type:     nodetasks.FileType_File,
+			Mode:     fi.PtrTo("0755"),
+		})
+	}
+
+	// Add configuration file for easier use of crictl
+	c.AddTask(&nodetasks.File{
+		Path:     "/etc/crictl.yaml",
+		Contents: fi.NewStringResource("\nruntime-endpoint: unix://" + crioSocketPath + "\n"),
+		Type:     nodetasks.FileType_File,
+	})
+
+	c.AddTask(b.buildSystemdService())
+
+	return nil
+}
+
+func (b *CrioBuilder) buildSystemdService() *nodetasks.Service {
+	// Based on https://github.com/cri-o/cri-o/blob/main/contrib/systemd/crio.service
+
+	manifest := &systemd.Manifest{}
+	manifest.Set("Unit", "Description", "Container Runtime Interface for OCI (CRI-O)")
+	manifest.Set("Unit", "Documentation", "https://github.com/cri-o/cri-o")
+	manifest.Set("Unit", "Wants", "network-online.target")
+	manifest.Set("Unit", "Before", "kubelet.service")
+	manifest.Set("Unit", "After", "network-online.target local-fs.target")
+
+	manifest.Set("Service", "EnvironmentFile", "-/etc/environment")
+	manifest.Set("Service", "Environment", "GOTRACEBACK=crash")
+	manifest.Set("Service", "ExecStartPre", "-/sbin/modprobe overlay")
+	manifest.Set("Service", "ExecStart", "/usr/bin/crio --config "+crioConfigFilePath)
+	manifest.Set("Service", "ExecReload", "/bin/kill -s HUP $MAINPID")
+
+	// notify the daemon's readiness to systemd
+	manifest.Set("Service", "Type", "notify")
+
+	manifest.Set("Service", "Restart", "on-failure")
+	manifest.Set("Service", "RestartSec", "5")
+
+	manifest.Set("Service", "LimitNPROC", "1048576")
+	manifest.Set("Service", "LimitCORE", "infinity")
+	manifest.Set("Service", "LimitNOFILE", "1048576")
+	manifest.Set("Service", "TasksMax", "infinity")
+
+	// make killing of processes of this unit under memory pressure very unlikely
+	manifest.Set("Service", "OOMScoreAdjust", "-999")
+
+	manifest.Set("Install", "WantedBy", "multi-user.target")
+
+	if b.NodeupConfig.KubeletConfig.CgroupDriver == "systemd" {
+		cgroup := b.NodeupConfig.KubeletConfig.RuntimeCgroups
+		if cgroup != "" {
+			manifest.Set("Service", "Slice", strings.Trim(cgroup, "/")+".slice")
+		}
+	}
+
+	manifestString := manifest.Render()
+	klog.V(8).Infof("Built service manifest %q\n%s", "crio", manifestString)
+
+	service := &nodetasks.Service{
+		Name:       "crio.service",
+		Definition: s(manifestString),
+	}
+
+	service.InitDefaults()
+
+	return service
+}
+
+// buildCrioConfig renders crio.conf, unless the user provided their own
+func (b *CrioBuilder) buildCrioConfig() (string, error) {
+	crio := b.crioConfig()
+	if fi.ValueOf(crio.ConfigOverride) != "" {
+		return *crio.ConfigOverride, nil
+	}
+
+	config, _ := toml.Load("")
+
+	if crio.Root != nil {
+		config.SetPath([]string{"crio", "root"}, fi.ValueOf(crio.Root))
+	}
+	if crio.RunRoot != nil {
+		config.SetPath([]string{"crio", "runroot"}, fi.ValueOf(crio.RunRoot))
+	}
+	storageDriver := "overlay"
+	if crio.StorageDriver != nil {
+		storageDriver = fi.ValueOf(crio.StorageDriver)
+	}
+	config.SetPath([]string{"crio", "storage_driver"}, storageDriver)
+
+	config.SetPath([]string{"crio", "api", "listen"}, crioSocketPath)
+
+	// CRI-O has to use the same cgroup driver as the kubelet
+	if b.NodeupConfig.KubeletConfig.CgroupDriver == "systemd" {
+		config.SetPath([]string{"crio", "runtime", "cgroup_manager"}, "systemd")
+	} else {
+		config.SetPath([]string{"crio", "runtime", "cgroup_manager"}, "cgroupfs")
+	}
+	config.SetPath([]string{"crio", "runtime", "conmon_cgroup"}, "pod")
+	logLevel := "info"
+	if crio.LogLevel != nil {
+		logLevel = fi.ValueOf(crio.LogLevel)
+	}
+	config.SetPath([]string{"crio", "runtime", "log_level"}, logLevel)
+	config.SetPath([]string{"crio", "runtime", "selinux"}, crio.SeLinuxEnabled)
+
+	defaultRuntime := "runc"
+	if crio.DefaultRuntime != nil {
+		defaultRuntime = fi.ValueOf(crio.DefaultRuntime)
+	}
+	config.SetPath([]string{"crio", "runtime", "default_runtime"}, defaultRuntime)
+
+	runtimes := map[string]kops.CrioRuntimeConfig{
+		"runc": {
+			RuntimePath: filepath.Join(crioLibexecDir, "runc"),
+			RuntimeRoot: "/run/runc",
+		},
+		"crun": {
+			RuntimePath: filepath.Join(crioLibexecDir, "crun"),
+			RuntimeRoot: "/run/crun",
+		},
+	}
+	for name, runtime := range crio.Runtimes {
+		runtimes[name] = runtime
+	}
+	for name, runtime := range runtimes {
+		runtimeType := runtime.RuntimeType
+		if runtimeType == "" {
+			runtimeType = "oci"
+		}
+
+		values := map[string]interface{}{
+			"runtime_type": runtimeType,
+		}
+		if runtime.RuntimePath != "" {
+			values["runtime_path"] = runtime.RuntimePath
+		}
+		if runtime.RuntimeRoot != "" {
+			values["runtime_root"] = runtime.RuntimeRoot
+		}
+		if runtimeType == "oci" {
+			values["monitor_path"] = filepath.Join(crioLibexecDir, "conmon")
+		}
+		if len(runtime.AllowedAnnotations) != 0 {
+			values["allowed_annotations"] = runtime.AllowedAnnotations
+		}
+
+		tree, err := toml.TreeFromMap(values)
+		if err != nil {
+			return "", fmt.Errorf("building CRI-O runtime handler %q: %w", name, err)
+		}
+		config.SetPath([]string{"crio", "runtime", "runtimes", name}, tree)
+	}
+
+	if b.NodeupConfig.KubeletConfig.PodInfraContainerImage != "" {
+		config.SetPath([]string{"crio", "image", "pause_image"}, b.NodeupConfig.KubeletConfig.PodInfraContainerImage)
+	}
+
+	config.SetPath([]string{"crio", "network", "network_dir"}, b.CNIConfDir())
+	config.SetPath([]string{"crio", "network", "plugin_dirs"}, []string{b.CNIBinDir()})
+
+	return strings.TrimPrefix(config.String(), "\n"), nil
+}
+
+// buildRegistriesConfig renders the registry mirrors, which CRI-O reads from the containers-registries.conf drop-in directory
+func (b *CrioBuilder) buildRegistriesConfig() (string, error) {
+	crio := b.crioConfig()
+
+	var names []string
+	for name := range crio.RegistryMirrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var registries []map[string]interface{}
+	for _, name := range names {
+		var mirrors []map[string]interface{}
+		for _, endpoint := range crio.RegistryMirrors[name] {
+			mirror := map[string]interface{}{}
+			if strings.HasPrefix(endpoint, "http://") {
+				mirror["insecure"] = true
+			}
+			endpoint = strings.TrimPrefix(endpoint, "http://")
+			endpoint = strings.TrimPrefix(endpoint, "https://")
+			mirror["location"] = strings.TrimSuffix(endpoint, "/")
+			mirrors = append(mirrors, mirror)
+		}
+		registries = append(registries, map[string]interface{}{
+			"prefix":   name,
+			"location": name,
+			"mirror":   mirrors,
+		})
+	}
+
+	contents := "# Built by kOps - do not edit\n"
+	if len(registries) == 0 {
+		return contents, nil
+	}
+
+	config, err := toml.TreeFromMap(map[string]interface{}{
+		"registry": registries,
+	})
+	if err != nil {
+		return "", fmt.Errorf("building registry mirrors config: %w", err)
+	}
+	return contents + config.String(), nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"path"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/distributions"
+)
+
+func TestCrioBuilder_Simple(t *testing.T) {
+	runCrioBuilderTest(t, "simple")
+}
+
+func TestCrioBuilder_Complex(t *testing.T) {
+	runCrioBuilderTest(t, "complex")
+}
+
+func TestCrioBuilder_Verification(t *testing.T) {
+	runCrioBuilderTest(t, "verification")
+}
+
+func runCrioBuilderTest(t *testing.T, key string) {
+	h := testutils.NewIntegrationTestHarness(t)
+	defer h.Close()
+
+	h.MockKopsVersion("1.18.0")
+	h.SetupMockAWS()
+
+	basedir := path.Join("tests/criobuilder/", key)
+
+	model, err := testutils.LoadModel(basedir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeUpModelContext, err := BuildNodeupModelContext(model)
+	if err != nil {
+		t.Fatalf("error parsing cluster yaml %q: %v", basedir, err)
+		return
+	}
+
+	nodeUpModelContext.Distribution = distributions.DistributionUbuntu2204
+
+	nodeUpModelContext.Assets = fi.NewAssetStore("")
+	for _, name := range []string{"crio", "pinns", "conmon", "conmonrs", "crun", "runc", "crictl"} {
+		nodeUpModelContext.Assets.AddForTest(name, "cri-o/bin/"+name, "testing cri-o content")
+	}
+	nodeUpModelContext.Assets.AddForTest("bridge", "cri-o/cni-plugins/bridge", "testing cri-o content")
+
+	if err := nodeUpModelContext.Init(); err != nil {
+		t.Fatalf("error from nodeupModelContext.Init(): %v", err)
+		return
+	}
+	context := &fi.NodeupModelBuilderContext{
+		Tasks: make(map[string]fi.NodeupTask),
+	}
+
+	builder := CrioBuilder{NodeupModelContext: nodeUpModelContext}
+
+	err = builder.Build(context)
+	if err != nil {
+		t.Fatalf("error from CrioBuilder Build: %v", err)
+		return
+	}
+
+	testutils.ValidateTasks(t, filepath.Join(basedir, "tasks.yaml"), context)
+}
+
+func TestCrioBuilder_SkipsContainerd(t *testing.T) {
+	b := &CrioBuilder{
+		NodeupModelContext: &NodeupModelContext{
+			NodeupConfig: &nodeup.Config{
+				ContainerdConfig: &kops.ContainerdConfig{},
+			},
+		},
+	}
+
+	context := &fi.NodeupModelBuilderContext{
+		Tasks: make(map[string]fi.NodeupTask),
+	}
+	if err := b.Build(context); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(context.Tasks) != 0 {
+		t.Errorf("expected no tasks when containerd is the container runtime, got %v", context.Tasks)
+	}
+}
+
+func TestCrioBuilder_ContainerRuntimeEndpoint(t *testing.T) {
+	grid := []struct {
+		config   nodeup.Config
+		expected string
+	}{
+		{
+			nodeup.Config{},
+			"unix:///run/containerd/containerd.sock",
+		},
+		{
+			nodeup.Config{
+				ContainerdConfig: &kops.ContainerdConfig{
+					Address: fi.PtrTo("/run/custom/containerd.sock"),
+				},
+			},
+			"unix:///run/custom/containerd.sock",
+		},
+		{
+			nodeup.Config{
+				ContainerRuntime: kops.ContainerRuntimeCrio,
+				CrioConfig:       &kops.CrioConfig{},
+			},
+			"unix:///run/crio/crio.sock",
+		},
+	}
+
+	for _, g := range grid {
+		c := &NodeupModelContext{NodeupConfig: &g.config}
+		if actual := c.ContainerRuntimeEndpoint(); actual != g.expected {
+			t.Errorf("endpoint did not match. actual=%q expected=%q", actual, g.expected)
+		}
+	}
+}
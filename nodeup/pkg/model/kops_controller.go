@@ -106,5 +106,16 @@ func (b *KopsControllerBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		Owner:    s(wellknownusers.KopsControllerName),
 	})
 
+	// kops-controller runs unprivileged, so give it a copy of the age identity it needs to decrypt the secret store
+	if configStore := b.NodeupConfig.ConfigStore; configStore != nil && configStore.Encryption != nil && configStore.Encryption.Age != nil {
+		c.AddTask(&nodetasks.File{
+			Path:     filepath.Join(pkiDir, "age-identity.txt"),
+			Contents: fi.NewFileResource(configStore.Encryption.Age.IdentityFile),
+			Type:     nodetasks.FileType_File,
+			Mode:     s("0600"),
+			Owner:    s(wellknownusers.KopsControllerName),
+		})
+	}
+
 	return nil
 }
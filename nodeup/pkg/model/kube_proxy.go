@@ -18,6 +18,7 @@ package model
 
 import (
 	"fmt"
+	"path/filepath"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -59,7 +60,7 @@ func (b *KubeProxyBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		}
 
 		c.AddTask(&nodetasks.File{
-			Path:     "/etc/kubernetes/manifests/kube-proxy.manifest",
+			Path:     filepath.Join(b.StaticPodDir(), "kube-proxy.manifest"),
 			Contents: fi.NewBytesResource(manifest),
 			Type:     nodetasks.FileType_File,
 		})
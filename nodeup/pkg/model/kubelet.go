@@ -346,11 +346,7 @@ func (b *KubeletBuilder) buildSystemdEnvironmentFile(ctx context.Context, kubele
 
 	// Add container runtime spcific flags
 	flags += " --runtime-request-timeout=15m"
-	if b.NodeupConfig.ContainerdConfig.Address == nil {
-		flags += " --container-runtime-endpoint=unix:///run/containerd/containerd.sock"
-	} else {
-		flags += " --container-runtime-endpoint=unix://" + fi.ValueOf(b.NodeupConfig.ContainerdConfig.Address)
-	}
+	flags += " --container-runtime-endpoint=" + b.ContainerRuntimeEndpoint()
 
 	flags += " --tls-cert-file=" + b.PathSrvKubernetes() + "/kubelet-server.crt"
 	flags += " --tls-private-key-file=" + b.PathSrvKubernetes() + "/kubelet-server.key"
@@ -32,6 +32,11 @@ type NerdctlBuilder struct {
 var _ fi.NodeupModelBuilder = &NerdctlBuilder{}
 
 func (b *NerdctlBuilder) Build(c *fi.NodeupModelBuilderContext) error {
+	// nerdctl only works with containerd
+	if b.UsesCrio() {
+		return nil
+	}
+
 	if b.skipInstall() {
 		klog.Info("containerd.skipInstall is set to true; won't install nerdctl")
 		return nil
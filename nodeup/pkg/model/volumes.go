@@ -17,8 +17,13 @@ limitations under the License.
 package model
 
 import (
+	"bytes"
+	"crypto/rand"
 	"fmt"
+	"os"
+	"strings"
 
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
 
 	"k8s.io/klog/v2"
@@ -42,6 +47,12 @@ func (b *VolumesBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		return nil
 	}
 
+	devices := &volumeDevices{
+		exec:    utilexec.New(),
+		exists:  deviceExists,
+		secrets: b.SecretStore,
+	}
+
 	// @step: iterate the volume mounts and attempt to mount the devices
 	for _, x := range b.NodeupConfig.VolumeMounts {
 		// @check the directory exists, else create it
@@ -50,22 +61,28 @@ func (b *VolumesBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 		}
 
 		m := &mount.SafeFormatAndMount{
-			Exec:      utilexec.New(),
+			Exec:      devices.exec,
 			Interface: mount.New(""),
 		}
 
+		// @step: assemble and unlock the device, if required
+		device, err := devices.setup(x)
+		if err != nil {
+			return err
+		}
+
 		// @check if the device is already mounted
-		if found, err := b.IsMounted(m, x.Device, x.Path); err != nil {
-			return fmt.Errorf("failed to check if device %q is mounted, error: %w", x.Device, err)
+		if found, err := b.IsMounted(m, device, x.Path); err != nil {
+			return fmt.Errorf("failed to check if device %q is mounted, error: %w", device, err)
 		} else if found {
-			klog.V(3).Infof("Skipping device: %s, path: %s as already mounted", x.Device, x.Path)
+			klog.V(3).Infof("Skipping device: %s, path: %s as already mounted", device, x.Path)
 			continue
 		}
 
-		klog.Infof("Attempting to format and mount device: %s, path: %s", x.Device, x.Path)
+		klog.Infof("Attempting to format and mount device: %s, path: %s", device, x.Path)
 
-		if err := m.FormatAndMount(x.Device, x.Path, x.Filesystem, x.MountOptions); err != nil {
-			klog.Errorf("failed to mount the device: %s on: %s, error: %s", x.Device, x.Path, err)
+		if err := m.FormatAndMount(device, x.Path, x.Filesystem, x.MountOptions); err != nil {
+			klog.Errorf("failed to mount the device: %s on: %s, error: %s", device, x.Path, err)
 
 			return err
 		}
@@ -73,3 +90,226 @@ func (b *VolumesBuilder) Build(c *fi.NodeupModelBuilderContext) error {
 
 	return nil
 }
+
+// volumeDevices assembles the RAID0 arrays and unlocks the encrypted devices of volume mounts
+type volumeDevices struct {
+	exec utilexec.Interface
+	// exists returns true if the device exists
+	exists func(device string) bool
+	// secrets holds the keys for volumes encrypted with the Secret key source
+	secrets fi.SecretStoreReader
+}
+
+func deviceExists(device string) bool {
+	_, err := os.Stat(device)
+	return err == nil
+}
+
+// volumeDeviceName returns the name of the RAID0 array and dm-crypt mapping for a volume mount,
+// such as kops-mnt-scratch for /mnt/scratch
+func volumeDeviceName(path string) string {
+	return "kops-" + strings.ReplaceAll(strings.Trim(path, "/"), "/", "-")
+}
+
+// setup assembles and unlocks the device of a volume mount, returning the device to format and mount
+func (d *volumeDevices) setup(x kops.VolumeMountSpec) (string, error) {
+	device := x.Device
+	name := volumeDeviceName(x.Path)
+
+	if len(x.Devices) != 0 {
+		var err error
+		device, err = d.assembleRAID0(name, x.Devices)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if x.Encryption != nil {
+		var err error
+		device, err = d.openEncrypted(name, device, x.Encryption)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return device, nil
+}
+
+// assembleRAID0 assembles the RAID0 array of devices, creating it if it does not exist
+func (d *volumeDevices) assembleRAID0(name string, devices []string) (string, error) {
+	array := "/dev/md/" + name
+	if d.exists(array) {
+		klog.V(3).Infof("RAID0 array %s already assembled", array)
+		return array, nil
+	}
+
+	// The array keeps its contents across reboots, so we only create it if it cannot be assembled
+	args := append([]string{"--assemble", array}, devices...)
+	if out, err := d.exec.Command("mdadm", args...).CombinedOutput(); err == nil {
+		klog.Infof("Assembled RAID0 array %s from %v", array, devices)
+		return array, nil
+	} else {
+		klog.V(2).Infof("unable to assemble RAID0 array %s, will create it: %v: %s", array, err, out)
+	}
+
+	args = append([]string{"--create", array, "--run", "--level=0", fmt.Sprintf("--raid-devices=%d", len(devices))}, devices...)
+	if out, err := d.exec.Command("mdadm", args...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("error creating RAID0 array %s from %v: %w: %s", array, devices, err, out)
+	}
+	klog.Infof("Created RAID0 array %s from %v", array, devices)
+	return array, nil
+}
+
+// openEncrypted unlocks the dm-crypt/LUKS2 device, formatting it first if it is not yet encrypted
+func (d *volumeDevices) openEncrypted(name string, device string, spec *kops.VolumeEncryptionSpec) (string, error) {
+	mapped := "/dev/mapper/" + name
+	if d.exists(mapped) {
+		klog.V(3).Infof("Encrypted device %s already open", mapped)
+		return mapped, nil
+	}
+
+	isLUKS, err := d.isLUKS(device)
+	if err != nil {
+		return "", err
+	}
+
+	switch spec.KeySource {
+	case kops.VolumeEncryptionKeySourceEphemeral:
+		// The key is never stored, so whatever the device held before this boot cannot be read
+		key, err := randomKey()
+		if err != nil {
+			return "", err
+		}
+		if err := d.luksFormat(device, spec.Cipher, key); err != nil {
+			return "", err
+		}
+		if err := d.luksOpen(device, name, key); err != nil {
+			return "", err
+		}
+
+	case kops.VolumeEncryptionKeySourceSecret:
+		secret, err := d.secrets.Secret(spec.SecretName)
+		if err != nil {
+			return "", fmt.Errorf("error reading volume encryption key %q: %w", spec.SecretName, err)
+		}
+		if !isLUKS {
+			if err := d.luksFormat(device, spec.Cipher, secret.Data); err != nil {
+				return "", err
+			}
+		}
+		if err := d.luksOpen(device, name, secret.Data); err != nil {
+			return "", err
+		}
+
+	case kops.VolumeEncryptionKeySourceTPM:
+		if !isLUKS {
+			if err := d.luksFormatTPM(device, spec.Cipher); err != nil {
+				return "", err
+			}
+		}
+		if out, err := d.exec.Command(systemdCryptsetup(), "attach", name, device, "-", "tpm2-device=auto").CombinedOutput(); err != nil {
+			return "", fmt.Errorf("error unlocking %s with the TPM: %w: %s", device, err, out)
+		}
+
+	default:
+		return "", fmt.Errorf("unknown volume encryption key source %q", spec.KeySource)
+	}
+
+	klog.Infof("Opened encrypted device %s as %s", device, mapped)
+	return mapped, nil
+}
+
+// isLUKS returns true if the device holds a LUKS header, and an error if it holds anything else,
+// so that we never encrypt over existing data
+func (d *volumeDevices) isLUKS(device string) (bool, error) {
+	out, err := d.exec.Command("blkid", "-p", "-s", "TYPE", "-o", "value", device).CombinedOutput()
+	if err != nil {
+		if exitErr, ok := err.(utilexec.ExitError); ok && exitErr.ExitStatus() == 2 {
+			// blkid exits with 2 when it finds no signature: the device is empty
+			return false, nil
+		}
+		return false, fmt.Errorf("error probing %s: %w: %s", device, err, out)
+	}
+
+	switch deviceType := strings.TrimSpace(string(out)); deviceType {
+	case "crypto_LUKS":
+		return true, nil
+	case "":
+		return false, nil
+	default:
+		return false, fmt.Errorf("refusing to encrypt %s, as it already holds data of type %q", device, deviceType)
+	}
+}
+
+func (d *volumeDevices) luksFormat(device string, cipher string, key []byte) error {
+	args := []string{"luksFormat", "--batch-mode", "--type", "luks2", "--key-file", "-"}
+	if cipher != "" {
+		args = append(args, "--cipher", cipher)
+	}
+	args = append(args, device)
+
+	klog.Infof("Encrypting device %s", device)
+	cmd := d.exec.Command("cryptsetup", args...)
+	cmd.SetStdin(bytes.NewReader(key))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error encrypting %s: %w: %s", device, err, out)
+	}
+	return nil
+}
+
+func (d *volumeDevices) luksOpen(device string, name string, key []byte) error {
+	cmd := d.exec.Command("cryptsetup", "open", "--type", "luks2", "--key-file", "-", device, name)
+	cmd.SetStdin(bytes.NewReader(key))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("error opening encrypted device %s: %w: %s", device, err, out)
+	}
+	return nil
+}
+
+// luksFormatTPM encrypts the device with a random key, which is then sealed by the TPM and removed from the device,
+// so that only this instance can unlock it
+func (d *volumeDevices) luksFormatTPM(device string, cipher string) error {
+	key, err := randomKey()
+	if err != nil {
+		return err
+	}
+	if err := d.luksFormat(device, cipher, key); err != nil {
+		return err
+	}
+
+	// systemd-cryptenroll reads the existing key from a file; /run is a tmpfs, so it never reaches a disk
+	keyFile, err := os.CreateTemp("/run", "kops-volume-key")
+	if err != nil {
+		return fmt.Errorf("error creating key file: %w", err)
+	}
+	defer os.Remove(keyFile.Name())
+	if _, err := keyFile.Write(key); err != nil {
+		keyFile.Close()
+		return fmt.Errorf("error writing key file: %w", err)
+	}
+	if err := keyFile.Close(); err != nil {
+		return fmt.Errorf("error writing key file: %w", err)
+	}
+
+	out, err := d.exec.Command("systemd-cryptenroll", "--unlock-key-file="+keyFile.Name(), "--tpm2-device=auto", "--wipe-slot=password", device).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error sealing the key for %s with the TPM: %w: %s", device, err, out)
+	}
+	return nil
+}
+
+// systemdCryptsetup returns the path of systemd-cryptsetup, which moved to /usr/bin in systemd 256
+func systemdCryptsetup() string {
+	if _, err := os.Stat("/usr/lib/systemd/systemd-cryptsetup"); err == nil {
+		return "/usr/lib/systemd/systemd-cryptsetup"
+	}
+	return "systemd-cryptsetup"
+}
+
+func randomKey() ([]byte, error) {
+	key := make([]byte, 64)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("error generating key: %w", err)
+	}
+	return key, nil
+}
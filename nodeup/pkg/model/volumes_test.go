@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	utilexec "k8s.io/utils/exec"
+	fakeexec "k8s.io/utils/exec/testing"
+)
+
+// fakeSecretStore mocks out fi.SecretStoreReader, for our tests.
+type fakeSecretStore map[string]*fi.Secret
+
+func (s fakeSecretStore) Secret(id string) (*fi.Secret, error) {
+	secret := s[id]
+	if secret == nil {
+		return nil, fmt.Errorf("secret %q not found", id)
+	}
+	return secret, nil
+}
+
+func (s fakeSecretStore) FindSecret(id string) (*fi.Secret, error) {
+	return s[id], nil
+}
+
+// fakeCommand is a scripted command, and what it was called with
+type fakeCommand struct {
+	output string
+	err    error
+
+	argv  []string
+	stdin string
+}
+
+func newFakeExec(commands ...*fakeCommand) *fakeexec.FakeExec {
+	fake := &fakeexec.FakeExec{}
+	for _, command := range commands {
+		fake.CommandScript = append(fake.CommandScript, func(cmd string, args ...string) utilexec.Cmd {
+			fakeCmd := &fakeexec.FakeCmd{}
+			fakeCmd.CombinedOutputScript = []fakeexec.FakeAction{func() ([]byte, []byte, error) {
+				command.argv = fakeCmd.Argv
+				if fakeCmd.Stdin != nil {
+					b, err := io.ReadAll(fakeCmd.Stdin)
+					if err != nil {
+						return nil, nil, err
+					}
+					command.stdin = string(b)
+				}
+				return []byte(command.output), nil, command.err
+			}}
+			return fakeexec.InitFakeCmd(fakeCmd, cmd, args...)
+		})
+	}
+	return fake
+}
+
+func TestVolumeDeviceName(t *testing.T) {
+	grid := map[string]string{
+		"/mnt/scratch":   "kops-mnt-scratch",
+		"/var/lib/data/": "kops-var-lib-data",
+	}
+	for path, expected := range grid {
+		if actual := volumeDeviceName(path); actual != expected {
+			t.Errorf("volumeDeviceName(%q) = %q, expected %q", path, actual, expected)
+		}
+	}
+}
+
+func TestVolumeDevicesRAID0WithSecretEncryption(t *testing.T) {
+	assemble := &fakeCommand{err: fakeexec.FakeExitError{Status: 1}}
+	create := &fakeCommand{}
+	probe := &fakeCommand{err: fakeexec.FakeExitError{Status: 2}}
+	format := &fakeCommand{}
+	open := &fakeCommand{}
+
+	d := &volumeDevices{
+		exec:   newFakeExec(assemble, create, probe, format, open),
+		exists: func(device string) bool { return false },
+		secrets: fakeSecretStore{
+			"scratch-key": &fi.Secret{Data: []byte("secret-key")},
+		},
+	}
+
+	device, err := d.setup(kops.VolumeMountSpec{
+		Devices: []string{"/dev/nvme1n1", "/dev/nvme2n1"},
+		Path:    "/mnt/scratch",
+		Encryption: &kops.VolumeEncryptionSpec{
+			KeySource:  kops.VolumeEncryptionKeySourceSecret,
+			SecretName: "scratch-key",
+			Cipher:     "aes-xts-plain64",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if device != "/dev/mapper/kops-mnt-scratch" {
+		t.Errorf("unexpected device %q", device)
+	}
+
+	expected := []struct {
+		command *fakeCommand
+		argv    string
+		stdin   string
+	}{
+		{assemble, "mdadm --assemble /dev/md/kops-mnt-scratch /dev/nvme1n1 /dev/nvme2n1", ""},
+		{create, "mdadm --create /dev/md/kops-mnt-scratch --run --level=0 --raid-devices=2 /dev/nvme1n1 /dev/nvme2n1", ""},
+		{probe, "blkid -p -s TYPE -o value /dev/md/kops-mnt-scratch", ""},
+		{format, "cryptsetup luksFormat --batch-mode --type luks2 --key-file - --cipher aes-xts-plain64 /dev/md/kops-mnt-scratch", "secret-key"},
+		{open, "cryptsetup open --type luks2 --key-file - /dev/md/kops-mnt-scratch kops-mnt-scratch", "secret-key"},
+	}
+	for _, e := range expected {
+		if argv := strings.Join(e.command.argv, " "); argv != e.argv {
+			t.Errorf("unexpected command %q, expected %q", argv, e.argv)
+		}
+		if e.command.stdin != e.stdin {
+			t.Errorf("unexpected stdin %q for %q, expected %q", e.command.stdin, e.argv, e.stdin)
+		}
+	}
+}
+
+func TestVolumeDevicesOpensExistingLUKS(t *testing.T) {
+	probe := &fakeCommand{output: "crypto_LUKS\n"}
+	open := &fakeCommand{}
+
+	d := &volumeDevices{
+		exec:   newFakeExec(probe, open),
+		exists: func(device string) bool { return false },
+		secrets: fakeSecretStore{
+			"data-key": &fi.Secret{Data: []byte("secret-key")},
+		},
+	}
+
+	device, err := d.setup(kops.VolumeMountSpec{
+		Device: "/dev/xvdd",
+		Path:   "/data",
+		Encryption: &kops.VolumeEncryptionSpec{
+			KeySource:  kops.VolumeEncryptionKeySourceSecret,
+			SecretName: "data-key",
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if device != "/dev/mapper/kops-data" {
+		t.Errorf("unexpected device %q", device)
+	}
+	if !reflect.DeepEqual(open.argv, []string{"cryptsetup", "open", "--type", "luks2", "--key-file", "-", "/dev/xvdd", "kops-data"}) {
+		t.Errorf("unexpected command %v", open.argv)
+	}
+}
+
+func TestVolumeDevicesSkipsOpenDevices(t *testing.T) {
+	d := &volumeDevices{
+		exec:   newFakeExec(),
+		exists: func(device string) bool { return true },
+	}
+
+	device, err := d.setup(kops.VolumeMountSpec{
+		Devices: []string{"/dev/nvme1n1", "/dev/nvme2n1"},
+		Path:    "/mnt/scratch",
+		Encryption: &kops.VolumeEncryptionSpec{
+			KeySource: kops.VolumeEncryptionKeySourceEphemeral,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if device != "/dev/mapper/kops-mnt-scratch" {
+		t.Errorf("unexpected device %q", device)
+	}
+}
+
+func TestVolumeDevicesRefusesToEncryptFilesystem(t *testing.T) {
+	probe := &fakeCommand{output: "ext4\n"}
+
+	d := &volumeDevices{
+		exec:   newFakeExec(probe),
+		exists: func(device string) bool { return false },
+	}
+
+	_, err := d.setup(kops.VolumeMountSpec{
+		Device: "/dev/xvdd",
+		Path:   "/data",
+		Encryption: &kops.VolumeEncryptionSpec{
+			KeySource: kops.VolumeEncryptionKeySourceEphemeral,
+		},
+	})
+	if err == nil || !strings.Contains(err.Error(), `already holds data of type "ext4"`) {
+		t.Errorf("expected error refusing to encrypt the filesystem, got %v", err)
+	}
+}
@@ -92,6 +92,14 @@ const (
 	InstanceManagerKarpenter  InstanceManager = "Karpenter"
 )
 
+// ImageFamily is a family of images that are bootstrapped the same way
+type ImageFamily string
+
+const (
+	// ImageFamilyBottlerocket is Bottlerocket, which is bootstrapped with settings rather than a script
+	ImageFamilyBottlerocket ImageFamily = "Bottlerocket"
+)
+
 // InstanceGroupSpec is the specification for an InstanceGroup
 type InstanceGroupSpec struct {
 	// Manager determines what is managing the node lifecycle
@@ -100,6 +108,9 @@ type InstanceGroupSpec struct {
 	Role InstanceGroupRole `json:"role,omitempty"`
 	// Image is the instance (ami etc) we should use
 	Image string `json:"image,omitempty"`
+	// ImageFamily is the family of the image, which determines how instances are bootstrapped.
+	// By default nodeup is run from a user-data script; with Bottlerocket the image is configured with settings instead.
+	ImageFamily ImageFamily `json:"imageFamily,omitempty"`
 	// MinSize is the minimum size of the pool
 	MinSize *int32 `json:"minSize,omitempty"`
 	// MaxSize is the maximum size of the pool
@@ -321,6 +332,10 @@ type VolumeSpec struct {
 type VolumeMountSpec struct {
 	// Device is the device name to provision and mount
 	Device string `json:"device,omitempty"`
+	// Devices are assembled into a RAID0 array, which is then provisioned and mounted in place of Device.
+	Devices []string `json:"devices,omitempty"`
+	// Encryption configures dm-crypt/LUKS2 encryption of the device
+	Encryption *VolumeEncryptionSpec `json:"encryption,omitempty"`
 	// Filesystem is the filesystem to mount
 	Filesystem string `json:"filesystem,omitempty"`
 	// FormatOptions is a collection of options passed when formatting the device
@@ -331,6 +346,35 @@ type VolumeMountSpec struct {
 	Path string `json:"path,omitempty"`
 }
 
+// VolumeEncryptionKeySource is where the key for an encrypted volume comes from.
+type VolumeEncryptionKeySource string
+
+const (
+	// VolumeEncryptionKeySourceSecret uses a key from the secret store, which kOps creates if it does not exist.
+	VolumeEncryptionKeySourceSecret VolumeEncryptionKeySource = "Secret"
+	// VolumeEncryptionKeySourceTPM uses a random key sealed by the instance's TPM, so only that instance can read the volume.
+	VolumeEncryptionKeySourceTPM VolumeEncryptionKeySource = "TPM"
+	// VolumeEncryptionKeySourceEphemeral uses a random key that is never stored, so the volume is reformatted on every boot.
+	VolumeEncryptionKeySourceEphemeral VolumeEncryptionKeySource = "Ephemeral"
+)
+
+// VolumeEncryptionSpec configures dm-crypt/LUKS2 encryption of a volume mount
+type VolumeEncryptionSpec struct {
+	// KeySource is where the key comes from: Secret, TPM or Ephemeral.
+	KeySource VolumeEncryptionKeySource `json:"keySource,omitempty"`
+	// SecretName is the name of the secret holding the key, for the Secret key source.
+	SecretName string `json:"secretName,omitempty"`
+	// Cipher is the cipher used to encrypt the volume; it defaults to the cryptsetup default, aes-xts-plain64.
+	Cipher string `json:"cipher,omitempty"`
+}
+
+// SupportedVolumeEncryptionKeySources is a list of the supported sources of keys for encrypted volumes
+var SupportedVolumeEncryptionKeySources = []string{
+	string(VolumeEncryptionKeySourceSecret),
+	string(VolumeEncryptionKeySourceTPM),
+	string(VolumeEncryptionKeySourceEphemeral),
+}
+
 // IAMProfileSpec is the AWS IAM Profile to attach to instances in this instance
 // group. Specify the ARN for the IAM instance profile (AWS only).
 type IAMProfileSpec struct {
@@ -374,6 +418,11 @@ func (g *InstanceGroup) IsBastion() bool {
 	}
 }
 
+// IsBottlerocket checks if the instanceGroup runs Bottlerocket, which is bootstrapped with settings rather than a script.
+func (g *InstanceGroup) IsBottlerocket() bool {
+	return g.Spec.ImageFamily == ImageFamilyBottlerocket
+}
+
 func (g *InstanceGroup) AddInstanceGroupNodeLabel() {
 	if g.Spec.NodeLabels == nil {
 		g.Spec.NodeLabels = make(map[string]string)
@@ -63,7 +63,7 @@ type ClusterSpec struct {
 	LegacyCloudProvider string `json:"cloudProvider,omitempty"`
 	// GossipConfig for the cluster assuming the use of gossip DNS
 	GossipConfig *GossipConfig `json:"gossipConfig,omitempty"`
-	// ContainerRuntime was removed.
+	// ContainerRuntime is the container runtime to run on the nodes: containerd (default) or crio.
 	ContainerRuntime string `json:"containerRuntime,omitempty"`
 	// The version of kubernetes to install (optional, and can be a "spec" like stable)
 	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
@@ -106,6 +106,9 @@ type ClusterSpec struct {
 	// ConfigStore is unused.
 	// +k8s:conversion-gen=false
 	LegacyConfigStore string `json:"configStore,omitempty"`
+	// ConfigStoreEncryption configures client-side encryption of the private keys and secrets in the key and secret stores.
+	// +k8s:conversion-gen=false
+	ConfigStoreEncryption *ConfigStoreEncryptionSpec `json:"configStoreEncryption,omitempty"`
 	// DNSZone is the DNS zone we should use when configuring DNS
 	// This is because some clouds let us define a managed zone foo.bar, and then have
 	// kubernetes.dev.foo.bar, without needing to define dev.foo.bar as a hosted zone.
@@ -171,6 +174,7 @@ type ClusterSpec struct {
 	Docker *DockerConfig `json:"docker,omitempty"`
 	// Component configurations
 	Containerd                     *ContainerdConfig             `json:"containerd,omitempty"`
+	Crio                           *CrioConfig                   `json:"crio,omitempty"`
 	KubeDNS                        *KubeDNSConfig                `json:"kubeDNS,omitempty"`
 	KubeAPIServer                  *KubeAPIServerConfig          `json:"kubeAPIServer,omitempty"`
 	KubeControllerManager          *KubeControllerManagerConfig  `json:"kubeControllerManager,omitempty"`
@@ -240,6 +244,8 @@ type ClusterSpec struct {
 	SysctlParameters []string `json:"sysctlParameters,omitempty"`
 	// RollingUpdate defines the default rolling-update settings for instance groups
 	RollingUpdate *RollingUpdate `json:"rollingUpdate,omitempty"`
+	// Validation configures additional checks for cluster validation.
+	Validation *ClusterValidationSpec `json:"validation,omitempty"`
 	// ClusterAutoscaler defines the cluster autoscaler configuration.
 	ClusterAutoscaler *ClusterAutoscalerConfig `json:"clusterAutoscaler,omitempty"`
 	// WarmPool defines the default warm pool settings for instance groups (AWS only).
@@ -356,6 +362,16 @@ type AssetsSpec struct {
 	FileRepository *string `json:"fileRepository,omitempty"`
 	// ContainerProxy is a url for a pull-through proxy of a docker registry
 	ContainerProxy *string `json:"containerProxy,omitempty"`
+	// Verification configures the verification of the signatures of assets before nodes install them.
+	Verification *AssetVerificationSpec `json:"verification,omitempty"`
+}
+
+// AssetVerificationSpec configures the verification of the sigstore/cosign signatures of the files and images that nodes install.
+type AssetVerificationSpec struct {
+	// PublicKeys are the PEM-encoded public keys of cosign key pairs, any of which may sign the assets.
+	PublicKeys []string `json:"publicKeys,omitempty"`
+	// Images also verifies the signatures of the container images that nodeup loads or pulls.
+	Images bool `json:"images,omitempty"`
 }
 
 // IAMSpec adds control over the IAM security policies applied to resources
@@ -841,6 +857,44 @@ type DNSControllerGossipConfigSecondary struct {
 	Seed     *string `json:"seed,omitempty"`
 }
 
+// ClusterValidationSpec configures checks that cluster validation performs in addition to its built-in checks.
+// Failing checks are reported by kops validate cluster and block rolling updates like any other validation failure.
+type ClusterValidationSpec struct {
+	// Workloads are Deployments and DaemonSets that must be fully available.
+	Workloads []ValidationWorkload `json:"workloads,omitempty"`
+	// CrashLoopNamespaces are namespaces that must not contain crash-looping pods.
+	CrashLoopNamespaces []string `json:"crashLoopNamespaces,omitempty"`
+	// HTTPProbes are requests, made through the API server proxy, that must succeed.
+	HTTPProbes []ValidationHTTPProbe `json:"httpProbes,omitempty"`
+}
+
+// ValidationWorkload identifies a workload that must be fully available.
+type ValidationWorkload struct {
+	// Kind is the kind of the workload, either Deployment or DaemonSet.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the workload.
+	Namespace string `json:"namespace"`
+	// Name is the name of the workload.
+	Name string `json:"name"`
+}
+
+// ValidationHTTPProbe is an HTTP GET request to a service, made through the API server proxy,
+// that must return a 2xx status.
+type ValidationHTTPProbe struct {
+	// Name identifies the probe in validation failures.
+	Name string `json:"name"`
+	// Namespace is the namespace of the service.
+	Namespace string `json:"namespace"`
+	// Service is the name of the service.
+	Service string `json:"service"`
+	// Port is the name or number of the service port.
+	Port string `json:"port"`
+	// Scheme is http (the default) or https.
+	Scheme string `json:"scheme,omitempty"`
+	// Path is the path to request, which defaults to /.
+	Path string `json:"path,omitempty"`
+}
+
 type RollingUpdate struct {
 	// DrainAndTerminate enables draining and terminating nodes during rolling updates.
 	// Defaults to true.
@@ -870,6 +924,74 @@ type RollingUpdate struct {
 	// nodes.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+	// MaintenanceWindow restricts the times at which instances may be replaced.
+	// When the window closes, the instances already being replaced are finished
+	// and the rolling update pauses until the window next opens.
+	// +optional
+	MaintenanceWindow *MaintenanceWindowSpec `json:"maintenanceWindow,omitempty"`
+	// Canary configures a canary phase: a subset of the instances is replaced first
+	// and must stay healthy for a soak period before the remaining instances are replaced.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+}
+
+// CanarySpec configures the canary phase of a rolling update.
+type CanarySpec struct {
+	// Count is the number of instances to replace first.
+	// The value can be an absolute number (for example 1) or a percentage of
+	// the instances needing update (for example 10%), which is rounded up.
+	// The canary phase is skipped if Count is not less than the number of instances needing update.
+	Count *intstr.IntOrString `json:"count,omitempty"`
+	// SoakDuration is how long the cluster must remain healthy after the canaries have been replaced.
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty"`
+	// HealthChecks are checks, in addition to cluster validation, that must pass throughout the soak.
+	HealthChecks []CanaryHealthCheck `json:"healthChecks,omitempty"`
+	// FailureThreshold is the number of consecutive failed checks after which the canary fails.
+	// Defaults to 3, so that a transient failure does not abort the rolling update.
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// CanaryHealthCheck is a check that must pass during the canary soak.
+// Exactly one of Pods or HTTP must be set.
+type CanaryHealthCheck struct {
+	// Name identifies the health check in logs.
+	Name string `json:"name"`
+	// Pods requires all the pods matching a selector to be Ready.
+	Pods *CanaryPodsHealthCheck `json:"pods,omitempty"`
+	// HTTP requires a GET request to return a 2xx status.
+	HTTP *CanaryHTTPHealthCheck `json:"http,omitempty"`
+}
+
+// CanaryPodsHealthCheck requires the pods matching a selector to be Ready.
+type CanaryPodsHealthCheck struct {
+	// Namespace is the namespace of the pods.
+	Namespace string `json:"namespace"`
+	// Selector are the labels of the pods. At least one pod must match.
+	Selector map[string]string `json:"selector"`
+}
+
+// CanaryHTTPHealthCheck requires a GET request to return a 2xx status.
+type CanaryHTTPHealthCheck struct {
+	// URL is the http or https URL to request.
+	URL string `json:"url"`
+}
+
+// MaintenanceWindowSpec defines when rolling updates may replace instances.
+type MaintenanceWindowSpec struct {
+	// TimeZone is the IANA time zone in which the schedules are evaluated, for example "Europe/Berlin".
+	// Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+	// Windows are the periods during which instances may be replaced.
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period during which instances may be replaced.
+type MaintenanceWindow struct {
+	// Schedule is a cron expression (minute hour day-of-month month day-of-week)
+	// for the times at which the window opens, for example "0 22 * * mon-fri".
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open after each time it opens.
+	Duration metav1.Duration `json:"duration"`
 }
 
 type PackagesConfig struct {
@@ -895,3 +1017,50 @@ type WarmPoolSpec struct {
 	// Note that the metadata API must be protected from arbitrary Pods when this is enabled.
 	EnableLifecycleHook bool `json:"enableLifecycleHook,omitempty"`
 }
+
+// ConfigStoreEncryptionSpec configures client-side envelope encryption of the private keys and secrets in the config store.
+// Each object is encrypted with its own data key, which is wrapped by exactly one key provider.
+type ConfigStoreEncryptionSpec struct {
+	// Age wraps data keys for an age X25519 recipient.
+	Age *AgeEncryptionSpec `json:"age,omitempty"`
+	// AWSKMS wraps data keys with an AWS KMS key.
+	AWSKMS *AWSKMSEncryptionSpec `json:"awsKMS,omitempty"`
+	// GCPKMS wraps data keys with a Google Cloud KMS key.
+	GCPKMS *GCPKMSEncryptionSpec `json:"gcpKMS,omitempty"`
+	// AzureKeyVault wraps data keys with an Azure Key Vault key.
+	AzureKeyVault *AzureKeyVaultEncryptionSpec `json:"azureKeyVault,omitempty"`
+}
+
+// AgeEncryptionSpec configures wrapping of data keys for an age X25519 recipient.
+type AgeEncryptionSpec struct {
+	// Recipient is the age X25519 public key, starting with age1, that data keys are encrypted to.
+	Recipient string `json:"recipient,omitempty"`
+	// IdentityFile is the path to the file holding the matching age identity, on every machine that reads the stores.
+	// The KOPS_AGE_IDENTITY_FILE environment variable overrides it for the kops CLI.
+	IdentityFile string `json:"identityFile,omitempty"`
+}
+
+// AWSKMSEncryptionSpec configures wrapping of data keys with an AWS KMS key.
+type AWSKMSEncryptionSpec struct {
+	// KeyARN is the ARN of the KMS key, or of an alias for it.
+	KeyARN string `json:"keyARN,omitempty"`
+}
+
+// GCPKMSEncryptionSpec configures wrapping of data keys with a Google Cloud KMS key.
+type GCPKMSEncryptionSpec struct {
+	// KeyName is the resource name of the key, in the form projects/*/locations/*/keyRings/*/cryptoKeys/*.
+	KeyName string `json:"keyName,omitempty"`
+}
+
+// AzureKeyVaultEncryptionSpec configures wrapping of data keys with an Azure Key Vault RSA key.
+type AzureKeyVaultEncryptionSpec struct {
+	// VaultURL is the URL of the key vault, such as https://example.vault.azure.net/.
+	VaultURL string `json:"vaultURL,omitempty"`
+	// KeyName is the name of the key.
+	KeyName string `json:"keyName,omitempty"`
+	// KeyVersion is the version of the key used to wrap new data keys. Defaults to the latest version.
+	KeyVersion string `json:"keyVersion,omitempty"`
+	// VaultID is the Azure resource ID of the key vault. Required on Azure clusters,
+	// where the control plane is granted the Key Vault Crypto User role on the vault.
+	VaultID string `json:"vaultID,omitempty"`
+}
@@ -100,6 +100,14 @@ func Convert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *kops
 	}
 	out.ConfigStore.Secrets = in.SecretStore
 	out.ConfigStore.Keypairs = in.KeyStore
+	if in.ConfigStoreEncryption != nil {
+		out.ConfigStore.Encryption = &kops.ConfigStoreEncryptionSpec{}
+		if err := Convert_v1alpha2_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(in.ConfigStoreEncryption, out.ConfigStore.Encryption, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigStore.Encryption = nil
+	}
 	if in.KubeAPIServer != nil {
 		kube := in.KubeAPIServer
 		if kube.OIDCClientID != nil ||
@@ -390,6 +398,14 @@ func Convert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec, out
 	out.ConfigBase = in.ConfigStore.Base
 	out.KeyStore = in.ConfigStore.Keypairs
 	out.SecretStore = in.ConfigStore.Secrets
+	if in.ConfigStore.Encryption != nil {
+		out.ConfigStoreEncryption = &ConfigStoreEncryptionSpec{}
+		if err := Convert_kops_ConfigStoreEncryptionSpec_To_v1alpha2_ConfigStoreEncryptionSpec(in.ConfigStore.Encryption, out.ConfigStoreEncryption, s); err != nil {
+			return err
+		}
+	} else {
+		out.ConfigStoreEncryption = nil
+	}
 	if in.ExternalPolicies != nil {
 		out.ExternalPolicies = make(map[string][]string, len(in.ExternalPolicies))
 		for k, v := range in.ExternalPolicies {
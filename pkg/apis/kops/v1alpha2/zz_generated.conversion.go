@@ -64,6 +64,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AWSKMSEncryptionSpec)(nil), (*kops.AWSKMSEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(a.(*AWSKMSEncryptionSpec), b.(*kops.AWSKMSEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AWSKMSEncryptionSpec)(nil), (*AWSKMSEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AWSKMSEncryptionSpec_To_v1alpha2_AWSKMSEncryptionSpec(a.(*kops.AWSKMSEncryptionSpec), b.(*AWSKMSEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AWSPermission)(nil), (*kops.AWSPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AWSPermission_To_kops_AWSPermission(a.(*AWSPermission), b.(*kops.AWSPermission), scope)
 	}); err != nil {
@@ -104,6 +114,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AgeEncryptionSpec)(nil), (*kops.AgeEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(a.(*AgeEncryptionSpec), b.(*kops.AgeEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AgeEncryptionSpec)(nil), (*AgeEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AgeEncryptionSpec_To_v1alpha2_AgeEncryptionSpec(a.(*kops.AgeEncryptionSpec), b.(*AgeEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AlwaysAllowAuthorizationSpec)(nil), (*kops.AlwaysAllowAuthorizationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AlwaysAllowAuthorizationSpec_To_kops_AlwaysAllowAuthorizationSpec(a.(*AlwaysAllowAuthorizationSpec), b.(*kops.AlwaysAllowAuthorizationSpec), scope)
 	}); err != nil {
@@ -124,6 +144,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AssetVerificationSpec)(nil), (*kops.AssetVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(a.(*AssetVerificationSpec), b.(*kops.AssetVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AssetVerificationSpec)(nil), (*AssetVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(a.(*kops.AssetVerificationSpec), b.(*AssetVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AssetsSpec)(nil), (*kops.AssetsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AssetsSpec_To_kops_AssetsSpec(a.(*AssetsSpec), b.(*kops.AssetsSpec), scope)
 	}); err != nil {
@@ -154,6 +184,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AzureKeyVaultEncryptionSpec)(nil), (*kops.AzureKeyVaultEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(a.(*AzureKeyVaultEncryptionSpec), b.(*kops.AzureKeyVaultEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AzureKeyVaultEncryptionSpec)(nil), (*AzureKeyVaultEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha2_AzureKeyVaultEncryptionSpec(a.(*kops.AzureKeyVaultEncryptionSpec), b.(*AzureKeyVaultEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AzureSpec)(nil), (*kops.AzureSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_AzureSpec_To_kops_AzureSpec(a.(*AzureSpec), b.(*kops.AzureSpec), scope)
 	}); err != nil {
@@ -204,6 +244,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CanaryHTTPHealthCheck)(nil), (*kops.CanaryHTTPHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(a.(*CanaryHTTPHealthCheck), b.(*kops.CanaryHTTPHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CanaryHTTPHealthCheck)(nil), (*CanaryHTTPHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CanaryHTTPHealthCheck_To_v1alpha2_CanaryHTTPHealthCheck(a.(*kops.CanaryHTTPHealthCheck), b.(*CanaryHTTPHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CanaryHealthCheck)(nil), (*kops.CanaryHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CanaryHealthCheck_To_kops_CanaryHealthCheck(a.(*CanaryHealthCheck), b.(*kops.CanaryHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CanaryHealthCheck)(nil), (*CanaryHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CanaryHealthCheck_To_v1alpha2_CanaryHealthCheck(a.(*kops.CanaryHealthCheck), b.(*CanaryHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CanaryPodsHealthCheck)(nil), (*kops.CanaryPodsHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(a.(*CanaryPodsHealthCheck), b.(*kops.CanaryPodsHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CanaryPodsHealthCheck)(nil), (*CanaryPodsHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CanaryPodsHealthCheck_To_v1alpha2_CanaryPodsHealthCheck(a.(*kops.CanaryPodsHealthCheck), b.(*CanaryPodsHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CanarySpec)(nil), (*kops.CanarySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CanarySpec_To_kops_CanarySpec(a.(*CanarySpec), b.(*kops.CanarySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CanarySpec)(nil), (*CanarySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CanarySpec_To_v1alpha2_CanarySpec(a.(*kops.CanarySpec), b.(*CanarySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertManagerConfig)(nil), (*kops.CertManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertManagerConfig_To_kops_CertManagerConfig(a.(*CertManagerConfig), b.(*kops.CertManagerConfig), scope)
 	}); err != nil {
@@ -294,6 +374,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterValidationSpec)(nil), (*kops.ClusterValidationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ClusterValidationSpec_To_kops_ClusterValidationSpec(a.(*ClusterValidationSpec), b.(*kops.ClusterValidationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ClusterValidationSpec)(nil), (*ClusterValidationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ClusterValidationSpec_To_v1alpha2_ClusterValidationSpec(a.(*kops.ClusterValidationSpec), b.(*ClusterValidationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ConfigStoreEncryptionSpec)(nil), (*kops.ConfigStoreEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(a.(*ConfigStoreEncryptionSpec), b.(*kops.ConfigStoreEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ConfigStoreEncryptionSpec)(nil), (*ConfigStoreEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ConfigStoreEncryptionSpec_To_v1alpha2_ConfigStoreEncryptionSpec(a.(*kops.ConfigStoreEncryptionSpec), b.(*ConfigStoreEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ContainerdConfig)(nil), (*kops.ContainerdConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_ContainerdConfig_To_kops_ContainerdConfig(a.(*ContainerdConfig), b.(*kops.ContainerdConfig), scope)
 	}); err != nil {
@@ -304,6 +404,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CrioConfig)(nil), (*kops.CrioConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CrioConfig_To_kops_CrioConfig(a.(*CrioConfig), b.(*kops.CrioConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CrioConfig)(nil), (*CrioConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CrioConfig_To_v1alpha2_CrioConfig(a.(*kops.CrioConfig), b.(*CrioConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CrioRuntimeConfig)(nil), (*kops.CrioRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(a.(*CrioRuntimeConfig), b.(*kops.CrioRuntimeConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CrioRuntimeConfig)(nil), (*CrioRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CrioRuntimeConfig_To_v1alpha2_CrioRuntimeConfig(a.(*kops.CrioRuntimeConfig), b.(*CrioRuntimeConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DCGMExporterConfig)(nil), (*kops.DCGMExporterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_DCGMExporterConfig_To_kops_DCGMExporterConfig(a.(*DCGMExporterConfig), b.(*kops.DCGMExporterConfig), scope)
 	}); err != nil {
@@ -464,6 +584,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GCPKMSEncryptionSpec)(nil), (*kops.GCPKMSEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(a.(*GCPKMSEncryptionSpec), b.(*kops.GCPKMSEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.GCPKMSEncryptionSpec)(nil), (*GCPKMSEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_GCPKMSEncryptionSpec_To_v1alpha2_GCPKMSEncryptionSpec(a.(*kops.GCPKMSEncryptionSpec), b.(*GCPKMSEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GCPNetworkingSpec)(nil), (*kops.GCPNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_GCPNetworkingSpec_To_kops_GCPNetworkingSpec(a.(*GCPNetworkingSpec), b.(*kops.GCPNetworkingSpec), scope)
 	}); err != nil {
@@ -784,6 +914,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceWindow)(nil), (*kops.MaintenanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MaintenanceWindow_To_kops_MaintenanceWindow(a.(*MaintenanceWindow), b.(*kops.MaintenanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MaintenanceWindow)(nil), (*MaintenanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(a.(*kops.MaintenanceWindow), b.(*MaintenanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceWindowSpec)(nil), (*kops.MaintenanceWindowSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(a.(*MaintenanceWindowSpec), b.(*kops.MaintenanceWindowSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MaintenanceWindowSpec)(nil), (*MaintenanceWindowSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MaintenanceWindowSpec_To_v1alpha2_MaintenanceWindowSpec(a.(*kops.MaintenanceWindowSpec), b.(*MaintenanceWindowSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MetricsServerConfig)(nil), (*kops.MetricsServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_MetricsServerConfig_To_kops_MetricsServerConfig(a.(*MetricsServerConfig), b.(*kops.MetricsServerConfig), scope)
 	}); err != nil {
@@ -1144,6 +1294,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ValidationHTTPProbe)(nil), (*kops.ValidationHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(a.(*ValidationHTTPProbe), b.(*kops.ValidationHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ValidationHTTPProbe)(nil), (*ValidationHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ValidationHTTPProbe_To_v1alpha2_ValidationHTTPProbe(a.(*kops.ValidationHTTPProbe), b.(*ValidationHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ValidationWorkload)(nil), (*kops.ValidationWorkload)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_ValidationWorkload_To_kops_ValidationWorkload(a.(*ValidationWorkload), b.(*kops.ValidationWorkload), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ValidationWorkload)(nil), (*ValidationWorkload)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ValidationWorkload_To_v1alpha2_ValidationWorkload(a.(*kops.ValidationWorkload), b.(*ValidationWorkload), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VolumeEncryptionSpec)(nil), (*kops.VolumeEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(a.(*VolumeEncryptionSpec), b.(*kops.VolumeEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.VolumeEncryptionSpec)(nil), (*VolumeEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_VolumeEncryptionSpec_To_v1alpha2_VolumeEncryptionSpec(a.(*kops.VolumeEncryptionSpec), b.(*VolumeEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VolumeMountSpec)(nil), (*kops.VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VolumeMountSpec_To_kops_VolumeMountSpec(a.(*VolumeMountSpec), b.(*kops.VolumeMountSpec), scope)
 	}); err != nil {
@@ -1395,6 +1575,26 @@ func Convert_kops_AWSAuthenticationSpec_To_v1alpha2_AWSAuthenticationSpec(in *ko
 	return autoConvert_kops_AWSAuthenticationSpec_To_v1alpha2_AWSAuthenticationSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(in *AWSKMSEncryptionSpec, out *kops.AWSKMSEncryptionSpec, s conversion.Scope) error {
+	out.KeyARN = in.KeyARN
+	return nil
+}
+
+// Convert_v1alpha2_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(in *AWSKMSEncryptionSpec, out *kops.AWSKMSEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_AWSKMSEncryptionSpec_To_v1alpha2_AWSKMSEncryptionSpec(in *kops.AWSKMSEncryptionSpec, out *AWSKMSEncryptionSpec, s conversion.Scope) error {
+	out.KeyARN = in.KeyARN
+	return nil
+}
+
+// Convert_kops_AWSKMSEncryptionSpec_To_v1alpha2_AWSKMSEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_AWSKMSEncryptionSpec_To_v1alpha2_AWSKMSEncryptionSpec(in *kops.AWSKMSEncryptionSpec, out *AWSKMSEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AWSKMSEncryptionSpec_To_v1alpha2_AWSKMSEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AWSPermission_To_kops_AWSPermission(in *AWSPermission, out *kops.AWSPermission, s conversion.Scope) error {
 	out.PolicyARNs = in.PolicyARNs
 	out.InlinePolicy = in.InlinePolicy
@@ -1483,6 +1683,28 @@ func Convert_kops_AddonSpec_To_v1alpha2_AddonSpec(in *kops.AddonSpec, out *Addon
 	return autoConvert_kops_AddonSpec_To_v1alpha2_AddonSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(in *AgeEncryptionSpec, out *kops.AgeEncryptionSpec, s conversion.Scope) error {
+	out.Recipient = in.Recipient
+	out.IdentityFile = in.IdentityFile
+	return nil
+}
+
+// Convert_v1alpha2_AgeEncryptionSpec_To_kops_AgeEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(in *AgeEncryptionSpec, out *kops.AgeEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_AgeEncryptionSpec_To_v1alpha2_AgeEncryptionSpec(in *kops.AgeEncryptionSpec, out *AgeEncryptionSpec, s conversion.Scope) error {
+	out.Recipient = in.Recipient
+	out.IdentityFile = in.IdentityFile
+	return nil
+}
+
+// Convert_kops_AgeEncryptionSpec_To_v1alpha2_AgeEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_AgeEncryptionSpec_To_v1alpha2_AgeEncryptionSpec(in *kops.AgeEncryptionSpec, out *AgeEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AgeEncryptionSpec_To_v1alpha2_AgeEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AlwaysAllowAuthorizationSpec_To_kops_AlwaysAllowAuthorizationSpec(in *AlwaysAllowAuthorizationSpec, out *kops.AlwaysAllowAuthorizationSpec, s conversion.Scope) error {
 	return nil
 }
@@ -1547,10 +1769,41 @@ func Convert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpec(in
 	return autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha2_AmazonVPCNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(in *AssetVerificationSpec, out *kops.AssetVerificationSpec, s conversion.Scope) error {
+	out.PublicKeys = in.PublicKeys
+	out.Images = in.Images
+	return nil
+}
+
+// Convert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(in *AssetVerificationSpec, out *kops.AssetVerificationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(in, out, s)
+}
+
+func autoConvert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(in *kops.AssetVerificationSpec, out *AssetVerificationSpec, s conversion.Scope) error {
+	out.PublicKeys = in.PublicKeys
+	out.Images = in.Images
+	return nil
+}
+
+// Convert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec is an autogenerated conversion function.
+func Convert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(in *kops.AssetVerificationSpec, out *AssetVerificationSpec, s conversion.Scope) error {
+	return autoConvert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AssetsSpec_To_kops_AssetsSpec(in *AssetsSpec, out *kops.AssetsSpec, s conversion.Scope) error {
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(kops.AssetVerificationSpec)
+		if err := Convert_v1alpha2_AssetVerificationSpec_To_kops_AssetVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -1563,6 +1816,15 @@ func autoConvert_kops_AssetsSpec_To_v1alpha2_AssetsSpec(in *kops.AssetsSpec, out
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(AssetVerificationSpec)
+		if err := Convert_kops_AssetVerificationSpec_To_v1alpha2_AssetVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -1681,6 +1943,32 @@ func Convert_kops_AuthorizationSpec_To_v1alpha2_AuthorizationSpec(in *kops.Autho
 	return autoConvert_kops_AuthorizationSpec_To_v1alpha2_AuthorizationSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(in *AzureKeyVaultEncryptionSpec, out *kops.AzureKeyVaultEncryptionSpec, s conversion.Scope) error {
+	out.VaultURL = in.VaultURL
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	out.VaultID = in.VaultID
+	return nil
+}
+
+// Convert_v1alpha2_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(in *AzureKeyVaultEncryptionSpec, out *kops.AzureKeyVaultEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha2_AzureKeyVaultEncryptionSpec(in *kops.AzureKeyVaultEncryptionSpec, out *AzureKeyVaultEncryptionSpec, s conversion.Scope) error {
+	out.VaultURL = in.VaultURL
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	out.VaultID = in.VaultID
+	return nil
+}
+
+// Convert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha2_AzureKeyVaultEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha2_AzureKeyVaultEncryptionSpec(in *kops.AzureKeyVaultEncryptionSpec, out *AzureKeyVaultEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha2_AzureKeyVaultEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_AzureSpec_To_kops_AzureSpec(in *AzureSpec, out *kops.AzureSpec, s conversion.Scope) error {
 	out.SubscriptionID = in.SubscriptionID
 	out.StorageAccountID = in.StorageAccountID
@@ -1901,6 +2189,150 @@ func autoConvert_kops_CanalNetworkingSpec_To_v1alpha2_CanalNetworkingSpec(in *ko
 	return nil
 }
 
+func autoConvert_v1alpha2_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(in *CanaryHTTPHealthCheck, out *kops.CanaryHTTPHealthCheck, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1alpha2_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha2_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(in *CanaryHTTPHealthCheck, out *kops.CanaryHTTPHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(in, out, s)
+}
+
+func autoConvert_kops_CanaryHTTPHealthCheck_To_v1alpha2_CanaryHTTPHealthCheck(in *kops.CanaryHTTPHealthCheck, out *CanaryHTTPHealthCheck, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_kops_CanaryHTTPHealthCheck_To_v1alpha2_CanaryHTTPHealthCheck is an autogenerated conversion function.
+func Convert_kops_CanaryHTTPHealthCheck_To_v1alpha2_CanaryHTTPHealthCheck(in *kops.CanaryHTTPHealthCheck, out *CanaryHTTPHealthCheck, s conversion.Scope) error {
+	return autoConvert_kops_CanaryHTTPHealthCheck_To_v1alpha2_CanaryHTTPHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha2_CanaryHealthCheck_To_kops_CanaryHealthCheck(in *CanaryHealthCheck, out *kops.CanaryHealthCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(kops.CanaryPodsHealthCheck)
+		if err := Convert_v1alpha2_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pods = nil
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(kops.CanaryHTTPHealthCheck)
+		if err := Convert_v1alpha2_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_CanaryHealthCheck_To_kops_CanaryHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha2_CanaryHealthCheck_To_kops_CanaryHealthCheck(in *CanaryHealthCheck, out *kops.CanaryHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CanaryHealthCheck_To_kops_CanaryHealthCheck(in, out, s)
+}
+
+func autoConvert_kops_CanaryHealthCheck_To_v1alpha2_CanaryHealthCheck(in *kops.CanaryHealthCheck, out *CanaryHealthCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(CanaryPodsHealthCheck)
+		if err := Convert_kops_CanaryPodsHealthCheck_To_v1alpha2_CanaryPodsHealthCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pods = nil
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(CanaryHTTPHealthCheck)
+		if err := Convert_kops_CanaryHTTPHealthCheck_To_v1alpha2_CanaryHTTPHealthCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP = nil
+	}
+	return nil
+}
+
+// Convert_kops_CanaryHealthCheck_To_v1alpha2_CanaryHealthCheck is an autogenerated conversion function.
+func Convert_kops_CanaryHealthCheck_To_v1alpha2_CanaryHealthCheck(in *kops.CanaryHealthCheck, out *CanaryHealthCheck, s conversion.Scope) error {
+	return autoConvert_kops_CanaryHealthCheck_To_v1alpha2_CanaryHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha2_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(in *CanaryPodsHealthCheck, out *kops.CanaryPodsHealthCheck, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Selector = in.Selector
+	return nil
+}
+
+// Convert_v1alpha2_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha2_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(in *CanaryPodsHealthCheck, out *kops.CanaryPodsHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(in, out, s)
+}
+
+func autoConvert_kops_CanaryPodsHealthCheck_To_v1alpha2_CanaryPodsHealthCheck(in *kops.CanaryPodsHealthCheck, out *CanaryPodsHealthCheck, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Selector = in.Selector
+	return nil
+}
+
+// Convert_kops_CanaryPodsHealthCheck_To_v1alpha2_CanaryPodsHealthCheck is an autogenerated conversion function.
+func Convert_kops_CanaryPodsHealthCheck_To_v1alpha2_CanaryPodsHealthCheck(in *kops.CanaryPodsHealthCheck, out *CanaryPodsHealthCheck, s conversion.Scope) error {
+	return autoConvert_kops_CanaryPodsHealthCheck_To_v1alpha2_CanaryPodsHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha2_CanarySpec_To_kops_CanarySpec(in *CanarySpec, out *kops.CanarySpec, s conversion.Scope) error {
+	out.Count = in.Count
+	out.SoakDuration = in.SoakDuration
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]kops.CanaryHealthCheck, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_CanaryHealthCheck_To_kops_CanaryHealthCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HealthChecks = nil
+	}
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_v1alpha2_CanarySpec_To_kops_CanarySpec is an autogenerated conversion function.
+func Convert_v1alpha2_CanarySpec_To_kops_CanarySpec(in *CanarySpec, out *kops.CanarySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CanarySpec_To_kops_CanarySpec(in, out, s)
+}
+
+func autoConvert_kops_CanarySpec_To_v1alpha2_CanarySpec(in *kops.CanarySpec, out *CanarySpec, s conversion.Scope) error {
+	out.Count = in.Count
+	out.SoakDuration = in.SoakDuration
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]CanaryHealthCheck, len(*in))
+		for i := range *in {
+			if err := Convert_kops_CanaryHealthCheck_To_v1alpha2_CanaryHealthCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HealthChecks = nil
+	}
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_kops_CanarySpec_To_v1alpha2_CanarySpec is an autogenerated conversion function.
+func Convert_kops_CanarySpec_To_v1alpha2_CanarySpec(in *kops.CanarySpec, out *CanarySpec, s conversion.Scope) error {
+	return autoConvert_kops_CanarySpec_To_v1alpha2_CanarySpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertManagerConfig_To_kops_CertManagerConfig(in *CertManagerConfig, out *kops.CertManagerConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Managed = in.Managed
@@ -2450,6 +2882,7 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	// INFO: in.SecretStore opted out of conversion generation
 	// INFO: in.KeyStore opted out of conversion generation
 	// INFO: in.LegacyConfigStore opted out of conversion generation
+	// INFO: in.ConfigStoreEncryption opted out of conversion generation
 	out.DNSZone = in.DNSZone
 	if in.DNSControllerGossipConfig != nil {
 		in, out := &in.DNSControllerGossipConfig, &out.DNSControllerGossipConfig
@@ -2514,6 +2947,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Containerd = nil
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(kops.CrioConfig)
+		if err := Convert_v1alpha2_CrioConfig_To_kops_CrioConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Crio = nil
+	}
 	if in.KubeDNS != nil {
 		in, out := &in.KubeDNS, &out.KubeDNS
 		*out = new(kops.KubeDNSConfig)
@@ -2730,6 +3172,15 @@ func autoConvert_v1alpha2_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(kops.ClusterValidationSpec)
+		if err := Convert_v1alpha2_ClusterValidationSpec_To_kops_ClusterValidationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(kops.ClusterAutoscalerConfig)
@@ -2854,6 +3305,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Containerd = nil
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(CrioConfig)
+		if err := Convert_kops_CrioConfig_To_v1alpha2_CrioConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Crio = nil
+	}
 	if in.KubeDNS != nil {
 		in, out := &in.KubeDNS, &out.KubeDNS
 		*out = new(KubeDNSConfig)
@@ -3065,6 +3525,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha2_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(ClusterValidationSpec)
+		if err := Convert_kops_ClusterValidationSpec_To_v1alpha2_ClusterValidationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(ClusterAutoscalerConfig)
@@ -3162,6 +3631,160 @@ func Convert_kops_ClusterSubnetSpec_To_v1alpha2_ClusterSubnetSpec(in *kops.Clust
 	return autoConvert_kops_ClusterSubnetSpec_To_v1alpha2_ClusterSubnetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_ClusterValidationSpec_To_kops_ClusterValidationSpec(in *ClusterValidationSpec, out *kops.ClusterValidationSpec, s conversion.Scope) error {
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]kops.ValidationWorkload, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ValidationWorkload_To_kops_ValidationWorkload(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Workloads = nil
+	}
+	out.CrashLoopNamespaces = in.CrashLoopNamespaces
+	if in.HTTPProbes != nil {
+		in, out := &in.HTTPProbes, &out.HTTPProbes
+		*out = make([]kops.ValidationHTTPProbe, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HTTPProbes = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ClusterValidationSpec_To_kops_ClusterValidationSpec is an autogenerated conversion function.
+func Convert_v1alpha2_ClusterValidationSpec_To_kops_ClusterValidationSpec(in *ClusterValidationSpec, out *kops.ClusterValidationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ClusterValidationSpec_To_kops_ClusterValidationSpec(in, out, s)
+}
+
+func autoConvert_kops_ClusterValidationSpec_To_v1alpha2_ClusterValidationSpec(in *kops.ClusterValidationSpec, out *ClusterValidationSpec, s conversion.Scope) error {
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]ValidationWorkload, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ValidationWorkload_To_v1alpha2_ValidationWorkload(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Workloads = nil
+	}
+	out.CrashLoopNamespaces = in.CrashLoopNamespaces
+	if in.HTTPProbes != nil {
+		in, out := &in.HTTPProbes, &out.HTTPProbes
+		*out = make([]ValidationHTTPProbe, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ValidationHTTPProbe_To_v1alpha2_ValidationHTTPProbe(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HTTPProbes = nil
+	}
+	return nil
+}
+
+// Convert_kops_ClusterValidationSpec_To_v1alpha2_ClusterValidationSpec is an autogenerated conversion function.
+func Convert_kops_ClusterValidationSpec_To_v1alpha2_ClusterValidationSpec(in *kops.ClusterValidationSpec, out *ClusterValidationSpec, s conversion.Scope) error {
+	return autoConvert_kops_ClusterValidationSpec_To_v1alpha2_ClusterValidationSpec(in, out, s)
+}
+
+func autoConvert_v1alpha2_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(in *ConfigStoreEncryptionSpec, out *kops.ConfigStoreEncryptionSpec, s conversion.Scope) error {
+	if in.Age != nil {
+		in, out := &in.Age, &out.Age
+		*out = new(kops.AgeEncryptionSpec)
+		if err := Convert_v1alpha2_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Age = nil
+	}
+	if in.AWSKMS != nil {
+		in, out := &in.AWSKMS, &out.AWSKMS
+		*out = new(kops.AWSKMSEncryptionSpec)
+		if err := Convert_v1alpha2_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSKMS = nil
+	}
+	if in.GCPKMS != nil {
+		in, out := &in.GCPKMS, &out.GCPKMS
+		*out = new(kops.GCPKMSEncryptionSpec)
+		if err := Convert_v1alpha2_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCPKMS = nil
+	}
+	if in.AzureKeyVault != nil {
+		in, out := &in.AzureKeyVault, &out.AzureKeyVault
+		*out = new(kops.AzureKeyVaultEncryptionSpec)
+		if err := Convert_v1alpha2_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AzureKeyVault = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(in *ConfigStoreEncryptionSpec, out *kops.ConfigStoreEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_ConfigStoreEncryptionSpec_To_v1alpha2_ConfigStoreEncryptionSpec(in *kops.ConfigStoreEncryptionSpec, out *ConfigStoreEncryptionSpec, s conversion.Scope) error {
+	if in.Age != nil {
+		in, out := &in.Age, &out.Age
+		*out = new(AgeEncryptionSpec)
+		if err := Convert_kops_AgeEncryptionSpec_To_v1alpha2_AgeEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Age = nil
+	}
+	if in.AWSKMS != nil {
+		in, out := &in.AWSKMS, &out.AWSKMS
+		*out = new(AWSKMSEncryptionSpec)
+		if err := Convert_kops_AWSKMSEncryptionSpec_To_v1alpha2_AWSKMSEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSKMS = nil
+	}
+	if in.GCPKMS != nil {
+		in, out := &in.GCPKMS, &out.GCPKMS
+		*out = new(GCPKMSEncryptionSpec)
+		if err := Convert_kops_GCPKMSEncryptionSpec_To_v1alpha2_GCPKMSEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCPKMS = nil
+	}
+	if in.AzureKeyVault != nil {
+		in, out := &in.AzureKeyVault, &out.AzureKeyVault
+		*out = new(AzureKeyVaultEncryptionSpec)
+		if err := Convert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha2_AzureKeyVaultEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AzureKeyVault = nil
+	}
+	return nil
+}
+
+// Convert_kops_ConfigStoreEncryptionSpec_To_v1alpha2_ConfigStoreEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_ConfigStoreEncryptionSpec_To_v1alpha2_ConfigStoreEncryptionSpec(in *kops.ConfigStoreEncryptionSpec, out *ConfigStoreEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_ConfigStoreEncryptionSpec_To_v1alpha2_ConfigStoreEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_ContainerdConfig_To_kops_ContainerdConfig(in *ContainerdConfig, out *kops.ContainerdConfig, s conversion.Scope) error {
 	out.Address = in.Address
 	out.ConfigAdditions = in.ConfigAdditions
@@ -3272,6 +3895,114 @@ func Convert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in *kops.Contain
 	return autoConvert_kops_ContainerdConfig_To_v1alpha2_ContainerdConfig(in, out, s)
 }
 
+func autoConvert_v1alpha2_CrioConfig_To_kops_CrioConfig(in *CrioConfig, out *kops.CrioConfig, s conversion.Scope) error {
+	out.ConfigOverride = in.ConfigOverride
+	out.DefaultRuntime = in.DefaultRuntime
+	out.LogLevel = in.LogLevel
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(kops.PackagesConfig)
+		if err := Convert_v1alpha2_PackagesConfig_To_kops_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	out.RegistryMirrors = in.RegistryMirrors
+	out.Root = in.Root
+	out.RunRoot = in.RunRoot
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make(map[string]kops.CrioRuntimeConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(kops.CrioRuntimeConfig)
+			if err := Convert_v1alpha2_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Runtimes = nil
+	}
+	out.SkipInstall = in.SkipInstall
+	out.StorageDriver = in.StorageDriver
+	out.Version = in.Version
+	out.SeLinuxEnabled = in.SeLinuxEnabled
+	return nil
+}
+
+// Convert_v1alpha2_CrioConfig_To_kops_CrioConfig is an autogenerated conversion function.
+func Convert_v1alpha2_CrioConfig_To_kops_CrioConfig(in *CrioConfig, out *kops.CrioConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CrioConfig_To_kops_CrioConfig(in, out, s)
+}
+
+func autoConvert_kops_CrioConfig_To_v1alpha2_CrioConfig(in *kops.CrioConfig, out *CrioConfig, s conversion.Scope) error {
+	out.ConfigOverride = in.ConfigOverride
+	out.DefaultRuntime = in.DefaultRuntime
+	out.LogLevel = in.LogLevel
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		if err := Convert_kops_PackagesConfig_To_v1alpha2_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	out.RegistryMirrors = in.RegistryMirrors
+	out.Root = in.Root
+	out.RunRoot = in.RunRoot
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make(map[string]CrioRuntimeConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(CrioRuntimeConfig)
+			if err := Convert_kops_CrioRuntimeConfig_To_v1alpha2_CrioRuntimeConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Runtimes = nil
+	}
+	out.SkipInstall = in.SkipInstall
+	out.StorageDriver = in.StorageDriver
+	out.Version = in.Version
+	out.SeLinuxEnabled = in.SeLinuxEnabled
+	return nil
+}
+
+// Convert_kops_CrioConfig_To_v1alpha2_CrioConfig is an autogenerated conversion function.
+func Convert_kops_CrioConfig_To_v1alpha2_CrioConfig(in *kops.CrioConfig, out *CrioConfig, s conversion.Scope) error {
+	return autoConvert_kops_CrioConfig_To_v1alpha2_CrioConfig(in, out, s)
+}
+
+func autoConvert_v1alpha2_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(in *CrioRuntimeConfig, out *kops.CrioRuntimeConfig, s conversion.Scope) error {
+	out.RuntimePath = in.RuntimePath
+	out.RuntimeType = in.RuntimeType
+	out.RuntimeRoot = in.RuntimeRoot
+	out.AllowedAnnotations = in.AllowedAnnotations
+	return nil
+}
+
+// Convert_v1alpha2_CrioRuntimeConfig_To_kops_CrioRuntimeConfig is an autogenerated conversion function.
+func Convert_v1alpha2_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(in *CrioRuntimeConfig, out *kops.CrioRuntimeConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(in, out, s)
+}
+
+func autoConvert_kops_CrioRuntimeConfig_To_v1alpha2_CrioRuntimeConfig(in *kops.CrioRuntimeConfig, out *CrioRuntimeConfig, s conversion.Scope) error {
+	out.RuntimePath = in.RuntimePath
+	out.RuntimeType = in.RuntimeType
+	out.RuntimeRoot = in.RuntimeRoot
+	out.AllowedAnnotations = in.AllowedAnnotations
+	return nil
+}
+
+// Convert_kops_CrioRuntimeConfig_To_v1alpha2_CrioRuntimeConfig is an autogenerated conversion function.
+func Convert_kops_CrioRuntimeConfig_To_v1alpha2_CrioRuntimeConfig(in *kops.CrioRuntimeConfig, out *CrioRuntimeConfig, s conversion.Scope) error {
+	return autoConvert_kops_CrioRuntimeConfig_To_v1alpha2_CrioRuntimeConfig(in, out, s)
+}
+
 func autoConvert_v1alpha2_DCGMExporterConfig_To_kops_DCGMExporterConfig(in *DCGMExporterConfig, out *kops.DCGMExporterConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -3892,6 +4623,26 @@ func Convert_kops_FlannelNetworkingSpec_To_v1alpha2_FlannelNetworkingSpec(in *ko
 	return autoConvert_kops_FlannelNetworkingSpec_To_v1alpha2_FlannelNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(in *GCPKMSEncryptionSpec, out *kops.GCPKMSEncryptionSpec, s conversion.Scope) error {
+	out.KeyName = in.KeyName
+	return nil
+}
+
+// Convert_v1alpha2_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(in *GCPKMSEncryptionSpec, out *kops.GCPKMSEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_GCPKMSEncryptionSpec_To_v1alpha2_GCPKMSEncryptionSpec(in *kops.GCPKMSEncryptionSpec, out *GCPKMSEncryptionSpec, s conversion.Scope) error {
+	out.KeyName = in.KeyName
+	return nil
+}
+
+// Convert_kops_GCPKMSEncryptionSpec_To_v1alpha2_GCPKMSEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_GCPKMSEncryptionSpec_To_v1alpha2_GCPKMSEncryptionSpec(in *kops.GCPKMSEncryptionSpec, out *GCPKMSEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_GCPKMSEncryptionSpec_To_v1alpha2_GCPKMSEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_GCPNetworkingSpec_To_kops_GCPNetworkingSpec(in *GCPNetworkingSpec, out *kops.GCPNetworkingSpec, s conversion.Scope) error {
 	return nil
 }
@@ -4216,6 +4967,7 @@ func autoConvert_v1alpha2_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	out.Manager = kops.InstanceManager(in.Manager)
 	out.Role = kops.InstanceGroupRole(in.Role)
 	out.Image = in.Image
+	out.ImageFamily = kops.ImageFamily(in.ImageFamily)
 	out.MinSize = in.MinSize
 	out.MaxSize = in.MaxSize
 	out.Autoscale = in.Autoscale
@@ -4400,6 +5152,7 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha2_InstanceGroupSpec(in *kops.I
 	out.Manager = InstanceManager(in.Manager)
 	out.Role = InstanceGroupRole(in.Role)
 	out.Image = in.Image
+	out.ImageFamily = ImageFamily(in.ImageFamily)
 	out.MinSize = in.MinSize
 	out.MaxSize = in.MaxSize
 	out.Autoscale = in.Autoscale
@@ -5904,6 +6657,70 @@ func Convert_kops_LyftVPCNetworkingSpec_To_v1alpha2_LyftVPCNetworkingSpec(in *ko
 	return autoConvert_kops_LyftVPCNetworkingSpec_To_v1alpha2_LyftVPCNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha2_MaintenanceWindow_To_kops_MaintenanceWindow(in *MaintenanceWindow, out *kops.MaintenanceWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_v1alpha2_MaintenanceWindow_To_kops_MaintenanceWindow is an autogenerated conversion function.
+func Convert_v1alpha2_MaintenanceWindow_To_kops_MaintenanceWindow(in *MaintenanceWindow, out *kops.MaintenanceWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MaintenanceWindow_To_kops_MaintenanceWindow(in, out, s)
+}
+
+func autoConvert_kops_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(in *kops.MaintenanceWindow, out *MaintenanceWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_kops_MaintenanceWindow_To_v1alpha2_MaintenanceWindow is an autogenerated conversion function.
+func Convert_kops_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(in *kops.MaintenanceWindow, out *MaintenanceWindow, s conversion.Scope) error {
+	return autoConvert_kops_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(in, out, s)
+}
+
+func autoConvert_v1alpha2_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(in *MaintenanceWindowSpec, out *kops.MaintenanceWindowSpec, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]kops.MaintenanceWindow, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_MaintenanceWindow_To_kops_MaintenanceWindow(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Windows = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha2_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec is an autogenerated conversion function.
+func Convert_v1alpha2_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(in *MaintenanceWindowSpec, out *kops.MaintenanceWindowSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(in, out, s)
+}
+
+func autoConvert_kops_MaintenanceWindowSpec_To_v1alpha2_MaintenanceWindowSpec(in *kops.MaintenanceWindowSpec, out *MaintenanceWindowSpec, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			if err := Convert_kops_MaintenanceWindow_To_v1alpha2_MaintenanceWindow(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Windows = nil
+	}
+	return nil
+}
+
+// Convert_kops_MaintenanceWindowSpec_To_v1alpha2_MaintenanceWindowSpec is an autogenerated conversion function.
+func Convert_kops_MaintenanceWindowSpec_To_v1alpha2_MaintenanceWindowSpec(in *kops.MaintenanceWindowSpec, out *MaintenanceWindowSpec, s conversion.Scope) error {
+	return autoConvert_kops_MaintenanceWindowSpec_To_v1alpha2_MaintenanceWindowSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_MetricsServerConfig_To_kops_MetricsServerConfig(in *MetricsServerConfig, out *kops.MetricsServerConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Image = in.Image
@@ -7026,6 +7843,24 @@ func autoConvert_v1alpha2_RollingUpdate_To_kops_RollingUpdate(in *RollingUpdate,
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(kops.MaintenanceWindowSpec)
+		if err := Convert_v1alpha2_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MaintenanceWindow = nil
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(kops.CanarySpec)
+		if err := Convert_v1alpha2_CanarySpec_To_kops_CanarySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Canary = nil
+	}
 	return nil
 }
 
@@ -7038,6 +7873,24 @@ func autoConvert_kops_RollingUpdate_To_v1alpha2_RollingUpdate(in *kops.RollingUp
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowSpec)
+		if err := Convert_kops_MaintenanceWindowSpec_To_v1alpha2_MaintenanceWindowSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MaintenanceWindow = nil
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		if err := Convert_kops_CanarySpec_To_v1alpha2_CanarySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Canary = nil
+	}
 	return nil
 }
 
@@ -7415,8 +8268,96 @@ func Convert_kops_UserData_To_v1alpha2_UserData(in *kops.UserData, out *UserData
 	return autoConvert_kops_UserData_To_v1alpha2_UserData(in, out, s)
 }
 
+func autoConvert_v1alpha2_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(in *ValidationHTTPProbe, out *kops.ValidationHTTPProbe, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Service = in.Service
+	out.Port = in.Port
+	out.Scheme = in.Scheme
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1alpha2_ValidationHTTPProbe_To_kops_ValidationHTTPProbe is an autogenerated conversion function.
+func Convert_v1alpha2_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(in *ValidationHTTPProbe, out *kops.ValidationHTTPProbe, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(in, out, s)
+}
+
+func autoConvert_kops_ValidationHTTPProbe_To_v1alpha2_ValidationHTTPProbe(in *kops.ValidationHTTPProbe, out *ValidationHTTPProbe, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Service = in.Service
+	out.Port = in.Port
+	out.Scheme = in.Scheme
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kops_ValidationHTTPProbe_To_v1alpha2_ValidationHTTPProbe is an autogenerated conversion function.
+func Convert_kops_ValidationHTTPProbe_To_v1alpha2_ValidationHTTPProbe(in *kops.ValidationHTTPProbe, out *ValidationHTTPProbe, s conversion.Scope) error {
+	return autoConvert_kops_ValidationHTTPProbe_To_v1alpha2_ValidationHTTPProbe(in, out, s)
+}
+
+func autoConvert_v1alpha2_ValidationWorkload_To_kops_ValidationWorkload(in *ValidationWorkload, out *kops.ValidationWorkload, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha2_ValidationWorkload_To_kops_ValidationWorkload is an autogenerated conversion function.
+func Convert_v1alpha2_ValidationWorkload_To_kops_ValidationWorkload(in *ValidationWorkload, out *kops.ValidationWorkload, s conversion.Scope) error {
+	return autoConvert_v1alpha2_ValidationWorkload_To_kops_ValidationWorkload(in, out, s)
+}
+
+func autoConvert_kops_ValidationWorkload_To_v1alpha2_ValidationWorkload(in *kops.ValidationWorkload, out *ValidationWorkload, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_ValidationWorkload_To_v1alpha2_ValidationWorkload is an autogenerated conversion function.
+func Convert_kops_ValidationWorkload_To_v1alpha2_ValidationWorkload(in *kops.ValidationWorkload, out *ValidationWorkload, s conversion.Scope) error {
+	return autoConvert_kops_ValidationWorkload_To_v1alpha2_ValidationWorkload(in, out, s)
+}
+
+func autoConvert_v1alpha2_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(in *VolumeEncryptionSpec, out *kops.VolumeEncryptionSpec, s conversion.Scope) error {
+	out.KeySource = kops.VolumeEncryptionKeySource(in.KeySource)
+	out.SecretName = in.SecretName
+	out.Cipher = in.Cipher
+	return nil
+}
+
+// Convert_v1alpha2_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha2_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(in *VolumeEncryptionSpec, out *kops.VolumeEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_VolumeEncryptionSpec_To_v1alpha2_VolumeEncryptionSpec(in *kops.VolumeEncryptionSpec, out *VolumeEncryptionSpec, s conversion.Scope) error {
+	out.KeySource = VolumeEncryptionKeySource(in.KeySource)
+	out.SecretName = in.SecretName
+	out.Cipher = in.Cipher
+	return nil
+}
+
+// Convert_kops_VolumeEncryptionSpec_To_v1alpha2_VolumeEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_VolumeEncryptionSpec_To_v1alpha2_VolumeEncryptionSpec(in *kops.VolumeEncryptionSpec, out *VolumeEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_VolumeEncryptionSpec_To_v1alpha2_VolumeEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha2_VolumeMountSpec_To_kops_VolumeMountSpec(in *VolumeMountSpec, out *kops.VolumeMountSpec, s conversion.Scope) error {
 	out.Device = in.Device
+	out.Devices = in.Devices
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(kops.VolumeEncryptionSpec)
+		if err := Convert_v1alpha2_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Encryption = nil
+	}
 	out.Filesystem = in.Filesystem
 	out.FormatOptions = in.FormatOptions
 	out.MountOptions = in.MountOptions
@@ -7431,6 +8372,16 @@ func Convert_v1alpha2_VolumeMountSpec_To_kops_VolumeMountSpec(in *VolumeMountSpe
 
 func autoConvert_kops_VolumeMountSpec_To_v1alpha2_VolumeMountSpec(in *kops.VolumeMountSpec, out *VolumeMountSpec, s conversion.Scope) error {
 	out.Device = in.Device
+	out.Devices = in.Devices
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(VolumeEncryptionSpec)
+		if err := Convert_kops_VolumeEncryptionSpec_To_v1alpha2_VolumeEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Encryption = nil
+	}
 	out.Filesystem = in.Filesystem
 	out.FormatOptions = in.FormatOptions
 	out.MountOptions = in.MountOptions
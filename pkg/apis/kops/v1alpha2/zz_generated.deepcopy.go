@@ -129,6 +129,22 @@ func (in *AWSAuthenticationSpec) DeepCopy() *AWSAuthenticationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSKMSEncryptionSpec) DeepCopyInto(out *AWSKMSEncryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSKMSEncryptionSpec.
+func (in *AWSKMSEncryptionSpec) DeepCopy() *AWSKMSEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSKMSEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AWSPermission) DeepCopyInto(out *AWSPermission) {
 	*out = *in
@@ -208,6 +224,22 @@ func (in *AddonSpec) DeepCopy() *AddonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgeEncryptionSpec) DeepCopyInto(out *AgeEncryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgeEncryptionSpec.
+func (in *AgeEncryptionSpec) DeepCopy() *AgeEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgeEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AlwaysAllowAuthorizationSpec) DeepCopyInto(out *AlwaysAllowAuthorizationSpec) {
 	*out = *in
@@ -245,6 +277,27 @@ func (in *AmazonVPCNetworkingSpec) DeepCopy() *AmazonVPCNetworkingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AssetVerificationSpec) DeepCopyInto(out *AssetVerificationSpec) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AssetVerificationSpec.
+func (in *AssetVerificationSpec) DeepCopy() *AssetVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AssetVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AssetsSpec) DeepCopyInto(out *AssetsSpec) {
 	*out = *in
@@ -263,6 +316,11 @@ func (in *AssetsSpec) DeepCopyInto(out *AssetsSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(AssetVerificationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -333,6 +391,22 @@ func (in *AuthorizationSpec) DeepCopy() *AuthorizationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyVaultEncryptionSpec) DeepCopyInto(out *AzureKeyVaultEncryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVaultEncryptionSpec.
+func (in *AzureKeyVaultEncryptionSpec) DeepCopy() *AzureKeyVaultEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyVaultEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureSpec) DeepCopyInto(out *AzureSpec) {
 	*out = *in
@@ -474,6 +548,109 @@ func (in *CanalNetworkingSpec) DeepCopy() *CanalNetworkingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryHTTPHealthCheck) DeepCopyInto(out *CanaryHTTPHealthCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryHTTPHealthCheck.
+func (in *CanaryHTTPHealthCheck) DeepCopy() *CanaryHTTPHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryHTTPHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryHealthCheck) DeepCopyInto(out *CanaryHealthCheck) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(CanaryPodsHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(CanaryHTTPHealthCheck)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryHealthCheck.
+func (in *CanaryHealthCheck) DeepCopy() *CanaryHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryPodsHealthCheck) DeepCopyInto(out *CanaryPodsHealthCheck) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryPodsHealthCheck.
+func (in *CanaryPodsHealthCheck) DeepCopy() *CanaryPodsHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryPodsHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
+	*out = *in
+	if in.Count != nil {
+		in, out := &in.Count, &out.Count
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.SoakDuration != nil {
+		in, out := &in.SoakDuration, &out.SoakDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]CanaryHealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertManagerConfig) DeepCopyInto(out *CertManagerConfig) {
 	*out = *in
@@ -1086,7 +1263,7 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = make([]AddonSpec, len(*in))
 		copy(*out, *in)
 	}
-	out.ConfigStore = in.ConfigStore
+	in.ConfigStore.DeepCopyInto(&out.ConfigStore)
 	in.CloudProvider.DeepCopyInto(&out.CloudProvider)
 	if in.GossipConfig != nil {
 		in, out := &in.GossipConfig, &out.GossipConfig
@@ -1110,6 +1287,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(TopologySpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConfigStoreEncryption != nil {
+		in, out := &in.ConfigStoreEncryption, &out.ConfigStoreEncryption
+		*out = new(ConfigStoreEncryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DNSControllerGossipConfig != nil {
 		in, out := &in.DNSControllerGossipConfig, &out.DNSControllerGossipConfig
 		*out = new(DNSControllerGossipConfig)
@@ -1201,6 +1383,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(ContainerdConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(CrioConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.KubeDNS != nil {
 		in, out := &in.KubeDNS, &out.KubeDNS
 		*out = new(KubeDNSConfig)
@@ -1367,6 +1554,11 @@ func (in *ClusterSpec) DeepCopyInto(out *ClusterSpec) {
 		*out = new(RollingUpdate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(ClusterValidationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(ClusterAutoscalerConfig)
@@ -1431,6 +1623,73 @@ func (in *ClusterSubnetSpec) DeepCopy() *ClusterSubnetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterValidationSpec) DeepCopyInto(out *ClusterValidationSpec) {
+	*out = *in
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]ValidationWorkload, len(*in))
+		copy(*out, *in)
+	}
+	if in.CrashLoopNamespaces != nil {
+		in, out := &in.CrashLoopNamespaces, &out.CrashLoopNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPProbes != nil {
+		in, out := &in.HTTPProbes, &out.HTTPProbes
+		*out = make([]ValidationHTTPProbe, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterValidationSpec.
+func (in *ClusterValidationSpec) DeepCopy() *ClusterValidationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterValidationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigStoreEncryptionSpec) DeepCopyInto(out *ConfigStoreEncryptionSpec) {
+	*out = *in
+	if in.Age != nil {
+		in, out := &in.Age, &out.Age
+		*out = new(AgeEncryptionSpec)
+		**out = **in
+	}
+	if in.AWSKMS != nil {
+		in, out := &in.AWSKMS, &out.AWSKMS
+		*out = new(AWSKMSEncryptionSpec)
+		**out = **in
+	}
+	if in.GCPKMS != nil {
+		in, out := &in.GCPKMS, &out.GCPKMS
+		*out = new(GCPKMSEncryptionSpec)
+		**out = **in
+	}
+	if in.AzureKeyVault != nil {
+		in, out := &in.AzureKeyVault, &out.AzureKeyVault
+		*out = new(AzureKeyVaultEncryptionSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigStoreEncryptionSpec.
+func (in *ConfigStoreEncryptionSpec) DeepCopy() *ConfigStoreEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigStoreEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerdConfig) DeepCopyInto(out *ContainerdConfig) {
 	*out = *in
@@ -1519,6 +1778,105 @@ func (in *ContainerdConfig) DeepCopy() *ContainerdConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrioConfig) DeepCopyInto(out *CrioConfig) {
+	*out = *in
+	if in.ConfigOverride != nil {
+		in, out := &in.ConfigOverride, &out.ConfigOverride
+		*out = new(string)
+		**out = **in
+	}
+	if in.DefaultRuntime != nil {
+		in, out := &in.DefaultRuntime, &out.DefaultRuntime
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(string)
+		**out = **in
+	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RegistryMirrors != nil {
+		in, out := &in.RegistryMirrors, &out.RegistryMirrors
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Root != nil {
+		in, out := &in.Root, &out.Root
+		*out = new(string)
+		**out = **in
+	}
+	if in.RunRoot != nil {
+		in, out := &in.RunRoot, &out.RunRoot
+		*out = new(string)
+		**out = **in
+	}
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make(map[string]CrioRuntimeConfig, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.StorageDriver != nil {
+		in, out := &in.StorageDriver, &out.StorageDriver
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrioConfig.
+func (in *CrioConfig) DeepCopy() *CrioConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CrioConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrioRuntimeConfig) DeepCopyInto(out *CrioRuntimeConfig) {
+	*out = *in
+	if in.AllowedAnnotations != nil {
+		in, out := &in.AllowedAnnotations, &out.AllowedAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrioRuntimeConfig.
+func (in *CrioRuntimeConfig) DeepCopy() *CrioRuntimeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CrioRuntimeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DCGMExporterConfig) DeepCopyInto(out *DCGMExporterConfig) {
 	*out = *in
@@ -2174,6 +2532,22 @@ func (in *FlannelNetworkingSpec) DeepCopy() *FlannelNetworkingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPKMSEncryptionSpec) DeepCopyInto(out *GCPKMSEncryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPKMSEncryptionSpec.
+func (in *GCPKMSEncryptionSpec) DeepCopy() *GCPKMSEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPKMSEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPNetworkingSpec) DeepCopyInto(out *GCPNetworkingSpec) {
 	*out = *in
@@ -4481,6 +4855,44 @@ func (in *LyftVPCNetworkingSpec) DeepCopy() *LyftVPCNetworkingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetricsServerConfig) DeepCopyInto(out *MetricsServerConfig) {
 	*out = *in
@@ -5480,6 +5892,16 @@ func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -5783,9 +6205,67 @@ func (in *UserData) DeepCopy() *UserData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationHTTPProbe) DeepCopyInto(out *ValidationHTTPProbe) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationHTTPProbe.
+func (in *ValidationHTTPProbe) DeepCopy() *ValidationHTTPProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationHTTPProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationWorkload) DeepCopyInto(out *ValidationWorkload) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationWorkload.
+func (in *ValidationWorkload) DeepCopy() *ValidationWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeEncryptionSpec) DeepCopyInto(out *VolumeEncryptionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeEncryptionSpec.
+func (in *VolumeEncryptionSpec) DeepCopy() *VolumeEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeMountSpec) DeepCopyInto(out *VolumeMountSpec) {
 	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(VolumeEncryptionSpec)
+		**out = **in
+	}
 	if in.FormatOptions != nil {
 		in, out := &in.FormatOptions, &out.FormatOptions
 		*out = make([]string, len(*in))
@@ -0,0 +1,57 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+// CrioConfig is the configuration for CRI-O
+type CrioConfig struct {
+	// ConfigOverride is the complete CRI-O config file provided by the user.
+	ConfigOverride *string `json:"configOverride,omitempty"`
+	// DefaultRuntime is the runtime handler used by pods without a RuntimeClass (default "runc").
+	DefaultRuntime *string `json:"defaultRuntime,omitempty"`
+	// LogLevel controls the logging details [fatal, panic, error, warn, info, debug, trace] (default "info").
+	LogLevel *string `json:"logLevel,omitempty"`
+	// Packages overrides the URL and hash for the packages.
+	Packages *PackagesConfig `json:"packages,omitempty"`
+	// RegistryMirrors is list of image registries
+	RegistryMirrors map[string][]string `json:"registryMirrors,omitempty"`
+	// Root directory for persistent data (default "/var/lib/containers/storage").
+	Root *string `json:"root,omitempty"`
+	// RunRoot directory for execution state files (default "/run/containers/storage").
+	RunRoot *string `json:"runRoot,omitempty"`
+	// Runtimes adds or overrides runtime handlers, keyed by the handler name used in RuntimeClasses.
+	Runtimes map[string]CrioRuntimeConfig `json:"runtimes,omitempty"`
+	// SkipInstall prevents kOps from installing and modifying CRI-O in any way (default "false").
+	SkipInstall bool `json:"skipInstall,omitempty"`
+	// StorageDriver is the containers/storage driver (default "overlay").
+	StorageDriver *string `json:"storageDriver,omitempty"`
+	// Version used to pick the CRI-O package.
+	Version *string `json:"version,omitempty"`
+	// SelinuxEnabled enables SELinux support
+	SeLinuxEnabled bool `json:"selinuxEnabled,omitempty"`
+}
+
+// CrioRuntimeConfig configures a CRI-O runtime handler
+type CrioRuntimeConfig struct {
+	// RuntimePath is the path of the OCI runtime binary.
+	RuntimePath string `json:"runtimePath,omitempty"`
+	// RuntimeType is the type of the runtime, either "oci" or "vm" (default "oci").
+	RuntimeType string `json:"runtimeType,omitempty"`
+	// RuntimeRoot is the directory the runtime keeps its state in.
+	RuntimeRoot string `json:"runtimeRoot,omitempty"`
+	// AllowedAnnotations are the experimental annotations the runtime handler is allowed to process.
+	AllowedAnnotations []string `json:"allowedAnnotations,omitempty"`
+}
@@ -64,6 +64,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AWSKMSEncryptionSpec)(nil), (*kops.AWSKMSEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(a.(*AWSKMSEncryptionSpec), b.(*kops.AWSKMSEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AWSKMSEncryptionSpec)(nil), (*AWSKMSEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AWSKMSEncryptionSpec_To_v1alpha3_AWSKMSEncryptionSpec(a.(*kops.AWSKMSEncryptionSpec), b.(*AWSKMSEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AWSPermission)(nil), (*kops.AWSPermission)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AWSPermission_To_kops_AWSPermission(a.(*AWSPermission), b.(*kops.AWSPermission), scope)
 	}); err != nil {
@@ -114,6 +124,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AgeEncryptionSpec)(nil), (*kops.AgeEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(a.(*AgeEncryptionSpec), b.(*kops.AgeEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AgeEncryptionSpec)(nil), (*AgeEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AgeEncryptionSpec_To_v1alpha3_AgeEncryptionSpec(a.(*kops.AgeEncryptionSpec), b.(*AgeEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AlwaysAllowAuthorizationSpec)(nil), (*kops.AlwaysAllowAuthorizationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AlwaysAllowAuthorizationSpec_To_kops_AlwaysAllowAuthorizationSpec(a.(*AlwaysAllowAuthorizationSpec), b.(*kops.AlwaysAllowAuthorizationSpec), scope)
 	}); err != nil {
@@ -134,6 +154,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AssetVerificationSpec)(nil), (*kops.AssetVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(a.(*AssetVerificationSpec), b.(*kops.AssetVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AssetVerificationSpec)(nil), (*AssetVerificationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(a.(*kops.AssetVerificationSpec), b.(*AssetVerificationSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AssetsSpec)(nil), (*kops.AssetsSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AssetsSpec_To_kops_AssetsSpec(a.(*AssetsSpec), b.(*kops.AssetsSpec), scope)
 	}); err != nil {
@@ -164,6 +194,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*AzureKeyVaultEncryptionSpec)(nil), (*kops.AzureKeyVaultEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(a.(*AzureKeyVaultEncryptionSpec), b.(*kops.AzureKeyVaultEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.AzureKeyVaultEncryptionSpec)(nil), (*AzureKeyVaultEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha3_AzureKeyVaultEncryptionSpec(a.(*kops.AzureKeyVaultEncryptionSpec), b.(*AzureKeyVaultEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AzureSpec)(nil), (*kops.AzureSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_AzureSpec_To_kops_AzureSpec(a.(*AzureSpec), b.(*kops.AzureSpec), scope)
 	}); err != nil {
@@ -224,6 +264,46 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CanaryHTTPHealthCheck)(nil), (*kops.CanaryHTTPHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(a.(*CanaryHTTPHealthCheck), b.(*kops.CanaryHTTPHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CanaryHTTPHealthCheck)(nil), (*CanaryHTTPHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CanaryHTTPHealthCheck_To_v1alpha3_CanaryHTTPHealthCheck(a.(*kops.CanaryHTTPHealthCheck), b.(*CanaryHTTPHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CanaryHealthCheck)(nil), (*kops.CanaryHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CanaryHealthCheck_To_kops_CanaryHealthCheck(a.(*CanaryHealthCheck), b.(*kops.CanaryHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CanaryHealthCheck)(nil), (*CanaryHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CanaryHealthCheck_To_v1alpha3_CanaryHealthCheck(a.(*kops.CanaryHealthCheck), b.(*CanaryHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CanaryPodsHealthCheck)(nil), (*kops.CanaryPodsHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(a.(*CanaryPodsHealthCheck), b.(*kops.CanaryPodsHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CanaryPodsHealthCheck)(nil), (*CanaryPodsHealthCheck)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CanaryPodsHealthCheck_To_v1alpha3_CanaryPodsHealthCheck(a.(*kops.CanaryPodsHealthCheck), b.(*CanaryPodsHealthCheck), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CanarySpec)(nil), (*kops.CanarySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CanarySpec_To_kops_CanarySpec(a.(*CanarySpec), b.(*kops.CanarySpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CanarySpec)(nil), (*CanarySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CanarySpec_To_v1alpha3_CanarySpec(a.(*kops.CanarySpec), b.(*CanarySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertManagerConfig)(nil), (*kops.CertManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_CertManagerConfig_To_kops_CertManagerConfig(a.(*CertManagerConfig), b.(*kops.CertManagerConfig), scope)
 	}); err != nil {
@@ -334,6 +414,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ClusterValidationSpec)(nil), (*kops.ClusterValidationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(a.(*ClusterValidationSpec), b.(*kops.ClusterValidationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ClusterValidationSpec)(nil), (*ClusterValidationSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(a.(*kops.ClusterValidationSpec), b.(*ClusterValidationSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ConfigStoreEncryptionSpec)(nil), (*kops.ConfigStoreEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(a.(*ConfigStoreEncryptionSpec), b.(*kops.ConfigStoreEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ConfigStoreEncryptionSpec)(nil), (*ConfigStoreEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ConfigStoreEncryptionSpec_To_v1alpha3_ConfigStoreEncryptionSpec(a.(*kops.ConfigStoreEncryptionSpec), b.(*ConfigStoreEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ConfigStoreSpec)(nil), (*kops.ConfigStoreSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_ConfigStoreSpec_To_kops_ConfigStoreSpec(a.(*ConfigStoreSpec), b.(*kops.ConfigStoreSpec), scope)
 	}); err != nil {
@@ -354,6 +454,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CrioConfig)(nil), (*kops.CrioConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CrioConfig_To_kops_CrioConfig(a.(*CrioConfig), b.(*kops.CrioConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CrioConfig)(nil), (*CrioConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CrioConfig_To_v1alpha3_CrioConfig(a.(*kops.CrioConfig), b.(*CrioConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CrioRuntimeConfig)(nil), (*kops.CrioRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(a.(*CrioRuntimeConfig), b.(*kops.CrioRuntimeConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.CrioRuntimeConfig)(nil), (*CrioRuntimeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_CrioRuntimeConfig_To_v1alpha3_CrioRuntimeConfig(a.(*kops.CrioRuntimeConfig), b.(*CrioRuntimeConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DCGMExporterConfig)(nil), (*kops.DCGMExporterConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_DCGMExporterConfig_To_kops_DCGMExporterConfig(a.(*DCGMExporterConfig), b.(*kops.DCGMExporterConfig), scope)
 	}); err != nil {
@@ -544,6 +664,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*GCPKMSEncryptionSpec)(nil), (*kops.GCPKMSEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(a.(*GCPKMSEncryptionSpec), b.(*kops.GCPKMSEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.GCPKMSEncryptionSpec)(nil), (*GCPKMSEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_GCPKMSEncryptionSpec_To_v1alpha3_GCPKMSEncryptionSpec(a.(*kops.GCPKMSEncryptionSpec), b.(*GCPKMSEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*GCPNetworkingSpec)(nil), (*kops.GCPNetworkingSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_GCPNetworkingSpec_To_kops_GCPNetworkingSpec(a.(*GCPNetworkingSpec), b.(*kops.GCPNetworkingSpec), scope)
 	}); err != nil {
@@ -894,6 +1024,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceWindow)(nil), (*kops.MaintenanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_MaintenanceWindow_To_kops_MaintenanceWindow(a.(*MaintenanceWindow), b.(*kops.MaintenanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MaintenanceWindow)(nil), (*MaintenanceWindow)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MaintenanceWindow_To_v1alpha3_MaintenanceWindow(a.(*kops.MaintenanceWindow), b.(*MaintenanceWindow), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MaintenanceWindowSpec)(nil), (*kops.MaintenanceWindowSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(a.(*MaintenanceWindowSpec), b.(*kops.MaintenanceWindowSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.MaintenanceWindowSpec)(nil), (*MaintenanceWindowSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_MaintenanceWindowSpec_To_v1alpha3_MaintenanceWindowSpec(a.(*kops.MaintenanceWindowSpec), b.(*MaintenanceWindowSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MetricsServerConfig)(nil), (*kops.MetricsServerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_MetricsServerConfig_To_kops_MetricsServerConfig(a.(*MetricsServerConfig), b.(*kops.MetricsServerConfig), scope)
 	}); err != nil {
@@ -1254,6 +1404,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ValidationHTTPProbe)(nil), (*kops.ValidationHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(a.(*ValidationHTTPProbe), b.(*kops.ValidationHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ValidationHTTPProbe)(nil), (*ValidationHTTPProbe)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ValidationHTTPProbe_To_v1alpha3_ValidationHTTPProbe(a.(*kops.ValidationHTTPProbe), b.(*ValidationHTTPProbe), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ValidationWorkload)(nil), (*kops.ValidationWorkload)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_ValidationWorkload_To_kops_ValidationWorkload(a.(*ValidationWorkload), b.(*kops.ValidationWorkload), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.ValidationWorkload)(nil), (*ValidationWorkload)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_ValidationWorkload_To_v1alpha3_ValidationWorkload(a.(*kops.ValidationWorkload), b.(*ValidationWorkload), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VolumeEncryptionSpec)(nil), (*kops.VolumeEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha3_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(a.(*VolumeEncryptionSpec), b.(*kops.VolumeEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*kops.VolumeEncryptionSpec)(nil), (*VolumeEncryptionSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_kops_VolumeEncryptionSpec_To_v1alpha3_VolumeEncryptionSpec(a.(*kops.VolumeEncryptionSpec), b.(*VolumeEncryptionSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VolumeMountSpec)(nil), (*kops.VolumeMountSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VolumeMountSpec_To_kops_VolumeMountSpec(a.(*VolumeMountSpec), b.(*kops.VolumeMountSpec), scope)
 	}); err != nil {
@@ -1435,6 +1615,26 @@ func Convert_kops_AWSAuthenticationSpec_To_v1alpha3_AWSAuthenticationSpec(in *ko
 	return autoConvert_kops_AWSAuthenticationSpec_To_v1alpha3_AWSAuthenticationSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(in *AWSKMSEncryptionSpec, out *kops.AWSKMSEncryptionSpec, s conversion.Scope) error {
+	out.KeyARN = in.KeyARN
+	return nil
+}
+
+// Convert_v1alpha3_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(in *AWSKMSEncryptionSpec, out *kops.AWSKMSEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_AWSKMSEncryptionSpec_To_v1alpha3_AWSKMSEncryptionSpec(in *kops.AWSKMSEncryptionSpec, out *AWSKMSEncryptionSpec, s conversion.Scope) error {
+	out.KeyARN = in.KeyARN
+	return nil
+}
+
+// Convert_kops_AWSKMSEncryptionSpec_To_v1alpha3_AWSKMSEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_AWSKMSEncryptionSpec_To_v1alpha3_AWSKMSEncryptionSpec(in *kops.AWSKMSEncryptionSpec, out *AWSKMSEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AWSKMSEncryptionSpec_To_v1alpha3_AWSKMSEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AWSPermission_To_kops_AWSPermission(in *AWSPermission, out *kops.AWSPermission, s conversion.Scope) error {
 	out.PolicyARNs = in.PolicyARNs
 	out.InlinePolicy = in.InlinePolicy
@@ -1643,6 +1843,28 @@ func Convert_kops_AddonSpec_To_v1alpha3_AddonSpec(in *kops.AddonSpec, out *Addon
 	return autoConvert_kops_AddonSpec_To_v1alpha3_AddonSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(in *AgeEncryptionSpec, out *kops.AgeEncryptionSpec, s conversion.Scope) error {
+	out.Recipient = in.Recipient
+	out.IdentityFile = in.IdentityFile
+	return nil
+}
+
+// Convert_v1alpha3_AgeEncryptionSpec_To_kops_AgeEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(in *AgeEncryptionSpec, out *kops.AgeEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_AgeEncryptionSpec_To_v1alpha3_AgeEncryptionSpec(in *kops.AgeEncryptionSpec, out *AgeEncryptionSpec, s conversion.Scope) error {
+	out.Recipient = in.Recipient
+	out.IdentityFile = in.IdentityFile
+	return nil
+}
+
+// Convert_kops_AgeEncryptionSpec_To_v1alpha3_AgeEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_AgeEncryptionSpec_To_v1alpha3_AgeEncryptionSpec(in *kops.AgeEncryptionSpec, out *AgeEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AgeEncryptionSpec_To_v1alpha3_AgeEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AlwaysAllowAuthorizationSpec_To_kops_AlwaysAllowAuthorizationSpec(in *AlwaysAllowAuthorizationSpec, out *kops.AlwaysAllowAuthorizationSpec, s conversion.Scope) error {
 	return nil
 }
@@ -1707,10 +1929,41 @@ func Convert_kops_AmazonVPCNetworkingSpec_To_v1alpha3_AmazonVPCNetworkingSpec(in
 	return autoConvert_kops_AmazonVPCNetworkingSpec_To_v1alpha3_AmazonVPCNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(in *AssetVerificationSpec, out *kops.AssetVerificationSpec, s conversion.Scope) error {
+	out.PublicKeys = in.PublicKeys
+	out.Images = in.Images
+	return nil
+}
+
+// Convert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(in *AssetVerificationSpec, out *kops.AssetVerificationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(in, out, s)
+}
+
+func autoConvert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(in *kops.AssetVerificationSpec, out *AssetVerificationSpec, s conversion.Scope) error {
+	out.PublicKeys = in.PublicKeys
+	out.Images = in.Images
+	return nil
+}
+
+// Convert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec is an autogenerated conversion function.
+func Convert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(in *kops.AssetVerificationSpec, out *AssetVerificationSpec, s conversion.Scope) error {
+	return autoConvert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AssetsSpec_To_kops_AssetsSpec(in *AssetsSpec, out *kops.AssetsSpec, s conversion.Scope) error {
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(kops.AssetVerificationSpec)
+		if err := Convert_v1alpha3_AssetVerificationSpec_To_kops_AssetVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -1723,6 +1976,15 @@ func autoConvert_kops_AssetsSpec_To_v1alpha3_AssetsSpec(in *kops.AssetsSpec, out
 	out.ContainerRegistry = in.ContainerRegistry
 	out.FileRepository = in.FileRepository
 	out.ContainerProxy = in.ContainerProxy
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(AssetVerificationSpec)
+		if err := Convert_kops_AssetVerificationSpec_To_v1alpha3_AssetVerificationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Verification = nil
+	}
 	return nil
 }
 
@@ -1857,6 +2119,32 @@ func Convert_kops_AuthorizationSpec_To_v1alpha3_AuthorizationSpec(in *kops.Autho
 	return autoConvert_kops_AuthorizationSpec_To_v1alpha3_AuthorizationSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(in *AzureKeyVaultEncryptionSpec, out *kops.AzureKeyVaultEncryptionSpec, s conversion.Scope) error {
+	out.VaultURL = in.VaultURL
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	out.VaultID = in.VaultID
+	return nil
+}
+
+// Convert_v1alpha3_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha3_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(in *AzureKeyVaultEncryptionSpec, out *kops.AzureKeyVaultEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha3_AzureKeyVaultEncryptionSpec(in *kops.AzureKeyVaultEncryptionSpec, out *AzureKeyVaultEncryptionSpec, s conversion.Scope) error {
+	out.VaultURL = in.VaultURL
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	out.VaultID = in.VaultID
+	return nil
+}
+
+// Convert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha3_AzureKeyVaultEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha3_AzureKeyVaultEncryptionSpec(in *kops.AzureKeyVaultEncryptionSpec, out *AzureKeyVaultEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha3_AzureKeyVaultEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_AzureSpec_To_kops_AzureSpec(in *AzureSpec, out *kops.AzureSpec, s conversion.Scope) error {
 	out.SubscriptionID = in.SubscriptionID
 	out.StorageAccountID = in.StorageAccountID
@@ -2083,6 +2371,150 @@ func Convert_kops_CanalNetworkingSpec_To_v1alpha3_CanalNetworkingSpec(in *kops.C
 	return autoConvert_kops_CanalNetworkingSpec_To_v1alpha3_CanalNetworkingSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(in *CanaryHTTPHealthCheck, out *kops.CanaryHTTPHealthCheck, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_v1alpha3_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha3_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(in *CanaryHTTPHealthCheck, out *kops.CanaryHTTPHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(in, out, s)
+}
+
+func autoConvert_kops_CanaryHTTPHealthCheck_To_v1alpha3_CanaryHTTPHealthCheck(in *kops.CanaryHTTPHealthCheck, out *CanaryHTTPHealthCheck, s conversion.Scope) error {
+	out.URL = in.URL
+	return nil
+}
+
+// Convert_kops_CanaryHTTPHealthCheck_To_v1alpha3_CanaryHTTPHealthCheck is an autogenerated conversion function.
+func Convert_kops_CanaryHTTPHealthCheck_To_v1alpha3_CanaryHTTPHealthCheck(in *kops.CanaryHTTPHealthCheck, out *CanaryHTTPHealthCheck, s conversion.Scope) error {
+	return autoConvert_kops_CanaryHTTPHealthCheck_To_v1alpha3_CanaryHTTPHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha3_CanaryHealthCheck_To_kops_CanaryHealthCheck(in *CanaryHealthCheck, out *kops.CanaryHealthCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(kops.CanaryPodsHealthCheck)
+		if err := Convert_v1alpha3_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pods = nil
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(kops.CanaryHTTPHealthCheck)
+		if err := Convert_v1alpha3_CanaryHTTPHealthCheck_To_kops_CanaryHTTPHealthCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_CanaryHealthCheck_To_kops_CanaryHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha3_CanaryHealthCheck_To_kops_CanaryHealthCheck(in *CanaryHealthCheck, out *kops.CanaryHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CanaryHealthCheck_To_kops_CanaryHealthCheck(in, out, s)
+}
+
+func autoConvert_kops_CanaryHealthCheck_To_v1alpha3_CanaryHealthCheck(in *kops.CanaryHealthCheck, out *CanaryHealthCheck, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = new(CanaryPodsHealthCheck)
+		if err := Convert_kops_CanaryPodsHealthCheck_To_v1alpha3_CanaryPodsHealthCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pods = nil
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(CanaryHTTPHealthCheck)
+		if err := Convert_kops_CanaryHTTPHealthCheck_To_v1alpha3_CanaryHTTPHealthCheck(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP = nil
+	}
+	return nil
+}
+
+// Convert_kops_CanaryHealthCheck_To_v1alpha3_CanaryHealthCheck is an autogenerated conversion function.
+func Convert_kops_CanaryHealthCheck_To_v1alpha3_CanaryHealthCheck(in *kops.CanaryHealthCheck, out *CanaryHealthCheck, s conversion.Scope) error {
+	return autoConvert_kops_CanaryHealthCheck_To_v1alpha3_CanaryHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha3_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(in *CanaryPodsHealthCheck, out *kops.CanaryPodsHealthCheck, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Selector = in.Selector
+	return nil
+}
+
+// Convert_v1alpha3_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck is an autogenerated conversion function.
+func Convert_v1alpha3_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(in *CanaryPodsHealthCheck, out *kops.CanaryPodsHealthCheck, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CanaryPodsHealthCheck_To_kops_CanaryPodsHealthCheck(in, out, s)
+}
+
+func autoConvert_kops_CanaryPodsHealthCheck_To_v1alpha3_CanaryPodsHealthCheck(in *kops.CanaryPodsHealthCheck, out *CanaryPodsHealthCheck, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.Selector = in.Selector
+	return nil
+}
+
+// Convert_kops_CanaryPodsHealthCheck_To_v1alpha3_CanaryPodsHealthCheck is an autogenerated conversion function.
+func Convert_kops_CanaryPodsHealthCheck_To_v1alpha3_CanaryPodsHealthCheck(in *kops.CanaryPodsHealthCheck, out *CanaryPodsHealthCheck, s conversion.Scope) error {
+	return autoConvert_kops_CanaryPodsHealthCheck_To_v1alpha3_CanaryPodsHealthCheck(in, out, s)
+}
+
+func autoConvert_v1alpha3_CanarySpec_To_kops_CanarySpec(in *CanarySpec, out *kops.CanarySpec, s conversion.Scope) error {
+	out.Count = in.Count
+	out.SoakDuration = in.SoakDuration
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]kops.CanaryHealthCheck, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_CanaryHealthCheck_To_kops_CanaryHealthCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HealthChecks = nil
+	}
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_v1alpha3_CanarySpec_To_kops_CanarySpec is an autogenerated conversion function.
+func Convert_v1alpha3_CanarySpec_To_kops_CanarySpec(in *CanarySpec, out *kops.CanarySpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CanarySpec_To_kops_CanarySpec(in, out, s)
+}
+
+func autoConvert_kops_CanarySpec_To_v1alpha3_CanarySpec(in *kops.CanarySpec, out *CanarySpec, s conversion.Scope) error {
+	out.Count = in.Count
+	out.SoakDuration = in.SoakDuration
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]CanaryHealthCheck, len(*in))
+		for i := range *in {
+			if err := Convert_kops_CanaryHealthCheck_To_v1alpha3_CanaryHealthCheck(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HealthChecks = nil
+	}
+	out.FailureThreshold = in.FailureThreshold
+	return nil
+}
+
+// Convert_kops_CanarySpec_To_v1alpha3_CanarySpec is an autogenerated conversion function.
+func Convert_kops_CanarySpec_To_v1alpha3_CanarySpec(in *kops.CanarySpec, out *CanarySpec, s conversion.Scope) error {
+	return autoConvert_kops_CanarySpec_To_v1alpha3_CanarySpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_CertManagerConfig_To_kops_CertManagerConfig(in *CertManagerConfig, out *kops.CertManagerConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Managed = in.Managed
@@ -2744,6 +3176,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.Containerd = nil
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(kops.CrioConfig)
+		if err := Convert_v1alpha3_CrioConfig_To_kops_CrioConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Crio = nil
+	}
 	if in.KubeDNS != nil {
 		in, out := &in.KubeDNS, &out.KubeDNS
 		*out = new(kops.KubeDNSConfig)
@@ -2947,6 +3388,15 @@ func autoConvert_v1alpha3_ClusterSpec_To_kops_ClusterSpec(in *ClusterSpec, out *
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(kops.ClusterValidationSpec)
+		if err := Convert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(kops.ClusterAutoscalerConfig)
@@ -3078,6 +3528,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.Containerd = nil
 	}
+	if in.Crio != nil {
+		in, out := &in.Crio, &out.Crio
+		*out = new(CrioConfig)
+		if err := Convert_kops_CrioConfig_To_v1alpha3_CrioConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Crio = nil
+	}
 	if in.KubeDNS != nil {
 		in, out := &in.KubeDNS, &out.KubeDNS
 		*out = new(KubeDNSConfig)
@@ -3281,6 +3740,15 @@ func autoConvert_kops_ClusterSpec_To_v1alpha3_ClusterSpec(in *kops.ClusterSpec,
 	} else {
 		out.RollingUpdate = nil
 	}
+	if in.Validation != nil {
+		in, out := &in.Validation, &out.Validation
+		*out = new(ClusterValidationSpec)
+		if err := Convert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Validation = nil
+	}
 	if in.ClusterAutoscaler != nil {
 		in, out := &in.ClusterAutoscaler, &out.ClusterAutoscaler
 		*out = new(ClusterAutoscalerConfig)
@@ -3383,10 +3851,173 @@ func Convert_kops_ClusterSubnetSpec_To_v1alpha3_ClusterSubnetSpec(in *kops.Clust
 	return autoConvert_kops_ClusterSubnetSpec_To_v1alpha3_ClusterSubnetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(in *ClusterValidationSpec, out *kops.ClusterValidationSpec, s conversion.Scope) error {
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]kops.ValidationWorkload, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ValidationWorkload_To_kops_ValidationWorkload(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Workloads = nil
+	}
+	out.CrashLoopNamespaces = in.CrashLoopNamespaces
+	if in.HTTPProbes != nil {
+		in, out := &in.HTTPProbes, &out.HTTPProbes
+		*out = make([]kops.ValidationHTTPProbe, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HTTPProbes = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(in *ClusterValidationSpec, out *kops.ClusterValidationSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ClusterValidationSpec_To_kops_ClusterValidationSpec(in, out, s)
+}
+
+func autoConvert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(in *kops.ClusterValidationSpec, out *ClusterValidationSpec, s conversion.Scope) error {
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]ValidationWorkload, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ValidationWorkload_To_v1alpha3_ValidationWorkload(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Workloads = nil
+	}
+	out.CrashLoopNamespaces = in.CrashLoopNamespaces
+	if in.HTTPProbes != nil {
+		in, out := &in.HTTPProbes, &out.HTTPProbes
+		*out = make([]ValidationHTTPProbe, len(*in))
+		for i := range *in {
+			if err := Convert_kops_ValidationHTTPProbe_To_v1alpha3_ValidationHTTPProbe(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.HTTPProbes = nil
+	}
+	return nil
+}
+
+// Convert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec is an autogenerated conversion function.
+func Convert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(in *kops.ClusterValidationSpec, out *ClusterValidationSpec, s conversion.Scope) error {
+	return autoConvert_kops_ClusterValidationSpec_To_v1alpha3_ClusterValidationSpec(in, out, s)
+}
+
+func autoConvert_v1alpha3_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(in *ConfigStoreEncryptionSpec, out *kops.ConfigStoreEncryptionSpec, s conversion.Scope) error {
+	if in.Age != nil {
+		in, out := &in.Age, &out.Age
+		*out = new(kops.AgeEncryptionSpec)
+		if err := Convert_v1alpha3_AgeEncryptionSpec_To_kops_AgeEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Age = nil
+	}
+	if in.AWSKMS != nil {
+		in, out := &in.AWSKMS, &out.AWSKMS
+		*out = new(kops.AWSKMSEncryptionSpec)
+		if err := Convert_v1alpha3_AWSKMSEncryptionSpec_To_kops_AWSKMSEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSKMS = nil
+	}
+	if in.GCPKMS != nil {
+		in, out := &in.GCPKMS, &out.GCPKMS
+		*out = new(kops.GCPKMSEncryptionSpec)
+		if err := Convert_v1alpha3_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCPKMS = nil
+	}
+	if in.AzureKeyVault != nil {
+		in, out := &in.AzureKeyVault, &out.AzureKeyVault
+		*out = new(kops.AzureKeyVaultEncryptionSpec)
+		if err := Convert_v1alpha3_AzureKeyVaultEncryptionSpec_To_kops_AzureKeyVaultEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AzureKeyVault = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha3_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(in *ConfigStoreEncryptionSpec, out *kops.ConfigStoreEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_ConfigStoreEncryptionSpec_To_v1alpha3_ConfigStoreEncryptionSpec(in *kops.ConfigStoreEncryptionSpec, out *ConfigStoreEncryptionSpec, s conversion.Scope) error {
+	if in.Age != nil {
+		in, out := &in.Age, &out.Age
+		*out = new(AgeEncryptionSpec)
+		if err := Convert_kops_AgeEncryptionSpec_To_v1alpha3_AgeEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Age = nil
+	}
+	if in.AWSKMS != nil {
+		in, out := &in.AWSKMS, &out.AWSKMS
+		*out = new(AWSKMSEncryptionSpec)
+		if err := Convert_kops_AWSKMSEncryptionSpec_To_v1alpha3_AWSKMSEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AWSKMS = nil
+	}
+	if in.GCPKMS != nil {
+		in, out := &in.GCPKMS, &out.GCPKMS
+		*out = new(GCPKMSEncryptionSpec)
+		if err := Convert_kops_GCPKMSEncryptionSpec_To_v1alpha3_GCPKMSEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GCPKMS = nil
+	}
+	if in.AzureKeyVault != nil {
+		in, out := &in.AzureKeyVault, &out.AzureKeyVault
+		*out = new(AzureKeyVaultEncryptionSpec)
+		if err := Convert_kops_AzureKeyVaultEncryptionSpec_To_v1alpha3_AzureKeyVaultEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AzureKeyVault = nil
+	}
+	return nil
+}
+
+// Convert_kops_ConfigStoreEncryptionSpec_To_v1alpha3_ConfigStoreEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_ConfigStoreEncryptionSpec_To_v1alpha3_ConfigStoreEncryptionSpec(in *kops.ConfigStoreEncryptionSpec, out *ConfigStoreEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_ConfigStoreEncryptionSpec_To_v1alpha3_ConfigStoreEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_ConfigStoreSpec_To_kops_ConfigStoreSpec(in *ConfigStoreSpec, out *kops.ConfigStoreSpec, s conversion.Scope) error {
 	out.Base = in.Base
 	out.Keypairs = in.Keypairs
 	out.Secrets = in.Secrets
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(kops.ConfigStoreEncryptionSpec)
+		if err := Convert_v1alpha3_ConfigStoreEncryptionSpec_To_kops_ConfigStoreEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Encryption = nil
+	}
 	return nil
 }
 
@@ -3399,6 +4030,15 @@ func autoConvert_kops_ConfigStoreSpec_To_v1alpha3_ConfigStoreSpec(in *kops.Confi
 	out.Base = in.Base
 	out.Keypairs = in.Keypairs
 	out.Secrets = in.Secrets
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(ConfigStoreEncryptionSpec)
+		if err := Convert_kops_ConfigStoreEncryptionSpec_To_v1alpha3_ConfigStoreEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Encryption = nil
+	}
 	return nil
 }
 
@@ -3517,6 +4157,114 @@ func Convert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in *kops.Contain
 	return autoConvert_kops_ContainerdConfig_To_v1alpha3_ContainerdConfig(in, out, s)
 }
 
+func autoConvert_v1alpha3_CrioConfig_To_kops_CrioConfig(in *CrioConfig, out *kops.CrioConfig, s conversion.Scope) error {
+	out.ConfigOverride = in.ConfigOverride
+	out.DefaultRuntime = in.DefaultRuntime
+	out.LogLevel = in.LogLevel
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(kops.PackagesConfig)
+		if err := Convert_v1alpha3_PackagesConfig_To_kops_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	out.RegistryMirrors = in.RegistryMirrors
+	out.Root = in.Root
+	out.RunRoot = in.RunRoot
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make(map[string]kops.CrioRuntimeConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(kops.CrioRuntimeConfig)
+			if err := Convert_v1alpha3_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Runtimes = nil
+	}
+	out.SkipInstall = in.SkipInstall
+	out.StorageDriver = in.StorageDriver
+	out.Version = in.Version
+	out.SeLinuxEnabled = in.SeLinuxEnabled
+	return nil
+}
+
+// Convert_v1alpha3_CrioConfig_To_kops_CrioConfig is an autogenerated conversion function.
+func Convert_v1alpha3_CrioConfig_To_kops_CrioConfig(in *CrioConfig, out *kops.CrioConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CrioConfig_To_kops_CrioConfig(in, out, s)
+}
+
+func autoConvert_kops_CrioConfig_To_v1alpha3_CrioConfig(in *kops.CrioConfig, out *CrioConfig, s conversion.Scope) error {
+	out.ConfigOverride = in.ConfigOverride
+	out.DefaultRuntime = in.DefaultRuntime
+	out.LogLevel = in.LogLevel
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = new(PackagesConfig)
+		if err := Convert_kops_PackagesConfig_To_v1alpha3_PackagesConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Packages = nil
+	}
+	out.RegistryMirrors = in.RegistryMirrors
+	out.Root = in.Root
+	out.RunRoot = in.RunRoot
+	if in.Runtimes != nil {
+		in, out := &in.Runtimes, &out.Runtimes
+		*out = make(map[string]CrioRuntimeConfig, len(*in))
+		for key, val := range *in {
+			newVal := new(CrioRuntimeConfig)
+			if err := Convert_kops_CrioRuntimeConfig_To_v1alpha3_CrioRuntimeConfig(&val, newVal, s); err != nil {
+				return err
+			}
+			(*out)[key] = *newVal
+		}
+	} else {
+		out.Runtimes = nil
+	}
+	out.SkipInstall = in.SkipInstall
+	out.StorageDriver = in.StorageDriver
+	out.Version = in.Version
+	out.SeLinuxEnabled = in.SeLinuxEnabled
+	return nil
+}
+
+// Convert_kops_CrioConfig_To_v1alpha3_CrioConfig is an autogenerated conversion function.
+func Convert_kops_CrioConfig_To_v1alpha3_CrioConfig(in *kops.CrioConfig, out *CrioConfig, s conversion.Scope) error {
+	return autoConvert_kops_CrioConfig_To_v1alpha3_CrioConfig(in, out, s)
+}
+
+func autoConvert_v1alpha3_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(in *CrioRuntimeConfig, out *kops.CrioRuntimeConfig, s conversion.Scope) error {
+	out.RuntimePath = in.RuntimePath
+	out.RuntimeType = in.RuntimeType
+	out.RuntimeRoot = in.RuntimeRoot
+	out.AllowedAnnotations = in.AllowedAnnotations
+	return nil
+}
+
+// Convert_v1alpha3_CrioRuntimeConfig_To_kops_CrioRuntimeConfig is an autogenerated conversion function.
+func Convert_v1alpha3_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(in *CrioRuntimeConfig, out *kops.CrioRuntimeConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha3_CrioRuntimeConfig_To_kops_CrioRuntimeConfig(in, out, s)
+}
+
+func autoConvert_kops_CrioRuntimeConfig_To_v1alpha3_CrioRuntimeConfig(in *kops.CrioRuntimeConfig, out *CrioRuntimeConfig, s conversion.Scope) error {
+	out.RuntimePath = in.RuntimePath
+	out.RuntimeType = in.RuntimeType
+	out.RuntimeRoot = in.RuntimeRoot
+	out.AllowedAnnotations = in.AllowedAnnotations
+	return nil
+}
+
+// Convert_kops_CrioRuntimeConfig_To_v1alpha3_CrioRuntimeConfig is an autogenerated conversion function.
+func Convert_kops_CrioRuntimeConfig_To_v1alpha3_CrioRuntimeConfig(in *kops.CrioRuntimeConfig, out *CrioRuntimeConfig, s conversion.Scope) error {
+	return autoConvert_kops_CrioRuntimeConfig_To_v1alpha3_CrioRuntimeConfig(in, out, s)
+}
+
 func autoConvert_v1alpha3_DCGMExporterConfig_To_kops_DCGMExporterConfig(in *DCGMExporterConfig, out *kops.DCGMExporterConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -4209,6 +4957,26 @@ func Convert_kops_GCESpec_To_v1alpha3_GCESpec(in *kops.GCESpec, out *GCESpec, s
 	return autoConvert_kops_GCESpec_To_v1alpha3_GCESpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(in *GCPKMSEncryptionSpec, out *kops.GCPKMSEncryptionSpec, s conversion.Scope) error {
+	out.KeyName = in.KeyName
+	return nil
+}
+
+// Convert_v1alpha3_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha3_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(in *GCPKMSEncryptionSpec, out *kops.GCPKMSEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_GCPKMSEncryptionSpec_To_kops_GCPKMSEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_GCPKMSEncryptionSpec_To_v1alpha3_GCPKMSEncryptionSpec(in *kops.GCPKMSEncryptionSpec, out *GCPKMSEncryptionSpec, s conversion.Scope) error {
+	out.KeyName = in.KeyName
+	return nil
+}
+
+// Convert_kops_GCPKMSEncryptionSpec_To_v1alpha3_GCPKMSEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_GCPKMSEncryptionSpec_To_v1alpha3_GCPKMSEncryptionSpec(in *kops.GCPKMSEncryptionSpec, out *GCPKMSEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_GCPKMSEncryptionSpec_To_v1alpha3_GCPKMSEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_GCPNetworkingSpec_To_kops_GCPNetworkingSpec(in *GCPNetworkingSpec, out *kops.GCPNetworkingSpec, s conversion.Scope) error {
 	return nil
 }
@@ -4561,6 +5329,7 @@ func autoConvert_v1alpha3_InstanceGroupSpec_To_kops_InstanceGroupSpec(in *Instan
 	out.Manager = kops.InstanceManager(in.Manager)
 	out.Role = kops.InstanceGroupRole(in.Role)
 	out.Image = in.Image
+	out.ImageFamily = kops.ImageFamily(in.ImageFamily)
 	out.MinSize = in.MinSize
 	out.MaxSize = in.MaxSize
 	out.Autoscale = in.Autoscale
@@ -4750,6 +5519,7 @@ func autoConvert_kops_InstanceGroupSpec_To_v1alpha3_InstanceGroupSpec(in *kops.I
 	out.Manager = InstanceManager(in.Manager)
 	out.Role = InstanceGroupRole(in.Role)
 	out.Image = in.Image
+	out.ImageFamily = ImageFamily(in.ImageFamily)
 	out.MinSize = in.MinSize
 	out.MaxSize = in.MaxSize
 	out.Autoscale = in.Autoscale
@@ -6277,6 +7047,70 @@ func Convert_kops_LoadBalancerSubnetSpec_To_v1alpha3_LoadBalancerSubnetSpec(in *
 	return autoConvert_kops_LoadBalancerSubnetSpec_To_v1alpha3_LoadBalancerSubnetSpec(in, out, s)
 }
 
+func autoConvert_v1alpha3_MaintenanceWindow_To_kops_MaintenanceWindow(in *MaintenanceWindow, out *kops.MaintenanceWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_v1alpha3_MaintenanceWindow_To_kops_MaintenanceWindow is an autogenerated conversion function.
+func Convert_v1alpha3_MaintenanceWindow_To_kops_MaintenanceWindow(in *MaintenanceWindow, out *kops.MaintenanceWindow, s conversion.Scope) error {
+	return autoConvert_v1alpha3_MaintenanceWindow_To_kops_MaintenanceWindow(in, out, s)
+}
+
+func autoConvert_kops_MaintenanceWindow_To_v1alpha3_MaintenanceWindow(in *kops.MaintenanceWindow, out *MaintenanceWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.Duration = in.Duration
+	return nil
+}
+
+// Convert_kops_MaintenanceWindow_To_v1alpha3_MaintenanceWindow is an autogenerated conversion function.
+func Convert_kops_MaintenanceWindow_To_v1alpha3_MaintenanceWindow(in *kops.MaintenanceWindow, out *MaintenanceWindow, s conversion.Scope) error {
+	return autoConvert_kops_MaintenanceWindow_To_v1alpha3_MaintenanceWindow(in, out, s)
+}
+
+func autoConvert_v1alpha3_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(in *MaintenanceWindowSpec, out *kops.MaintenanceWindowSpec, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]kops.MaintenanceWindow, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_MaintenanceWindow_To_kops_MaintenanceWindow(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Windows = nil
+	}
+	return nil
+}
+
+// Convert_v1alpha3_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec is an autogenerated conversion function.
+func Convert_v1alpha3_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(in *MaintenanceWindowSpec, out *kops.MaintenanceWindowSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(in, out, s)
+}
+
+func autoConvert_kops_MaintenanceWindowSpec_To_v1alpha3_MaintenanceWindowSpec(in *kops.MaintenanceWindowSpec, out *MaintenanceWindowSpec, s conversion.Scope) error {
+	out.TimeZone = in.TimeZone
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			if err := Convert_kops_MaintenanceWindow_To_v1alpha3_MaintenanceWindow(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Windows = nil
+	}
+	return nil
+}
+
+// Convert_kops_MaintenanceWindowSpec_To_v1alpha3_MaintenanceWindowSpec is an autogenerated conversion function.
+func Convert_kops_MaintenanceWindowSpec_To_v1alpha3_MaintenanceWindowSpec(in *kops.MaintenanceWindowSpec, out *MaintenanceWindowSpec, s conversion.Scope) error {
+	return autoConvert_kops_MaintenanceWindowSpec_To_v1alpha3_MaintenanceWindowSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_MetricsServerConfig_To_kops_MetricsServerConfig(in *MetricsServerConfig, out *kops.MetricsServerConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	out.Image = in.Image
@@ -7313,6 +8147,24 @@ func autoConvert_v1alpha3_RollingUpdate_To_kops_RollingUpdate(in *RollingUpdate,
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(kops.MaintenanceWindowSpec)
+		if err := Convert_v1alpha3_MaintenanceWindowSpec_To_kops_MaintenanceWindowSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MaintenanceWindow = nil
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(kops.CanarySpec)
+		if err := Convert_v1alpha3_CanarySpec_To_kops_CanarySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Canary = nil
+	}
 	return nil
 }
 
@@ -7325,6 +8177,24 @@ func autoConvert_kops_RollingUpdate_To_v1alpha3_RollingUpdate(in *kops.RollingUp
 	out.DrainAndTerminate = in.DrainAndTerminate
 	out.MaxUnavailable = in.MaxUnavailable
 	out.MaxSurge = in.MaxSurge
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindowSpec)
+		if err := Convert_kops_MaintenanceWindowSpec_To_v1alpha3_MaintenanceWindowSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.MaintenanceWindow = nil
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		if err := Convert_kops_CanarySpec_To_v1alpha3_CanarySpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Canary = nil
+	}
 	return nil
 }
 
@@ -7705,8 +8575,96 @@ func Convert_kops_UserData_To_v1alpha3_UserData(in *kops.UserData, out *UserData
 	return autoConvert_kops_UserData_To_v1alpha3_UserData(in, out, s)
 }
 
+func autoConvert_v1alpha3_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(in *ValidationHTTPProbe, out *kops.ValidationHTTPProbe, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Service = in.Service
+	out.Port = in.Port
+	out.Scheme = in.Scheme
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_v1alpha3_ValidationHTTPProbe_To_kops_ValidationHTTPProbe is an autogenerated conversion function.
+func Convert_v1alpha3_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(in *ValidationHTTPProbe, out *kops.ValidationHTTPProbe, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ValidationHTTPProbe_To_kops_ValidationHTTPProbe(in, out, s)
+}
+
+func autoConvert_kops_ValidationHTTPProbe_To_v1alpha3_ValidationHTTPProbe(in *kops.ValidationHTTPProbe, out *ValidationHTTPProbe, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Namespace = in.Namespace
+	out.Service = in.Service
+	out.Port = in.Port
+	out.Scheme = in.Scheme
+	out.Path = in.Path
+	return nil
+}
+
+// Convert_kops_ValidationHTTPProbe_To_v1alpha3_ValidationHTTPProbe is an autogenerated conversion function.
+func Convert_kops_ValidationHTTPProbe_To_v1alpha3_ValidationHTTPProbe(in *kops.ValidationHTTPProbe, out *ValidationHTTPProbe, s conversion.Scope) error {
+	return autoConvert_kops_ValidationHTTPProbe_To_v1alpha3_ValidationHTTPProbe(in, out, s)
+}
+
+func autoConvert_v1alpha3_ValidationWorkload_To_kops_ValidationWorkload(in *ValidationWorkload, out *kops.ValidationWorkload, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1alpha3_ValidationWorkload_To_kops_ValidationWorkload is an autogenerated conversion function.
+func Convert_v1alpha3_ValidationWorkload_To_kops_ValidationWorkload(in *ValidationWorkload, out *kops.ValidationWorkload, s conversion.Scope) error {
+	return autoConvert_v1alpha3_ValidationWorkload_To_kops_ValidationWorkload(in, out, s)
+}
+
+func autoConvert_kops_ValidationWorkload_To_v1alpha3_ValidationWorkload(in *kops.ValidationWorkload, out *ValidationWorkload, s conversion.Scope) error {
+	out.Kind = in.Kind
+	out.Namespace = in.Namespace
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_kops_ValidationWorkload_To_v1alpha3_ValidationWorkload is an autogenerated conversion function.
+func Convert_kops_ValidationWorkload_To_v1alpha3_ValidationWorkload(in *kops.ValidationWorkload, out *ValidationWorkload, s conversion.Scope) error {
+	return autoConvert_kops_ValidationWorkload_To_v1alpha3_ValidationWorkload(in, out, s)
+}
+
+func autoConvert_v1alpha3_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(in *VolumeEncryptionSpec, out *kops.VolumeEncryptionSpec, s conversion.Scope) error {
+	out.KeySource = kops.VolumeEncryptionKeySource(in.KeySource)
+	out.SecretName = in.SecretName
+	out.Cipher = in.Cipher
+	return nil
+}
+
+// Convert_v1alpha3_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec is an autogenerated conversion function.
+func Convert_v1alpha3_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(in *VolumeEncryptionSpec, out *kops.VolumeEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_v1alpha3_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(in, out, s)
+}
+
+func autoConvert_kops_VolumeEncryptionSpec_To_v1alpha3_VolumeEncryptionSpec(in *kops.VolumeEncryptionSpec, out *VolumeEncryptionSpec, s conversion.Scope) error {
+	out.KeySource = VolumeEncryptionKeySource(in.KeySource)
+	out.SecretName = in.SecretName
+	out.Cipher = in.Cipher
+	return nil
+}
+
+// Convert_kops_VolumeEncryptionSpec_To_v1alpha3_VolumeEncryptionSpec is an autogenerated conversion function.
+func Convert_kops_VolumeEncryptionSpec_To_v1alpha3_VolumeEncryptionSpec(in *kops.VolumeEncryptionSpec, out *VolumeEncryptionSpec, s conversion.Scope) error {
+	return autoConvert_kops_VolumeEncryptionSpec_To_v1alpha3_VolumeEncryptionSpec(in, out, s)
+}
+
 func autoConvert_v1alpha3_VolumeMountSpec_To_kops_VolumeMountSpec(in *VolumeMountSpec, out *kops.VolumeMountSpec, s conversion.Scope) error {
 	out.Device = in.Device
+	out.Devices = in.Devices
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(kops.VolumeEncryptionSpec)
+		if err := Convert_v1alpha3_VolumeEncryptionSpec_To_kops_VolumeEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Encryption = nil
+	}
 	out.Filesystem = in.Filesystem
 	out.FormatOptions = in.FormatOptions
 	out.MountOptions = in.MountOptions
@@ -7721,6 +8679,16 @@ func Convert_v1alpha3_VolumeMountSpec_To_kops_VolumeMountSpec(in *VolumeMountSpe
 
 func autoConvert_kops_VolumeMountSpec_To_v1alpha3_VolumeMountSpec(in *kops.VolumeMountSpec, out *VolumeMountSpec, s conversion.Scope) error {
 	out.Device = in.Device
+	out.Devices = in.Devices
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(VolumeEncryptionSpec)
+		if err := Convert_kops_VolumeEncryptionSpec_To_v1alpha3_VolumeEncryptionSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Encryption = nil
+	}
 	out.Filesystem = in.Filesystem
 	out.FormatOptions = in.FormatOptions
 	out.MountOptions = in.MountOptions
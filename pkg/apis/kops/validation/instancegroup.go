@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"k8s.io/kops/pkg/apis/kops"
@@ -66,6 +67,10 @@ func ValidateInstanceGroup(g *kops.InstanceGroup, cloud fi.Cloud, strict bool) f
 		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "role"), g.Spec.Role, supported))
 	}
 
+	if g.Spec.ImageFamily != "" && g.Spec.ImageFamily != kops.ImageFamilyBottlerocket {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "imageFamily"), g.Spec.ImageFamily, []kops.ImageFamily{kops.ImageFamilyBottlerocket}))
+	}
+
 	if g.Spec.Tenancy != "" {
 		tenancy := ec2types.Tenancy(g.Spec.Tenancy)
 		allErrs = append(allErrs, IsValidValue(field.NewPath("spec", "tenancy"), &tenancy, ec2types.Tenancy("").Values())...)
@@ -215,9 +220,18 @@ func validateVolumeSpec(path *field.Path, v kops.VolumeSpec) field.ErrorList {
 func validateVolumeMountSpec(path *field.Path, spec kops.VolumeMountSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if spec.Device == "" {
+	if spec.Device == "" && len(spec.Devices) == 0 {
 		allErrs = append(allErrs, field.Required(path.Child("device"), "device name required"))
 	}
+	if spec.Device != "" && len(spec.Devices) != 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("devices"), "devices cannot be used with device"))
+	}
+	if len(spec.Devices) == 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("devices"), spec.Devices, "at least two devices are required for a RAID0 array"))
+	}
+	if spec.Encryption != nil {
+		allErrs = append(allErrs, validateVolumeEncryptionSpec(path.Child("encryption"), spec.Encryption)...)
+	}
 	if spec.Filesystem == "" {
 		allErrs = append(allErrs, field.Required(path.Child("filesystem"), "filesystem type required"))
 	}
@@ -229,6 +243,34 @@ func validateVolumeMountSpec(path *field.Path, spec kops.VolumeMountSpec) field.
 	return allErrs
 }
 
+func validateVolumeEncryptionSpec(path *field.Path, spec *kops.VolumeEncryptionSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	keySource := string(spec.KeySource)
+	allErrs = append(allErrs, IsValidValue(path.Child("keySource"), &keySource, kops.SupportedVolumeEncryptionKeySources)...)
+
+	if spec.KeySource == kops.VolumeEncryptionKeySourceSecret {
+		if spec.SecretName == "" {
+			allErrs = append(allErrs, field.Required(path.Child("secretName"), "secretName is required for the Secret key source"))
+		} else {
+			for _, msg := range utilvalidation.IsDNS1123Label(spec.SecretName) {
+				allErrs = append(allErrs, field.Invalid(path.Child("secretName"), spec.SecretName, msg))
+			}
+			if reservedVolumeEncryptionSecretNames.Has(spec.SecretName) {
+				allErrs = append(allErrs, field.Invalid(path.Child("secretName"), spec.SecretName, "secret is used by kOps for another purpose"))
+			}
+		}
+	} else if spec.SecretName != "" {
+		allErrs = append(allErrs, field.Forbidden(path.Child("secretName"), "secretName can only be used with the Secret key source"))
+	}
+
+	return allErrs
+}
+
+// reservedVolumeEncryptionSecretNames are the secrets that kOps uses for other purposes,
+// which must not be handed to nodes as volume encryption keys.
+var reservedVolumeEncryptionSecretNames = sets.New("admin", "kube", "kube-proxy", "kubelet", "dockerconfig", "encryptionconfig")
+
 // CrossValidateInstanceGroup performs validation of the instance group, including that it is consistent with the Cluster
 // It calls ValidateInstanceGroup, so all that validation is included.
 func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cloud fi.Cloud, strict bool) field.ErrorList {
@@ -247,6 +289,10 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 		}
 	}
 
+	if g.IsBottlerocket() {
+		allErrs = append(allErrs, validateBottlerocketInstanceGroup(g, cluster)...)
+	}
+
 	// Check that instance groups are defined in subnets that are defined in the cluster
 	{
 		clusterSubnets := make(map[string]*kops.ClusterSubnetSpec)
@@ -292,7 +338,103 @@ func CrossValidateInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster, cl
 	}
 
 	if g.Spec.Containerd != nil {
-		allErrs = append(allErrs, validateContainerdConfig(&cluster.Spec, g.Spec.Containerd, field.NewPath("spec", "containerd"), false)...)
+		if cluster.Spec.UsesCrio() {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "containerd"), "containerd can not be configured when the cluster's containerRuntime is crio"))
+		} else {
+			allErrs = append(allErrs, validateContainerdConfig(&cluster.Spec, g.Spec.Containerd, field.NewPath("spec", "containerd"), false)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateBottlerocketInstanceGroup checks that the instance group only uses features that nodeup supports on Bottlerocket,
+// where the kubelet and containerd are part of the image and the root filesystem is read-only
+func validateBottlerocketInstanceGroup(g *kops.InstanceGroup, cluster *kops.Cluster) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec")
+
+	if cluster.Spec.GetCloudProvider() != kops.CloudProviderAWS {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("imageFamily"), "Bottlerocket is only supported on AWS"))
+	}
+	if g.Spec.Role != kops.InstanceGroupRoleNode {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("role"), "Bottlerocket is only supported for instance groups with role Node"))
+	}
+	if !cluster.PublishesDNSRecords() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("imageFamily"), "Bottlerocket requires the cluster to publish DNS records"))
+	}
+	if cluster.Spec.UsesCrio() {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("imageFamily"), "Bottlerocket only supports the containerd container runtime"))
+	}
+	if g.Spec.Containerd != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("containerd"), "containerd is configured by Bottlerocket"))
+	}
+	if len(g.Spec.AdditionalUserData) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("additionalUserData"), "Bottlerocket does not run user-data scripts"))
+	}
+	if len(g.Spec.Hooks) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("hooks"), "hooks are not supported on Bottlerocket"))
+	}
+	if len(g.Spec.FileAssets) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("fileAssets"), "fileAssets are not supported on Bottlerocket"))
+	}
+	if len(g.Spec.VolumeMounts) > 0 {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("volumeMounts"), "volumeMounts are not supported on Bottlerocket"))
+	}
+	allErrs = append(allErrs, validateBottlerocketKubelet(g.Spec.Kubelet, fldPath.Child("kubelet"))...)
+	allErrs = append(allErrs, validateBottlerocketKubelet(cluster.Spec.Kubelet, field.NewPath("cluster", "spec", "kubelet"))...)
+	if cluster.Spec.CloudProvider.AWS != nil {
+		warmPool := cluster.Spec.CloudProvider.AWS.WarmPool.ResolveDefaults(g)
+		if warmPool.MaxSize == nil || *warmPool.MaxSize != 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("warmPool"), "warm pools are not supported on Bottlerocket"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateBottlerocketKubelet rejects the kubelet options that Bottlerocket has no settings for.
+// Options that kOps sets by default are ignored on Bottlerocket instead, so they are not checked here.
+func validateBottlerocketKubelet(kubelet *kops.KubeletConfigSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if kubelet == nil {
+		return allErrs
+	}
+
+	unsupported := []struct {
+		name string
+		set  bool
+	}{
+		{"anonymousAuth", fi.ValueOf(kubelet.AnonymousAuth)},
+		{"tlsCipherSuites", len(kubelet.TLSCipherSuites) != 0},
+		{"tlsMinVersion", kubelet.TLSMinVersion != ""},
+		{"kubeletCgroups", kubelet.KubeletCgroups != ""},
+		{"runtimeCgroups", kubelet.RuntimeCgroups != ""},
+		{"systemCgroups", kubelet.SystemCgroups != ""},
+		{"readOnlyPort", fi.ValueOf(kubelet.ReadOnlyPort) != 0},
+		{"resolvConf", kubelet.ResolverConfig != nil},
+		{"serializeImagePulls", kubelet.SerializeImagePulls != nil},
+		{"imageMinimumGCAge", kubelet.ImageMinimumGCAge != nil},
+		{"imageMaximumGCAge", kubelet.ImageMaximumGCAge != nil},
+		{"evictionPressureTransitionPeriod", kubelet.EvictionPressureTransitionPeriod != nil},
+		{"evictionMinimumReclaim", kubelet.EvictionMinimumReclaim != ""},
+		{"volumePluginDirectory", kubelet.VolumePluginDirectory != ""},
+		{"kubeReservedCgroup", kubelet.KubeReservedCgroup != ""},
+		{"systemReservedCgroup", kubelet.SystemReservedCgroup != ""},
+		{"enforceNodeAllocatable", kubelet.EnforceNodeAllocatable != ""},
+		{"runtimeRequestTimeout", kubelet.RuntimeRequestTimeout != nil},
+		{"volumeStatsAggPeriod", kubelet.VolumeStatsAggPeriod != nil},
+		{"failSwapOn", kubelet.FailSwapOn != nil},
+		{"streamingConnectionIdleTimeout", kubelet.StreamingConnectionIdleTimeout != nil},
+		{"rootDir", kubelet.RootDir != ""},
+		{"cpuCFSQuotaPeriod", kubelet.CPUCFSQuotaPeriod != nil},
+		{"housekeepingInterval", kubelet.HousekeepingInterval != nil},
+		{"memorySwapBehavior", kubelet.MemorySwapBehavior != ""},
+	}
+	for _, option := range unsupported {
+		if option.set {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(option.name), "not supported on Bottlerocket"))
+		}
 	}
 
 	return allErrs
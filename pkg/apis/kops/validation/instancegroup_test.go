@@ -18,6 +18,7 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"k8s.io/kops/pkg/nodeidentity/aws"
 
@@ -229,6 +230,97 @@ func TestValidBootDevice(t *testing.T) {
 	}
 }
 
+func TestValidVolumeMounts(t *testing.T) {
+	grid := []struct {
+		name        string
+		volumeMount kops.VolumeMountSpec
+		expected    []string
+	}{
+		{
+			name: "device",
+			volumeMount: kops.VolumeMountSpec{
+				Device: "/dev/nvme1n1",
+			},
+		},
+		{
+			name: "raid0",
+			volumeMount: kops.VolumeMountSpec{
+				Devices: []string{"/dev/nvme1n1", "/dev/nvme2n1"},
+			},
+		},
+		{
+			name: "raid0 of one device",
+			volumeMount: kops.VolumeMountSpec{
+				Devices: []string{"/dev/nvme1n1"},
+			},
+			expected: []string{"Invalid value::spec.volumeMounts[0].devices"},
+		},
+		{
+			name: "device and devices",
+			volumeMount: kops.VolumeMountSpec{
+				Device:  "/dev/nvme1n1",
+				Devices: []string{"/dev/nvme2n1", "/dev/nvme3n1"},
+			},
+			expected: []string{"Forbidden::spec.volumeMounts[0].devices"},
+		},
+		{
+			name: "secret key",
+			volumeMount: kops.VolumeMountSpec{
+				Device:     "/dev/nvme1n1",
+				Encryption: &kops.VolumeEncryptionSpec{KeySource: kops.VolumeEncryptionKeySourceSecret, SecretName: "scratch-key"},
+			},
+		},
+		{
+			name: "secret key without a name",
+			volumeMount: kops.VolumeMountSpec{
+				Device:     "/dev/nvme1n1",
+				Encryption: &kops.VolumeEncryptionSpec{KeySource: kops.VolumeEncryptionKeySourceSecret},
+			},
+			expected: []string{"Required value::spec.volumeMounts[0].encryption.secretName"},
+		},
+		{
+			name: "reserved secret",
+			volumeMount: kops.VolumeMountSpec{
+				Device:     "/dev/nvme1n1",
+				Encryption: &kops.VolumeEncryptionSpec{KeySource: kops.VolumeEncryptionKeySourceSecret, SecretName: "admin"},
+			},
+			expected: []string{"Invalid value::spec.volumeMounts[0].encryption.secretName"},
+		},
+		{
+			name: "ephemeral key with a secret name",
+			volumeMount: kops.VolumeMountSpec{
+				Device:     "/dev/nvme1n1",
+				Encryption: &kops.VolumeEncryptionSpec{KeySource: kops.VolumeEncryptionKeySourceEphemeral, SecretName: "scratch-key"},
+			},
+			expected: []string{"Forbidden::spec.volumeMounts[0].encryption.secretName"},
+		},
+		{
+			name: "tpm key",
+			volumeMount: kops.VolumeMountSpec{
+				Device:     "/dev/nvme1n1",
+				Encryption: &kops.VolumeEncryptionSpec{KeySource: kops.VolumeEncryptionKeySourceTPM},
+			},
+		},
+		{
+			name: "unknown key source",
+			volumeMount: kops.VolumeMountSpec{
+				Device:     "/dev/nvme1n1",
+				Encryption: &kops.VolumeEncryptionSpec{KeySource: "KMS"},
+			},
+			expected: []string{"Unsupported value::spec.volumeMounts[0].encryption.keySource"},
+		},
+	}
+
+	for _, g := range grid {
+		ig := createMinimalInstanceGroup()
+		g.volumeMount.Filesystem = kops.Ext4Filesystem
+		g.volumeMount.Path = "/mnt/scratch"
+		ig.Spec.VolumeMounts = []kops.VolumeMountSpec{g.volumeMount}
+		errs := ValidateInstanceGroup(ig, nil, true)
+		testErrors(t, g.name, errs, g.expected)
+	}
+}
+
 func TestValidNodeLabels(t *testing.T) {
 	grid := []struct {
 		label    string
@@ -488,6 +580,23 @@ func TestValidInstanceGroup(t *testing.T) {
 			},
 			Description: "Invalid control-plane instance group sizes",
 		},
+		{
+			IG: &kops.InstanceGroup{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "eu-central-1a",
+				},
+				Spec: kops.InstanceGroupSpec{
+					Role:        kops.InstanceGroupRoleNode,
+					Subnets:     []string{"eu-central-1a"},
+					MaxSize:     fi.PtrTo(int32(1)),
+					MinSize:     fi.PtrTo(int32(1)),
+					Image:       "my-image",
+					ImageFamily: "Flatcar",
+				},
+			},
+			ExpectedErrors: []string{"Unsupported value::spec.imageFamily"},
+			Description:    "Invalid image family",
+		},
 	}
 	for _, g := range grid {
 		errList := ValidateInstanceGroup(g.IG, nil, true)
@@ -510,3 +619,112 @@ func createMinimalInstanceGroup() *kops.InstanceGroup {
 	}
 	return ig
 }
+
+func TestValidateBottlerocketInstanceGroup(t *testing.T) {
+	grid := []struct {
+		Cluster        kops.Cluster
+		IG             kops.InstanceGroupSpec
+		ExpectedErrors []string
+	}{
+		{
+			Cluster: kops.Cluster{
+				ObjectMeta: v1.ObjectMeta{Name: "example.com"},
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+				},
+			},
+			IG: kops.InstanceGroupSpec{
+				Role:        kops.InstanceGroupRoleNode,
+				Image:       "ssm:/aws/service/bottlerocket/aws-k8s-1.30/x86_64/latest/image_id",
+				ImageFamily: kops.ImageFamilyBottlerocket,
+				Kubelet: &kops.KubeletConfigSpec{
+					AnonymousAuth: fi.PtrTo(false),
+					KubeReserved:  map[string]string{"cpu": "100m"},
+					EvictionHard:  fi.PtrTo("memory.available<100Mi"),
+				},
+			},
+		},
+		{
+			Cluster: kops.Cluster{
+				ObjectMeta: v1.ObjectMeta{Name: "example.k8s.local"},
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{GCE: &kops.GCESpec{}},
+				},
+			},
+			IG: kops.InstanceGroupSpec{
+				Role:        kops.InstanceGroupRoleControlPlane,
+				Image:       "bottlerocket-aws-k8s-1.30-x86_64-v1.20.3-5d9ac849",
+				ImageFamily: kops.ImageFamilyBottlerocket,
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.imageFamily",
+				"Forbidden::spec.role",
+			},
+		},
+		{
+			Cluster: kops.Cluster{
+				ObjectMeta: v1.ObjectMeta{Name: "example.com"},
+				Spec: kops.ClusterSpec{
+					CloudProvider:    kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+					ContainerRuntime: "crio",
+				},
+			},
+			IG: kops.InstanceGroupSpec{
+				Role:               kops.InstanceGroupRoleNode,
+				Image:              "amazon/bottlerocket-aws-k8s-1.30-x86_64-v1.20.3-5d9ac849",
+				ImageFamily:        kops.ImageFamilyBottlerocket,
+				Containerd:         &kops.ContainerdConfig{},
+				AdditionalUserData: []kops.UserData{{Name: "script", Type: "text/x-shellscript", Content: "echo"}},
+				Hooks:              []kops.HookSpec{{Name: "hook"}},
+				FileAssets:         []kops.FileAssetSpec{{Name: "asset"}},
+				VolumeMounts:       []kops.VolumeMountSpec{{Path: "/data"}},
+				WarmPool:           &kops.WarmPoolSpec{MaxSize: fi.PtrTo(int64(2))},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.imageFamily",
+				"Forbidden::spec.containerd",
+				"Forbidden::spec.additionalUserData",
+				"Forbidden::spec.hooks",
+				"Forbidden::spec.fileAssets",
+				"Forbidden::spec.volumeMounts",
+				"Forbidden::spec.warmPool",
+			},
+		},
+		{
+			Cluster: kops.Cluster{
+				ObjectMeta: v1.ObjectMeta{Name: "example.com"},
+				Spec: kops.ClusterSpec{
+					CloudProvider: kops.CloudProviderSpec{AWS: &kops.AWSSpec{}},
+					Kubelet: &kops.KubeletConfigSpec{
+						RootDir: "/data/kubelet",
+					},
+				},
+			},
+			IG: kops.InstanceGroupSpec{
+				Role:        kops.InstanceGroupRoleNode,
+				Image:       "amazon/bottlerocket-aws-k8s-1.30-x86_64-v1.20.3-5d9ac849",
+				ImageFamily: kops.ImageFamilyBottlerocket,
+				Kubelet: &kops.KubeletConfigSpec{
+					AnonymousAuth:          fi.PtrTo(true),
+					EnforceNodeAllocatable: "pods",
+					HousekeepingInterval:   &v1.Duration{Duration: 30 * time.Second},
+				},
+			},
+			ExpectedErrors: []string{
+				"Forbidden::spec.kubelet.anonymousAuth",
+				"Forbidden::spec.kubelet.enforceNodeAllocatable",
+				"Forbidden::spec.kubelet.housekeepingInterval",
+				"Forbidden::cluster.spec.kubelet.rootDir",
+			},
+		},
+	}
+
+	for _, g := range grid {
+		ig := &kops.InstanceGroup{
+			ObjectMeta: v1.ObjectMeta{Name: "nodes"},
+			Spec:       g.IG,
+		}
+		errs := validateBottlerocketInstanceGroup(ig, &g.Cluster)
+		testErrors(t, g.IG, errs, g.ExpectedErrors)
+	}
+}
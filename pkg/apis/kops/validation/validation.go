@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/blang/semver/v4"
@@ -38,10 +39,12 @@ import (
 	"k8s.io/kops/pkg/util/subnet"
 
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets/cosign"
 	"k8s.io/kops/pkg/model/components"
 	"k8s.io/kops/pkg/model/iam"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/cron"
 )
 
 func newValidateCluster(cluster *kops.Cluster, strict bool) field.ErrorList {
@@ -115,6 +118,10 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 	// UpdatePolicy
 	allErrs = append(allErrs, IsValidValue(fieldPath.Child("updatePolicy"), spec.UpdatePolicy, []string{kops.UpdatePolicyAutomatic, kops.UpdatePolicyExternal})...)
 
+	if spec.ConfigStore.Encryption != nil {
+		allErrs = append(allErrs, validateConfigStoreEncryption(spec, spec.ConfigStore.Encryption, fieldPath.Child("configStore", "encryption"))...)
+	}
+
 	// Hooks
 	for i := range spec.Hooks {
 		allErrs = append(allErrs, validateHookSpec(&spec.Hooks[i], fieldPath.Child("hooks").Index(i))...)
@@ -200,7 +207,13 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateContainerRuntime(c, spec.ContainerRuntime, fieldPath.Child("containerRuntime"))...)
 	}
 
-	if spec.Containerd != nil {
+	if spec.UsesCrio() {
+		allErrs = append(allErrs, validateCrioRuntime(spec, fieldPath)...)
+	} else if spec.Crio != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("crio"), "crio can only be set when containerRuntime is crio"))
+	}
+
+	if spec.Containerd != nil && !spec.UsesCrio() {
 		allErrs = append(allErrs, validateContainerdConfig(spec, spec.Containerd, fieldPath.Child("containerd"), true)...)
 	}
 
@@ -212,6 +225,9 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		if spec.Assets.ContainerProxy != nil && spec.Assets.ContainerRegistry != nil {
 			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("assets", "containerProxy"), "containerProxy cannot be used in conjunction with containerRegistry"))
 		}
+		if spec.Assets.Verification != nil {
+			allErrs = append(allErrs, validateAssetVerification(spec.Assets.Verification, fieldPath.Child("assets", "verification"))...)
+		}
 	}
 
 	for i, sysctlParameter := range spec.SysctlParameters {
@@ -224,6 +240,10 @@ func validateClusterSpec(spec *kops.ClusterSpec, c *kops.Cluster, fieldPath *fie
 		allErrs = append(allErrs, validateRollingUpdate(spec.RollingUpdate, fieldPath.Child("rollingUpdate"), false)...)
 	}
 
+	if spec.Validation != nil {
+		allErrs = append(allErrs, validateClusterValidation(spec.Validation, fieldPath.Child("validation"))...)
+	}
+
 	if spec.API.LoadBalancer != nil {
 		lbSpec := spec.API.LoadBalancer
 		lbPath := fieldPath.Child("api", "loadBalancer")
@@ -684,6 +704,22 @@ func validateFileAssetSpec(v *kops.FileAssetSpec, fieldPath *field.Path) field.E
 	return allErrs
 }
 
+func validateAssetVerification(v *kops.AssetVerificationSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(v.PublicKeys) == 0 {
+		allErrs = append(allErrs, field.Required(fieldPath.Child("publicKeys"), "publicKeys must be set to verify signatures"))
+	}
+
+	for i, publicKey := range v.PublicKeys {
+		if _, err := cosign.ParsePublicKey(publicKey); err != nil {
+			allErrs = append(allErrs, field.Invalid(fieldPath.Child("publicKeys").Index(i), publicKey, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
 func validateHookSpec(v *kops.HookSpec, fieldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -1691,7 +1727,7 @@ func validateCalicoEncapsulationMode(mode string, fldPath *field.Path) field.Err
 }
 
 func validateContainerRuntime(c *kops.Cluster, runtime string, fldPath *field.Path) field.ErrorList {
-	valid := []string{"containerd", "docker"}
+	valid := []string{kops.ContainerRuntimeContainerd, kops.ContainerRuntimeCrio, "docker"}
 
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, IsValidValue(fldPath, &runtime, valid)...)
@@ -1703,6 +1739,87 @@ func validateContainerRuntime(c *kops.Cluster, runtime string, fldPath *field.Pa
 	return allErrs
 }
 
+// validateCrioRuntime checks that the cluster only uses features that work with CRI-O
+func validateCrioRuntime(spec *kops.ClusterSpec, fieldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.Containerd != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("containerd"), "containerd can not be configured when containerRuntime is crio"))
+	}
+
+	if spec.Networking.Kubenet != nil {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("networking", "kubenet"), "kubenet is not supported with CRI-O"))
+	}
+
+	for i, hook := range spec.Hooks {
+		if hook.ExecContainer != nil {
+			allErrs = append(allErrs, field.Forbidden(fieldPath.Child("hooks").Index(i).Child("execContainer"), "execContainer hooks are not supported with CRI-O"))
+		}
+	}
+
+	// The control plane images of a Kubernetes build are distributed as tarballs, which CRI-O cannot load
+	if components.IsBaseURL(spec.KubernetesVersion) {
+		allErrs = append(allErrs, field.Forbidden(fieldPath.Child("kubernetesVersion"), "a base URL can not be used as the kubernetesVersion with CRI-O, as CRI-O cannot load the images of the build"))
+	}
+
+	if spec.Crio != nil {
+		allErrs = append(allErrs, validateCrioConfig(spec.Crio, fieldPath.Child("crio"))...)
+	}
+
+	return allErrs
+}
+
+func validateCrioConfig(config *kops.CrioConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if config.Version != nil {
+		sv, err := semver.ParseTolerant(*config.Version)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), config.Version,
+				fmt.Sprintf("unable to parse version string: %s", err.Error())))
+		} else if sv.LT(semver.MustParse("1.28.0")) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("version"), config.Version,
+				"unsupported legacy version"))
+		}
+	}
+
+	if config.LogLevel != nil {
+		allErrs = append(allErrs, IsValidValue(fldPath.Child("logLevel"), config.LogLevel, []string{"fatal", "panic", "error", "warn", "info", "debug", "trace"})...)
+	}
+
+	if config.Packages != nil {
+		allErrs = append(allErrs, validatePackagesConfig(config.Packages, fldPath)...)
+	}
+
+	for registry := range config.RegistryMirrors {
+		if registry == "*" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("registryMirrors").Key(registry), registry, "CRI-O does not support mirrors for all registries"))
+		}
+	}
+
+	for name, runtime := range config.Runtimes {
+		fldRuntime := fldPath.Child("runtimes").Key(name)
+		for _, msg := range utilvalidation.IsDNS1123Label(name) {
+			allErrs = append(allErrs, field.Invalid(fldRuntime, name, msg))
+		}
+		if runtime.RuntimeType != "" {
+			allErrs = append(allErrs, IsValidValue(fldRuntime.Child("runtimeType"), &runtime.RuntimeType, []string{"oci", "vm"})...)
+		}
+		if runtime.RuntimePath == "" && name != "runc" && name != "crun" {
+			allErrs = append(allErrs, field.Required(fldRuntime.Child("runtimePath"), "runtimePath is required for runtimes not included with CRI-O"))
+		}
+	}
+
+	if config.DefaultRuntime != nil {
+		defaultRuntime := fi.ValueOf(config.DefaultRuntime)
+		if _, found := config.Runtimes[defaultRuntime]; !found && defaultRuntime != "runc" && defaultRuntime != "crun" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("defaultRuntime"), defaultRuntime, "defaultRuntime must be runc, crun or one of the configured runtimes"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateContainerdConfig(spec *kops.ClusterSpec, config *kops.ContainerdConfig, fldPath *field.Path, inClusterConfig bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -1723,45 +1840,7 @@ func validateContainerdConfig(spec *kops.ClusterSpec, config *kops.ContainerdCon
 	}
 
 	if config.Packages != nil {
-		if config.Packages.UrlAmd64 != nil && config.Packages.HashAmd64 != nil {
-			u := fi.ValueOf(config.Packages.UrlAmd64)
-			_, err := url.Parse(u)
-			if err != nil {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrl"), config.Packages.UrlAmd64,
-					fmt.Sprintf("cannot parse package URL: %v", err)))
-			}
-			h := fi.ValueOf(config.Packages.HashAmd64)
-			if len(h) > 64 {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHash"), config.Packages.HashAmd64,
-					"Package hash must be 64 characters long"))
-			}
-		} else if config.Packages.UrlAmd64 != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrl"), config.Packages.HashAmd64,
-				"Package hash must also be set"))
-		} else if config.Packages.HashAmd64 != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHash"), config.Packages.HashAmd64,
-				"Package URL must also be set"))
-		}
-
-		if config.Packages.UrlArm64 != nil && config.Packages.HashArm64 != nil {
-			u := fi.ValueOf(config.Packages.UrlArm64)
-			_, err := url.Parse(u)
-			if err != nil {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrlArm64"), config.Packages.UrlArm64,
-					fmt.Sprintf("cannot parse package URL: %v", err)))
-			}
-			h := fi.ValueOf(config.Packages.HashArm64)
-			if len(h) > 64 {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHashArm64"), config.Packages.HashArm64,
-					"Package hash must be 64 characters long"))
-			}
-		} else if config.Packages.UrlArm64 != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrlArm64"), config.Packages.HashArm64,
-				"Package hash must also be set"))
-		} else if config.Packages.HashArm64 != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHashArm64"), config.Packages.HashArm64,
-				"Package URL must also be set"))
-		}
+		allErrs = append(allErrs, validatePackagesConfig(config.Packages, fldPath)...)
 	}
 
 	if config.NvidiaGPU != nil {
@@ -1771,6 +1850,53 @@ func validateContainerdConfig(spec *kops.ClusterSpec, config *kops.ContainerdCon
 	return allErrs
 }
 
+// validatePackagesConfig checks the URL and hash overrides of a container runtime package
+func validatePackagesConfig(packages *kops.PackagesConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if packages.UrlAmd64 != nil && packages.HashAmd64 != nil {
+		u := fi.ValueOf(packages.UrlAmd64)
+		_, err := url.Parse(u)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrl"), packages.UrlAmd64,
+				fmt.Sprintf("cannot parse package URL: %v", err)))
+		}
+		h := fi.ValueOf(packages.HashAmd64)
+		if len(h) > 64 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHash"), packages.HashAmd64,
+				"Package hash must be 64 characters long"))
+		}
+	} else if packages.UrlAmd64 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrl"), packages.HashAmd64,
+			"Package hash must also be set"))
+	} else if packages.HashAmd64 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHash"), packages.HashAmd64,
+			"Package URL must also be set"))
+	}
+
+	if packages.UrlArm64 != nil && packages.HashArm64 != nil {
+		u := fi.ValueOf(packages.UrlArm64)
+		_, err := url.Parse(u)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrlArm64"), packages.UrlArm64,
+				fmt.Sprintf("cannot parse package URL: %v", err)))
+		}
+		h := fi.ValueOf(packages.HashArm64)
+		if len(h) > 64 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHashArm64"), packages.HashArm64,
+				"Package hash must be 64 characters long"))
+		}
+	} else if packages.UrlArm64 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("packageUrlArm64"), packages.HashArm64,
+			"Package hash must also be set"))
+	} else if packages.HashArm64 != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("packageHashArm64"), packages.HashArm64,
+			"Package URL must also be set"))
+	}
+
+	return allErrs
+}
+
 func validateNriConfig(containerd *kops.ContainerdConfig, fldPath *field.Path) (allErrs field.ErrorList) {
 	if containerd.NRI.Enabled == nil || !fi.ValueOf(containerd.NRI.Enabled) {
 		return allErrs
@@ -1833,6 +1959,131 @@ func validateRollingUpdate(rollingUpdate *kops.RollingUpdate, fldpath *field.Pat
 			allErrs = append(allErrs, field.Forbidden(fldpath.Child("maxSurge"), "Cannot be zero if maxUnavailable is zero"))
 		}
 	}
+	if rollingUpdate.MaintenanceWindow != nil {
+		allErrs = append(allErrs, validateMaintenanceWindow(rollingUpdate.MaintenanceWindow, fldpath.Child("maintenanceWindow"))...)
+	}
+	if rollingUpdate.Canary != nil {
+		allErrs = append(allErrs, validateCanary(rollingUpdate.Canary, fldpath.Child("canary"))...)
+	}
+	return allErrs
+}
+
+func validateCanary(spec *kops.CanarySpec, fldpath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.Count != nil {
+		count, err := intstr.GetScaledValueFromIntOrPercent(spec.Count, 1000, true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("count"), spec.Count, fmt.Sprintf("Unable to parse: %v", err)))
+		} else if count <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("count"), spec.Count, "Must be positive"))
+		}
+	}
+	if spec.SoakDuration != nil && spec.SoakDuration.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldpath.Child("soakDuration"), spec.SoakDuration.Duration.String(), "Cannot be negative"))
+	}
+	if spec.FailureThreshold != nil && *spec.FailureThreshold < 1 {
+		allErrs = append(allErrs, field.Invalid(fldpath.Child("failureThreshold"), *spec.FailureThreshold, "Must be at least 1"))
+	}
+	names := sets.NewString()
+	for i, check := range spec.HealthChecks {
+		checkPath := fldpath.Child("healthChecks").Index(i)
+		if check.Name == "" {
+			allErrs = append(allErrs, field.Required(checkPath.Child("name"), ""))
+		} else if names.Has(check.Name) {
+			allErrs = append(allErrs, field.Duplicate(checkPath.Child("name"), check.Name))
+		} else {
+			names.Insert(check.Name)
+		}
+
+		if (check.Pods == nil) == (check.HTTP == nil) {
+			allErrs = append(allErrs, field.Invalid(checkPath, check.Name, "Exactly one of pods or http must be specified"))
+		}
+		if check.Pods != nil {
+			if check.Pods.Namespace == "" {
+				allErrs = append(allErrs, field.Required(checkPath.Child("pods", "namespace"), ""))
+			}
+			if len(check.Pods.Selector) == 0 {
+				allErrs = append(allErrs, field.Required(checkPath.Child("pods", "selector"), ""))
+			}
+		}
+		if check.HTTP != nil {
+			if u, err := url.Parse(check.HTTP.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(checkPath.Child("http", "url"), check.HTTP.URL, "Must be an http or https URL"))
+			}
+		}
+	}
+	return allErrs
+}
+
+func validateMaintenanceWindow(spec *kops.MaintenanceWindowSpec, fldpath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.TimeZone != "" {
+		if _, err := time.LoadLocation(spec.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("timeZone"), spec.TimeZone, fmt.Sprintf("Unknown time zone: %v", err)))
+		}
+	}
+	if len(spec.Windows) == 0 {
+		allErrs = append(allErrs, field.Required(fldpath.Child("windows"), "At least one window must be specified"))
+	}
+	for i, window := range spec.Windows {
+		windowPath := fldpath.Child("windows").Index(i)
+		if window.Schedule == "" {
+			allErrs = append(allErrs, field.Required(windowPath.Child("schedule"), ""))
+		} else if schedule, err := cron.Parse(window.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("schedule"), window.Schedule, err.Error()))
+		} else if schedule.Next(time.Now()).IsZero() {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("schedule"), window.Schedule, "Schedule never matches"))
+		}
+		if window.Duration.Duration < time.Minute {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("duration"), window.Duration.Duration.String(), "Must be at least one minute"))
+		}
+	}
+	return allErrs
+}
+
+func validateClusterValidation(spec *kops.ClusterValidationSpec, fldpath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, workload := range spec.Workloads {
+		workloadPath := fldpath.Child("workloads").Index(i)
+		allErrs = append(allErrs, IsValidValue(workloadPath.Child("kind"), &workload.Kind, []string{"Deployment", "DaemonSet"})...)
+		if workload.Namespace == "" {
+			allErrs = append(allErrs, field.Required(workloadPath.Child("namespace"), ""))
+		}
+		if workload.Name == "" {
+			allErrs = append(allErrs, field.Required(workloadPath.Child("name"), ""))
+		}
+	}
+	for i, namespace := range spec.CrashLoopNamespaces {
+		for _, msg := range utilvalidation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(fldpath.Child("crashLoopNamespaces").Index(i), namespace, msg))
+		}
+	}
+	names := sets.NewString()
+	for i, probe := range spec.HTTPProbes {
+		probePath := fldpath.Child("httpProbes").Index(i)
+		if probe.Name == "" {
+			allErrs = append(allErrs, field.Required(probePath.Child("name"), ""))
+		} else if names.Has(probe.Name) {
+			allErrs = append(allErrs, field.Duplicate(probePath.Child("name"), probe.Name))
+		} else {
+			names.Insert(probe.Name)
+		}
+		if probe.Namespace == "" {
+			allErrs = append(allErrs, field.Required(probePath.Child("namespace"), ""))
+		}
+		if probe.Service == "" {
+			allErrs = append(allErrs, field.Required(probePath.Child("service"), ""))
+		}
+		if probe.Port == "" {
+			allErrs = append(allErrs, field.Required(probePath.Child("port"), ""))
+		}
+		if probe.Scheme != "" {
+			allErrs = append(allErrs, IsValidValue(probePath.Child("scheme"), &probe.Scheme, []string{"http", "https"})...)
+		}
+		if probe.Path != "" && !strings.HasPrefix(probe.Path, "/") {
+			allErrs = append(allErrs, field.Invalid(probePath.Child("path"), probe.Path, "Must start with /"))
+		}
+	}
 	return allErrs
 }
 
@@ -1980,3 +2231,56 @@ func validateCertManager(cluster *kops.Cluster, spec *kops.CertManagerConfig, fl
 	}
 	return allErrs
 }
+
+var gcpKMSKeyNameRegex = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`)
+
+func validateConfigStoreEncryption(clusterSpec *kops.ClusterSpec, spec *kops.ConfigStoreEncryptionSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	providers := 0
+	if spec.Age != nil {
+		providers++
+		if !strings.HasPrefix(spec.Age.Recipient, "age1") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("age", "recipient"), spec.Age.Recipient, "must be an age X25519 recipient, starting with age1"))
+		}
+		if spec.Age.IdentityFile == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("age", "identityFile"), ""))
+		} else if !filepath.IsAbs(spec.Age.IdentityFile) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("age", "identityFile"), spec.Age.IdentityFile, "must be an absolute path"))
+		}
+	}
+	if spec.AWSKMS != nil {
+		providers++
+		parsed, err := arn.Parse(spec.AWSKMS.KeyARN)
+		if err != nil || parsed.Service != "kms" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("awsKMS", "keyARN"), spec.AWSKMS.KeyARN, "must be the ARN of a KMS key or alias"))
+		}
+	}
+	if spec.GCPKMS != nil {
+		providers++
+		if !gcpKMSKeyNameRegex.MatchString(spec.GCPKMS.KeyName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("gcpKMS", "keyName"), spec.GCPKMS.KeyName, "must be in the form projects/*/locations/*/keyRings/*/cryptoKeys/*"))
+		}
+	}
+	if spec.AzureKeyVault != nil {
+		providers++
+		if u, err := url.Parse(spec.AzureKeyVault.VaultURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("azureKeyVault", "vaultURL"), spec.AzureKeyVault.VaultURL, "must be an https URL"))
+		}
+		if spec.AzureKeyVault.KeyName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("azureKeyVault", "keyName"), ""))
+		}
+		if spec.AzureKeyVault.VaultID == "" {
+			if clusterSpec.GetCloudProvider() == kops.CloudProviderAzure {
+				allErrs = append(allErrs, field.Required(fldPath.Child("azureKeyVault", "vaultID"), "the control plane is granted access to the vault by its resource ID"))
+			}
+		} else if !strings.Contains(strings.ToLower(spec.AzureKeyVault.VaultID), "/providers/microsoft.keyvault/vaults/") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("azureKeyVault", "vaultID"), spec.AzureKeyVault.VaultID, "must be the resource ID of a key vault"))
+		}
+	}
+
+	if providers == 0 {
+		allErrs = append(allErrs, field.Required(fldPath, "a key provider must be specified"))
+	} else if providers > 1 {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "only one key provider permitted"))
+	}
+	return allErrs
+}
@@ -1311,6 +1311,90 @@ func Test_Validate_RollingUpdate(t *testing.T) {
 			},
 			ExpectedErrors: []string{"Forbidden::testField.maxSurge"},
 		},
+		{
+			Input: kops.RollingUpdate{
+				MaintenanceWindow: &kops.MaintenanceWindowSpec{
+					TimeZone: "UTC",
+					Windows: []kops.MaintenanceWindow{
+						{Schedule: "0 22 * * mon-fri", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+					},
+				},
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				MaintenanceWindow: &kops.MaintenanceWindowSpec{},
+			},
+			ExpectedErrors: []string{"Required value::testField.maintenanceWindow.windows"},
+		},
+		{
+			Input: kops.RollingUpdate{
+				MaintenanceWindow: &kops.MaintenanceWindowSpec{
+					TimeZone: "Nowhere/Nothing",
+					Windows: []kops.MaintenanceWindow{
+						{Schedule: "0 22 * *", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+						{Schedule: "0 0 31 2 *", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+						{Schedule: "0 22 * * *"},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::testField.maintenanceWindow.timeZone",
+				"Invalid value::testField.maintenanceWindow.windows[0].schedule",
+				"Invalid value::testField.maintenanceWindow.windows[1].schedule",
+				"Invalid value::testField.maintenanceWindow.windows[2].duration",
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Canary: &kops.CanarySpec{
+					Count:        intStr(intstr.FromString("10%")),
+					SoakDuration: &metav1.Duration{Duration: 10 * time.Minute},
+					HealthChecks: []kops.CanaryHealthCheck{
+						{
+							Name: "ingress",
+							Pods: &kops.CanaryPodsHealthCheck{
+								Namespace: "ingress-nginx",
+								Selector:  map[string]string{"app": "ingress-nginx"},
+							},
+						},
+						{
+							Name: "app",
+							HTTP: &kops.CanaryHTTPHealthCheck{URL: "https://example.com/healthz"},
+						},
+					},
+				},
+			},
+		},
+		{
+			Input: kops.RollingUpdate{
+				Canary: &kops.CanarySpec{
+					Count:        intStr(intstr.FromInt(0)),
+					SoakDuration: &metav1.Duration{Duration: -time.Minute},
+					HealthChecks: []kops.CanaryHealthCheck{
+						{},
+						{
+							Name: "pods",
+							Pods: &kops.CanaryPodsHealthCheck{},
+						},
+						{
+							Name: "pods",
+							HTTP: &kops.CanaryHTTPHealthCheck{URL: "ftp://example.com"},
+						},
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::testField.canary.count",
+				"Invalid value::testField.canary.soakDuration",
+				"Required value::testField.canary.healthChecks[0].name",
+				"Invalid value::testField.canary.healthChecks[0]",
+				"Required value::testField.canary.healthChecks[1].pods.namespace",
+				"Required value::testField.canary.healthChecks[1].pods.selector",
+				"Duplicate value::testField.canary.healthChecks[2].name",
+				"Invalid value::testField.canary.healthChecks[2].http.url",
+			},
+		},
 	}
 	for _, g := range grid {
 		errs := validateRollingUpdate(&g.Input, field.NewPath("testField"), g.OnMasterIG)
@@ -1772,3 +1856,326 @@ func Test_Validate_NriConfig(t *testing.T) {
 		testErrors(t, g.Input.Containerd, errs, g.ExpectedErrors)
 	}
 }
+
+func Test_Validate_ConfigStoreEncryption(t *testing.T) {
+	grid := []struct {
+		Input          kops.ConfigStoreEncryptionSpec
+		CloudProvider  kops.CloudProviderSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input:          kops.ConfigStoreEncryptionSpec{},
+			ExpectedErrors: []string{"Required value::configStore.encryption"},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				Age: &kops.AgeEncryptionSpec{
+					Recipient:    "age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p",
+					IdentityFile: "/etc/kops/age-identity.txt",
+				},
+			},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				Age: &kops.AgeEncryptionSpec{
+					Recipient:    "ssh-ed25519 AAAA",
+					IdentityFile: "age-identity.txt",
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::configStore.encryption.age.recipient",
+				"Invalid value::configStore.encryption.age.identityFile",
+			},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				AWSKMS: &kops.AWSKMSEncryptionSpec{KeyARN: "arn:aws:kms:us-east-1:123456789012:alias/kops"},
+			},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				AWSKMS: &kops.AWSKMSEncryptionSpec{KeyARN: "arn:aws:s3:::bucket"},
+			},
+			ExpectedErrors: []string{"Invalid value::configStore.encryption.awsKMS.keyARN"},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				GCPKMS: &kops.GCPKMSEncryptionSpec{KeyName: "projects/p/locations/global/keyRings/r/cryptoKeys/k"},
+			},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				GCPKMS: &kops.GCPKMSEncryptionSpec{KeyName: "k"},
+			},
+			ExpectedErrors: []string{"Invalid value::configStore.encryption.gcpKMS.keyName"},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				AzureKeyVault: &kops.AzureKeyVaultEncryptionSpec{VaultURL: "https://example.vault.azure.net/", KeyName: "kops"},
+			},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				AzureKeyVault: &kops.AzureKeyVaultEncryptionSpec{
+					VaultURL: "https://example.vault.azure.net/",
+					KeyName:  "kops",
+					VaultID:  "/subscriptions/s/resourceGroups/rg/providers/Microsoft.KeyVault/vaults/example",
+				},
+			},
+			CloudProvider: kops.CloudProviderSpec{Azure: &kops.AzureSpec{}},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				AzureKeyVault: &kops.AzureKeyVaultEncryptionSpec{VaultURL: "https://example.vault.azure.net/", KeyName: "kops"},
+			},
+			CloudProvider:  kops.CloudProviderSpec{Azure: &kops.AzureSpec{}},
+			ExpectedErrors: []string{"Required value::configStore.encryption.azureKeyVault.vaultID"},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				AzureKeyVault: &kops.AzureKeyVaultEncryptionSpec{VaultURL: "https://example.vault.azure.net/", KeyName: "kops", VaultID: "example"},
+			},
+			ExpectedErrors: []string{"Invalid value::configStore.encryption.azureKeyVault.vaultID"},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				AzureKeyVault: &kops.AzureKeyVaultEncryptionSpec{VaultURL: "example.vault.azure.net"},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::configStore.encryption.azureKeyVault.vaultURL",
+				"Required value::configStore.encryption.azureKeyVault.keyName",
+			},
+		},
+		{
+			Input: kops.ConfigStoreEncryptionSpec{
+				AWSKMS: &kops.AWSKMSEncryptionSpec{KeyARN: "arn:aws:kms:us-east-1:123456789012:alias/kops"},
+				GCPKMS: &kops.GCPKMSEncryptionSpec{KeyName: "projects/p/locations/global/keyRings/r/cryptoKeys/k"},
+			},
+			ExpectedErrors: []string{"Forbidden::configStore.encryption"},
+		},
+	}
+	for _, g := range grid {
+		clusterSpec := &kops.ClusterSpec{CloudProvider: g.CloudProvider}
+		errs := validateConfigStoreEncryption(clusterSpec, &g.Input, field.NewPath("configStore", "encryption"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_ClusterValidation(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterValidationSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterValidationSpec{
+				Workloads: []kops.ValidationWorkload{
+					{Kind: "Deployment", Namespace: "ingress-nginx", Name: "ingress-nginx-controller"},
+					{Kind: "DaemonSet", Namespace: "istio-system", Name: "istio-cni-node"},
+				},
+				CrashLoopNamespaces: []string{"ingress-nginx"},
+				HTTPProbes: []kops.ValidationHTTPProbe{
+					{Name: "app", Namespace: "app", Service: "app", Port: "http", Scheme: "https", Path: "/healthz"},
+				},
+			},
+		},
+		{
+			Input: kops.ClusterValidationSpec{
+				Workloads: []kops.ValidationWorkload{
+					{Kind: "StatefulSet"},
+				},
+				CrashLoopNamespaces: []string{"Not_A_Namespace"},
+				HTTPProbes: []kops.ValidationHTTPProbe{
+					{Scheme: "ftp", Path: "healthz"},
+					{Name: "app", Namespace: "app", Service: "app", Port: "80"},
+					{Name: "app", Namespace: "app", Service: "app", Port: "80"},
+				},
+			},
+			ExpectedErrors: []string{
+				"Unsupported value::spec.validation.workloads[0].kind",
+				"Required value::spec.validation.workloads[0].namespace",
+				"Required value::spec.validation.workloads[0].name",
+				"Invalid value::spec.validation.crashLoopNamespaces[0]",
+				"Required value::spec.validation.httpProbes[0].name",
+				"Required value::spec.validation.httpProbes[0].namespace",
+				"Required value::spec.validation.httpProbes[0].service",
+				"Required value::spec.validation.httpProbes[0].port",
+				"Unsupported value::spec.validation.httpProbes[0].scheme",
+				"Invalid value::spec.validation.httpProbes[0].path",
+				"Duplicate value::spec.validation.httpProbes[2].name",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateClusterValidation(&g.Input, field.NewPath("spec", "validation"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_AssetVerification(t *testing.T) {
+	publicKey := `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEv+zfp+yxl2VWTg4BFM3XYYh2RNr+
+jedQR01sj8BvHO9RNYZAT888JVu78UddAc1Ah2Fl+1Rya2Wv3H5NkZrAhg==
+-----END PUBLIC KEY-----
+`
+
+	grid := []struct {
+		Input          kops.AssetVerificationSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.AssetVerificationSpec{
+				PublicKeys: []string{publicKey},
+				Images:     true,
+			},
+		},
+		{
+			Input: kops.AssetVerificationSpec{},
+			ExpectedErrors: []string{
+				"Required value::spec.assets.verification.publicKeys",
+			},
+		},
+		{
+			Input: kops.AssetVerificationSpec{
+				PublicKeys: []string{publicKey, "not a key"},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::spec.assets.verification.publicKeys[1]",
+			},
+		},
+	}
+	for _, g := range grid {
+		errs := validateAssetVerification(&g.Input, field.NewPath("spec", "assets", "verification"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_Crio_Runtime(t *testing.T) {
+	grid := []struct {
+		Input          kops.ClusterSpec
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.ClusterSpec{
+				ContainerRuntime: "crio",
+				Crio:             &kops.CrioConfig{},
+			},
+		},
+		{
+			Input: kops.ClusterSpec{
+				ContainerRuntime: "crio",
+				Containerd:       &kops.ContainerdConfig{},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.containerd"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				ContainerRuntime: "crio",
+				Networking: kops.NetworkingSpec{
+					Kubenet: &kops.KubenetNetworkingSpec{},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.networking.kubenet"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				ContainerRuntime: "crio",
+				Hooks: []kops.HookSpec{
+					{
+						ExecContainer: &kops.ExecContainerAction{Image: "busybox"},
+					},
+				},
+			},
+			ExpectedErrors: []string{"Forbidden::spec.hooks[0].execContainer"},
+		},
+		{
+			Input: kops.ClusterSpec{
+				ContainerRuntime:  "crio",
+				KubernetesVersion: "https://storage.googleapis.com/k8s-release-dev/ci/v1.31.0-alpha.0",
+			},
+			ExpectedErrors: []string{"Forbidden::spec.kubernetesVersion"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateCrioRuntime(&g.Input, field.NewPath("spec"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
+
+func Test_Validate_Crio_Config(t *testing.T) {
+	grid := []struct {
+		Input          kops.CrioConfig
+		ExpectedErrors []string
+	}{
+		{
+			Input: kops.CrioConfig{
+				Version:        fi.PtrTo("1.30.6"),
+				DefaultRuntime: fi.PtrTo("kata"),
+				LogLevel:       fi.PtrTo("debug"),
+				RegistryMirrors: map[string][]string{
+					"docker.io": {"https://mirror.example.com"},
+				},
+				Runtimes: map[string]kops.CrioRuntimeConfig{
+					"kata": {
+						RuntimePath: "/usr/bin/containerd-shim-kata-v2",
+						RuntimeType: "vm",
+					},
+				},
+			},
+		},
+		{
+			Input: kops.CrioConfig{
+				Version: fi.PtrTo("1.27.1"),
+			},
+			ExpectedErrors: []string{"Invalid value::crio.version"},
+		},
+		{
+			Input: kops.CrioConfig{
+				LogLevel: fi.PtrTo("verbose"),
+			},
+			ExpectedErrors: []string{"Unsupported value::crio.logLevel"},
+		},
+		{
+			Input: kops.CrioConfig{
+				RegistryMirrors: map[string][]string{
+					"*": {"https://mirror.example.com"},
+				},
+			},
+			ExpectedErrors: []string{"Invalid value::crio.registryMirrors[*]"},
+		},
+		{
+			Input: kops.CrioConfig{
+				Runtimes: map[string]kops.CrioRuntimeConfig{
+					"Kata_VM": {
+						RuntimePath: "/usr/bin/containerd-shim-kata-v2",
+						RuntimeType: "wasm",
+					},
+				},
+			},
+			ExpectedErrors: []string{
+				"Invalid value::crio.runtimes[Kata_VM]",
+				"Unsupported value::crio.runtimes[Kata_VM].runtimeType",
+			},
+		},
+		{
+			Input: kops.CrioConfig{
+				Runtimes: map[string]kops.CrioRuntimeConfig{
+					"crun": {
+						AllowedAnnotations: []string{"io.kubernetes.cri-o.Devices"},
+					},
+					"youki": {},
+				},
+			},
+			ExpectedErrors: []string{"Required value::crio.runtimes[youki].runtimePath"},
+		},
+		{
+			Input: kops.CrioConfig{
+				DefaultRuntime: fi.PtrTo("kata"),
+			},
+			ExpectedErrors: []string{"Invalid value::crio.defaultRuntime"},
+		},
+	}
+	for _, g := range grid {
+		errs := validateCrioConfig(&g.Input, field.NewPath("crio"))
+		testErrors(t, g.Input, errs, g.ExpectedErrors)
+	}
+}
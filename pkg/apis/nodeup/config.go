@@ -86,10 +86,16 @@ type Config struct {
 
 	// FileAssets are a collection of file assets for this instance group.
 	FileAssets []kops.FileAssetSpec `json:",omitempty"`
+	// AssetVerification holds the public keys against which the signatures of assets and images are verified, if set.
+	AssetVerification *kops.AssetVerificationSpec `json:"assetVerification,omitempty"`
 	// Hooks are for custom actions, for example on first installation.
 	Hooks [][]kops.HookSpec
 	// ContainerdConfig holds the configuration for containerd.
 	ContainerdConfig *kops.ContainerdConfig `json:"containerdConfig,omitempty"`
+	// ContainerRuntime is the container runtime to run on the node, when it is not containerd.
+	ContainerRuntime string `json:"containerRuntime,omitempty"`
+	// CrioConfig holds the configuration for CRI-O.
+	CrioConfig *kops.CrioConfig `json:"crioConfig,omitempty"`
 
 	// APIServerConfig is additional configuration for nodes running an APIServer.
 	APIServerConfig *APIServerConfig `json:",omitempty"`
@@ -247,6 +253,15 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 		config.ContainerdConfig = buildContainerdConfig(cluster, instanceGroup)
 	}
 
+	if cluster.Spec.UsesCrio() {
+		config.ContainerRuntime = cluster.Spec.ContainerRuntime
+		config.CrioConfig = cluster.Spec.Crio
+	}
+
+	if cluster.Spec.Assets != nil {
+		config.AssetVerification = cluster.Spec.Assets.Verification
+	}
+
 	if (cluster.Spec.Containerd != nil && cluster.Spec.Containerd.NvidiaGPU != nil) || (instanceGroup.Spec.Containerd != nil && instanceGroup.Spec.Containerd.NvidiaGPU != nil) {
 		config.NvidiaGPU = buildNvidiaConfig(cluster, instanceGroup)
 	}
@@ -358,8 +373,9 @@ func NewConfig(cluster *kops.Cluster, instanceGroup *kops.InstanceGroup) (*Confi
 
 	if instanceGroup.HasAPIServer() || !model.UseKopsControllerForNodeConfig(cluster) {
 		config.ConfigStore = &kops.ConfigStoreSpec{
-			Keypairs: cluster.Spec.ConfigStore.Keypairs,
-			Secrets:  cluster.Spec.ConfigStore.Secrets,
+			Keypairs:   cluster.Spec.ConfigStore.Keypairs,
+			Secrets:    cluster.Spec.ConfigStore.Secrets,
+			Encryption: cluster.Spec.ConfigStore.Encryption,
 		}
 	}
 
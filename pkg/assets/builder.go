@@ -28,11 +28,13 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/crane"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/assets/assetdata"
+	"k8s.io/kops/pkg/assets/cosign"
 	"k8s.io/kops/pkg/featureflag"
 	"k8s.io/kops/pkg/kubemanifest"
 	"k8s.io/kops/pkg/values"
@@ -89,6 +91,8 @@ type ImageAsset struct {
 	DownloadLocation string
 	// CanonicalLocation will be the source location of the image.
 	CanonicalLocation string
+	// Signature is true if the image is the cosign signature of another image, which may not be published.
+	Signature bool
 }
 
 // FileAsset models a file's location.
@@ -99,6 +103,16 @@ type FileAsset struct {
 	CanonicalURL *url.URL
 	// SHAValue is the SHA hash of the FileAsset.
 	SHAValue *hashing.Hash
+	// Signature is the cosign signature published beside the file, if asset verification is enabled.
+	Signature *SignatureAsset
+}
+
+// SignatureAsset models the location of the signature of a FileAsset.
+type SignatureAsset struct {
+	// DownloadURL is the URL from which the cluster should download the artifact.
+	DownloadURL *url.URL
+	// CanonicalURL is the canonical location of the signature, beside the canonical location of the file.
+	CanonicalURL *url.URL
 }
 
 // NewAssetBuilder creates a new AssetBuilder.
@@ -219,6 +233,12 @@ func (a *AssetBuilder) RemapImage(image string) (string, error) {
 
 	a.ImageAssets = append(a.ImageAssets, asset)
 
+	if a.GetAssets && a.AssetsLocation != nil && a.AssetsLocation.Verification != nil && a.AssetsLocation.Verification.Images {
+		if err := a.addImageSignature(asset); err != nil {
+			return "", err
+		}
+	}
+
 	if !featureflag.ImageDigest.Enabled() || os.Getenv("KOPS_BASE_URL") != "" {
 		return image, nil
 	}
@@ -236,6 +256,38 @@ func (a *AssetBuilder) RemapImage(image string) (string, error) {
 	return image + "@" + digest, nil
 }
 
+// addImageSignature records the cosign signature of the image as an asset, so that it is copied alongside the image.
+// Signatures are stored under a tag derived from the digest, so we resolve the digest of the canonical image.
+func (a *AssetBuilder) addImageSignature(asset *ImageAsset) error {
+	_, digest, found := strings.Cut(asset.CanonicalLocation, "@")
+	if !found {
+		d, err := crane.Digest(asset.CanonicalLocation, crane.WithAuthFromKeychain(authn.DefaultKeychain))
+		if err != nil {
+			return fmt.Errorf("unable to resolve digest of image %q to find its signature: %w", asset.CanonicalLocation, err)
+		}
+		digest = d
+	}
+	hash, err := v1.NewHash(digest)
+	if err != nil {
+		return fmt.Errorf("unable to parse digest of image %q: %w", asset.CanonicalLocation, err)
+	}
+
+	canonical, err := cosign.SignatureImage(asset.CanonicalLocation, hash)
+	if err != nil {
+		return err
+	}
+	download, err := cosign.SignatureImage(asset.DownloadLocation, hash)
+	if err != nil {
+		return err
+	}
+	a.ImageAssets = append(a.ImageAssets, &ImageAsset{
+		DownloadLocation:  download,
+		CanonicalLocation: canonical,
+		Signature:         true,
+	})
+	return nil
+}
+
 // RemapFile returns a remapped URL for the file, if AssetsLocation is defined.
 // It is returns in a FileAsset, alongside the SHA hash of the file.
 // The SHA hash is is knownHash is provided, and otherwise will be found first by
@@ -272,6 +324,13 @@ func (a *AssetBuilder) RemapFile(canonicalURL *url.URL, knownHash *hashing.Hash)
 
 	fileAsset.SHAValue = knownHash
 
+	if a.AssetsLocation != nil && a.AssetsLocation.Verification != nil {
+		fileAsset.Signature = &SignatureAsset{
+			DownloadURL:  withSuffix(fileAsset.DownloadURL, cosign.SignatureSuffix),
+			CanonicalURL: withSuffix(fileAsset.CanonicalURL, cosign.SignatureSuffix),
+		}
+	}
+
 	klog.V(8).Infof("adding file: %+v", fileAsset)
 	a.FileAssets = append(a.FileAssets, fileAsset)
 
@@ -355,6 +414,14 @@ func (a *AssetBuilder) findHash(file *FileAsset) (*hashing.Hash, error) {
 	return nil, fmt.Errorf("cannot determine hash for %q (have you specified a valid file location?)", u)
 }
 
+// withSuffix returns a copy of the URL with the suffix appended to its path
+func withSuffix(u *url.URL, suffix string) *url.URL {
+	out := *u
+	out.Path += suffix
+	out.RawPath = ""
+	return &out
+}
+
 func (a *AssetBuilder) remapURL(canonicalURL *url.URL) (*url.URL, error) {
 	f := ""
 	if a.AssetsLocation != nil {
@@ -369,6 +436,16 @@ func (a *AssetBuilder) remapURL(canonicalURL *url.URL) (*url.URL, error) {
 		return nil, fmt.Errorf("unable to parse assetsLocation.fileRepository %q: %v", f, err)
 	}
 
+	// In an OCI registry the file path follows the repository after a separator,
+	// so let the vfs build the location
+	if fileRepo.Scheme == "oci" {
+		p, err := a.vfsContext.BuildVfsPath(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse assetsLocation.fileRepository %q: %v", f, err)
+		}
+		return url.Parse(p.Join(canonicalURL.Path).Path())
+	}
+
 	fileRepo.Path = path.Join(fileRepo.Path, canonicalURL.Path)
 
 	return fileRepo, nil
@@ -17,6 +17,7 @@ limitations under the License.
 package assets
 
 import (
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
@@ -24,6 +25,8 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/util"
 	"k8s.io/kops/pkg/testutils/golden"
+	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 func buildAssetBuilder(t *testing.T) *AssetBuilder {
@@ -180,3 +183,62 @@ func TestRemapEmptySection(t *testing.T) {
 
 	golden.AssertMatchesFile(t, string(actual), expectedPath)
 }
+
+func TestRemapFile_OCIFileRepository(t *testing.T) {
+	fileRepository := "oci://registry.example.com/kops-assets"
+	builder := NewAssetBuilder(vfs.NewVFSContext(), &kops.AssetsSpec{
+		FileRepository: &fileRepository,
+	}, "1.29.0", false)
+
+	canonicalURL, err := url.Parse("https://dl.k8s.io/release/v1.29.0/bin/linux/amd64/kubelet")
+	if err != nil {
+		t.Fatalf("error parsing url: %v", err)
+	}
+	hash, err := hashing.FromString("0000000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatalf("error parsing hash: %v", err)
+	}
+
+	fileAsset, err := builder.RemapFile(canonicalURL, hash)
+	if err != nil {
+		t.Fatalf("error remapping file: %v", err)
+	}
+
+	expected := "oci://registry.example.com/kops-assets/-/release/v1.29.0/bin/linux/amd64/kubelet"
+	if fileAsset.DownloadURL.String() != expected {
+		t.Errorf("expected download url %q, got %q", expected, fileAsset.DownloadURL.String())
+	}
+}
+
+func TestRemapFile_Signature(t *testing.T) {
+	fileRepository := "https://assets.example.com/mirror"
+	builder := NewAssetBuilder(vfs.NewVFSContext(), &kops.AssetsSpec{
+		FileRepository: &fileRepository,
+		Verification: &kops.AssetVerificationSpec{
+			PublicKeys: []string{"key"},
+		},
+	}, "1.29.0", false)
+
+	canonicalURL, err := url.Parse("https://dl.k8s.io/release/v1.29.0/bin/linux/amd64/kubelet")
+	if err != nil {
+		t.Fatalf("error parsing url: %v", err)
+	}
+	hash, err := hashing.FromString("0000000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatalf("error parsing hash: %v", err)
+	}
+
+	fileAsset, err := builder.RemapFile(canonicalURL, hash)
+	if err != nil {
+		t.Fatalf("error remapping file: %v", err)
+	}
+
+	if fileAsset.Signature == nil {
+		t.Fatalf("expected a signature")
+	}
+	actual := fileAsset.Signature.CanonicalURL.String() + " -> " + fileAsset.Signature.DownloadURL.String()
+	expected := "https://dl.k8s.io/release/v1.29.0/bin/linux/amd64/kubelet.sig -> https://assets.example.com/mirror/release/v1.29.0/bin/linux/amd64/kubelet.sig"
+	if actual != expected {
+		t.Errorf("unexpected signature %q, expected %q", actual, expected)
+	}
+}
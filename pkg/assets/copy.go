@@ -38,6 +38,7 @@ func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VF
 				Name:        imageAsset.DownloadLocation,
 				SourceImage: imageAsset.CanonicalLocation,
 				TargetImage: imageAsset.DownloadLocation,
+				Signature:   imageAsset.Signature,
 			}
 
 			if existing, ok := tasks[copyImageTask.Name]; ok {
@@ -76,6 +77,17 @@ func Copy(imageAssets []*ImageAsset, fileAssets []*FileAsset, vfsContext *vfs.VF
 
 			tasks[copyFileTask.Name] = copyFileTask
 		}
+
+		if signature := fileAsset.Signature; signature != nil && signature.DownloadURL.String() != signature.CanonicalURL.String() {
+			// Signatures are verified by nodeup, and have no published hash
+			tasks[signature.CanonicalURL.String()] = &CopyFile{
+				Name:       signature.CanonicalURL.String(),
+				TargetFile: signature.DownloadURL.String(),
+				SourceFile: signature.CanonicalURL.String(),
+				VFSContext: vfsContext,
+				Cluster:    cluster,
+			}
+		}
 	}
 
 	ch := make(chan error, 5)
@@ -60,6 +60,10 @@ func (e *CopyFile) Run() error {
 
 	expectedSHA := strings.TrimSpace(e.SHA)
 
+	if expectedSHA == "" {
+		return e.copySignature(ctx)
+	}
+
 	shaExtension, err := fileExtensionForSHA(expectedSHA)
 	if err != nil {
 		return err
@@ -99,6 +103,39 @@ func (e *CopyFile) Run() error {
 	return nil
 }
 
+// copySignature copies a signature artifact, which has no hash to compare the target against.
+// A signature that is already in the target repository is never overwritten, so that a compromised source
+// can't replace the signatures nodes trust, and a signature that the source doesn't publish is skipped.
+func (e *CopyFile) copySignature(ctx context.Context) error {
+	objectStore, err := buildVFSPath(e.TargetFile)
+	if err != nil {
+		return err
+	}
+	uploadVFS, err := e.VFSContext.BuildVfsPath(objectStore)
+	if err != nil {
+		return fmt.Errorf("error building path %q: %v", objectStore, err)
+	}
+
+	if _, err := uploadVFS.ReadFile(ctx); err == nil {
+		klog.V(8).Infof("found existing signature: %q", e.TargetFile)
+		return nil
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("unable to check for existing signature %q: %v", objectStore, err)
+	}
+
+	data, err := e.VFSContext.ReadFile(e.SourceFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			klog.Warningf("signature %q not found, skipping", e.SourceFile)
+			return nil
+		}
+		return fmt.Errorf("error downloading file %q: %v", e.SourceFile, err)
+	}
+
+	klog.Infof("uploading %q to %q", e.SourceFile, objectStore)
+	return writeFile(ctx, e.Cluster, uploadVFS, data)
+}
+
 // transferFile downloads a file from the source location, validates the file matches the SHA,
 // and uploads the file to the target location.
 func transferFile(ctx context.Context, vfsContext *vfs.VFSContext, cluster *kops.Cluster, source string, target string, sha string) error {
@@ -179,7 +216,7 @@ func writeFile(ctx context.Context, cluster *kops.Cluster, p vfs.Path, data []by
 // buildVFSPath task a recognizable https url and transforms that URL into the equivalent url with the object
 // store prefix.
 func buildVFSPath(target string) (string, error) {
-	if !strings.Contains(target, "://") || strings.HasPrefix(target, "memfs://") || strings.HasPrefix(target, "file://") {
+	if !strings.Contains(target, "://") || strings.HasPrefix(target, "memfs://") || strings.HasPrefix(target, "file://") || strings.HasPrefix(target, "oci://") {
 		return target, nil
 	}
 
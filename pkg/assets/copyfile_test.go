@@ -17,7 +17,12 @@ limitations under the License.
 package assets
 
 import (
+	"context"
+	"os"
+	"strings"
 	"testing"
+
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 func Test_BuildVFSPath(t *testing.T) {
@@ -67,6 +72,11 @@ func Test_BuildVFSPath(t *testing.T) {
 			"gs://k8s-for-greeks-kops/kubernetes-release/release/v1.7.2/bin/linux/amd64/kubectl",
 			true,
 		},
+		{
+			"oci://registry.example.com/kops-assets/-/kubernetes-release/release/v1.7.2/bin/linux/amd64/kubectl",
+			"oci://registry.example.com/kops-assets/-/kubernetes-release/release/v1.7.2/bin/linux/amd64/kubectl",
+			true,
+		},
 	}
 
 	for _, test := range grid {
@@ -80,3 +90,58 @@ func Test_BuildVFSPath(t *testing.T) {
 		}
 	}
 }
+
+func TestCopyFileSignature(t *testing.T) {
+	ctx := context.TODO()
+	vfsContext := vfs.NewTestingVFSContext()
+
+	write := func(location string, data string) {
+		p, err := vfsContext.BuildVfsPath(location)
+		if err != nil {
+			t.Fatalf("building path %q: %v", location, err)
+		}
+		if err := p.WriteFile(ctx, strings.NewReader(data), nil); err != nil {
+			t.Fatalf("writing %q: %v", location, err)
+		}
+	}
+	read := func(location string) string {
+		data, err := vfsContext.ReadFile(location)
+		if err != nil {
+			t.Fatalf("reading %q: %v", location, err)
+		}
+		return string(data)
+	}
+
+	write("memfs://source/kubelet.sig", "upstream")
+	copyFile := &CopyFile{
+		SourceFile: "memfs://source/kubelet.sig",
+		TargetFile: "memfs://target/kubelet.sig",
+		VFSContext: vfsContext,
+	}
+	if err := copyFile.Run(); err != nil {
+		t.Fatalf("copying signature: %v", err)
+	}
+	if got := read("memfs://target/kubelet.sig"); got != "upstream" {
+		t.Errorf("unexpected copied signature %q", got)
+	}
+
+	write("memfs://source/kubelet.sig", "replaced")
+	if err := copyFile.Run(); err != nil {
+		t.Fatalf("copying signature again: %v", err)
+	}
+	if got := read("memfs://target/kubelet.sig"); got != "upstream" {
+		t.Errorf("existing signature was overwritten with %q", got)
+	}
+
+	missing := &CopyFile{
+		SourceFile: "memfs://source/kubectl.sig",
+		TargetFile: "memfs://target/kubectl.sig",
+		VFSContext: vfsContext,
+	}
+	if err := missing.Run(); err != nil {
+		t.Fatalf("expected missing signature to be skipped, got %v", err)
+	}
+	if _, err := vfsContext.ReadFile("memfs://target/kubectl.sig"); !os.IsNotExist(err) {
+		t.Errorf("expected no signature to be copied, got %v", err)
+	}
+}
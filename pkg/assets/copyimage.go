@@ -17,11 +17,14 @@ limitations under the License.
 package assets
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	"github.com/google/go-containerregistry/pkg/v1/types"
 	"k8s.io/klog/v2"
 )
@@ -32,6 +35,9 @@ type CopyImage struct {
 	Name        string
 	SourceImage string
 	TargetImage string
+	// Signature is true for the cosign signature of an image, which is skipped if the source doesn't publish it
+	// and never overwritten once copied.
+	Signature bool
 }
 
 func (e *CopyImage) Run() error {
@@ -50,8 +56,21 @@ func (e *CopyImage) Run() error {
 
 	options := []remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}
 
+	if e.Signature {
+		if _, err := remote.Head(targetRef, options...); err == nil {
+			klog.Infof("signature %v already exists, not overwriting it", targetRef)
+			return nil
+		} else if !isNotFound(err) {
+			return fmt.Errorf("checking for signature %q: %v", target, err)
+		}
+	}
+
 	desc, err := remote.Get(sourceRef, options...)
 	if err != nil {
+		if e.Signature && isNotFound(err) {
+			klog.Warningf("signature %v not found, skipping", sourceRef)
+			return nil
+		}
 		return fmt.Errorf("fetching %q: %v", source, err)
 	}
 
@@ -96,3 +115,9 @@ func copyIndex(desc *remote.Descriptor, sourceRef name.Reference, targetRef name
 	}
 	return remote.WriteIndex(targetRef, idx, options...)
 }
+
+// isNotFound returns true if the error is a registry response for a missing manifest
+func isNotFound(err error) bool {
+	var transportErr *transport.Error
+	return errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// testSigner signs like cosign sign-blob with a key pair
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func newKeySigner(t *testing.T) (*testSigner, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("error marshaling public key: %v", err)
+	}
+	return &testSigner{key: key}, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// sign returns the base64-encoded signature of data
+func (s *testSigner) sign(t *testing.T, data []byte) []byte {
+	digest := sha256.Sum256(data)
+	signature, err := s.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("error signing: %v", err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(signature))
+}
+
+func TestVerifyBlobWithPublicKey(t *testing.T) {
+	signer, publicKey := newKeySigner(t)
+	other, otherPublicKey := newKeySigner(t)
+
+	v, err := NewVerifier(&kops.AssetVerificationSpec{PublicKeys: []string{otherPublicKey, publicKey}})
+	if err != nil {
+		t.Fatalf("error building verifier: %v", err)
+	}
+
+	data := []byte("kubelet")
+	if err := v.VerifyBlob(data, signer.sign(t, data)); err != nil {
+		t.Errorf("unexpected error verifying signature: %v", err)
+	}
+	if err := v.VerifyBlob(data, other.sign(t, data)); err != nil {
+		t.Errorf("unexpected error verifying signature of the other key: %v", err)
+	}
+	if err := v.VerifyBlob([]byte("tampered"), signer.sign(t, data)); err == nil {
+		t.Errorf("expected error verifying signature of tampered data")
+	}
+
+	unknown, _ := newKeySigner(t)
+	if err := v.VerifyBlob(data, unknown.sign(t, data)); err == nil {
+		t.Errorf("expected error verifying signature of an unknown key")
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	if v, err := NewVerifier(nil); v != nil || err != nil {
+		t.Errorf("expected no verifier without a spec, got %v, %v", v, err)
+	}
+	if _, err := NewVerifier(&kops.AssetVerificationSpec{}); err == nil {
+		t.Errorf("expected error building a verifier without public keys")
+	}
+	if _, err := NewVerifier(&kops.AssetVerificationSpec{PublicKeys: []string{"not a key"}}); err == nil || !strings.Contains(err.Error(), "PEM") {
+		t.Errorf("expected error parsing public key, got %v", err)
+	}
+}
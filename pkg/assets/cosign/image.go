@@ -0,0 +1,133 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/klog/v2"
+)
+
+// signatureAnnotation is the annotation of a signature layer holding the base64-encoded signature of the layer
+const signatureAnnotation = "dev.cosignproject.cosign/signature"
+
+// simpleSigning is the payload that cosign signs for a container image
+type simpleSigning struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+}
+
+// SignatureTag returns the tag under which cosign stores the signatures of the image with the digest
+func SignatureTag(digest v1.Hash) string {
+	return digest.Algorithm + "-" + digest.Hex + SignatureSuffix
+}
+
+// SignatureImage returns the reference to the signatures of the image with the digest, in the repository of the image
+func SignatureImage(image string, digest v1.Hash) (string, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("error parsing image %q: %w", image, err)
+	}
+	return ref.Context().Tag(SignatureTag(digest)).String(), nil
+}
+
+// VerifyImage verifies the signature of the image in its registry,
+// returning a reference to the image by the verified digest, so that the image can be pulled without being replaced
+func (v *Verifier) VerifyImage(image string, options ...remote.Option) (string, error) {
+	options = append([]remote.Option{remote.WithAuthFromKeychain(authn.DefaultKeychain)}, options...)
+
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return "", fmt.Errorf("error parsing image %q: %w", image, err)
+	}
+
+	desc, err := remote.Head(ref, options...)
+	if err != nil {
+		return "", fmt.Errorf("error resolving digest of %q: %w", image, err)
+	}
+	digest := desc.Digest
+
+	signatures, err := remote.Image(ref.Context().Tag(SignatureTag(digest)), options...)
+	if err != nil {
+		return "", fmt.Errorf("error fetching signatures of %q: %w", image, err)
+	}
+	manifest, err := signatures.Manifest()
+	if err != nil {
+		return "", fmt.Errorf("error reading signatures of %q: %w", image, err)
+	}
+
+	var errs []error
+	for _, layer := range manifest.Layers {
+		err := v.verifyImageSignature(signatures, layer, digest)
+		if err == nil {
+			verified := ref.Context().Digest(digest.String()).String()
+			klog.Infof("verified signature of %q as %q", image, verified)
+			return verified, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return "", fmt.Errorf("no signatures found for %q", image)
+	}
+	return "", fmt.Errorf("error verifying signature of %q: %w", image, errors.Join(errs...))
+}
+
+// verifyImageSignature verifies a signature layer, and that its payload names the digest of the image
+func (v *Verifier) verifyImageSignature(signatures v1.Image, desc v1.Descriptor, digest v1.Hash) error {
+	signature, ok := desc.Annotations[signatureAnnotation]
+	if !ok {
+		return fmt.Errorf("layer %s has no signature", desc.Digest)
+	}
+
+	layer, err := signatures.LayerByDigest(desc.Digest)
+	if err != nil {
+		return fmt.Errorf("error reading layer %s: %w", desc.Digest, err)
+	}
+	r, err := layer.Compressed()
+	if err != nil {
+		return fmt.Errorf("error reading layer %s: %w", desc.Digest, err)
+	}
+	defer r.Close()
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error reading layer %s: %w", desc.Digest, err)
+	}
+
+	if err := v.VerifyBlob(payload, []byte(signature)); err != nil {
+		return err
+	}
+
+	var signed simpleSigning
+	if err := json.Unmarshal(payload, &signed); err != nil {
+		return fmt.Errorf("error parsing signed payload: %w", err)
+	}
+	if signed.Critical.Image.DockerManifestDigest != digest.String() {
+		return fmt.Errorf("signature is for digest %q, not %q", signed.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cosign
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// pushTestImage pushes an image to the registry, returning its digest
+func pushTestImage(t *testing.T, image string, content string) v1.Hash {
+	img, err := mutate.AppendLayers(empty.Image, static.NewLayer([]byte(content), types.OCILayer))
+	if err != nil {
+		t.Fatalf("error building image: %v", err)
+	}
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		t.Fatalf("error parsing %q: %v", image, err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatalf("error pushing %q: %v", image, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		t.Fatalf("error getting digest: %v", err)
+	}
+	return digest
+}
+
+// pushTestSignature pushes a cosign signature of the digest, as cosign sign does
+func pushTestSignature(t *testing.T, image string, digest v1.Hash, signer *testSigner) {
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, image, digest))
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer: static.NewLayer(payload, "application/vnd.dev.cosign.simplesigning.v1+json"),
+		Annotations: map[string]string{
+			signatureAnnotation: string(signer.sign(t, payload)),
+		},
+	})
+	if err != nil {
+		t.Fatalf("error building signature: %v", err)
+	}
+	signatureImage, err := SignatureImage(image, digest)
+	if err != nil {
+		t.Fatalf("error building signature reference: %v", err)
+	}
+	ref, err := name.ParseReference(signatureImage)
+	if err != nil {
+		t.Fatalf("error parsing %q: %v", signatureImage, err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatalf("error pushing %q: %v", signatureImage, err)
+	}
+}
+
+func TestVerifyImage(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("error parsing %q: %v", server.URL, err)
+	}
+
+	signer, publicKey := newKeySigner(t)
+	v, err := NewVerifier(&kops.AssetVerificationSpec{PublicKeys: []string{publicKey}, Images: true})
+	if err != nil {
+		t.Fatalf("error building verifier: %v", err)
+	}
+
+	signed := u.Host + "/kube-proxy:v1.30.0"
+	digest := pushTestImage(t, signed, "signed")
+	pushTestSignature(t, signed, digest, signer)
+
+	verified, err := v.VerifyImage(signed)
+	if err != nil {
+		t.Fatalf("unexpected error verifying image: %v", err)
+	}
+	if expected := u.Host + "/kube-proxy@" + digest.String(); verified != expected {
+		t.Errorf("unexpected verified image %q, expected %q", verified, expected)
+	}
+
+	unsigned := u.Host + "/pause:3.9"
+	pushTestImage(t, unsigned, "unsigned")
+	if _, err := v.VerifyImage(unsigned); err == nil {
+		t.Errorf("expected error verifying unsigned image")
+	}
+
+	// A signature of another image must not be accepted, even in the right place
+	replaced := u.Host + "/coredns:v1.11.1"
+	replacedDigest := pushTestImage(t, replaced, "replaced")
+	otherDigest := pushTestImage(t, u.Host+"/coredns:other", "other")
+	pushTestSignature(t, replaced, otherDigest, signer)
+	signatureImage, err := SignatureImage(replaced, otherDigest)
+	if err != nil {
+		t.Fatalf("error building signature reference: %v", err)
+	}
+	if err := copySignature(signatureImage, strings.Replace(signatureImage, otherDigest.Hex, replacedDigest.Hex, 1)); err != nil {
+		t.Fatalf("error moving signature: %v", err)
+	}
+	if _, err := v.VerifyImage(replaced); err == nil || !strings.Contains(err.Error(), "not \""+replacedDigest.String()+"\"") {
+		t.Errorf("expected error verifying image with the signature of another image, got %v", err)
+	}
+}
+
+func copySignature(source, target string) error {
+	sourceRef, err := name.ParseReference(source)
+	if err != nil {
+		return err
+	}
+	targetRef, err := name.ParseReference(target)
+	if err != nil {
+		return err
+	}
+	img, err := remote.Image(sourceRef)
+	if err != nil {
+		return err
+	}
+	return remote.Write(targetRef, img)
+}
@@ -68,6 +68,13 @@ func (c *RESTClientset) AddonsFor(cluster *kops.Cluster) simple.AddonsClient {
 	return nil
 }
 
+// RevisionsFor fetches the RevisionsClient for the cluster
+func (c *RESTClientset) RevisionsFor(cluster *kops.Cluster) simple.RevisionsClient {
+	// Revision history is only recorded for VFS state stores
+	klog.Fatalf("RevisionsFor not implemented for RESTClientset")
+	return nil
+}
+
 // CreateCluster implements the CreateCluster method of Clientset for a kubernetes-API state store
 func (c *RESTClientset) CreateCluster(ctx context.Context, cluster *kops.Cluster) (*kops.Cluster, error) {
 	namespace := restNamespaceForClusterName(cluster.Name)
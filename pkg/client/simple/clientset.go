@@ -52,6 +52,9 @@ type Clientset interface {
 	// AddonsFor returns the client for addon objects for a particular Cluster
 	AddonsFor(cluster *kops.Cluster) AddonsClient
 
+	// RevisionsFor returns the client for the revision history of a particular Cluster
+	RevisionsFor(cluster *kops.Cluster) RevisionsClient
+
 	// SecretStore builds the secret store for the specified cluster
 	SecretStore(cluster *kops.Cluster) (fi.SecretStore, error)
 
@@ -74,3 +77,35 @@ type AddonsClient interface {
 	// List returns all the addon objects
 	List(ctx context.Context) (kubemanifest.ObjectList, error)
 }
+
+// RevisionsClient is a client for the revision history of the cluster and instance group specs.
+// A revision is recorded for every change made to the specs through the Clientset.
+type RevisionsClient interface {
+	// List returns all the revisions, oldest first
+	List(ctx context.Context) ([]*Revision, error)
+
+	// Get returns the revision with the given number
+	Get(ctx context.Context, number int) (*Revision, error)
+
+	// Rollback restores the cluster and instance groups to the specs recorded in the given revision
+	Rollback(ctx context.Context, number int, status *kops.ClusterStatus) (*Revision, error)
+}
+
+// Revision is a snapshot of the cluster and instance group specs, taken after a change.
+type Revision struct {
+	// Number identifies the revision; revisions are numbered sequentially from 1
+	Number int `json:"number"`
+	// Timestamp is the time the change was made
+	Timestamp metav1.Time `json:"timestamp"`
+	// User is the user that made the change
+	User string `json:"user,omitempty"`
+	// Change describes the change, for example "Update InstanceGroup nodes"
+	Change string `json:"change"`
+	// Diff is the difference from the previous revision
+	Diff string `json:"diff,omitempty"`
+
+	// Cluster is the cluster spec
+	Cluster *kops.Cluster `json:"-"`
+	// InstanceGroups are the instance group specs, sorted by name
+	InstanceGroups []*kops.InstanceGroup `json:"-"`
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops/registry"
 	kopsinternalversion "k8s.io/kops/pkg/client/clientset_generated/clientset/typed/kops/internalversion"
 	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/secrets"
 	"k8s.io/kops/util/pkg/vfs"
@@ -58,12 +59,30 @@ func (c *VFSClientset) GetCluster(ctx context.Context, name string) (*kops.Clust
 
 // UpdateCluster implements the UpdateCluster method of simple.Clientset for a VFS-backed state store
 func (c *VFSClientset) UpdateCluster(ctx context.Context, cluster *kops.Cluster, status *kops.ClusterStatus) (*kops.Cluster, error) {
-	return c.clusters().Update(cluster, status)
+	updated, err := c.clusters().Update(cluster, status)
+	if err != nil {
+		return nil, err
+	}
+	c.recordRevision(ctx, cluster.Name, "Update Cluster "+cluster.Name)
+	return updated, nil
 }
 
 // CreateCluster implements the CreateCluster method of simple.Clientset for a VFS-backed state store
 func (c *VFSClientset) CreateCluster(ctx context.Context, cluster *kops.Cluster) (*kops.Cluster, error) {
-	return c.clusters().Create(cluster)
+	created, err := c.clusters().Create(cluster)
+	if err != nil {
+		return nil, err
+	}
+	c.recordRevision(ctx, cluster.Name, "Create Cluster "+cluster.Name)
+	return created, nil
+}
+
+// recordRevision adds a revision to the cluster's revision history.
+// Failing to record a revision does not fail the change itself.
+func (c *VFSClientset) recordRevision(ctx context.Context, clusterName string, change string) {
+	if err := newRevisionsVFS(c, clusterName).record(ctx, change); err != nil {
+		klog.Warningf("error recording revision of cluster %q: %v", clusterName, err)
+	}
 }
 
 // ListClusters implements the ListClusters method of simple.Clientset for a VFS-backed state store
@@ -91,17 +110,27 @@ func (c *VFSClientset) AddonsFor(cluster *kops.Cluster) simple.AddonsClient {
 	return newAddonsVFS(c, cluster)
 }
 
+// RevisionsFor implements the RevisionsFor method of simple.Clientset for a VFS-backed state store
+func (c *VFSClientset) RevisionsFor(cluster *kops.Cluster) simple.RevisionsClient {
+	return newRevisionsVFS(c, cluster.Name)
+}
+
 func (c *VFSClientset) SecretStore(cluster *kops.Cluster) (fi.SecretStore, error) {
+	encrypter, err := envelope.ForCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
 	if cluster.Spec.ConfigStore.Secrets == "" {
 		configBase, err := registry.ConfigBase(c.VFSContext(), cluster)
 		if err != nil {
 			return nil, err
 		}
 		basedir := configBase.Join("secrets")
-		return secrets.NewVFSSecretStore(cluster, basedir), nil
+		return secrets.NewVFSSecretStore(cluster, basedir, encrypter), nil
 	} else {
 		storePath, err := c.VFSContext().BuildVfsPath(cluster.Spec.ConfigStore.Secrets)
-		return secrets.NewVFSSecretStore(cluster, storePath), err
+		return secrets.NewVFSSecretStore(cluster, storePath, encrypter), err
 	}
 }
 
@@ -113,7 +142,12 @@ func (c *VFSClientset) KeyStore(cluster *kops.Cluster) (fi.CAStore, error) {
 
 	klog.V(8).Infof("Using keystore path: %q", basedir)
 
-	return fi.NewVFSCAStore(cluster, basedir), err
+	encrypter, err := envelope.ForCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return fi.NewVFSCAStore(cluster, basedir, encrypter), nil
 }
 
 func (c *VFSClientset) SSHCredentialStore(cluster *kops.Cluster) (fi.SSHCredentialStore, error) {
@@ -178,6 +212,13 @@ func DeleteAllClusterState(ctx context.Context, basePath vfs.Path) error {
 		if strings.HasPrefix(relativePath, "manifests/") {
 			continue
 		}
+		if strings.HasPrefix(relativePath, historyDir+"/") {
+			continue
+		}
+		// The checkpoint of an interrupted rolling update
+		if strings.HasPrefix(relativePath, "rolling-update/") {
+			continue
+		}
 		// TODO: offer an option _not_ to delete backups?
 		if strings.HasPrefix(relativePath, "backups/") {
 			continue
@@ -17,6 +17,9 @@ limitations under the License.
 package vfsclientset
 
 import (
+	"context"
+	"os"
+	"strings"
 	"testing"
 
 	"k8s.io/kops/pkg/apis/kops"
@@ -78,3 +81,51 @@ func TestSSHCredentialStoreOnOwnCFS(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, actual)
 	}
 }
+
+func TestDeleteAllClusterState(t *testing.T) {
+	ctx := context.TODO()
+	vfs.Context.ResetMemfsContext(true)
+
+	basePath, err := vfs.Context.BuildVfsPath("memfs://state/example.com")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	files := []string{
+		"config",
+		"instancegroup/nodes",
+		"pki/private/ca/keyset.yaml",
+		"rolling-update/checkpoint.json",
+	}
+	for _, file := range files {
+		if err := basePath.Join(file).WriteFile(ctx, strings.NewReader("data"), nil); err != nil {
+			t.Fatalf("error writing %s: %v", file, err)
+		}
+	}
+
+	if err := DeleteAllClusterState(ctx, basePath); err != nil {
+		t.Fatalf("error deleting cluster state: %v", err)
+	}
+
+	for _, file := range files {
+		if _, err := basePath.Join(file).ReadFile(ctx); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be deleted, got %v", file, err)
+		}
+	}
+}
+
+func TestDeleteAllClusterStateUnknownFile(t *testing.T) {
+	ctx := context.TODO()
+	vfs.Context.ResetMemfsContext(true)
+
+	basePath, err := vfs.Context.BuildVfsPath("memfs://state/example.com")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	if err := basePath.Join("unknown").WriteFile(ctx, strings.NewReader("data"), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	if err := DeleteAllClusterState(ctx, basePath); err == nil || !strings.Contains(err.Error(), "unknown file found") {
+		t.Errorf("expected unknown file to be refused, got %v", err)
+	}
+}
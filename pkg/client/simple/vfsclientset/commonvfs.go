@@ -19,15 +19,18 @@ package vfsclientset
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
 	"sort"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
@@ -116,7 +119,14 @@ func (c *commonVFS) serialize(o runtime.Object) ([]byte, error) {
 }
 
 func (c *commonVFS) readConfig(ctx context.Context, configPath vfs.Path) (runtime.Object, error) {
-	data, err := configPath.ReadFile(ctx)
+	var data []byte
+	var version string
+	var err error
+	if conditional, ok := configPath.(vfs.HasConditionalWrite); ok {
+		data, version, err = conditional.ReadFileVersion(ctx)
+	} else {
+		data, err = configPath.ReadFile(ctx)
+	}
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, err
@@ -128,11 +138,36 @@ func (c *commonVFS) readConfig(ctx context.Context, configPath vfs.Path) (runtim
 	if err != nil {
 		return nil, fmt.Errorf("error parsing %s: %v", configPath, err)
 	}
+
+	// The version of the file is exposed as the resourceVersion, so that updates can be rejected if the file has changed since.
+	objectMeta, err := meta.Accessor(object)
+	if err != nil {
+		return nil, err
+	}
+	objectMeta.SetResourceVersion(version)
+
 	return object, nil
 }
 
 func (c *commonVFS) writeConfig(ctx context.Context, cluster *kops.Cluster, configPath vfs.Path, o runtime.Object, writeOptions ...vfs.WriteOption) error {
-	data, err := c.serialize(o)
+	objectMeta, err := meta.Accessor(o)
+	if err != nil {
+		return err
+	}
+
+	// The resourceVersion is the version of the file, so it is not stored in the file itself.
+	resourceVersion := objectMeta.GetResourceVersion()
+	stored := o
+	if resourceVersion != "" {
+		stored = o.DeepCopyObject()
+		storedMeta, err := meta.Accessor(stored)
+		if err != nil {
+			return err
+		}
+		storedMeta.SetResourceVersion("")
+	}
+
+	data, err := c.serialize(stored)
 	if err != nil {
 		return fmt.Errorf("error marshaling object: %v", err)
 	}
@@ -161,8 +196,18 @@ func (c *commonVFS) writeConfig(ctx context.Context, cluster *kops.Cluster, conf
 	}
 
 	rs := bytes.NewReader(data)
+	conditional, isConditional := configPath.(vfs.HasConditionalWrite)
 	if create {
 		err = configPath.CreateFile(ctx, rs, acl)
+	} else if isConditional && resourceVersion != "" {
+		var newVersion string
+		newVersion, err = conditional.WriteFileIfVersion(ctx, rs, acl, resourceVersion)
+		if errors.Is(err, vfs.ErrVersionConflict) {
+			return c.newConflict(ctx, conditional, objectMeta.GetName())
+		}
+		if err == nil {
+			objectMeta.SetResourceVersion(newVersion)
+		}
 	} else {
 		err = configPath.WriteFile(ctx, rs, acl)
 	}
@@ -176,6 +221,26 @@ func (c *commonVFS) writeConfig(ctx context.Context, cluster *kops.Cluster, conf
 	return nil
 }
 
+// newConflict builds the error returned when an object has been modified since it was read,
+// carrying the current resourceVersion of the object.
+func (c *commonVFS) newConflict(ctx context.Context, configPath vfs.HasConditionalWrite, name string) error {
+	var current string
+	if _, version, err := configPath.ReadFileVersion(ctx); err == nil {
+		current = version
+	} else if !os.IsNotExist(err) {
+		klog.Warningf("error reading current version of %s %q: %v", c.kind, name, err)
+	}
+
+	err := apierrors.NewConflict(schema.GroupResource{Group: kops.GroupName, Resource: c.kind}, name,
+		fmt.Errorf("the object has been modified; please apply your changes to the latest version (resourceVersion %q) and try again", current))
+	err.ErrStatus.Details.Causes = append(err.ErrStatus.Details.Causes, metav1.StatusCause{
+		Type:    metav1.CauseTypeFieldValueInvalid,
+		Field:   "metadata.resourceVersion",
+		Message: current,
+	})
+	return err
+}
+
 func (c *commonVFS) update(ctx context.Context, cluster *kops.Cluster, i runtime.Object) error {
 	objectMeta, err := meta.Accessor(i)
 	if err != nil {
@@ -196,6 +261,9 @@ func (c *commonVFS) update(ctx context.Context, cluster *kops.Cluster, i runtime
 
 	err = c.writeConfig(ctx, cluster, c.basePath.Join(objectMeta.GetName()), i, vfs.WriteOptionOnlyIfExists)
 	if err != nil {
+		if apierrors.IsConflict(err) {
+			return err
+		}
 		return fmt.Errorf("error writing %s: %v", c.kind, err)
 	}
 
@@ -0,0 +1,434 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/acls"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/validation"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/util/pkg/text"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/yaml"
+)
+
+// historyDir is the directory under the cluster's config base in which revisions are recorded.
+const historyDir = "history"
+
+// maxRevisions is the number of revisions kept in the history; older revisions are deleted when a new one is recorded.
+const maxRevisions = 100
+
+// RevisionsVFS records the revision history of a cluster in a VFS-backed state store.
+// Each revision is stored as a single file, named by its number, holding the specs of the cluster and all its instance groups.
+type RevisionsVFS struct {
+	clientset   *VFSClientset
+	clusterName string
+	basePath    vfs.Path
+
+	// maxRevisions is the number of revisions kept in the history
+	maxRevisions int
+}
+
+var _ simple.RevisionsClient = &RevisionsVFS{}
+
+func newRevisionsVFS(c *VFSClientset, clusterName string) *RevisionsVFS {
+	return &RevisionsVFS{
+		clientset:   c,
+		clusterName: clusterName,
+		basePath:    c.basePath.Join(clusterName, historyDir),
+
+		maxRevisions: maxRevisions,
+	}
+}
+
+// storedRevision is the serialized form of a revision.
+type storedRevision struct {
+	simple.Revision
+
+	// Specs holds the cluster and instance group specs, as YAML documents
+	Specs string `json:"specs"`
+}
+
+// List implements simple.RevisionsClient::List
+func (r *RevisionsVFS) List(ctx context.Context) ([]*simple.Revision, error) {
+	numbers, err := r.listNumbers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []*simple.Revision
+	for _, number := range numbers {
+		revision, err := r.Get(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, nil
+}
+
+// Get implements simple.RevisionsClient::Get
+func (r *RevisionsVFS) Get(ctx context.Context, number int) (*simple.Revision, error) {
+	stored, err := r.read(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := stored.Revision
+	for _, section := range text.SplitContentToSections([]byte(stored.Specs)) {
+		o, _, err := kopscodecs.Decode(section, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing revision %d of cluster %q: %w", number, r.clusterName, err)
+		}
+		switch v := o.(type) {
+		case *kops.Cluster:
+			revision.Cluster = v
+		case *kops.InstanceGroup:
+			revision.InstanceGroups = append(revision.InstanceGroups, v)
+		default:
+			return nil, fmt.Errorf("unexpected object of type %T in revision %d of cluster %q", o, number, r.clusterName)
+		}
+	}
+	if revision.Cluster == nil {
+		return nil, fmt.Errorf("revision %d of cluster %q has no cluster spec", number, r.clusterName)
+	}
+	return &revision, nil
+}
+
+// Rollback implements simple.RevisionsClient::Rollback
+// Every spec is validated before any is written, and every write is conditional on the spec being unchanged since the rollback read it.
+// The rollback fails if another revision is recorded while it runs. It is not atomic: if a write fails, the specs that were
+// in place before the rollback are restored. If restoring also fails, the error names the objects left at the revision's specs.
+func (r *RevisionsVFS) Rollback(ctx context.Context, number int, status *kops.ClusterStatus) (*simple.Revision, error) {
+	revision, err := r.Get(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers, err := r.listNumbers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	latest := numbers[len(numbers)-1]
+
+	previousCluster, previousInstanceGroups, err := r.readSpecs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := revision.Cluster.DeepCopy()
+	cluster.ResourceVersion = ""
+	if errs := validation.ValidateClusterUpdate(cluster, status, previousCluster, r.clientset.VFSContext()); len(errs) != 0 {
+		return nil, fmt.Errorf("cannot roll back to revision %d: %w", number, errs.ToAggregate())
+	}
+	for _, ig := range revision.InstanceGroups {
+		if errs := validation.CrossValidateInstanceGroup(ig, cluster, nil, false); len(errs) != 0 {
+			return nil, fmt.Errorf("cannot roll back to revision %d: %w", number, errs.ToAggregate())
+		}
+	}
+
+	// Fail before writing anything if the specs have already changed since we read them
+	if err := r.checkLatest(ctx, latest); err != nil {
+		return nil, fmt.Errorf("error rolling back to revision %d: %w", number, err)
+	}
+
+	written, err := r.writeSpecs(ctx, previousCluster, previousInstanceGroups, cluster, revision.InstanceGroups, status)
+	if err == nil {
+		err = r.checkLatest(ctx, latest)
+	}
+	if err != nil {
+		if len(written) == 0 {
+			return nil, fmt.Errorf("error rolling back to revision %d: %w", number, err)
+		}
+		klog.Warningf("error rolling back cluster %q to revision %d after writing %s, restoring previous specs: %v", r.clusterName, number, strings.Join(written, ", "), err)
+		restored, restoreErr := r.restoreSpecs(ctx, previousCluster, previousInstanceGroups, status)
+		if restoreErr != nil {
+			var unrestored []string
+			for _, name := range written {
+				if !slices.Contains(restored, name) {
+					unrestored = append(unrestored, name)
+				}
+			}
+			return nil, fmt.Errorf("error rolling back to revision %d: %w; error restoring previous specs: %v; "+
+				"cluster %q is left partially rolled back, %s may still have the specs of revision %d",
+				number, err, restoreErr, r.clusterName, strings.Join(unrestored, ", "), number)
+		}
+		return nil, fmt.Errorf("error rolling back to revision %d, restored previous specs of %s: %w", number, strings.Join(restored, ", "), err)
+	}
+
+	if err := r.record(ctx, fmt.Sprintf("Rollback to revision %d", number)); err != nil {
+		return nil, err
+	}
+	return revision, nil
+}
+
+// checkLatest returns a conflict error if a revision newer than latest has been recorded,
+// which means another writer has changed the specs.
+func (r *RevisionsVFS) checkLatest(ctx context.Context, latest int) error {
+	numbers, err := r.listNumbers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(numbers) != 0 && numbers[len(numbers)-1] != latest {
+		return errors.NewConflict(schema.GroupResource{Group: kops.GroupName, Resource: "Revision"}, r.clusterName,
+			fmt.Errorf("revision %d was recorded by a concurrent change", numbers[len(numbers)-1]))
+	}
+	return nil
+}
+
+// restoreSpecs writes back the specs that were in place before a failed rollback, on top of whatever is currently stored.
+// It returns the names of the objects it restored, even if it fails part way.
+func (r *RevisionsVFS) restoreSpecs(ctx context.Context, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, status *kops.ClusterStatus) ([]string, error) {
+	current, currentInstanceGroups, err := r.readSpecs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.writeSpecs(ctx, current, currentInstanceGroups, cluster, instanceGroups, status)
+}
+
+// writeSpecs replaces the stored cluster and instance groups with the given specs, deleting any other instance groups.
+// current and currentInstanceGroups are the stored specs as last read; updates are conditional on their resourceVersions,
+// and creates fail if the instance group exists. Writes are not recorded as revisions.
+// It returns the names of the objects it wrote, even if it fails part way.
+func (r *RevisionsVFS) writeSpecs(ctx context.Context, current *kops.Cluster, currentInstanceGroups []*kops.InstanceGroup, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup, status *kops.ClusterStatus) ([]string, error) {
+	var written []string
+
+	cluster = cluster.DeepCopy()
+	cluster.ResourceVersion = current.ResourceVersion
+	if _, err := r.clientset.clusters().Update(cluster, status); err != nil {
+		return written, err
+	}
+	written = append(written, fmt.Sprintf("Cluster %q", cluster.Name))
+
+	igClient := newInstanceGroupVFS(r.clientset, cluster)
+	igClient.recordHistory = false
+
+	existing := make(map[string]*kops.InstanceGroup)
+	for _, ig := range currentInstanceGroups {
+		existing[ig.Name] = ig
+	}
+	for _, ig := range instanceGroups {
+		ig = ig.DeepCopy()
+		if old := existing[ig.Name]; old != nil {
+			ig.ResourceVersion = old.ResourceVersion
+			if _, err := igClient.Update(ctx, ig, metav1.UpdateOptions{}); err != nil {
+				return written, err
+			}
+			delete(existing, ig.Name)
+		} else {
+			ig.ResourceVersion = ""
+			if _, err := igClient.Create(ctx, ig, metav1.CreateOptions{}); err != nil {
+				return written, err
+			}
+		}
+		written = append(written, fmt.Sprintf("InstanceGroup %q", ig.Name))
+	}
+	for _, ig := range currentInstanceGroups {
+		if existing[ig.Name] == nil {
+			continue
+		}
+		if err := igClient.Delete(ctx, ig.Name, metav1.DeleteOptions{}); err != nil {
+			return written, err
+		}
+		written = append(written, fmt.Sprintf("InstanceGroup %q", ig.Name))
+	}
+	return written, nil
+}
+
+// readSpecs reads the cluster and its instance groups, sorted by name.
+func (r *RevisionsVFS) readSpecs(ctx context.Context) (*kops.Cluster, []*kops.InstanceGroup, error) {
+	cluster, err := r.clientset.clusters().Get(ctx, r.clusterName, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	igList, err := newInstanceGroupVFS(r.clientset, cluster).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	var instanceGroups []*kops.InstanceGroup
+	for i := range igList.Items {
+		instanceGroups = append(instanceGroups, &igList.Items[i])
+	}
+	sort.Slice(instanceGroups, func(i, j int) bool {
+		return instanceGroups[i].Name < instanceGroups[j].Name
+	})
+	return cluster, instanceGroups, nil
+}
+
+// record stores a revision with the current specs, unless they are unchanged since the last revision.
+func (r *RevisionsVFS) record(ctx context.Context, change string) error {
+	cluster, instanceGroups, err := r.readSpecs(ctx)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			klog.V(2).Infof("not recording revision of cluster %q, which does not exist", r.clusterName)
+			return nil
+		}
+		return err
+	}
+
+	var objects []runtime.Object
+	objects = append(objects, cluster)
+	for _, ig := range instanceGroups {
+		objects = append(objects, ig)
+	}
+	var sections []string
+	for _, o := range objects {
+		o = o.DeepCopyObject()
+		objectMeta, err := meta.Accessor(o)
+		if err != nil {
+			return err
+		}
+		// The resourceVersion changes on every write, so is not part of the spec
+		objectMeta.SetResourceVersion("")
+		data, err := kopscodecs.ToVersionedYaml(o)
+		if err != nil {
+			return fmt.Errorf("error serializing %s: %w", objectMeta.GetName(), err)
+		}
+		sections = append(sections, string(data))
+	}
+	specs := strings.Join(sections, "---\n")
+
+	acl, err := acls.GetACL(ctx, r.basePath, cluster)
+	if err != nil {
+		return err
+	}
+
+	// Another writer may record a revision concurrently, in which case we take the next number
+	for attempt := 0; attempt < 5; attempt++ {
+		numbers, err := r.listNumbers(ctx)
+		if err != nil {
+			return err
+		}
+
+		stored := &storedRevision{
+			Revision: simple.Revision{
+				Number:    1,
+				Timestamp: metav1.NewTime(time.Now().UTC()),
+				User:      currentUser(),
+				Change:    change,
+			},
+			Specs: specs,
+		}
+		if len(numbers) != 0 {
+			last := numbers[len(numbers)-1]
+			previous, err := r.read(ctx, last)
+			if err != nil {
+				return err
+			}
+			if previous.Specs == specs {
+				klog.V(2).Infof("specs of cluster %q unchanged since revision %d", r.clusterName, last)
+				return nil
+			}
+			stored.Number = last + 1
+			stored.Diff = diff.FormatDiff(previous.Specs, specs)
+		}
+
+		data, err := yaml.Marshal(stored)
+		if err != nil {
+			return fmt.Errorf("error serializing revision: %w", err)
+		}
+		err = r.basePath.Join(strconv.Itoa(stored.Number)).CreateFile(ctx, bytes.NewReader(data), acl)
+		if err == nil {
+			klog.V(2).Infof("recorded revision %d of cluster %q: %s", stored.Number, r.clusterName, change)
+			r.prune(ctx, append(numbers, stored.Number))
+			return nil
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("error writing revision %d of cluster %q: %w", stored.Number, r.clusterName, err)
+		}
+	}
+	return fmt.Errorf("error writing revision of cluster %q: too many concurrent changes", r.clusterName)
+}
+
+// prune deletes the oldest revisions, keeping the most recent maxRevisions. numbers are the recorded revisions, in ascending order.
+// Failures are only logged, as the change being recorded has already been made.
+func (r *RevisionsVFS) prune(ctx context.Context, numbers []int) {
+	if r.maxRevisions <= 0 || len(numbers) <= r.maxRevisions {
+		return
+	}
+	for _, number := range numbers[:len(numbers)-r.maxRevisions] {
+		if err := r.basePath.Join(strconv.Itoa(number)).Remove(ctx); err != nil && !os.IsNotExist(err) {
+			klog.Warningf("error deleting revision %d of cluster %q: %v", number, r.clusterName, err)
+			return
+		}
+		klog.V(2).Infof("deleted revision %d of cluster %q", number, r.clusterName)
+	}
+}
+
+func (r *RevisionsVFS) read(ctx context.Context, number int) (*storedRevision, error) {
+	data, err := r.basePath.Join(strconv.Itoa(number)).ReadFile(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewNotFound(schema.GroupResource{Group: kops.GroupName, Resource: "Revision"}, strconv.Itoa(number))
+		}
+		return nil, fmt.Errorf("error reading revision %d of cluster %q: %w", number, r.clusterName, err)
+	}
+
+	stored := &storedRevision{}
+	if err := yaml.Unmarshal(data, stored); err != nil {
+		return nil, fmt.Errorf("error parsing revision %d of cluster %q: %w", number, r.clusterName, err)
+	}
+	return stored, nil
+}
+
+// listNumbers returns the numbers of the recorded revisions, in ascending order.
+func (r *RevisionsVFS) listNumbers(ctx context.Context) ([]int, error) {
+	names, err := listChildNames(ctx, r.basePath)
+	if err != nil {
+		return nil, fmt.Errorf("error listing revisions of cluster %q: %w", r.clusterName, err)
+	}
+
+	var numbers []int
+	for _, name := range names {
+		number, err := strconv.Atoi(name)
+		if err != nil {
+			klog.Warningf("ignoring unexpected file %q in revision history of cluster %q", name, r.clusterName)
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+	return numbers, nil
+}
+
+// currentUser returns the name of the user making a change, for the revision history.
+func currentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/pkg/testutils/testcontext"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestRevisionHistory(t *testing.T) {
+	ctx := testcontext.ForTest(t)
+
+	// memfs does not remove deleted files from listings, which rollback relies on
+	vfsContext := vfs.NewVFSContext()
+	basePath, err := vfsContext.BuildVfsPath(t.TempDir())
+	if err != nil {
+		t.Fatalf("error building state store path: %v", err)
+	}
+	clientset := NewVFSClientset(vfsContext, basePath)
+
+	cluster, err := clientset.CreateCluster(ctx, testutils.BuildMinimalCluster("example.com"))
+	if err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	igs := clientset.InstanceGroupsFor(cluster)
+	nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+	nodes.Spec.MachineType = "m5.large"
+	if _, err := igs.Create(ctx, &nodes, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+
+	// Revision 3 changes the machine type and adds an instance group
+	ig, err := igs.Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting instance group: %v", err)
+	}
+	ig.Spec.MachineType = "m5.xlarge"
+	if _, err := igs.Update(ctx, ig, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error updating instance group: %v", err)
+	}
+	// Writes that don't change the specs are not recorded
+	ig, err = igs.Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting instance group: %v", err)
+	}
+	if _, err := igs.Update(ctx, ig, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("error updating instance group: %v", err)
+	}
+	extra := testutils.BuildMinimalNodeInstanceGroup("extra", "subnet-us-test-1b")
+	if _, err := igs.Create(ctx, &extra, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+
+	revisions := clientset.RevisionsFor(cluster)
+	history, err := revisions.List(ctx)
+	if err != nil {
+		t.Fatalf("error listing revisions: %v", err)
+	}
+	var changes []string
+	for i, revision := range history {
+		if revision.Number != i+1 {
+			t.Errorf("expected revision %d to have number %d, got %d", i, i+1, revision.Number)
+		}
+		changes = append(changes, revision.Change)
+	}
+	expected := []string{
+		"Create Cluster example.com",
+		"Create InstanceGroup nodes",
+		"Update InstanceGroup nodes",
+		"Create InstanceGroup extra",
+	}
+	if strings.Join(changes, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected revisions; got %q, expected %q", changes, expected)
+	}
+	if history[0].Diff != "" {
+		t.Errorf("expected first revision to have no diff, got %q", history[0].Diff)
+	}
+	if !strings.Contains(history[2].Diff, "+   machineType: m5.xlarge") {
+		t.Errorf("expected diff of revision 3 to show the new machine type, got %q", history[2].Diff)
+	}
+
+	if _, err := revisions.Get(ctx, 10); !apierrors.IsNotFound(err) {
+		t.Errorf("expected NotFound error for missing revision, got %v", err)
+	}
+
+	if _, err := revisions.Rollback(ctx, 2, nil); err != nil {
+		t.Fatalf("error rolling back: %v", err)
+	}
+	list, err := igs.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("error listing instance groups: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "nodes" {
+		t.Fatalf("expected only instance group nodes after rollback, got %v", list.Items)
+	}
+	if list.Items[0].Spec.MachineType != "m5.large" {
+		t.Errorf("expected machine type %q after rollback, got %q", "m5.large", list.Items[0].Spec.MachineType)
+	}
+
+	history, err = revisions.List(ctx)
+	if err != nil {
+		t.Fatalf("error listing revisions: %v", err)
+	}
+	last := history[len(history)-1]
+	if last.Number != 5 || last.Change != "Rollback to revision 2" {
+		t.Errorf("expected rollback to be recorded as revision 5, got %d %q", last.Number, last.Change)
+	}
+}
+
+func TestRollbackConcurrentChange(t *testing.T) {
+	ctx := testcontext.ForTest(t)
+
+	vfsContext := vfs.NewVFSContext()
+	basePath, err := vfsContext.BuildVfsPath(t.TempDir())
+	if err != nil {
+		t.Fatalf("error building state store path: %v", err)
+	}
+	clientset := NewVFSClientset(vfsContext, basePath)
+
+	cluster, err := clientset.CreateCluster(ctx, testutils.BuildMinimalCluster("example.com"))
+	if err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	revisions := clientset.RevisionsFor(cluster).(*RevisionsVFS)
+
+	stale, staleInstanceGroups, err := revisions.readSpecs(ctx)
+	if err != nil {
+		t.Fatalf("error reading specs: %v", err)
+	}
+	numbers, err := revisions.listNumbers(ctx)
+	if err != nil {
+		t.Fatalf("error listing revisions: %v", err)
+	}
+	latest := numbers[len(numbers)-1]
+
+	// Another writer changes the cluster after the rollback has read it
+	nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+	if _, err := clientset.InstanceGroupsFor(cluster).Create(ctx, &nodes, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+	updated, err := clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("error getting cluster: %v", err)
+	}
+	updated.Spec.KubernetesVersion = "1.30.0"
+	if _, err := clientset.UpdateCluster(ctx, updated, nil); err != nil {
+		t.Fatalf("error updating cluster: %v", err)
+	}
+
+	if err := revisions.checkLatest(ctx, latest); !apierrors.IsConflict(err) {
+		t.Errorf("expected conflict for a revision recorded concurrently, got %v", err)
+	}
+	if written, err := revisions.writeSpecs(ctx, stale, staleInstanceGroups, stale, staleInstanceGroups, nil); !apierrors.IsConflict(err) {
+		t.Errorf("expected conflict when writing over a changed cluster, got %v", err)
+	} else if len(written) != 0 {
+		t.Errorf("expected nothing to be written, got %q", written)
+	}
+
+	// A write that fails part way reports the objects already written
+	current, err := clientset.GetCluster(ctx, cluster.Name)
+	if err != nil {
+		t.Fatalf("error getting cluster: %v", err)
+	}
+	written, err := revisions.writeSpecs(ctx, current, staleInstanceGroups, current, []*kops.InstanceGroup{&nodes}, nil)
+	if !os.IsExist(err) {
+		t.Errorf("expected error creating an existing instance group, got %v", err)
+	}
+	if expected := []string{`Cluster "example.com"`}; !reflect.DeepEqual(written, expected) {
+		t.Errorf("expected written objects %q, got %q", expected, written)
+	}
+}
+
+func TestRevisionHistoryRetention(t *testing.T) {
+	ctx := testcontext.ForTest(t)
+
+	vfsContext := vfs.NewVFSContext()
+	basePath, err := vfsContext.BuildVfsPath(t.TempDir())
+	if err != nil {
+		t.Fatalf("error building state store path: %v", err)
+	}
+	clientset := NewVFSClientset(vfsContext, basePath)
+
+	cluster, err := clientset.CreateCluster(ctx, testutils.BuildMinimalCluster("example.com"))
+	if err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	revisions := clientset.RevisionsFor(cluster).(*RevisionsVFS)
+	revisions.maxRevisions = 2
+
+	for _, version := range []string{"1.29.0", "1.30.0", "1.31.0"} {
+		cluster, err = clientset.GetCluster(ctx, cluster.Name)
+		if err != nil {
+			t.Fatalf("error getting cluster: %v", err)
+		}
+		cluster.Spec.KubernetesVersion = version
+		if _, err := clientset.(*VFSClientset).clusters().Update(cluster, nil); err != nil {
+			t.Fatalf("error updating cluster: %v", err)
+		}
+		if err := revisions.record(ctx, "Update Cluster "+cluster.Name); err != nil {
+			t.Fatalf("error recording revision: %v", err)
+		}
+	}
+
+	numbers, err := revisions.listNumbers(ctx)
+	if err != nil {
+		t.Fatalf("error listing revisions: %v", err)
+	}
+	if expected := []int{3, 4}; !reflect.DeepEqual(numbers, expected) {
+		t.Errorf("expected revisions %v to be kept, got %v", expected, numbers)
+	}
+	if _, err := revisions.Get(ctx, 1); !apierrors.IsNotFound(err) {
+		t.Errorf("expected NotFound error for pruned revision, got %v", err)
+	}
+}
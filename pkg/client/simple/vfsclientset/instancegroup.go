@@ -38,6 +38,10 @@ type InstanceGroupVFS struct {
 
 	clusterName string
 	cluster     *kopsapi.Cluster
+
+	clientset *VFSClientset
+	// recordHistory is true if changes should be recorded in the cluster's revision history
+	recordHistory bool
 }
 
 func newInstanceGroupVFS(c *VFSClientset, cluster *kopsapi.Cluster) *InstanceGroupVFS {
@@ -49,8 +53,10 @@ func newInstanceGroupVFS(c *VFSClientset, cluster *kopsapi.Cluster) *InstanceGro
 	kind := "InstanceGroup"
 
 	r := &InstanceGroupVFS{
-		cluster:     cluster,
-		clusterName: clusterName,
+		cluster:       cluster,
+		clusterName:   clusterName,
+		clientset:     c,
+		recordHistory: true,
 	}
 	r.init(kind, c.VFSContext(), c.basePath.Join(clusterName, "instancegroup"), StoreVersion)
 	r.validate = func(o runtime.Object) error {
@@ -105,6 +111,7 @@ func (c *InstanceGroupVFS) Create(ctx context.Context, g *kopsapi.InstanceGroup,
 	if err != nil {
 		return nil, err
 	}
+	c.record(ctx, "Create InstanceGroup "+g.Name)
 	return g, nil
 }
 
@@ -123,11 +130,26 @@ func (c *InstanceGroupVFS) Update(ctx context.Context, g *kopsapi.InstanceGroup,
 	if err != nil {
 		return nil, err
 	}
+	c.record(ctx, "Update InstanceGroup "+g.Name)
 	return g, nil
 }
 
 func (c *InstanceGroupVFS) Delete(ctx context.Context, name string, options metav1.DeleteOptions) error {
-	return c.delete(ctx, name, options)
+	if err := c.delete(ctx, name, options); err != nil {
+		return err
+	}
+	c.record(ctx, "Delete InstanceGroup "+name)
+	return nil
+}
+
+// record adds a revision to the cluster's revision history, unless recording is disabled.
+func (c *InstanceGroupVFS) record(ctx context.Context, change string) {
+	if !c.recordHistory {
+		return
+	}
+	if err := newRevisionsVFS(c.clientset, c.clusterName).record(ctx, change); err != nil {
+		klog.Warningf("error recording revision of cluster %q: %v", c.clusterName, err)
+	}
 }
 
 func (r *InstanceGroupVFS) DeleteCollection(ctx context.Context, options metav1.DeleteOptions, listOptions metav1.ListOptions) error {
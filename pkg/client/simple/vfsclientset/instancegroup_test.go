@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfsclientset
+
+import (
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/testutils/testcontext"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestInstanceGroupUpdateConflict(t *testing.T) {
+	ctx := testcontext.ForTest(t)
+
+	vfsContext := vfs.NewVFSContext()
+	vfsContext.ResetMemfsContext(true)
+	basePath, err := vfsContext.BuildVfsPath("memfs://state")
+	if err != nil {
+		t.Fatalf("error building state store path: %v", err)
+	}
+	clientset := NewVFSClientset(vfsContext, basePath)
+
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "example.com"},
+	}
+	igs := clientset.InstanceGroupsFor(cluster)
+
+	ig := &kops.InstanceGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+		Spec: kops.InstanceGroupSpec{
+			Role:    kops.InstanceGroupRoleNode,
+			Subnets: []string{"subnet-a"},
+		},
+	}
+	if _, err := igs.Create(ctx, ig, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+
+	first, err := igs.Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting instance group: %v", err)
+	}
+	second, err := igs.Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting instance group: %v", err)
+	}
+	if first.ResourceVersion == "" {
+		t.Fatalf("expected instance group to have a resourceVersion")
+	}
+
+	first.Spec.MachineType = "m5.large"
+	updated, err := igs.Update(ctx, first, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("error updating instance group: %v", err)
+	}
+	if updated.ResourceVersion == second.ResourceVersion {
+		t.Errorf("expected resourceVersion to change from %q on update", second.ResourceVersion)
+	}
+
+	// The second writer read the instance group before the first update, so must be rejected
+	second.Spec.MachineType = "m5.xlarge"
+	_, err = igs.Update(ctx, second, metav1.UpdateOptions{})
+	if !apierrors.IsConflict(err) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+	status := err.(apierrors.APIStatus).Status()
+	if len(status.Details.Causes) != 1 || status.Details.Causes[0].Message != updated.ResourceVersion {
+		t.Errorf("expected conflict to carry current resourceVersion %q, got %v", updated.ResourceVersion, status.Details.Causes)
+	}
+
+	// Updates made on top of the current version succeed
+	current, err := igs.Get(ctx, "nodes", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting instance group: %v", err)
+	}
+	if current.Spec.MachineType != "m5.large" {
+		t.Errorf("expected machine type %q, got %q", "m5.large", current.Spec.MachineType)
+	}
+	current.Spec.MachineType = "m5.xlarge"
+	if _, err := igs.Update(ctx, current, metav1.UpdateOptions{}); err != nil {
+		t.Errorf("error updating instance group at current version: %v", err)
+	}
+
+	// Updates without a resourceVersion are not checked
+	current.ResourceVersion = ""
+	if _, err := igs.Update(ctx, current, metav1.UpdateOptions{}); err != nil {
+		t.Errorf("error updating instance group without resourceVersion: %v", err)
+	}
+}
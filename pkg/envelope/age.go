@@ -0,0 +1,137 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"filippo.io/age"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// EnvAgeIdentityFile overrides the path to the age identity file.
+const EnvAgeIdentityFile = "KOPS_AGE_IDENTITY_FILE"
+
+// ageProvider wraps data keys for an age X25519 recipient.
+type ageProvider struct {
+	recipient    *age.X25519Recipient
+	identityFile string
+
+	mutex      sync.Mutex
+	identities []age.Identity
+}
+
+var _ KeyProvider = &ageProvider{}
+
+func newAgeProvider(spec *kops.AgeEncryptionSpec) (*ageProvider, error) {
+	recipient, err := age.ParseX25519Recipient(spec.Recipient)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing age recipient %q: %w", spec.Recipient, err)
+	}
+
+	return &ageProvider{
+		recipient:    recipient,
+		identityFile: ageIdentityFile(spec),
+	}, nil
+}
+
+// ageIdentityFile returns the path to the age identity file, which may be overridden by KOPS_AGE_IDENTITY_FILE.
+func ageIdentityFile(spec *kops.AgeEncryptionSpec) string {
+	if s := os.Getenv(EnvAgeIdentityFile); s != "" {
+		return s
+	}
+	return spec.IdentityFile
+}
+
+// ReadAgeIdentity reads and checks the age identity, so that it can be delivered to the control plane.
+func ReadAgeIdentity(spec *kops.AgeEncryptionSpec) ([]byte, error) {
+	identityFile := ageIdentityFile(spec)
+	if identityFile == "" {
+		return nil, fmt.Errorf("no age identity file configured; set %s", EnvAgeIdentityFile)
+	}
+	data, err := os.ReadFile(identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading age identity file: %w", err)
+	}
+	if _, err := age.ParseIdentities(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("error parsing age identity file %q: %w", identityFile, err)
+	}
+	return data, nil
+}
+
+// Name implements KeyProvider::Name
+func (p *ageProvider) Name() string {
+	return "age"
+}
+
+// WrapKey implements KeyProvider::WrapKey
+func (p *ageProvider) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	var wrapped bytes.Buffer
+	w, err := age.Encrypt(&wrapped, p.recipient)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := w.Write(dataKey); err != nil {
+		return "", nil, err
+	}
+	if err := w.Close(); err != nil {
+		return "", nil, err
+	}
+	return p.recipient.String(), wrapped.Bytes(), nil
+}
+
+// UnwrapKey implements KeyProvider::UnwrapKey
+func (p *ageProvider) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	identities, err := p.loadIdentities()
+	if err != nil {
+		return nil, err
+	}
+	r, err := age.Decrypt(bytes.NewReader(wrappedKey), identities...)
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
+
+func (p *ageProvider) loadIdentities() ([]age.Identity, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.identities != nil {
+		return p.identities, nil
+	}
+	if p.identityFile == "" {
+		return nil, fmt.Errorf("no age identity file configured; set %s", EnvAgeIdentityFile)
+	}
+	f, err := os.Open(p.identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("error opening age identity file: %w", err)
+	}
+	defer f.Close()
+
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing age identity file %q: %w", p.identityFile, err)
+	}
+	p.identities = identities
+	return identities, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// awsKMSProvider wraps data keys with an AWS KMS key.
+type awsKMSProvider struct {
+	keyARN string
+	region string
+
+	mutex  sync.Mutex
+	client *kms.Client
+}
+
+var _ KeyProvider = &awsKMSProvider{}
+
+func newAWSKMSProvider(spec *kops.AWSKMSEncryptionSpec) (*awsKMSProvider, error) {
+	parsed, err := arn.Parse(spec.KeyARN)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing KMS key ARN %q: %w", spec.KeyARN, err)
+	}
+	return &awsKMSProvider{
+		keyARN: spec.KeyARN,
+		region: parsed.Region,
+	}, nil
+}
+
+// Name implements KeyProvider::Name
+func (p *awsKMSProvider) Name() string {
+	return "aws-kms"
+}
+
+func (p *awsKMSProvider) kmsClient(ctx context.Context) (*kms.Client, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.client == nil {
+		config, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(p.region))
+		if err != nil {
+			return nil, fmt.Errorf("error loading AWS config: %w", err)
+		}
+		p.client = kms.NewFromConfig(config)
+	}
+	return p.client, nil
+}
+
+// WrapKey implements KeyProvider::WrapKey
+func (p *awsKMSProvider) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	client, err := p.kmsClient(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	response, err := client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(p.keyARN),
+		Plaintext: dataKey,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return aws.ToString(response.KeyId), response.CiphertextBlob, nil
+}
+
+// UnwrapKey implements KeyProvider::UnwrapKey
+func (p *awsKMSProvider) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	client, err := p.kmsClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if keyID == "" {
+		keyID = p.keyARN
+	}
+	response, err := client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: wrappedKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response.Plaintext, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azkeys"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// azureKeyVaultProvider wraps data keys with an Azure Key Vault RSA key.
+type azureKeyVaultProvider struct {
+	vaultURL   string
+	keyName    string
+	keyVersion string
+
+	mutex  sync.Mutex
+	client *azkeys.Client
+}
+
+var _ KeyProvider = &azureKeyVaultProvider{}
+
+func newAzureKeyVaultProvider(spec *kops.AzureKeyVaultEncryptionSpec) *azureKeyVaultProvider {
+	return &azureKeyVaultProvider{
+		vaultURL:   spec.VaultURL,
+		keyName:    spec.KeyName,
+		keyVersion: spec.KeyVersion,
+	}
+}
+
+// Name implements KeyProvider::Name
+func (p *azureKeyVaultProvider) Name() string {
+	return "azure-keyvault"
+}
+
+func (p *azureKeyVaultProvider) keysClient() (*azkeys.Client, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.client == nil {
+		credential, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Azure credential: %w", err)
+		}
+		client, err := azkeys.NewClient(p.vaultURL, credential, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error building Key Vault client: %w", err)
+		}
+		p.client = client
+	}
+	return p.client, nil
+}
+
+// WrapKey implements KeyProvider::WrapKey
+func (p *azureKeyVaultProvider) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	client, err := p.keysClient()
+	if err != nil {
+		return "", nil, err
+	}
+	response, err := client.WrapKey(ctx, p.keyName, p.keyVersion, azkeys.KeyOperationParameters{
+		Algorithm: to.Ptr(azkeys.EncryptionAlgorithmRSAOAEP256),
+		Value:     dataKey,
+	}, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	if response.KID == nil {
+		return "", nil, fmt.Errorf("key vault did not return the key identifier")
+	}
+	return string(*response.KID), response.Result, nil
+}
+
+// UnwrapKey implements KeyProvider::UnwrapKey
+// The data key is unwrapped with the key version that wrapped it, so keys can be rotated.
+func (p *azureKeyVaultProvider) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	client, err := p.keysClient()
+	if err != nil {
+		return nil, err
+	}
+	name, version := p.keyName, p.keyVersion
+	if keyID != "" {
+		id := azkeys.ID(keyID)
+		name, version = id.Name(), id.Version()
+	}
+	response, err := client.UnwrapKey(ctx, name, version, azkeys.KeyOperationParameters{
+		Algorithm: to.Ptr(azkeys.EncryptionAlgorithmRSAOAEP256),
+		Value:     wrappedKey,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return response.Result, nil
+}
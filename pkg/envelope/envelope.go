@@ -0,0 +1,235 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package envelope implements client-side envelope encryption of the objects in the keystore and secret store.
+//
+// Each object is encrypted with AES-256-GCM under its own randomly generated data key.
+// The data key is wrapped by a KeyProvider, such as a cloud KMS, and stored alongside the ciphertext,
+// so that reading an object requires both access to the state store and permission to use the key.
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// formatVersion identifies the format of encrypted objects.
+const formatVersion = "kops.k8s.io/envelope/v1"
+
+// sealedPrefix is the prefix of every encrypted object, used to tell encrypted objects from plaintext ones.
+var sealedPrefix = []byte(`{"envelope":"` + formatVersion + `"`)
+
+// dataKeySize is the size of the per-object AES-256 data keys.
+const dataKeySize = 32
+
+// KeyProvider wraps and unwraps data keys.
+type KeyProvider interface {
+	// Name identifies the kind of provider, such as aws-kms; it is recorded in every encrypted object.
+	Name() string
+	// WrapKey encrypts a data key, returning the identifier of the key that wrapped it and the wrapped key.
+	WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error)
+	// UnwrapKey decrypts a data key wrapped by WrapKey with the identified key.
+	UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+// sealedObject is the serialized form of an encrypted object.
+type sealedObject struct {
+	// Envelope is the format version; it must be the first field.
+	Envelope   string `json:"envelope"`
+	Provider   string `json:"provider"`
+	KeyID      string `json:"keyID,omitempty"`
+	WrappedKey []byte `json:"wrappedKey"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Encrypter encrypts and decrypts objects using a KeyProvider.
+// A nil Encrypter stores objects as plaintext, and refuses to read encrypted objects.
+type Encrypter struct {
+	provider KeyProvider
+}
+
+// NewEncrypter builds an Encrypter that wraps data keys with the provider.
+func NewEncrypter(provider KeyProvider) *Encrypter {
+	return &Encrypter{provider: provider}
+}
+
+// New builds the Encrypter for a config store encryption spec.
+// A nil Encrypter is returned if spec is nil.
+// Clients for the key provider are created when first used, so this does not make any remote calls.
+func New(spec *kops.ConfigStoreEncryptionSpec) (*Encrypter, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	switch {
+	case spec.Age != nil:
+		provider, err := newAgeProvider(spec.Age)
+		if err != nil {
+			return nil, err
+		}
+		return NewEncrypter(provider), nil
+	case spec.AWSKMS != nil:
+		provider, err := newAWSKMSProvider(spec.AWSKMS)
+		if err != nil {
+			return nil, err
+		}
+		return NewEncrypter(provider), nil
+	case spec.GCPKMS != nil:
+		return NewEncrypter(newGCPKMSProvider(spec.GCPKMS)), nil
+	case spec.AzureKeyVault != nil:
+		return NewEncrypter(newAzureKeyVaultProvider(spec.AzureKeyVault)), nil
+	default:
+		return nil, fmt.Errorf("no key provider configured for config store encryption")
+	}
+}
+
+// Enabled returns true if objects are encrypted.
+func (e *Encrypter) Enabled() bool {
+	return e != nil
+}
+
+// IsSealed returns true if data is an encrypted object.
+func IsSealed(data []byte) bool {
+	return bytes.HasPrefix(data, sealedPrefix)
+}
+
+// Seal encrypts plaintext under a new data key.
+// If the Encrypter is nil, the plaintext is returned unchanged.
+func (e *Encrypter) Seal(ctx context.Context, plaintext []byte) ([]byte, error) {
+	if e == nil {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, fmt.Errorf("error generating data key: %w", err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+
+	keyID, wrappedKey, err := e.provider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping data key with %s: %w", e.provider.Name(), err)
+	}
+
+	sealed := &sealedObject{
+		Envelope:   formatVersion,
+		Provider:   e.provider.Name(),
+		KeyID:      keyID,
+		WrappedKey: wrappedKey,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, []byte(formatVersion)),
+	}
+	data, err := json.Marshal(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing encrypted object: %w", err)
+	}
+	return data, nil
+}
+
+// Open decrypts data encrypted by Seal.
+// Data that is not encrypted is returned unchanged, so that objects written before encryption was enabled can still be read.
+func (e *Encrypter) Open(ctx context.Context, data []byte) ([]byte, error) {
+	if !IsSealed(data) {
+		return data, nil
+	}
+
+	sealed := &sealedObject{}
+	if err := json.Unmarshal(data, sealed); err != nil {
+		return nil, fmt.Errorf("error parsing encrypted object: %w", err)
+	}
+	if e == nil {
+		return nil, fmt.Errorf("object is encrypted with %s, but config store encryption is not configured", sealed.Provider)
+	}
+	if sealed.Provider != e.provider.Name() {
+		return nil, fmt.Errorf("object is encrypted with %s, but %s is configured", sealed.Provider, e.provider.Name())
+	}
+
+	dataKey, err := e.provider.UnwrapKey(ctx, sealed.KeyID, sealed.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("error unwrapping data key with %s: %w", sealed.Provider, err)
+	}
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted object has invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, []byte(formatVersion))
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting object: %w", err)
+	}
+	return plaintext, nil
+}
+
+// WriteFile encrypts data, if the Encrypter is not nil, and writes it to p.
+func (e *Encrypter) WriteFile(ctx context.Context, p vfs.Path, data []byte, acl vfs.ACL) error {
+	sealed, err := e.Seal(ctx, data)
+	if err != nil {
+		return fmt.Errorf("error encrypting %s: %w", p, err)
+	}
+	return p.WriteFile(ctx, bytes.NewReader(sealed), acl)
+}
+
+// CreateFile encrypts data, if the Encrypter is not nil, and writes it to p, failing if p already exists.
+func (e *Encrypter) CreateFile(ctx context.Context, p vfs.Path, data []byte, acl vfs.ACL) error {
+	sealed, err := e.Seal(ctx, data)
+	if err != nil {
+		return fmt.Errorf("error encrypting %s: %w", p, err)
+	}
+	return p.CreateFile(ctx, bytes.NewReader(sealed), acl)
+}
+
+func newAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != dataKeySize {
+		return nil, fmt.Errorf("data key has unexpected length %d", len(dataKey))
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("error building cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error building cipher: %w", err)
+	}
+	return aead, nil
+}
+
+// ForCluster builds the Encrypter for the cluster's config store.
+// A nil Encrypter is returned if cluster is nil or does not enable encryption.
+func ForCluster(cluster *kops.Cluster) (*Encrypter, error) {
+	if cluster == nil {
+		return nil, nil
+	}
+	return New(cluster.Spec.ConfigStore.Encryption)
+}
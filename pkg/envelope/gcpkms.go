@@ -0,0 +1,101 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package envelope
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// gcpKMSProvider wraps data keys with a Google Cloud KMS key.
+type gcpKMSProvider struct {
+	keyName string
+
+	mutex   sync.Mutex
+	service *cloudkms.Service
+}
+
+var _ KeyProvider = &gcpKMSProvider{}
+
+func newGCPKMSProvider(spec *kops.GCPKMSEncryptionSpec) *gcpKMSProvider {
+	return &gcpKMSProvider{
+		keyName: spec.KeyName,
+	}
+}
+
+// Name implements KeyProvider::Name
+func (p *gcpKMSProvider) Name() string {
+	return "gcp-kms"
+}
+
+func (p *gcpKMSProvider) cryptoKeys(ctx context.Context) (*cloudkms.ProjectsLocationsKeyRingsCryptoKeysService, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.service == nil {
+		service, err := cloudkms.NewService(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error building Cloud KMS client: %w", err)
+		}
+		p.service = service
+	}
+	return p.service.Projects.Locations.KeyRings.CryptoKeys, nil
+}
+
+// WrapKey implements KeyProvider::WrapKey
+func (p *gcpKMSProvider) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	cryptoKeys, err := p.cryptoKeys(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	response, err := cryptoKeys.Encrypt(p.keyName, &cloudkms.EncryptRequest{
+		Plaintext: base64.StdEncoding.EncodeToString(dataKey),
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", nil, err
+	}
+	wrappedKey, err := base64.StdEncoding.DecodeString(response.Ciphertext)
+	if err != nil {
+		return "", nil, fmt.Errorf("error decoding ciphertext: %w", err)
+	}
+	return response.Name, wrappedKey, nil
+}
+
+// UnwrapKey implements KeyProvider::UnwrapKey
+// Cloud KMS finds the key version from the ciphertext, so decryption always uses the configured key.
+func (p *gcpKMSProvider) UnwrapKey(ctx context.Context, keyID string, wrappedKey []byte) ([]byte, error) {
+	cryptoKeys, err := p.cryptoKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := cryptoKeys.Decrypt(p.keyName, &cloudkms.DecryptRequest{
+		Ciphertext: base64.StdEncoding.EncodeToString(wrappedKey),
+	}).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(response.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding plaintext: %w", err)
+	}
+	return dataKey, nil
+}
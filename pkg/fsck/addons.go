@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsck
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"k8s.io/kops/channels/pkg/channels"
+	"k8s.io/kops/upup/pkg/fi/utils"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// checkBootstrapChannel checks that the manifests of the addons in the bootstrap channel exist and match their hashes.
+func (c *Checker) checkBootstrapChannel(ctx context.Context, p vfs.Path) {
+	data, ok := c.readFile(ctx, p)
+	if !ok {
+		return
+	}
+	fix := fmt.Sprintf("run \"kops update cluster %s --yes\" to regenerate the addons", c.Cluster.Name)
+
+	location, err := url.Parse(p.Path())
+	if err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("has an invalid location: %v", err), fix, nil)
+		return
+	}
+	addons, err := channels.ParseAddons("bootstrap", location, data)
+	if err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("is not a valid addons channel: %v", err), fix, nil)
+		return
+	}
+
+	for _, addon := range addons.APIObject.Spec.Addons {
+		if addon.Manifest == nil || addon.ManifestHash == "" {
+			continue
+		}
+		manifest := *addon.Manifest
+		if strings.Contains(manifest, "://") || strings.HasPrefix(manifest, "/") {
+			// Manifests outside the state store are not checked
+			continue
+		}
+
+		manifestPath := c.configBase.Join("addons", manifest)
+		manifestData, err := manifestPath.ReadFile(ctx)
+		if err != nil {
+			if os.IsNotExist(err) {
+				c.report(manifestPath, SeverityError, fmt.Sprintf("manifest of addon %q is missing", fromPtr(addon.Name)), fix, nil)
+			} else {
+				c.report(manifestPath, SeverityError, fmt.Sprintf("cannot be read: %v", err), "check the permissions of the state store", nil)
+			}
+			continue
+		}
+
+		manifestHash, err := utils.HashString(string(manifestData))
+		if err != nil {
+			c.report(manifestPath, SeverityError, fmt.Sprintf("cannot be hashed: %v", err), fix, nil)
+			continue
+		}
+		if manifestHash != addon.ManifestHash {
+			c.report(manifestPath, SeverityError, fmt.Sprintf("manifest of addon %q has hash %s, but the bootstrap channel expects %s", fromPtr(addon.Name), manifestHash, addon.ManifestHash), fix, nil)
+		}
+	}
+}
+
+func fromPtr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fsck checks the integrity of a cluster's files in the state store.
+package fsck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/kops/registry"
+	"k8s.io/kops/pkg/apis/kops/util"
+	"k8s.io/kops/pkg/apis/kops/v1alpha2"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/pkg/client/simple"
+	"k8s.io/kops/pkg/envelope"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/sshcredentials"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+	"sigs.k8s.io/yaml"
+)
+
+// Severity is how serious a Problem is.
+type Severity string
+
+const (
+	// SeverityError is a problem that breaks the cluster, or will break it when it is next updated.
+	SeverityError Severity = "Error"
+	// SeverityWarning is a problem that does not break the cluster yet.
+	SeverityWarning Severity = "Warning"
+)
+
+// certificateExpiryWarning is how long before a certificate expires that it is reported.
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+// Problem is an integrity problem found in the state store.
+type Problem struct {
+	// Path is the file with the problem.
+	Path string
+	// Severity is how serious the problem is.
+	Severity Severity
+	// Message describes the problem.
+	Message string
+	// Fix suggests how to fix the problem.
+	Fix string
+
+	// repair fixes the problem, if it can be fixed safely.
+	repair func(ctx context.Context) error
+}
+
+// Repairable returns true if Repair can fix the problem.
+func (p *Problem) Repairable() bool {
+	return p.repair != nil
+}
+
+// Repair fixes the problem in the state store.
+func (p *Problem) Repair(ctx context.Context) error {
+	if p.repair == nil {
+		return fmt.Errorf("problem with %s cannot be repaired automatically", p.Path)
+	}
+	return p.repair(ctx)
+}
+
+// Checker checks a cluster's files in the state store.
+// It only reads from the state store; problems are fixed by Problem.Repair.
+type Checker struct {
+	Clientset simple.Clientset
+	Cluster   *kops.Cluster
+
+	// Now is the time used to check certificate expiry; it defaults to the current time.
+	Now time.Time
+
+	configBase vfs.Path
+	keystore   vfs.Path
+	secrets    vfs.Path
+	encrypter  *envelope.Encrypter
+
+	problems []*Problem
+}
+
+// Check walks the cluster's files in the state store, and returns the problems found.
+func (c *Checker) Check(ctx context.Context) ([]*Problem, error) {
+	if c.Now.IsZero() {
+		c.Now = time.Now()
+	}
+	c.problems = nil
+
+	var err error
+	c.configBase, err = c.Clientset.ConfigBaseFor(c.Cluster)
+	if err != nil {
+		return nil, err
+	}
+	c.keystore = c.configBase.Join("pki")
+	if c.Cluster.Spec.ConfigStore.Keypairs != "" {
+		if c.keystore, err = c.Clientset.VFSContext().BuildVfsPath(c.Cluster.Spec.ConfigStore.Keypairs); err != nil {
+			return nil, err
+		}
+	}
+	c.secrets = c.configBase.Join("secrets")
+	if c.Cluster.Spec.ConfigStore.Secrets != "" {
+		if c.secrets, err = c.Clientset.VFSContext().BuildVfsPath(c.Cluster.Spec.ConfigStore.Secrets); err != nil {
+			return nil, err
+		}
+	}
+	c.encrypter, err = envelope.ForCluster(c.Cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := c.configBase.ReadTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %q: %w", c.configBase, err)
+	}
+	for _, store := range []vfs.Path{c.keystore, c.secrets} {
+		if isUnder(store, c.configBase) {
+			continue
+		}
+		storeFiles, err := store.ReadTree(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error listing %q: %w", store, err)
+		}
+		files = append(files, storeFiles...)
+	}
+
+	instanceGroups := map[string]bool{}
+	keysets := map[string]bool{}
+	secrets := map[string]bool{}
+	var completed *kops.Cluster
+	var bootstrapChannel vfs.Path
+	var igConfigs []vfs.Path
+
+	for _, file := range files {
+		switch {
+		case isUnder(file, c.keystore):
+			relativePath, _ := vfs.RelativePath(c.keystore, file)
+			tokens := strings.Split(relativePath, "/")
+			if len(tokens) == 3 && tokens[0] == "private" && tokens[2] == "keyset.yaml" {
+				keysets[tokens[1]] = true
+				c.checkKeyset(ctx, file, tokens[1])
+			} else if len(tokens) == 4 && tokens[0] == "ssh" && tokens[1] == "public" {
+				c.checkSSHPublicKey(ctx, file)
+			} else {
+				klog.V(2).Infof("ignoring unexpected file in keystore: %q", file)
+			}
+
+		case isUnder(file, c.secrets):
+			secrets[file.Base()] = true
+			c.checkSecret(ctx, file)
+
+		default:
+			relativePath, err := vfs.RelativePath(c.configBase, file)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case relativePath == registry.PathCluster:
+				c.checkClusterSpec(ctx, file)
+			case relativePath == registry.PathClusterCompleted:
+				completed = c.checkClusterSpec(ctx, file)
+			case strings.HasPrefix(relativePath, "instancegroup/"):
+				if c.checkInstanceGroup(ctx, file) {
+					instanceGroups[file.Base()] = true
+				}
+			case strings.HasPrefix(relativePath, "igconfig/"):
+				igConfigs = append(igConfigs, file)
+			case relativePath == "addons/bootstrap-channel.yaml":
+				bootstrapChannel = file
+			default:
+				klog.V(4).Infof("not checking %q", file)
+			}
+		}
+	}
+
+	for _, file := range igConfigs {
+		c.checkInstanceGroupConfig(ctx, file, instanceGroups)
+	}
+	if bootstrapChannel != nil {
+		c.checkBootstrapChannel(ctx, bootstrapChannel)
+	}
+	if completed != nil {
+		c.checkCompletedSpec(completed)
+
+		// Once the cluster has been applied, these must exist for nodes to join
+		for _, name := range []string{fi.CertificateIDCA, "service-account"} {
+			if !keysets[name] {
+				c.report(c.keystore.Join("private", name, "keyset.yaml"), SeverityError, fmt.Sprintf("keyset %q is missing", name),
+					"run \"kops update cluster --yes\" to create it", nil)
+			}
+		}
+		if completed.Spec.EncryptionConfig != nil && *completed.Spec.EncryptionConfig && !secrets["encryptionconfig"] {
+			c.report(c.secrets.Join("encryptionconfig"), SeverityError, "secret \"encryptionconfig\" is missing, but encryptionConfig is enabled",
+				"create it with \"kops create secret encryptionconfig\"", nil)
+		}
+	}
+
+	sort.SliceStable(c.problems, func(i, j int) bool {
+		return c.problems[i].Path < c.problems[j].Path
+	})
+	return c.problems, nil
+}
+
+func (c *Checker) report(p vfs.Path, severity Severity, message string, fix string, repair func(ctx context.Context) error) {
+	c.problems = append(c.problems, &Problem{
+		Path:     p.Path(),
+		Severity: severity,
+		Message:  message,
+		Fix:      fix,
+		repair:   repair,
+	})
+}
+
+// readFile reads a file, reporting a problem if it cannot be read.
+func (c *Checker) readFile(ctx context.Context, p vfs.Path) ([]byte, bool) {
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("cannot be read: %v", err), "check the permissions of the state store", nil)
+		return nil, false
+	}
+	return data, true
+}
+
+// decode decodes a kOps API object, reporting a problem if it is not valid or not of the expected type.
+func (c *Checker) decode(p vfs.Path, data []byte, kind string) (runtime.Object, bool) {
+	defaultReadVersion := v1alpha2.SchemeGroupVersion.WithKind(kind)
+	object, gvk, err := kopscodecs.Decode(data, &defaultReadVersion)
+	if err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("is not a valid %s: %v", kind, err), "restore the file from a backup or from the revision history", nil)
+		return nil, false
+	}
+	if gvk.Kind != kind {
+		c.report(p, SeverityError, fmt.Sprintf("is a %s, expected a %s", gvk.Kind, kind), "restore the file from a backup or from the revision history", nil)
+		return nil, false
+	}
+	return object, true
+}
+
+// checkClusterSpec checks the cluster spec or completed cluster spec.
+func (c *Checker) checkClusterSpec(ctx context.Context, p vfs.Path) *kops.Cluster {
+	data, ok := c.readFile(ctx, p)
+	if !ok {
+		return nil
+	}
+	object, ok := c.decode(p, data, "Cluster")
+	if !ok {
+		return nil
+	}
+	cluster := object.(*kops.Cluster)
+	if cluster.Name != c.Cluster.Name {
+		c.report(p, SeverityError, fmt.Sprintf("is for cluster %q, expected %q", cluster.Name, c.Cluster.Name), "restore the file from a backup", nil)
+		return nil
+	}
+	return cluster
+}
+
+// checkCompletedSpec checks that the completed cluster spec, which nodes read, is up to date with the cluster spec.
+func (c *Checker) checkCompletedSpec(completed *kops.Cluster) {
+	p := c.configBase.Join(registry.PathClusterCompleted)
+	fix := fmt.Sprintf("run \"kops update cluster %s --yes\" to regenerate it", c.Cluster.Name)
+
+	if completed.Generation < c.Cluster.Generation {
+		c.report(p, SeverityWarning, fmt.Sprintf("is from generation %d of the cluster spec, which is now at generation %d", completed.Generation, c.Cluster.Generation), fix, nil)
+		return
+	}
+	if _, err := util.ParseKubernetesVersion(c.Cluster.Spec.KubernetesVersion); err == nil {
+		if strings.TrimPrefix(completed.Spec.KubernetesVersion, "v") != strings.TrimPrefix(c.Cluster.Spec.KubernetesVersion, "v") {
+			c.report(p, SeverityWarning, fmt.Sprintf("has kubernetesVersion %q, but the cluster spec has %q", completed.Spec.KubernetesVersion, c.Cluster.Spec.KubernetesVersion), fix, nil)
+		}
+	}
+}
+
+// checkInstanceGroup checks an instance group, returning true if it is valid.
+func (c *Checker) checkInstanceGroup(ctx context.Context, p vfs.Path) bool {
+	data, ok := c.readFile(ctx, p)
+	if !ok {
+		return false
+	}
+	object, ok := c.decode(p, data, "InstanceGroup")
+	if !ok {
+		return false
+	}
+	ig := object.(*kops.InstanceGroup)
+	if ig.Name != p.Base() {
+		c.report(p, SeverityError, fmt.Sprintf("contains instance group %q", ig.Name), fmt.Sprintf("rename the file to %q", ig.Name), nil)
+		return false
+	}
+	return true
+}
+
+// checkInstanceGroupConfig checks the nodeup configuration of an instance group.
+func (c *Checker) checkInstanceGroupConfig(ctx context.Context, p vfs.Path, instanceGroups map[string]bool) {
+	relativePath, _ := vfs.RelativePath(c.configBase, p)
+	tokens := strings.Split(relativePath, "/")
+	if len(tokens) != 4 || tokens[3] != "nodeupconfig.yaml" {
+		klog.V(2).Infof("ignoring unexpected file in igconfig: %q", p)
+		return
+	}
+
+	name := tokens[2]
+	if !instanceGroups[name] {
+		c.report(p, SeverityWarning, fmt.Sprintf("is the configuration of instance group %q, which does not exist", name), "delete the file", func(ctx context.Context) error {
+			return p.Remove(ctx)
+		})
+		return
+	}
+
+	data, ok := c.readFile(ctx, p)
+	if !ok {
+		return
+	}
+	config := &nodeup.Config{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("is not a valid nodeup configuration: %v", err), fmt.Sprintf("run \"kops update cluster %s --yes\" to regenerate it", c.Cluster.Name), nil)
+	}
+}
+
+// checkSecret checks a secret can be read and parsed.
+func (c *Checker) checkSecret(ctx context.Context, p vfs.Path) {
+	data, ok := c.readFile(ctx, p)
+	if !ok {
+		return
+	}
+	data, err := c.encrypter.Open(ctx, data)
+	if err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("cannot be decrypted: %v", err), "check spec.configStore.encryption and access to the key", nil)
+		return
+	}
+	secret := &fi.Secret{}
+	if err := json.Unmarshal(data, secret); err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("is not a valid secret: %v", err), fmt.Sprintf("recreate it with \"kops create secret %s\"", p.Base()), nil)
+		return
+	}
+	if len(secret.Data) == 0 {
+		c.report(p, SeverityError, "secret is empty", fmt.Sprintf("recreate it with \"kops create secret %s\"", p.Base()), nil)
+	}
+}
+
+// checkSSHPublicKey checks an SSH public key can be parsed.
+func (c *Checker) checkSSHPublicKey(ctx context.Context, p vfs.Path) {
+	data, ok := c.readFile(ctx, p)
+	if !ok {
+		return
+	}
+	if _, err := sshcredentials.Fingerprint(strings.TrimSpace(string(data))); err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("is not a valid SSH public key: %v", err), "replace it with \"kops create sshpublickey\"", nil)
+	}
+}
+
+// isUnder returns true if p is base or a file under base.
+func isUnder(p vfs.Path, base vfs.Path) bool {
+	return p.Path() == base.Path() || strings.HasPrefix(p.Path(), strings.TrimSuffix(base.Path(), "/")+"/")
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsck
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/pkg/client/simple/vfsclientset"
+	"k8s.io/kops/pkg/kopscodecs"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/pkg/testutils"
+	"k8s.io/kops/pkg/testutils/testcontext"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func TestCheck(t *testing.T) {
+	ctx := testcontext.ForTest(t)
+
+	// memfs does not remove deleted files from listings, which repairs rely on
+	vfsContext := vfs.NewVFSContext()
+	basePath, err := vfsContext.BuildVfsPath(t.TempDir())
+	if err != nil {
+		t.Fatalf("error building state store path: %v", err)
+	}
+	clientset := vfsclientset.NewVFSClientset(vfsContext, basePath)
+
+	cluster := testutils.BuildMinimalCluster("example.com")
+	cluster.Spec.ConfigStore.Base = basePath.Join("example.com").Path()
+	cluster, err = clientset.CreateCluster(ctx, cluster)
+	if err != nil {
+		t.Fatalf("error creating cluster: %v", err)
+	}
+	nodes := testutils.BuildMinimalNodeInstanceGroup("nodes", "subnet-us-test-1a")
+	if _, err := clientset.InstanceGroupsFor(cluster).Create(ctx, &nodes, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("error creating instance group: %v", err)
+	}
+	configBase, err := clientset.ConfigBaseFor(cluster)
+	if err != nil {
+		t.Fatalf("error getting config base: %v", err)
+	}
+
+	checker := &Checker{Clientset: clientset, Cluster: cluster}
+	problems, err := checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("error checking state store: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("expected no problems in a new cluster, got %v", describe(problems))
+	}
+
+	// A keyset whose primary key has been lost, and which holds a certificate that is about to expire
+	cert, privateKey, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+		Type:     "ca",
+		Subject:  pkix.Name{CommonName: "etcd-clients-ca"},
+		Serial:   big.NewInt(1),
+		Validity: 10 * 24 * time.Hour,
+	}, nil)
+	if err != nil {
+		t.Fatalf("error issuing certificate: %v", err)
+	}
+	keyset, err := fi.NewKeyset(cert, privateKey)
+	if err != nil {
+		t.Fatalf("error building keyset: %v", err)
+	}
+	o, err := keyset.ToAPIObject("etcd-clients-ca")
+	if err != nil {
+		t.Fatalf("error serializing keyset: %v", err)
+	}
+	o.Spec.PrimaryID = ""
+	data, err := kopscodecs.ToVersionedYaml(o)
+	if err != nil {
+		t.Fatalf("error serializing keyset: %v", err)
+	}
+	keysetPath := configBase.Join("pki", "private", "etcd-clients-ca", "keyset.yaml")
+	if err := keysetPath.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		t.Fatalf("error writing keyset: %v", err)
+	}
+
+	// The configuration of an instance group that has been deleted
+	orphanPath := configBase.Join("igconfig", "node", "deleted", "nodeupconfig.yaml")
+	if err := orphanPath.WriteFile(ctx, bytes.NewReader([]byte("{}")), nil); err != nil {
+		t.Fatalf("error writing instance group config: %v", err)
+	}
+
+	// An instance group that cannot be parsed
+	if err := configBase.Join("instancegroup", "broken").WriteFile(ctx, bytes.NewReader([]byte("spec: [")), nil); err != nil {
+		t.Fatalf("error writing instance group: %v", err)
+	}
+
+	// An addon whose manifest has been changed
+	channel := "kind: Addons\nmetadata:\n  name: bootstrap\nspec:\n  addons:\n  - name: example\n    manifest: example/v1.yaml\n    manifestHash: 0123456789abcdef\n"
+	if err := configBase.Join("addons", "bootstrap-channel.yaml").WriteFile(ctx, bytes.NewReader([]byte(channel)), nil); err != nil {
+		t.Fatalf("error writing bootstrap channel: %v", err)
+	}
+	if err := configBase.Join("addons", "example", "v1.yaml").WriteFile(ctx, bytes.NewReader([]byte("kind: ConfigMap\n")), nil); err != nil {
+		t.Fatalf("error writing addon manifest: %v", err)
+	}
+
+	problems, err = checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("error checking state store: %v", err)
+	}
+	expected := []string{
+		configBase.Join("addons", "example", "v1.yaml").Path() + ": Error: manifest of addon \"example\" has hash",
+		orphanPath.Path() + ": Warning: is the configuration of instance group \"deleted\", which does not exist (repairable)",
+		configBase.Join("instancegroup", "broken").Path() + ": Error: is not a valid InstanceGroup",
+		keysetPath.Path() + ": Warning: key " + keyset.Primary.Id + " expires at",
+		keysetPath.Path() + ": Error: has no primary key (repairable)",
+	}
+	actual := describe(problems)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d problems, got %v", len(expected), actual)
+	}
+	for i := range expected {
+		prefix, suffix, _ := strings.Cut(expected[i], " (")
+		if !strings.HasPrefix(actual[i], prefix) || (suffix != "" && !strings.HasSuffix(actual[i], "(repairable)")) {
+			t.Errorf("problem %d: expected %q, got %q", i, expected[i], actual[i])
+		}
+	}
+
+	for _, problem := range problems {
+		if problem.Repairable() {
+			if err := problem.Repair(ctx); err != nil {
+				t.Fatalf("error repairing %s: %v", problem.Path, err)
+			}
+		}
+	}
+
+	if _, err := orphanPath.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Errorf("expected orphaned instance group config to be removed, got error %v", err)
+	}
+	problems, err = checker.Check(ctx)
+	if err != nil {
+		t.Fatalf("error checking state store: %v", err)
+	}
+	for _, problem := range problems {
+		if problem.Repairable() {
+			t.Errorf("expected problem to be repaired: %s", describe([]*Problem{problem}))
+		}
+	}
+	if len(problems) != 3 {
+		t.Errorf("expected the 3 problems that cannot be repaired to remain, got %v", describe(problems))
+	}
+}
+
+func describe(problems []*Problem) []string {
+	var descriptions []string
+	for _, problem := range problems {
+		description := problem.Path + ": " + string(problem.Severity) + ": " + problem.Message
+		if problem.Repairable() {
+			description += " (repairable)"
+		}
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
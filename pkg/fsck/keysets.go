@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsck
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/pki"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// checkKeyset checks that a keyset's certificates and private keys are valid, consistent and unexpired,
+// and that it has a usable primary key.
+func (c *Checker) checkKeyset(ctx context.Context, p vfs.Path, name string) {
+	data, ok := c.readFile(ctx, p)
+	if !ok {
+		return
+	}
+	data, err := c.encrypter.Open(ctx, data)
+	if err != nil {
+		c.report(p, SeverityError, fmt.Sprintf("cannot be decrypted: %v", err), "check spec.configStore.encryption and access to the key", nil)
+		return
+	}
+	object, ok := c.decode(p, data, "Keyset")
+	if !ok {
+		return
+	}
+	keyset := object.(*kops.Keyset)
+	if keyset.Name != name {
+		c.report(p, SeverityError, fmt.Sprintf("contains keyset %q", keyset.Name), "restore the file from a backup", nil)
+		return
+	}
+
+	rotate := fmt.Sprintf("rotate it with \"kops create keypair %s\" and \"kops promote keypair %s\"", name, name)
+	for i := range keyset.Spec.Keys {
+		item := &keyset.Spec.Keys[i]
+		if item.DistrustTimestamp != nil {
+			continue
+		}
+
+		var cert *pki.Certificate
+		if len(item.PublicMaterial) != 0 {
+			cert, err = pki.ParsePEMCertificate(item.PublicMaterial)
+			if err != nil {
+				c.report(p, SeverityError, fmt.Sprintf("key %s has an invalid certificate: %v", item.Id, err), fmt.Sprintf("distrust it with \"kops distrust keypair %s %s\"", name, item.Id), nil)
+				continue
+			}
+		} else {
+			c.report(p, SeverityError, fmt.Sprintf("key %s has no certificate", item.Id), fmt.Sprintf("distrust it with \"kops distrust keypair %s %s\"", name, item.Id), nil)
+			continue
+		}
+
+		if len(item.PrivateMaterial) != 0 {
+			privateKey, err := pki.ParsePEMPrivateKey(item.PrivateMaterial)
+			if err != nil {
+				c.report(p, SeverityError, fmt.Sprintf("key %s has an invalid private key: %v", item.Id, err), fmt.Sprintf("distrust it with \"kops distrust keypair %s %s\"", name, item.Id), nil)
+				continue
+			}
+			if publicKey, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !publicKey.Equal(privateKey.Key.Public()) {
+				c.report(p, SeverityError, fmt.Sprintf("key %s has a private key that does not match its certificate", item.Id), fmt.Sprintf("distrust it with \"kops distrust keypair %s %s\"", name, item.Id), nil)
+				continue
+			}
+		}
+
+		notAfter := cert.Certificate.NotAfter
+		if c.Now.After(notAfter) {
+			c.report(p, SeverityError, fmt.Sprintf("key %s expired at %s", item.Id, notAfter.UTC().Format("2006-01-02T15:04:05Z")), rotate, nil)
+		} else if notAfter.Sub(c.Now) < certificateExpiryWarning {
+			c.report(p, SeverityWarning, fmt.Sprintf("key %s expires at %s", item.Id, notAfter.UTC().Format("2006-01-02T15:04:05Z")), rotate, nil)
+		}
+	}
+
+	// The primary key is the one used to sign certificates, so it must be trusted and have a private key
+	primary := fi.FindPrimary(keyset)
+	switch {
+	case primary == nil:
+		c.report(p, SeverityError, "has no trusted key that can be the primary", rotate, nil)
+	case len(primary.PrivateMaterial) == 0:
+		if keyset.Spec.PrimaryID != primary.Id {
+			c.report(p, SeverityError, fmt.Sprintf("primary key %q is missing, and the newest trusted key has no private key", keyset.Spec.PrimaryID), rotate, nil)
+		} else {
+			c.report(p, SeverityError, fmt.Sprintf("primary key %s has no private key", primary.Id), rotate, nil)
+		}
+	case keyset.Spec.PrimaryID == "":
+		c.report(p, SeverityError, "has no primary key", fmt.Sprintf("set the primary key to the newest trusted key, %s", primary.Id), c.repairPrimary(name))
+	case keyset.Spec.PrimaryID != primary.Id:
+		c.report(p, SeverityError, fmt.Sprintf("primary key %q is missing or distrusted", keyset.Spec.PrimaryID), fmt.Sprintf("set the primary key to the newest trusted key, %s", primary.Id), c.repairPrimary(name))
+	}
+}
+
+// repairPrimary sets the primary key of a keyset to its newest trusted key.
+func (c *Checker) repairPrimary(name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		keystore, err := c.Clientset.KeyStore(c.Cluster)
+		if err != nil {
+			return err
+		}
+		// FindKeyset chooses the newest trusted key as the primary, if the recorded primary is not usable
+		keyset, err := keystore.FindKeyset(ctx, name)
+		if err != nil {
+			return err
+		}
+		if keyset == nil || keyset.Primary == nil {
+			return fmt.Errorf("keyset %q has no usable primary key", name)
+		}
+		return keystore.StoreKeyset(ctx, name, keyset)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+// CanaryFailedError is returned when the canary instances of an instance group
+// did not stay healthy for the soak duration.
+type CanaryFailedError struct {
+	group string
+	err   error
+}
+
+func (e *CanaryFailedError) Error() string {
+	return fmt.Sprintf("canary for InstanceGroup %q failed: %s", e.group, e.err.Error())
+}
+
+func (e *CanaryFailedError) Unwrap() error {
+	return e.err
+}
+
+// Is checks that a given error is a CanaryFailedError.
+func (e *CanaryFailedError) Is(err error) bool {
+	_, ok := err.(*CanaryFailedError)
+	return ok
+}
+
+const (
+	// canaryHTTPTimeout is the timeout for each HTTP health check request.
+	canaryHTTPTimeout = 10 * time.Second
+	// defaultCanaryFailureThreshold is the number of consecutive failed checks after which a canary fails, if not configured.
+	defaultCanaryFailureThreshold = 3
+)
+
+// canaryHTTPClient is the client for HTTP health checks; the timeout bounds a check even if the server stalls mid-response.
+var canaryHTTPClient = &http.Client{Timeout: canaryHTTPTimeout}
+
+// resolveCanaryCount returns the number of instances to replace as canaries,
+// or 0 if there should be no canary phase.
+func resolveCanaryCount(settings api.RollingUpdate, numUpdate int) int {
+	if settings.Canary == nil {
+		return 0
+	}
+
+	count := intstr.FromInt(1)
+	if settings.Canary.Count != nil {
+		count = *settings.Canary.Count
+	}
+	canaries, err := intstr.GetScaledValueFromIntOrPercent(&count, numUpdate, true)
+	if err != nil || canaries <= 0 || canaries >= numUpdate {
+		return 0
+	}
+	return canaries
+}
+
+// soakCanary checks the health of the cluster for the soak duration after the canaries have been replaced.
+// It fails once the checks have failed FailureThreshold times in a row, so that the remaining instances are left untouched,
+// and the canary passes only once the soak duration has elapsed and the most recent check passed.
+func (c *RollingUpdateCluster) soakCanary(group *cloudinstances.CloudInstanceGroup, canary *api.CanarySpec) error {
+	name := group.InstanceGroup.ObjectMeta.Name
+
+	var soakDuration time.Duration
+	if canary.SoakDuration != nil {
+		soakDuration = canary.SoakDuration.Duration
+	}
+	failureThreshold := defaultCanaryFailureThreshold
+	if canary.FailureThreshold != nil {
+		failureThreshold = int(*canary.FailureThreshold)
+	}
+
+	klog.Infof("Soaking canary instance(s) of InstanceGroup %q for %s.", name, soakDuration)
+	deadline := time.Now().Add(soakDuration)
+	failures := 0
+	for {
+		if err := c.checkCanaryHealth(group, canary); err != nil {
+			failures++
+			if failures >= failureThreshold {
+				klog.Errorf("Canary for InstanceGroup %q failed; not replacing the remaining instances: %v", name, err)
+				return &CanaryFailedError{group: name, err: err}
+			}
+			klog.Warningf("Canary check for InstanceGroup %q failed (%d of %d), will retry in %s: %v", name, failures, failureThreshold, c.ValidateTickDuration, err)
+		} else {
+			failures = 0
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 && failures == 0 {
+			break
+		}
+		if remaining <= 0 || remaining > c.ValidateTickDuration {
+			remaining = c.ValidateTickDuration
+		}
+
+		timer := time.NewTimer(remaining)
+		select {
+		case <-timer.C:
+		case <-c.Ctx.Done():
+			timer.Stop()
+			return context.Cause(c.Ctx)
+		}
+	}
+
+	klog.Infof("Canary for InstanceGroup %q passed.", name)
+	return nil
+}
+
+func (c *RollingUpdateCluster) checkCanaryHealth(group *cloudinstances.CloudInstanceGroup, canary *api.CanarySpec) error {
+	if !c.CloudOnly && !group.InstanceGroup.IsBastion() {
+		result, err := c.ClusterValidator.Validate()
+		if err != nil {
+			return fmt.Errorf("cluster validation: %w", err)
+		}
+		if hasFailureRelevantToGroup(result.Failures, group) {
+			var messages []string
+			for _, failure := range result.Failures {
+				messages = append(messages, failure.Message)
+			}
+			return fmt.Errorf("cluster validation: %s", strings.Join(messages, ", "))
+		}
+	}
+
+	for _, check := range canary.HealthChecks {
+		var err error
+		switch {
+		case check.Pods != nil:
+			err = c.checkCanaryPods(check.Pods)
+		case check.HTTP != nil:
+			err = c.checkCanaryHTTP(check.HTTP)
+		}
+		if err != nil {
+			return fmt.Errorf("health check %q: %w", check.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *RollingUpdateCluster) checkCanaryPods(check *api.CanaryPodsHealthCheck) error {
+	if c.K8sClient == nil {
+		return fmt.Errorf("pod health checks require a k8s client")
+	}
+
+	selector := labels.SelectorFromSet(check.Selector)
+	pods, err := c.K8sClient.CoreV1().Pods(check.Namespace).List(c.Ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return fmt.Errorf("error listing pods: %w", err)
+	}
+
+	var matching int
+	var notReady []string
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		matching++
+		if !isPodReady(pod) {
+			notReady = append(notReady, pod.Name)
+		}
+	}
+	if matching == 0 {
+		return fmt.Errorf("no pods in namespace %q match %q", check.Namespace, selector.String())
+	}
+	if len(notReady) != 0 {
+		return fmt.Errorf("pods in namespace %q are not ready: %s", check.Namespace, strings.Join(notReady, ", "))
+	}
+	return nil
+}
+
+func (c *RollingUpdateCluster) checkCanaryHTTP(check *api.CanaryHTTPHealthCheck) error {
+	req, err := http.NewRequestWithContext(c.Ctx, http.MethodGet, check.URL, nil)
+	if err != nil {
+		return fmt.Errorf("error building request: %w", err)
+	}
+	resp, err := canaryHTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error requesting %q: %w", check.URL, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %q from %q", resp.Status, check.URL)
+	}
+	return nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	testingclient "k8s.io/client-go/testing"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestResolveCanaryCount(t *testing.T) {
+	grid := []struct {
+		name      string
+		canary    *kopsapi.CanarySpec
+		numUpdate int
+		expected  int
+	}{
+		{
+			name:      "no canary",
+			numUpdate: 5,
+			expected:  0,
+		},
+		{
+			name:      "default count",
+			canary:    &kopsapi.CanarySpec{},
+			numUpdate: 5,
+			expected:  1,
+		},
+		{
+			name:      "count",
+			canary:    &kopsapi.CanarySpec{Count: fi.PtrTo(intstr.FromInt(2))},
+			numUpdate: 5,
+			expected:  2,
+		},
+		{
+			name:      "percentage rounds up",
+			canary:    &kopsapi.CanarySpec{Count: fi.PtrTo(intstr.FromString("10%"))},
+			numUpdate: 5,
+			expected:  1,
+		},
+		{
+			name:      "count covers every instance",
+			canary:    &kopsapi.CanarySpec{Count: fi.PtrTo(intstr.FromInt(5))},
+			numUpdate: 5,
+			expected:  0,
+		},
+		{
+			name:      "single instance",
+			canary:    &kopsapi.CanarySpec{},
+			numUpdate: 1,
+			expected:  0,
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			actual := resolveCanaryCount(kopsapi.RollingUpdate{Canary: g.canary}, g.numUpdate)
+			assert.Equal(t, g.expected, actual)
+		})
+	}
+}
+
+func TestRollingUpdateCanaryPasses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		Canary: &kopsapi.CanarySpec{
+			SoakDuration: &v1meta.Duration{Duration: 5 * time.Millisecond},
+			HealthChecks: []kopsapi.CanaryHealthCheck{
+				{Name: "app", HTTP: &kopsapi.CanaryHTTPHealthCheck{URL: server.URL}},
+			},
+		},
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+	assertGroupInstanceCount(t, cloud, "master-1", 0)
+	assertGroupInstanceCount(t, cloud, "bastion-1", 0)
+}
+
+func TestRollingUpdateCanaryFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		Canary: &kopsapi.CanarySpec{
+			SoakDuration: &v1meta.Duration{Duration: time.Hour},
+			HealthChecks: []kopsapi.CanaryHealthCheck{
+				{Name: "app", HTTP: &kopsapi.CanaryHTTPHealthCheck{URL: server.URL}},
+			},
+		},
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.Error(t, err, "rolling update")
+	assert.True(t, errors.Is(err, &CanaryFailedError{}), "canary failed error")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 2)
+	assertGroupInstanceCount(t, cloud, "master-1", 0)
+	assertGroupInstanceCount(t, cloud, "bastion-1", 0)
+
+	// Only the canary is tainted; the instances left after the failed canary stay schedulable
+	tainted := map[string]bool{}
+	for _, action := range c.K8sClient.(*fake.Clientset).Actions() {
+		if a, ok := action.(testingclient.PatchAction); ok && string(a.GetPatch()) == taintPatch {
+			tainted[a.GetName()] = true
+		}
+	}
+	taintedInGroup := 0
+	for _, instance := range groups["node-1"].NeedUpdate {
+		if tainted[instance.Node.Name] {
+			taintedInGroup++
+		}
+	}
+	assert.Equal(t, 1, taintedInGroup, "nodes tainted in node-1")
+}
+
+func TestRollingUpdateCanaryPodsNotReady(t *testing.T) {
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		Canary: &kopsapi.CanarySpec{
+			SoakDuration: &v1meta.Duration{Duration: time.Hour},
+			HealthChecks: []kopsapi.CanaryHealthCheck{
+				{
+					Name: "ingress",
+					Pods: &kopsapi.CanaryPodsHealthCheck{
+						Namespace: "ingress",
+						Selector:  map[string]string{"app": "ingress"},
+					},
+				},
+			},
+		},
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.True(t, errors.Is(err, &CanaryFailedError{}), "canary failed error")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 2)
+}
+
+func TestRollingUpdateCanaryToleratesTransientFailure(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		Canary: &kopsapi.CanarySpec{
+			SoakDuration: &v1meta.Duration{Duration: 5 * time.Millisecond},
+			HealthChecks: []kopsapi.CanaryHealthCheck{
+				{Name: "app", HTTP: &kopsapi.CanaryHTTPHealthCheck{URL: server.URL}},
+			},
+		},
+	}
+
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+}
+
+func TestSoakCanaryInterrupted(t *testing.T) {
+	c, cloud := getTestSetup()
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.Ctx = ctx
+	c.ValidateTickDuration = time.Hour
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	err := c.soakCanary(groups["node-1"], &kopsapi.CanarySpec{SoakDuration: &v1meta.Duration{Duration: time.Hour}})
+	assert.ErrorIs(t, err, context.Canceled)
+}
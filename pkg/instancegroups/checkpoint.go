@@ -0,0 +1,442 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// DefaultCheckpointLeaseDuration is the time after which a checkpoint that has not been renewed
+// is considered abandoned, and another rolling update may take it over.
+const DefaultCheckpointLeaseDuration = 5 * time.Minute
+
+// RollingUpdateCheckpoint is the progress of a rolling update, as persisted in the state store.
+type RollingUpdateCheckpoint struct {
+	// Holder identifies the process that owns the rolling update.
+	Holder string `json:"holder,omitempty"`
+	// StartTime is the time the rolling update was started.
+	StartTime time.Time `json:"startTime"`
+	// RenewTime is the time the holder last renewed its lease.
+	RenewTime time.Time `json:"renewTime"`
+	// LeaseDurationSeconds is how long the lease is valid after RenewTime.
+	LeaseDurationSeconds int64 `json:"leaseDurationSeconds"`
+	// CompletedGroups are the names of the instance groups that have been fully updated.
+	CompletedGroups []string `json:"completedGroups,omitempty"`
+	// InFlight are the instances that were being replaced.
+	InFlight []CheckpointInstance `json:"inFlight,omitempty"`
+	// Validated is true if the most recent cluster validation succeeded.
+	Validated bool `json:"validated"`
+	// ValidationTime is the time of the most recent cluster validation.
+	ValidationTime *time.Time `json:"validationTime,omitempty"`
+}
+
+// CheckpointInstance is an instance recorded in a RollingUpdateCheckpoint.
+type CheckpointInstance struct {
+	// ID is the cloud ID of the instance.
+	ID string `json:"id"`
+	// NodeName is the name of the kubernetes node, if the instance had registered.
+	NodeName string `json:"nodeName,omitempty"`
+	// InstanceGroup is the name of the instance group the instance belongs to.
+	InstanceGroup string `json:"instanceGroup"`
+}
+
+// leaseExpired returns true if the lease held by the checkpoint is no longer valid at the given time.
+func (c *RollingUpdateCheckpoint) leaseExpired(now time.Time) bool {
+	if c.Holder == "" {
+		return true
+	}
+	return now.After(c.RenewTime.Add(time.Duration(c.LeaseDurationSeconds) * time.Second))
+}
+
+// Checkpointer persists the progress of a rolling update to the state store.
+// The checkpoint doubles as a lease, so that two rolling updates cannot run against the same cluster at once.
+type Checkpointer struct {
+	path          vfs.Path
+	holder        string
+	leaseDuration time.Duration
+
+	// now is overridable for tests
+	now func() time.Time
+
+	mutex sync.Mutex
+	state *RollingUpdateCheckpoint
+	// version is the version of the checkpoint we last read or wrote, if the state store supports conditional writes
+	version string
+	// resumed is the progress of the interrupted rolling update we resumed, if any
+	resumed *RollingUpdateCheckpoint
+	// resumeValidated is true once the cluster has been validated after resuming
+	resumeValidated bool
+}
+
+// ErrLeaseLost is returned when another process has taken over the lease on the rolling update.
+var ErrLeaseLost = errors.New("lease on rolling update was lost")
+
+// NewCheckpointer builds a Checkpointer storing its state under the cluster's config base.
+// holder should uniquely identify this process.
+func NewCheckpointer(configBase vfs.Path, holder string) *Checkpointer {
+	return &Checkpointer{
+		path:          configBase.Join("rolling-update", "checkpoint.json"),
+		holder:        holder,
+		leaseDuration: DefaultCheckpointLeaseDuration,
+		now:           time.Now,
+	}
+}
+
+// DefaultCheckpointHolder returns an identity for the current process, for use with NewCheckpointer.
+func DefaultCheckpointHolder() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
+
+// Acquire takes the lease on the rolling update.
+// It fails if another process holds an unexpired lease.
+// If resume is true, the progress recorded by an interrupted rolling update is kept; otherwise it is discarded.
+func (c *Checkpointer) Acquire(ctx context.Context, resume bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+
+	existing, version, err := c.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := &RollingUpdateCheckpoint{
+		StartTime: now,
+	}
+	c.resumed = nil
+	c.resumeValidated = false
+	if existing != nil {
+		if existing.Holder != c.holder && !existing.leaseExpired(now) {
+			return fmt.Errorf("a rolling update of this cluster is already in progress (held by %q, renewed at %s); wait for it to finish or for its lease to expire", existing.Holder, existing.RenewTime.Format(time.RFC3339))
+		}
+		if resume {
+			klog.Infof("Resuming rolling update started at %s; %d instance group(s) already completed.", existing.StartTime.Format(time.RFC3339), len(existing.CompletedGroups))
+			resumed := *existing
+			c.resumed = &resumed
+			state = existing
+		} else if len(existing.CompletedGroups) != 0 || len(existing.InFlight) != 0 {
+			klog.Warningf("Discarding progress of the rolling update started at %s; use --resume to continue it instead.", existing.StartTime.Format(time.RFC3339))
+		}
+	} else if resume {
+		klog.Infof("No interrupted rolling update found; starting a new one.")
+	}
+
+	state.Holder = c.holder
+	state.RenewTime = now
+	state.LeaseDurationSeconds = int64(c.leaseDuration / time.Second)
+
+	if existing == nil {
+		// Use CreateFile so that two processes racing to start cannot both win.
+		data, err := json.Marshal(state)
+		if err != nil {
+			return fmt.Errorf("error serializing rolling update checkpoint: %w", err)
+		}
+		if err := c.path.CreateFile(ctx, bytes.NewReader(data), nil); err != nil {
+			if os.IsExist(err) {
+				return fmt.Errorf("a rolling update of this cluster was started concurrently")
+			}
+			return fmt.Errorf("error writing rolling update checkpoint %q: %w", c.path, err)
+		}
+		c.state = state
+		// Pick up the version we created, so that our later writes fail if the lease is taken over
+		if _, version, err = c.read(ctx); err != nil {
+			return err
+		}
+		c.version = version
+		return nil
+	}
+
+	// Taking over an expired lease is a conditional write against the version we read,
+	// so that of two processes taking it over at the same time only one wins.
+	c.state = state
+	c.version = version
+	if err := c.write(ctx); err != nil {
+		c.state = nil
+		if errors.Is(err, ErrLeaseLost) {
+			return fmt.Errorf("a rolling update of this cluster was started concurrently")
+		}
+		return err
+	}
+	return nil
+}
+
+// Renew extends the lease. It fails if the lease has been taken over by another process.
+func (c *Checkpointer) Renew(ctx context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == nil {
+		return fmt.Errorf("rolling update checkpoint has not been acquired")
+	}
+
+	existing, version, err := c.read(ctx)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("%w: checkpoint was removed", ErrLeaseLost)
+	}
+	if existing.Holder != c.holder {
+		return fmt.Errorf("%w: taken over by %q", ErrLeaseLost, existing.Holder)
+	}
+	if c.version != "" && version != c.version {
+		return fmt.Errorf("%w: checkpoint was modified by another process", ErrLeaseLost)
+	}
+
+	c.state.RenewTime = c.now()
+	return c.write(ctx)
+}
+
+// Release gives up the lease. If completed is true the checkpoint is removed,
+// otherwise the progress is kept so that a later run can resume it.
+func (c *Checkpointer) Release(ctx context.Context, completed bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == nil {
+		return nil
+	}
+
+	if completed {
+		// Don't remove the checkpoint of a process that took over our lease
+		existing, _, err := c.read(ctx)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.Holder != c.holder {
+			c.state = nil
+			return fmt.Errorf("%w: taken over by %q", ErrLeaseLost, existing.Holder)
+		}
+		if err := c.path.Remove(ctx); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("error removing rolling update checkpoint %q: %w", c.path, err)
+		}
+		c.state = nil
+		return nil
+	}
+
+	c.state.Holder = ""
+	err := c.write(ctx)
+	c.state = nil
+	return err
+}
+
+// IsGroupCompleted returns true if the named instance group was completed by this or a previous (resumed) run.
+func (c *Checkpointer) IsGroupCompleted(name string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == nil {
+		return false
+	}
+	for _, completed := range c.state.CompletedGroups {
+		if completed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupCompleted records that the named instance group has been fully updated.
+func (c *Checkpointer) GroupCompleted(ctx context.Context, name string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == nil {
+		return nil
+	}
+	for _, completed := range c.state.CompletedGroups {
+		if completed == name {
+			return nil
+		}
+	}
+	c.state.CompletedGroups = append(c.state.CompletedGroups, name)
+	sort.Strings(c.state.CompletedGroups)
+	return c.write(ctx)
+}
+
+// InstanceStarted records that the replacement of an instance has begun.
+func (c *Checkpointer) InstanceStarted(ctx context.Context, u *cloudinstances.CloudInstance) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == nil {
+		return nil
+	}
+	instance := CheckpointInstance{
+		ID: u.ID,
+	}
+	if u.Node != nil {
+		instance.NodeName = u.Node.Name
+	}
+	if u.CloudInstanceGroup != nil && u.CloudInstanceGroup.InstanceGroup != nil {
+		instance.InstanceGroup = u.CloudInstanceGroup.InstanceGroup.Name
+	}
+	c.state.InFlight = append(c.state.InFlight, instance)
+	c.state.Validated = false
+	return c.write(ctx)
+}
+
+// InstanceFinished records that an instance has been terminated.
+func (c *Checkpointer) InstanceFinished(ctx context.Context, id string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == nil {
+		return nil
+	}
+	var inFlight []CheckpointInstance
+	for _, instance := range c.state.InFlight {
+		if instance.ID != id {
+			inFlight = append(inFlight, instance)
+		}
+	}
+	c.state.InFlight = inFlight
+	return c.write(ctx)
+}
+
+// Validated records the result of a cluster validation.
+func (c *Checkpointer) Validated(ctx context.Context, validated bool) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == nil {
+		return nil
+	}
+	now := c.now()
+	c.state.Validated = validated
+	c.state.ValidationTime = &now
+	return c.write(ctx)
+}
+
+// ResumedInFlight returns the IDs of the instances of the named instance group
+// that an interrupted rolling update was replacing when it stopped, if we resumed it.
+func (c *Checkpointer) ResumedInFlight(group string) []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.resumed == nil {
+		return nil
+	}
+	var ids []string
+	for _, instance := range c.resumed.InFlight {
+		if instance.InstanceGroup == group {
+			ids = append(ids, instance.ID)
+		}
+	}
+	return ids
+}
+
+// TakeResumeValidation returns true if the interrupted rolling update we resumed stopped without a successful
+// validation of the cluster since it last replaced an instance, so the cluster must be validated fully before continuing.
+// It returns true at most once.
+func (c *Checkpointer) TakeResumeValidation() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.resumed == nil || c.resumeValidated {
+		return false
+	}
+	c.resumeValidated = true
+	if c.resumed.Validated && len(c.resumed.InFlight) == 0 {
+		return false
+	}
+	if c.resumed.ValidationTime != nil {
+		klog.Infof("The interrupted rolling update last validated the cluster at %s; validating it again before replacing more instances.", c.resumed.ValidationTime.Format(time.RFC3339))
+	}
+	return true
+}
+
+// State returns a copy of the current checkpoint, or nil if the lease is not held.
+func (c *Checkpointer) State() *RollingUpdateCheckpoint {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.state == nil {
+		return nil
+	}
+	state := *c.state
+	state.CompletedGroups = append([]string(nil), c.state.CompletedGroups...)
+	state.InFlight = append([]CheckpointInstance(nil), c.state.InFlight...)
+	return &state
+}
+
+// read returns the checkpoint in the state store, or nil if there is none,
+// along with its version if the state store supports conditional writes.
+func (c *Checkpointer) read(ctx context.Context) (*RollingUpdateCheckpoint, string, error) {
+	var data []byte
+	var version string
+	var err error
+	if conditional, ok := c.path.(vfs.HasConditionalWrite); ok {
+		data, version, err = conditional.ReadFileVersion(ctx)
+	} else {
+		data, err = c.path.ReadFile(ctx)
+	}
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "", nil
+		}
+		return nil, "", fmt.Errorf("error reading rolling update checkpoint %q: %w", c.path, err)
+	}
+
+	state := &RollingUpdateCheckpoint{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, "", fmt.Errorf("error parsing rolling update checkpoint %q: %w", c.path, err)
+	}
+	return state, version, nil
+}
+
+// write saves the checkpoint. If the state store supports conditional writes, the write fails with ErrLeaseLost
+// if anyone else has written the checkpoint since we last did, so that a process that lost its lease cannot overwrite the new holder's progress.
+func (c *Checkpointer) write(ctx context.Context) error {
+	data, err := json.Marshal(c.state)
+	if err != nil {
+		return fmt.Errorf("error serializing rolling update checkpoint: %w", err)
+	}
+	if conditional, ok := c.path.(vfs.HasConditionalWrite); ok && c.version != "" {
+		version, err := conditional.WriteFileIfVersion(ctx, bytes.NewReader(data), nil, c.version)
+		if err != nil {
+			if errors.Is(err, vfs.ErrVersionConflict) {
+				return fmt.Errorf("%w: checkpoint %q was modified by another process", ErrLeaseLost, c.path)
+			}
+			return fmt.Errorf("error writing rolling update checkpoint %q: %w", c.path, err)
+		}
+		c.version = version
+		return nil
+	}
+	if err := c.path.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		return fmt.Errorf("error writing rolling update checkpoint %q: %w", c.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+func newTestCheckpointer(base vfs.Path, holder string, now *time.Time) *Checkpointer {
+	c := NewCheckpointer(base, holder)
+	c.now = func() time.Time { return *now }
+	return c
+}
+
+func TestCheckpointLease(t *testing.T) {
+	ctx := context.TODO()
+	base := vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/test.k8s.local")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := newTestCheckpointer(base, "first", &now)
+	second := newTestCheckpointer(base, "second", &now)
+
+	require.NoError(t, first.Acquire(ctx, false))
+	assert.Error(t, second.Acquire(ctx, false), "second rolling update should not acquire a held lease")
+
+	now = now.Add(DefaultCheckpointLeaseDuration / 2)
+	require.NoError(t, first.Renew(ctx))
+
+	now = now.Add(DefaultCheckpointLeaseDuration / 2)
+	assert.Error(t, second.Acquire(ctx, false), "lease should still be held after renewal")
+
+	now = now.Add(2 * DefaultCheckpointLeaseDuration)
+	require.NoError(t, second.Acquire(ctx, false), "expired lease should be taken over")
+	assert.Error(t, first.Renew(ctx), "renewing a lease that was taken over should fail")
+}
+
+func TestCheckpointLeaseLost(t *testing.T) {
+	ctx := context.TODO()
+	base := vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/test.k8s.local")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := newTestCheckpointer(base, "first", &now)
+	require.NoError(t, first.Acquire(ctx, false))
+
+	now = now.Add(2 * DefaultCheckpointLeaseDuration)
+	second := newTestCheckpointer(base, "second", &now)
+	require.NoError(t, second.Acquire(ctx, false))
+
+	assert.ErrorIs(t, first.GroupCompleted(ctx, "master-1"), ErrLeaseLost, "a process that lost its lease should not record progress")
+	assert.ErrorIs(t, first.Renew(ctx), ErrLeaseLost)
+	assert.ErrorIs(t, first.Release(ctx, true), ErrLeaseLost, "a process that lost its lease should not remove the checkpoint")
+
+	require.NoError(t, second.GroupCompleted(ctx, "master-1"))
+	assert.Equal(t, "second", second.State().Holder)
+}
+
+func TestCheckpointConcurrentTakeover(t *testing.T) {
+	ctx := context.TODO()
+	base := vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/test.k8s.local")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	abandoned := newTestCheckpointer(base, "abandoned", &now)
+	require.NoError(t, abandoned.Acquire(ctx, false))
+	now = now.Add(2 * DefaultCheckpointLeaseDuration)
+
+	// Both processes have read the expired lease before either takes it over
+	first := newTestCheckpointer(base, "first", &now)
+	second := newTestCheckpointer(base, "second", &now)
+	_, version, err := first.read(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, second.Acquire(ctx, false))
+
+	first.state = &RollingUpdateCheckpoint{Holder: "first", RenewTime: now}
+	first.version = version
+	assert.ErrorIs(t, first.write(ctx), ErrLeaseLost, "the slower takeover should fail")
+}
+
+func TestCheckpointResumeState(t *testing.T) {
+	ctx := context.TODO()
+	base := vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/test.k8s.local")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := newTestCheckpointer(base, "first", &now)
+	require.NoError(t, first.Acquire(ctx, false))
+	require.NoError(t, first.Validated(ctx, true))
+	require.NoError(t, first.InstanceStarted(ctx, &cloudinstances.CloudInstance{
+		ID:                 "node-1a",
+		CloudInstanceGroup: &cloudinstances.CloudInstanceGroup{InstanceGroup: &kopsapi.InstanceGroup{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}},
+	}))
+	require.NoError(t, first.Release(ctx, false))
+
+	resumed := newTestCheckpointer(base, "resumed", &now)
+	require.NoError(t, resumed.Acquire(ctx, true))
+	assert.Equal(t, []string{"node-1a"}, resumed.ResumedInFlight("node-1"))
+	assert.Empty(t, resumed.ResumedInFlight("node-2"))
+	assert.True(t, resumed.TakeResumeValidation(), "an interrupted replacement should be validated before continuing")
+	assert.False(t, resumed.TakeResumeValidation(), "the cluster should be validated only once after resuming")
+
+	fresh := newTestCheckpointer(vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/other.k8s.local"), "fresh", &now)
+	require.NoError(t, fresh.Acquire(ctx, true))
+	assert.False(t, fresh.TakeResumeValidation())
+}
+
+func TestCheckpointResume(t *testing.T) {
+	ctx := context.TODO()
+	base := vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/test.k8s.local")
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first := newTestCheckpointer(base, "first", &now)
+	require.NoError(t, first.Acquire(ctx, false))
+	require.NoError(t, first.GroupCompleted(ctx, "master-1"))
+	require.NoError(t, first.Validated(ctx, true))
+	require.NoError(t, first.Release(ctx, false))
+
+	resumed := newTestCheckpointer(base, "resumed", &now)
+	require.NoError(t, resumed.Acquire(ctx, true))
+	assert.True(t, resumed.IsGroupCompleted("master-1"))
+	assert.False(t, resumed.IsGroupCompleted("node-1"))
+	require.NoError(t, resumed.Release(ctx, false))
+
+	restarted := newTestCheckpointer(base, "restarted", &now)
+	require.NoError(t, restarted.Acquire(ctx, false))
+	assert.False(t, restarted.IsGroupCompleted("master-1"), "progress should be discarded without resume")
+	require.NoError(t, restarted.Release(ctx, true))
+
+	_, err := base.Join("rolling-update", "checkpoint.json").ReadFile(ctx)
+	assert.Error(t, err, "checkpoint should be removed after a successful rolling update")
+}
+
+func TestRollingUpdateResumeSkipsCompletedGroups(t *testing.T) {
+	ctx := context.TODO()
+	base := vfs.NewMemFSPath(vfs.NewMemFSContext(), "memfs://tests/test.k8s.local")
+
+	{
+		checkpointer := NewCheckpointer(base, "previous")
+		require.NoError(t, checkpointer.Acquire(ctx, false))
+		require.NoError(t, checkpointer.GroupCompleted(ctx, "bastion-1"))
+		require.NoError(t, checkpointer.GroupCompleted(ctx, "master-1"))
+		require.NoError(t, checkpointer.Release(ctx, false))
+	}
+
+	c, cloud := getTestSetup()
+	c.Force = true
+	c.Checkpointer = NewCheckpointer(base, "current")
+	c.Options.Resume = true
+
+	groups := getGroups(c.K8sClient, cloud)
+	err := c.RollingUpdate(groups, nil)
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "bastion-1", 1)
+	assertGroupInstanceCount(t, cloud, "master-1", 2)
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+	assert.Nil(t, c.Checkpointer.State(), "lease should be released")
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/pkg/validation"
+)
+
+// EventType is the type of a rolling update event.
+type EventType string
+
+const (
+	// EventGroupStarted is emitted when the rolling update of an instance group starts.
+	EventGroupStarted EventType = "GroupStarted"
+	// EventInstanceTainted is emitted when the node of an instance needing update is tainted.
+	EventInstanceTainted EventType = "InstanceTainted"
+	// EventDrainStarted is emitted when draining the node of an instance starts.
+	EventDrainStarted EventType = "DrainStarted"
+	// EventDrainFinished is emitted when draining the node of an instance finishes, with Error set if it failed.
+	EventDrainFinished EventType = "DrainFinished"
+	// EventInstanceDetached is emitted when an instance is detached from its group to surge a replacement.
+	EventInstanceDetached EventType = "InstanceDetached"
+	// EventInstanceTerminated is emitted when an instance has been terminated.
+	EventInstanceTerminated EventType = "InstanceTerminated"
+	// EventValidation is emitted for each cluster validation attempt.
+	EventValidation EventType = "Validation"
+	// EventGroupCompleted is emitted when the rolling update of an instance group completes.
+	EventGroupCompleted EventType = "GroupCompleted"
+	// EventGroupFailed is emitted when the rolling update of an instance group fails.
+	EventGroupFailed EventType = "GroupFailed"
+)
+
+// Event is a step of a rolling update.
+type Event struct {
+	Time          time.Time `json:"time"`
+	Type          EventType `json:"type"`
+	InstanceGroup string    `json:"instanceGroup,omitempty"`
+	InstanceID    string    `json:"instanceID,omitempty"`
+	NodeName      string    `json:"nodeName,omitempty"`
+	// Valid is set on validation events.
+	Valid *bool `json:"valid,omitempty"`
+	// Failures are the validation failures, on validation events.
+	Failures []EventValidationFailure `json:"failures,omitempty"`
+	// Error is set if the step failed.
+	Error string `json:"error,omitempty"`
+}
+
+// EventValidationFailure is a cluster validation failure.
+type EventValidationFailure struct {
+	Kind          string `json:"kind,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Message       string `json:"message,omitempty"`
+	InstanceGroup string `json:"instanceGroup,omitempty"`
+}
+
+// EventSink receives rolling update events. Emit may be called concurrently.
+type EventSink interface {
+	Emit(event *Event)
+}
+
+type jsonEventSink struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONEventSink returns an EventSink that writes each event to w as a line of JSON.
+func NewJSONEventSink(w io.Writer) EventSink {
+	return &jsonEventSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *jsonEventSink) Emit(event *Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.encoder.Encode(event); err != nil {
+		klog.Warningf("failed to write rolling update event: %v", err)
+	}
+}
+
+// eventRecorder sends events to the EventSink and records them as OpenTelemetry spans:
+// a span for each instance group, with a child span for each instance being replaced.
+type eventRecorder struct {
+	sink EventSink
+
+	mutex         sync.Mutex
+	groupSpans    map[string]trace.Span
+	instanceSpans map[string]trace.Span
+}
+
+func (c *RollingUpdateCluster) recordEvent(event *Event) {
+	c.eventsOnce.Do(func() {
+		c.events = &eventRecorder{
+			sink:          c.EventSink,
+			groupSpans:    make(map[string]trace.Span),
+			instanceSpans: make(map[string]trace.Span),
+		}
+	})
+
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	c.events.record(c, event)
+}
+
+func (r *eventRecorder) record(c *RollingUpdateCluster, event *Event) {
+	r.recordSpan(c, event)
+	if r.sink != nil {
+		r.sink.Emit(event)
+	}
+}
+
+func (r *eventRecorder) recordSpan(c *RollingUpdateCluster, event *Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attributes := []attribute.KeyValue{attribute.String("instanceGroup", event.InstanceGroup)}
+	if event.InstanceID != "" {
+		attributes = append(attributes, attribute.String("instanceID", event.InstanceID))
+	}
+	if event.NodeName != "" {
+		attributes = append(attributes, attribute.String("nodeName", event.NodeName))
+	}
+	if event.Valid != nil {
+		attributes = append(attributes, attribute.Bool("valid", *event.Valid))
+	}
+	if event.Error != "" {
+		attributes = append(attributes, attribute.String("error", event.Error))
+	}
+	ctx := c.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	eventOptions := []trace.EventOption{trace.WithTimestamp(event.Time), trace.WithAttributes(attributes...)}
+
+	switch event.Type {
+	case EventGroupStarted:
+		_, span := tracer.Start(ctx, "RollingUpdate::InstanceGroup", trace.WithTimestamp(event.Time),
+			trace.WithAttributes(attribute.String("instanceGroup", event.InstanceGroup)))
+		r.groupSpans[event.InstanceGroup] = span
+		return
+
+	case EventGroupCompleted, EventGroupFailed:
+		for key, span := range r.instanceSpans {
+			if strings.HasPrefix(key, event.InstanceGroup+"/") {
+				span.End(trace.WithTimestamp(event.Time))
+				delete(r.instanceSpans, key)
+			}
+		}
+		if span := r.groupSpans[event.InstanceGroup]; span != nil {
+			if event.Type == EventGroupFailed {
+				span.SetStatus(codes.Error, event.Error)
+			}
+			span.End(trace.WithTimestamp(event.Time))
+			delete(r.groupSpans, event.InstanceGroup)
+		}
+		return
+	}
+
+	if event.InstanceID == "" {
+		if span := r.groupSpans[event.InstanceGroup]; span != nil {
+			span.AddEvent(string(event.Type), eventOptions...)
+		}
+		return
+	}
+
+	// The instance span covers replacing the instance, from detaching or draining it until it is terminated
+	key := event.InstanceGroup + "/" + event.InstanceID
+	span := r.instanceSpans[key]
+	if span == nil && event.Type != EventInstanceTainted {
+		if groupSpan := r.groupSpans[event.InstanceGroup]; groupSpan != nil {
+			ctx = trace.ContextWithSpan(ctx, groupSpan)
+		}
+		_, span = tracer.Start(ctx, "RollingUpdate::Instance", trace.WithTimestamp(event.Time), trace.WithAttributes(attributes...))
+		r.instanceSpans[key] = span
+	}
+	if span == nil {
+		span = r.groupSpans[event.InstanceGroup]
+	}
+	if span == nil {
+		return
+	}
+
+	span.AddEvent(string(event.Type), eventOptions...)
+	if event.Type == EventInstanceTerminated {
+		span.End(trace.WithTimestamp(event.Time))
+		delete(r.instanceSpans, key)
+	}
+}
+
+// instanceEvent builds an event for a step on an instance.
+func instanceEvent(eventType EventType, u *cloudinstances.CloudInstance) *Event {
+	event := &Event{
+		Type:       eventType,
+		InstanceID: u.ID,
+	}
+	if u.CloudInstanceGroup != nil && u.CloudInstanceGroup.InstanceGroup != nil {
+		event.InstanceGroup = u.CloudInstanceGroup.InstanceGroup.ObjectMeta.Name
+	}
+	if u.Node != nil {
+		event.NodeName = u.Node.Name
+	}
+	return event
+}
+
+// validationEvent builds an event for a cluster validation attempt.
+func validationEvent(group *cloudinstances.CloudInstanceGroup, result *validation.ValidationCluster, err error) *Event {
+	event := &Event{
+		Type:          EventValidation,
+		InstanceGroup: group.InstanceGroup.ObjectMeta.Name,
+	}
+	valid := err == nil && !hasFailureRelevantToGroup(result.Failures, group)
+	event.Valid = &valid
+	if err != nil {
+		event.Error = err.Error()
+	} else {
+		for _, failure := range result.Failures {
+			f := EventValidationFailure{
+				Kind:    failure.Kind,
+				Name:    failure.Name,
+				Message: failure.Message,
+			}
+			if failure.InstanceGroup != nil {
+				f.InstanceGroup = failure.InstanceGroup.ObjectMeta.Name
+			}
+			event.Failures = append(event.Failures, f)
+		}
+	}
+	return event
+}
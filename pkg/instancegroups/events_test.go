@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+)
+
+type recordingEventSink struct {
+	mutex  sync.Mutex
+	events []*Event
+}
+
+func (s *recordingEventSink) Emit(event *Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *recordingEventSink) countByType(group string) map[EventType]int {
+	counts := make(map[EventType]int)
+	for _, event := range s.events {
+		if event.InstanceGroup == group {
+			counts[event.Type]++
+		}
+	}
+	return counts
+}
+
+func TestRollingUpdateEvents(t *testing.T) {
+	c, cloud := getTestSetup()
+	sink := &recordingEventSink{}
+	c.EventSink = sink
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	require.NoError(t, err, "rolling update")
+
+	assert.Equal(t, map[EventType]int{
+		EventGroupStarted:       1,
+		EventInstanceTainted:    3,
+		EventDrainStarted:       3,
+		EventDrainFinished:      3,
+		EventInstanceTerminated: 3,
+		EventValidation:         7,
+		EventGroupCompleted:     1,
+	}, sink.countByType("node-1"))
+
+	assert.Equal(t, map[EventType]int{
+		EventGroupStarted:       1,
+		EventInstanceTerminated: 1,
+		EventValidation:         2,
+		EventGroupCompleted:     1,
+	}, sink.countByType("bastion-1"))
+
+	var nodeEvents []*Event
+	for _, event := range sink.events {
+		assert.False(t, event.Time.IsZero(), "event time")
+		if event.InstanceGroup == "node-1" {
+			nodeEvents = append(nodeEvents, event)
+		}
+	}
+	assert.Equal(t, EventGroupStarted, nodeEvents[0].Type, "first event")
+	assert.Equal(t, EventGroupCompleted, nodeEvents[len(nodeEvents)-1].Type, "last event")
+	for _, event := range nodeEvents {
+		if event.Type == EventDrainStarted {
+			assert.NotEmpty(t, event.InstanceID, "instance ID")
+			assert.Equal(t, event.InstanceID+".local", event.NodeName, "node name")
+		}
+	}
+}
+
+func TestRollingUpdateEventsValidationFailure(t *testing.T) {
+	c, cloud := getTestSetup()
+	sink := &recordingEventSink{}
+	c.EventSink = sink
+	c.ClusterValidator = &failingClusterValidator{}
+	c.ValidationTimeout = 0
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	require.Error(t, err, "rolling update")
+
+	var validation, failed *Event
+	for _, event := range sink.events {
+		switch event.Type {
+		case EventValidation:
+			validation = event
+		case EventGroupFailed:
+			failed = event
+		}
+	}
+	require.NotNil(t, validation, "validation event")
+	require.NotNil(t, validation.Valid, "valid")
+	assert.False(t, *validation.Valid, "valid")
+	assert.NotEmpty(t, validation.Failures, "validation failures")
+	require.NotNil(t, failed, "group failed event")
+	assert.Equal(t, "bastion-1", failed.InstanceGroup)
+	assert.NotEmpty(t, failed.Error)
+}
+
+func TestJSONEventSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONEventSink(&buf)
+	sink.Emit(&Event{
+		Time:          time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Type:          EventDrainStarted,
+		InstanceGroup: "nodes",
+		InstanceID:    "i-1",
+		NodeName:      "node-1",
+	})
+	sink.Emit(&Event{Type: EventGroupCompleted, InstanceGroup: "nodes"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, `{"time":"2024-01-01T00:00:00Z","type":"DrainStarted","instanceGroup":"nodes","instanceID":"i-1","nodeName":"node-1"}`, lines[0])
+
+	var event Event
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, EventGroupCompleted, event.Type)
+
+	// The kind of a validation failure must not be confused with the type of the event
+	buf.Reset()
+	valid := false
+	sink.Emit(&Event{
+		Time:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Type:     EventValidation,
+		Valid:    &valid,
+		Failures: []EventValidationFailure{{Kind: "Node", Name: "node-1", Message: "node \"node-1\" is not ready", InstanceGroup: "nodes"}},
+	})
+	assert.Equal(t, `{"time":"2024-01-01T00:00:00Z","type":"Validation","valid":false,"failures":[{"kind":"Node","name":"node-1","message":"node \"node-1\" is not ready","instanceGroup":"nodes"}]}`, strings.TrimSpace(buf.String()))
+}
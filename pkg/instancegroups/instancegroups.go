@@ -19,6 +19,7 @@ package instancegroups
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -116,17 +117,34 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 		return nil
 	}
 
-	if isBastion {
-		klog.V(3).Info("Not validating the cluster as instance is a bastion.")
-	} else if err = c.maybeValidate("", 1, group); err != nil {
+	c.recordEvent(&Event{Type: EventGroupStarted, InstanceGroup: group.InstanceGroup.ObjectMeta.Name})
+	defer func() {
+		event := &Event{Type: EventGroupCompleted, InstanceGroup: group.InstanceGroup.ObjectMeta.Name}
+		if err != nil {
+			event.Type = EventGroupFailed
+			event.Error = err.Error()
+		}
+		c.recordEvent(event)
+	}()
+
+	settings := resolveSettings(c.Cluster, group.InstanceGroup, numInstances)
+
+	if _, err := c.waitForMaintenanceWindow(group, settings); err != nil {
 		return err
 	}
 
-	if !c.CloudOnly {
-		err = c.taintAllNeedUpdate(group, update)
-		if err != nil {
-			return err
-		}
+	validateCount := 1
+	operation := ""
+	if c.Checkpointer != nil && c.Checkpointer.TakeResumeValidation() {
+		// The interrupted rolling update may have left the cluster mid-replacement
+		validateCount = c.ValidateCount
+		operation = " before resuming rolling update"
+	}
+
+	if isBastion {
+		klog.V(3).Info("Not validating the cluster as instance is a bastion.")
+	} else if err = c.maybeValidate(operation, validateCount, group); err != nil {
+		return err
 	}
 
 	nonWarmPool := []*cloudinstances.CloudInstance{}
@@ -144,42 +162,46 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 	}
 	update = nonWarmPool
 
-	settings := resolveSettings(c.Cluster, group.InstanceGroup, numInstances)
+	maxSurge, maxConcurrency := c.resolveConcurrency(group, settings, len(update))
 
-	runningDrains := 0
-	maxSurge := settings.MaxSurge.IntValue()
-
-	if maxSurge > len(update) {
-		maxSurge = len(update)
+	update = prioritizeUpdate(update)
+	if c.Checkpointer != nil {
+		update = prioritizeInFlight(update, c.Checkpointer.ResumedInFlight(group.InstanceGroup.ObjectMeta.Name))
 	}
 
-	maxConcurrency := maxSurge + settings.MaxUnavailable.IntValue()
+	if canaries := resolveCanaryCount(settings, len(update)); canaries > 0 && *settings.DrainAndTerminate {
+		// Canaries are replaced without surging, so that a failed canary leaves the remaining instances untouched
+		canaryConcurrency := maxConcurrency - maxSurge
+		if canaryConcurrency < 1 {
+			canaryConcurrency = 1
+		}
 
-	// Karpenter cannot surge
-	if group.InstanceGroup.Spec.Manager == api.InstanceManagerKarpenter {
-		maxSurge = 0
-	}
+		// The remaining instances are only tainted once the canaries pass, so a failed canary doesn't keep pods off them
+		if !c.CloudOnly {
+			if err := c.taintAllNeedUpdate(group, update[:canaries]); err != nil {
+				return err
+			}
+		}
 
-	if group.InstanceGroup.Spec.Role == api.InstanceGroupRoleControlPlane && maxSurge != 0 {
-		// Control plane nodes are incapable of surging because they rely on registering themselves through
-		// the local apiserver. That apiserver depends on the local etcd, which relies on being
-		// joined to the etcd cluster.
-		maxSurge = 0
-		maxConcurrency = settings.MaxUnavailable.IntValue()
-		if maxConcurrency == 0 {
-			maxConcurrency = 1
+		klog.Infof("Replacing %d canary instance(s) in InstanceGroup %q before the remaining %d.", canaries, group.InstanceGroup.Name, len(update)-canaries)
+		if err := c.replaceInstances(group, update[:canaries], settings, canaryConcurrency, noneReady, sleepAfterTerminate); err != nil {
+			return err
 		}
+		if err := c.soakCanary(group, settings.Canary); err != nil {
+			return err
+		}
+
+		update = update[canaries:]
+		noneReady = false
+		maxSurge, maxConcurrency = c.resolveConcurrency(group, settings, len(update))
 	}
 
-	if c.Interactive {
-		if maxSurge > 1 {
-			maxSurge = 1
+	if !c.CloudOnly {
+		if err := c.taintAllNeedUpdate(group, update); err != nil {
+			return err
 		}
-		maxConcurrency = 1
 	}
 
-	update = prioritizeUpdate(update)
-
 	if maxSurge > 0 && !c.CloudOnly {
 		skippedNodes := 0
 		for numSurge := 1; numSurge <= maxSurge; numSurge++ {
@@ -217,9 +239,30 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 		return nil
 	}
 
+	return c.replaceInstances(group, update, settings, maxConcurrency, noneReady, sleepAfterTerminate)
+}
+
+// replaceInstances drains and terminates the instances, at most maxConcurrency at a time,
+// validating the cluster as replacements come up.
+func (c *RollingUpdateCluster) replaceInstances(group *cloudinstances.CloudInstanceGroup, update []*cloudinstances.CloudInstance, settings api.RollingUpdate, maxConcurrency int, noneReady bool, sleepAfterTerminate time.Duration) (err error) {
+	runningDrains := 0
 	terminateChan := make(chan error, maxConcurrency)
 
 	for uIdx, u := range update {
+		if settings.MaintenanceWindow != nil {
+			open, _, err := maintenanceWindowState(settings.MaintenanceWindow, time.Now())
+			if err != nil {
+				return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
+			}
+			if !open {
+				err = c.pauseForMaintenanceWindow(group, settings, runningDrains, terminateChan)
+				runningDrains = 0
+				if err != nil {
+					return err
+				}
+			}
+		}
+
 		go func(m *cloudinstances.CloudInstance) {
 			terminateChan <- c.drainTerminateAndWait(m, sleepAfterTerminate)
 		}(u)
@@ -292,6 +335,75 @@ func (c *RollingUpdateCluster) rollingUpdateInstanceGroup(group *cloudinstances.
 	return nil
 }
 
+// resolveConcurrency returns the number of instances to surge and the maximum number of instances
+// to drain concurrently, when updating the given number of instances in the group.
+func (c *RollingUpdateCluster) resolveConcurrency(group *cloudinstances.CloudInstanceGroup, settings api.RollingUpdate, numUpdate int) (maxSurge int, maxConcurrency int) {
+	maxSurge = settings.MaxSurge.IntValue()
+
+	if maxSurge > numUpdate {
+		maxSurge = numUpdate
+	}
+
+	maxConcurrency = maxSurge + settings.MaxUnavailable.IntValue()
+
+	// Karpenter cannot surge
+	if group.InstanceGroup.Spec.Manager == api.InstanceManagerKarpenter {
+		maxSurge = 0
+	}
+
+	if group.InstanceGroup.Spec.Role == api.InstanceGroupRoleControlPlane && maxSurge != 0 {
+		// Control plane nodes are incapable of surging because they rely on registering themselves through
+		// the local apiserver. That apiserver depends on the local etcd, which relies on being
+		// joined to the etcd cluster.
+		maxSurge = 0
+		maxConcurrency = settings.MaxUnavailable.IntValue()
+		if maxConcurrency == 0 {
+			maxConcurrency = 1
+		}
+	}
+
+	if c.Interactive {
+		if maxSurge > 1 {
+			maxSurge = 1
+		}
+		maxConcurrency = 1
+	}
+
+	return maxSurge, maxConcurrency
+}
+
+// pauseForMaintenanceWindow finishes the drains in progress, then waits for the group's maintenance window to reopen.
+func (c *RollingUpdateCluster) pauseForMaintenanceWindow(group *cloudinstances.CloudInstanceGroup, settings api.RollingUpdate, runningDrains int, terminateChan chan error) error {
+	if runningDrains > 0 {
+		klog.Infof("Maintenance window for InstanceGroup %q has closed; finishing the %d instance(s) being replaced.", group.InstanceGroup.ObjectMeta.Name, runningDrains)
+		for runningDrains > 0 {
+			err := <-terminateChan
+			runningDrains--
+			if err != nil {
+				return waitForPendingBeforeReturningError(runningDrains, terminateChan, err)
+			}
+		}
+
+		if !group.InstanceGroup.IsBastion() {
+			if err := c.maybeValidate(" after terminating instance", c.ValidateCount, group); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := c.waitForMaintenanceWindow(group, settings); err != nil {
+		return err
+	}
+
+	if !group.InstanceGroup.IsBastion() {
+		// The cluster may have changed while we were paused
+		if err := c.maybeValidate(" after maintenance window opened", 1, group); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func prioritizeUpdate(update []*cloudinstances.CloudInstance) []*cloudinstances.CloudInstance {
 	// The priorities are, in order:
 	//   attached before detached
@@ -311,6 +423,30 @@ func prioritizeUpdate(update []*cloudinstances.CloudInstance) []*cloudinstances.
 	return result
 }
 
+// prioritizeInFlight moves the instances that an interrupted rolling update was replacing to the front,
+// as they may already be drained, preserving the order otherwise.
+func prioritizeInFlight(update []*cloudinstances.CloudInstance, inFlight []string) []*cloudinstances.CloudInstance {
+	if len(inFlight) == 0 {
+		return update
+	}
+	isInFlight := make(map[string]bool, len(inFlight))
+	for _, id := range inFlight {
+		isInFlight[id] = true
+	}
+
+	result := make([]*cloudinstances.CloudInstance, 0, len(update))
+	var rest []*cloudinstances.CloudInstance
+	for _, u := range update {
+		if isInFlight[u.ID] {
+			klog.Infof("Continuing replacement of instance %q, which was interrupted.", u.ID)
+			result = append(result, u)
+		} else {
+			rest = append(rest, u)
+		}
+	}
+	return append(result, rest...)
+}
+
 func waitForPendingBeforeReturningError(runningDrains int, terminateChan chan error, err error) error {
 	for runningDrains > 0 {
 		<-terminateChan
@@ -320,7 +456,7 @@ func waitForPendingBeforeReturningError(runningDrains int, terminateChan chan er
 }
 
 func (c *RollingUpdateCluster) taintAllNeedUpdate(group *cloudinstances.CloudInstanceGroup, update []*cloudinstances.CloudInstance) error {
-	var toTaint []*corev1.Node
+	var toTaint []*cloudinstances.CloudInstance
 	for _, u := range update {
 		if u.Node != nil && !u.Node.Spec.Unschedulable {
 			foundTaint := false
@@ -330,7 +466,7 @@ func (c *RollingUpdateCluster) taintAllNeedUpdate(group *cloudinstances.CloudIns
 				}
 			}
 			if !foundTaint {
-				toTaint = append(toTaint, u.Node)
+				toTaint = append(toTaint, u)
 			}
 		}
 	}
@@ -340,13 +476,16 @@ func (c *RollingUpdateCluster) taintAllNeedUpdate(group *cloudinstances.CloudIns
 			noun = "node"
 		}
 		klog.Infof("Tainting %d %s in %q instancegroup.", len(toTaint), noun, group.InstanceGroup.Name)
-		for _, n := range toTaint {
+		for _, u := range toTaint {
+			n := u.Node
 			if err := c.patchTaint(n); err != nil {
 				if c.FailOnDrainError {
 					return fmt.Errorf("failed to taint node %q: %v", n, err)
 				}
 				klog.Infof("Ignoring error tainting node %q: %v", n, err)
+				continue
 			}
+			c.recordEvent(instanceEvent(EventInstanceTainted, u))
 		}
 	}
 	return nil
@@ -422,6 +561,20 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInst
 
 	isBastion := u.CloudInstanceGroup.InstanceGroup.IsBastion()
 
+	// Never start replacing an instance once the rolling update has been stopped, for example because its lease was lost
+	if c.Ctx.Err() != nil {
+		return context.Cause(c.Ctx)
+	}
+
+	if c.Checkpointer != nil {
+		if err := c.Checkpointer.InstanceStarted(c.Ctx, u); err != nil {
+			if errors.Is(err, ErrLeaseLost) {
+				return err
+			}
+			klog.Warningf("failed to record progress for instance %q: %v", instanceID, err)
+		}
+	}
+
 	if isBastion {
 		// We don't want to validate for bastions - they aren't part of the cluster
 	} else if c.CloudOnly {
@@ -430,7 +583,14 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInst
 		if u.Node != nil {
 			klog.Infof("Draining the node: %q.", nodeName)
 
-			if err := c.drainNode(u); err != nil {
+			c.recordEvent(instanceEvent(EventDrainStarted, u))
+			err := c.drainNode(u)
+			drainFinished := instanceEvent(EventDrainFinished, u)
+			if err != nil {
+				drainFinished.Error = err.Error()
+			}
+			c.recordEvent(drainFinished)
+			if err != nil {
 				if c.FailOnDrainError {
 					return fmt.Errorf("failed to drain node %q: %v", nodeName, err)
 				}
@@ -460,6 +620,15 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInst
 		return err
 	}
 
+	if c.Checkpointer != nil {
+		if err := c.Checkpointer.InstanceFinished(c.Ctx, instanceID); err != nil {
+			if errors.Is(err, ErrLeaseLost) {
+				return err
+			}
+			klog.Warningf("failed to record progress for instance %q: %v", instanceID, err)
+		}
+	}
+
 	if err := c.reconcileInstanceGroup(); err != nil {
 		klog.Errorf("error reconciling instance group %q: %v", u.CloudInstanceGroup.HumanName, err)
 		return err
@@ -467,7 +636,13 @@ func (c *RollingUpdateCluster) drainTerminateAndWait(u *cloudinstances.CloudInst
 
 	// Wait for the minimum interval
 	klog.Infof("waiting for %v after terminating instance", sleepAfterTerminate)
-	time.Sleep(sleepAfterTerminate)
+	timer := time.NewTimer(sleepAfterTerminate)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-c.Ctx.Done():
+		return context.Cause(c.Ctx)
+	}
 
 	return nil
 }
@@ -507,7 +682,13 @@ func (c *RollingUpdateCluster) maybeValidate(operation string, validateCount int
 	} else {
 		klog.Info("Validating the cluster.")
 
-		if err := c.validateClusterWithTimeout(validateCount, group); err != nil {
+		err := c.validateClusterWithTimeout(validateCount, group)
+		if c.Checkpointer != nil {
+			if checkpointErr := c.Checkpointer.Validated(c.Ctx, err == nil); checkpointErr != nil {
+				klog.Warningf("failed to record validation result: %v", checkpointErr)
+			}
+		}
+		if err != nil {
 
 			if c.FailOnValidate {
 				klog.Errorf("Cluster did not validate within %s", c.ValidationTimeout)
@@ -538,6 +719,7 @@ func (c *RollingUpdateCluster) validateClusterWithTimeout(validateCount int, gro
 	for {
 		// Note that we validate at least once before checking the timeout, in case the cluster is healthy with a short timeout
 		result, err := c.ClusterValidator.Validate()
+		c.recordEvent(validationEvent(group, result, err))
 		if err == nil && !hasFailureRelevantToGroup(result.Failures, group) {
 			successCount++
 			if successCount >= validateCount {
@@ -618,6 +800,7 @@ func (c *RollingUpdateCluster) detachInstance(u *cloudinstances.CloudInstance) e
 		return fmt.Errorf("error detaching instance %q: %v", id, err)
 	}
 
+	c.recordEvent(instanceEvent(EventInstanceDetached, u))
 	return nil
 }
 
@@ -641,6 +824,7 @@ func (c *RollingUpdateCluster) deleteInstance(u *cloudinstances.CloudInstance) e
 		return fmt.Errorf("error deleting instance %q: %v", id, err)
 	}
 
+	c.recordEvent(instanceEvent(EventInstanceTerminated, u))
 	return nil
 }
 
@@ -0,0 +1,95 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/util/pkg/cron"
+)
+
+// maintenanceWindowState reports whether a maintenance window is open at the given time.
+// If no window is open, it returns the time at which the next one opens.
+func maintenanceWindowState(spec *api.MaintenanceWindowSpec, now time.Time) (open bool, nextOpen time.Time, err error) {
+	if spec == nil {
+		return true, time.Time{}, nil
+	}
+
+	loc := time.UTC
+	if spec.TimeZone != "" {
+		loc, err = time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid maintenance window time zone %q: %w", spec.TimeZone, err)
+		}
+	}
+	now = now.In(loc)
+
+	for _, window := range spec.Windows {
+		schedule, err := cron.Parse(window.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid maintenance window schedule: %w", err)
+		}
+
+		// The window is open if it last opened less than its duration ago
+		lastOpen := schedule.Next(now.Add(-window.Duration.Duration))
+		if !lastOpen.IsZero() && !lastOpen.After(now) {
+			return true, time.Time{}, nil
+		}
+
+		next := schedule.Next(now)
+		if !next.IsZero() && (nextOpen.IsZero() || next.Before(nextOpen)) {
+			nextOpen = next
+		}
+	}
+
+	if nextOpen.IsZero() {
+		return false, time.Time{}, fmt.Errorf("maintenance window never opens")
+	}
+	return false, nextOpen, nil
+}
+
+// waitForMaintenanceWindow blocks until a maintenance window for the instance group is open.
+// It returns true if it had to wait.
+func (c *RollingUpdateCluster) waitForMaintenanceWindow(group *cloudinstances.CloudInstanceGroup, settings api.RollingUpdate) (bool, error) {
+	waited := false
+	for {
+		open, nextOpen, err := maintenanceWindowState(settings.MaintenanceWindow, time.Now())
+		if err != nil {
+			return waited, fmt.Errorf("instance group %q: %w", group.InstanceGroup.ObjectMeta.Name, err)
+		}
+		if open {
+			if waited {
+				klog.Infof("Maintenance window for InstanceGroup %q is open; continuing rolling update.", group.InstanceGroup.ObjectMeta.Name)
+			}
+			return waited, nil
+		}
+
+		klog.Infof("Maintenance window for InstanceGroup %q is closed; pausing rolling update until it opens at %s.", group.InstanceGroup.ObjectMeta.Name, nextOpen.Format(time.RFC3339))
+		waited = true
+
+		select {
+		case <-c.Ctx.Done():
+			return waited, c.Ctx.Err()
+		case <-time.After(time.Until(nextOpen)):
+		}
+	}
+}
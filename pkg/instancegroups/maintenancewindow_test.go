@@ -0,0 +1,144 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+)
+
+func TestMaintenanceWindowState(t *testing.T) {
+	weeknights := &kopsapi.MaintenanceWindowSpec{
+		Windows: []kopsapi.MaintenanceWindow{
+			{Schedule: "0 22 * * mon-fri", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+		},
+	}
+
+	grid := []struct {
+		name         string
+		spec         *kopsapi.MaintenanceWindowSpec
+		now          string
+		expectedOpen bool
+		expectedNext string
+	}{
+		{
+			name:         "no window",
+			now:          "2024-01-01T12:00:00Z",
+			expectedOpen: true,
+		},
+		{
+			name:         "before window",
+			spec:         weeknights,
+			now:          "2024-01-01T21:59:00Z", // Monday
+			expectedNext: "2024-01-01T22:00:00Z",
+		},
+		{
+			name:         "window opening",
+			spec:         weeknights,
+			now:          "2024-01-01T22:00:00Z",
+			expectedOpen: true,
+		},
+		{
+			name:         "window open past midnight",
+			spec:         weeknights,
+			now:          "2024-01-02T01:59:00Z",
+			expectedOpen: true,
+		},
+		{
+			name:         "window closed",
+			spec:         weeknights,
+			now:          "2024-01-02T02:00:00Z",
+			expectedNext: "2024-01-02T22:00:00Z",
+		},
+		{
+			name:         "weekend",
+			spec:         weeknights,
+			now:          "2024-01-06T23:00:00Z", // Saturday
+			expectedNext: "2024-01-08T22:00:00Z",
+		},
+		{
+			name: "time zone",
+			spec: &kopsapi.MaintenanceWindowSpec{
+				TimeZone: "Asia/Tokyo",
+				Windows: []kopsapi.MaintenanceWindow{
+					{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			now:          "2024-01-01T17:30:00Z", // 02:30 in Tokyo
+			expectedOpen: true,
+		},
+		{
+			name: "earliest of several windows",
+			spec: &kopsapi.MaintenanceWindowSpec{
+				Windows: []kopsapi.MaintenanceWindow{
+					{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+					{Schedule: "0 6 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			now:          "2024-01-01T00:00:00Z",
+			expectedNext: "2024-01-01T06:00:00Z",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			if g.spec != nil && g.spec.TimeZone != "" {
+				if _, err := time.LoadLocation(g.spec.TimeZone); err != nil {
+					t.Skipf("time zone database not available: %v", err)
+				}
+			}
+
+			now, err := time.Parse(time.RFC3339, g.now)
+			if err != nil {
+				t.Fatalf("error parsing time: %v", err)
+			}
+
+			open, next, err := maintenanceWindowState(g.spec, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assert.Equal(t, g.expectedOpen, open, "open")
+			if g.expectedNext != "" {
+				assert.Equal(t, g.expectedNext, next.UTC().Format(time.RFC3339), "next open")
+			}
+		})
+	}
+}
+
+func TestRollingUpdateMaintenanceWindowOpen(t *testing.T) {
+	c, cloud := getTestSetup()
+	c.Cluster.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		MaintenanceWindow: &kopsapi.MaintenanceWindowSpec{
+			Windows: []kopsapi.MaintenanceWindow{
+				{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}},
+			},
+		},
+	}
+
+	groups := getGroupsAllNeedUpdate(c.K8sClient, cloud)
+	err := c.RollingUpdate(groups, &kopsapi.InstanceGroupList{})
+	assert.NoError(t, err, "rolling update")
+
+	assertGroupInstanceCount(t, cloud, "node-1", 0)
+	assertGroupInstanceCount(t, cloud, "node-2", 0)
+	assertGroupInstanceCount(t, cloud, "master-1", 0)
+	assertGroupInstanceCount(t, cloud, "bastion-1", 0)
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+)
+
+// RollingUpdatePlan is the result of simulating the drains a rolling update would perform.
+type RollingUpdatePlan struct {
+	Groups []*GroupPlan `json:"groups"`
+}
+
+// GroupPlan is the simulated rolling update of a single instance group.
+type GroupPlan struct {
+	// Name is the name of the instance group.
+	Name string `json:"name"`
+	// Role is the role of the instance group.
+	Role api.InstanceGroupRole `json:"role"`
+	// MaxSurge is the resolved number of instances that will be surged.
+	MaxSurge int `json:"maxSurge"`
+	// MaxUnavailable is the resolved number of instances that may be unavailable.
+	MaxUnavailable int `json:"maxUnavailable"`
+	// MaxConcurrency is the maximum number of nodes that will be drained at the same time.
+	MaxConcurrency int `json:"maxConcurrency"`
+	// Nodes are the instances that will be replaced, in the order they will be replaced.
+	Nodes []*NodePlan `json:"nodes,omitempty"`
+}
+
+// NodePlan is the simulated drain of a single instance.
+type NodePlan struct {
+	// InstanceID is the cloud ID of the instance.
+	InstanceID string `json:"instanceID"`
+	// NodeName is the name of the kubernetes node, if the instance is registered.
+	NodeName string `json:"nodeName,omitempty"`
+	// Batch is the index of the group of nodes this node is drained together with.
+	Batch int `json:"batch"`
+	// EvictedPods is the number of pods that draining the node will evict.
+	EvictedPods int `json:"evictedPods"`
+	// Blockers are the reasons the drain of this node is expected to block.
+	Blockers []*EvictionBlocker `json:"blockers,omitempty"`
+}
+
+// EvictionBlocker is a pod whose eviction is expected to be refused.
+type EvictionBlocker struct {
+	InstanceGroup       string `json:"instanceGroup"`
+	InstanceID          string `json:"instanceID"`
+	NodeName            string `json:"nodeName"`
+	Pod                 string `json:"pod"`
+	PodDisruptionBudget string `json:"podDisruptionBudget,omitempty"`
+	Reason              string `json:"reason"`
+}
+
+// Blockers returns all the eviction blockers found in the plan.
+func (p *RollingUpdatePlan) Blockers() []*EvictionBlocker {
+	var blockers []*EvictionBlocker
+	for _, group := range p.Groups {
+		for _, node := range group.Nodes {
+			blockers = append(blockers, node.Blockers...)
+		}
+	}
+	return blockers
+}
+
+// Plan simulates the drains that RollingUpdate would perform on the given groups, without changing anything.
+// It reports, for each node, the pods whose eviction is expected to be refused
+// because of the PodDisruptionBudgets currently in the cluster.
+func (c *RollingUpdateCluster) Plan(groups map[string]*cloudinstances.CloudInstanceGroup) (*RollingUpdatePlan, error) {
+	if c.K8sClient == nil {
+		return nil, fmt.Errorf("planning a rolling update requires a k8s client")
+	}
+
+	podList, err := c.K8sClient.CoreV1().Pods(metav1.NamespaceAll).List(c.Ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+	podsByNode := make(map[string][]*corev1.Pod)
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Spec.NodeName == "" || !isEvictedByDrain(pod) {
+			continue
+		}
+		podsByNode[pod.Spec.NodeName] = append(podsByNode[pod.Spec.NodeName], pod)
+	}
+
+	pdbList, err := c.K8sClient.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(c.Ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing PodDisruptionBudgets: %w", err)
+	}
+
+	plan := &RollingUpdatePlan{}
+	for _, role := range []api.InstanceGroupRole{api.InstanceGroupRoleControlPlane, api.InstanceGroupRoleAPIServer, api.InstanceGroupRoleNode} {
+		roleGroups := make(map[string]*cloudinstances.CloudInstanceGroup)
+		for k, group := range groups {
+			if group.InstanceGroup.Spec.Role == role {
+				roleGroups[k] = group
+			}
+		}
+		for _, k := range sortGroups(roleGroups) {
+			groupPlan, err := c.planInstanceGroup(roleGroups[k], podsByNode, pdbList.Items)
+			if err != nil {
+				return nil, err
+			}
+			if groupPlan != nil {
+				plan.Groups = append(plan.Groups, groupPlan)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+func (c *RollingUpdateCluster) planInstanceGroup(group *cloudinstances.CloudInstanceGroup, podsByNode map[string][]*corev1.Pod, pdbs []policyv1.PodDisruptionBudget) (*GroupPlan, error) {
+	numInstances := len(group.Ready) + len(group.NeedUpdate)
+	update := group.NeedUpdate
+	if c.Force {
+		update = append(update, group.Ready...)
+	}
+
+	var nonWarmPool []*cloudinstances.CloudInstance
+	for _, instance := range update {
+		if instance.State != cloudinstances.WarmPool {
+			nonWarmPool = append(nonWarmPool, instance)
+		}
+	}
+	update = prioritizeUpdate(nonWarmPool)
+	if len(update) == 0 {
+		return nil, nil
+	}
+
+	settings := resolveSettings(c.Cluster, group.InstanceGroup, numInstances)
+	maxSurge, maxConcurrency := c.resolveConcurrency(group, settings, len(update))
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	groupPlan := &GroupPlan{
+		Name:           group.InstanceGroup.ObjectMeta.Name,
+		Role:           group.InstanceGroup.Spec.Role,
+		MaxSurge:       maxSurge,
+		MaxUnavailable: settings.MaxUnavailable.IntValue(),
+		MaxConcurrency: maxConcurrency,
+	}
+
+	if !*settings.DrainAndTerminate {
+		return groupPlan, nil
+	}
+
+	for start := 0; start < len(update); start += maxConcurrency {
+		end := start + maxConcurrency
+		if end > len(update) {
+			end = len(update)
+		}
+		batch := start / maxConcurrency
+
+		// Pods matching each PodDisruptionBudget that would be evicted while this batch drains
+		evictions := make(map[*policyv1.PodDisruptionBudget][]plannedEviction)
+
+		for _, u := range update[start:end] {
+			nodePlan := &NodePlan{
+				InstanceID: u.ID,
+				Batch:      batch,
+			}
+			groupPlan.Nodes = append(groupPlan.Nodes, nodePlan)
+			if u.Node == nil {
+				continue
+			}
+			nodePlan.NodeName = u.Node.Name
+
+			for _, pod := range podsByNode[u.Node.Name] {
+				nodePlan.EvictedPods++
+
+				matching := matchingPodDisruptionBudgets(pod, pdbs)
+				if len(matching) > 1 {
+					nodePlan.Blockers = append(nodePlan.Blockers, newEvictionBlocker(group, nodePlan, pod, nil,
+						"pod is covered by more than one PodDisruptionBudget, which the eviction API does not support"))
+					continue
+				}
+				for _, pdb := range matching {
+					evictions[pdb] = append(evictions[pdb], plannedEviction{node: nodePlan, pod: pod})
+				}
+			}
+		}
+
+		for pdb, planned := range evictions {
+			allowed := int(pdb.Status.DisruptionsAllowed)
+			if len(planned) <= allowed {
+				continue
+			}
+
+			var reason string
+			if allowed == 0 {
+				reason = fmt.Sprintf("PodDisruptionBudget allows no disruptions (%d of %d desired pods healthy)", pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy)
+			} else {
+				reason = fmt.Sprintf("PodDisruptionBudget allows %d disruption(s), but %d matching pods would be evicted concurrently", allowed, len(planned))
+			}
+			for _, eviction := range planned {
+				eviction.node.Blockers = append(eviction.node.Blockers, newEvictionBlocker(group, eviction.node, eviction.pod, pdb, reason))
+			}
+		}
+	}
+
+	for _, nodePlan := range groupPlan.Nodes {
+		sort.Slice(nodePlan.Blockers, func(i, j int) bool {
+			return nodePlan.Blockers[i].Pod < nodePlan.Blockers[j].Pod
+		})
+	}
+
+	return groupPlan, nil
+}
+
+type plannedEviction struct {
+	node *NodePlan
+	pod  *corev1.Pod
+}
+
+func newEvictionBlocker(group *cloudinstances.CloudInstanceGroup, nodePlan *NodePlan, pod *corev1.Pod, pdb *policyv1.PodDisruptionBudget, reason string) *EvictionBlocker {
+	blocker := &EvictionBlocker{
+		InstanceGroup: group.InstanceGroup.ObjectMeta.Name,
+		InstanceID:    nodePlan.InstanceID,
+		NodeName:      nodePlan.NodeName,
+		Pod:           pod.Namespace + "/" + pod.Name,
+		Reason:        reason,
+	}
+	if pdb != nil {
+		blocker.PodDisruptionBudget = pdb.Namespace + "/" + pdb.Name
+	}
+	return blocker
+}
+
+// isEvictedByDrain returns true if draining the pod's node would evict the pod,
+// mirroring the filters applied by the drain helper as configured in drainNode.
+func isEvictedByDrain(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, found := pod.Annotations[corev1.MirrorPodAnnotationKey]; found {
+		return false
+	}
+	if controllerRef := metav1.GetControllerOf(pod); controllerRef != nil && controllerRef.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+func matchingPodDisruptionBudgets(pod *corev1.Pod, pdbs []policyv1.PodDisruptionBudget) []*policyv1.PodDisruptionBudget {
+	var matching []*policyv1.PodDisruptionBudget
+	for i := range pdbs {
+		pdb := &pdbs[i]
+		// As in the disruption controller, a nil selector matches nothing and an empty one matches every pod.
+		if pdb.Namespace != pod.Namespace || pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			matching = append(matching, pdb)
+		}
+	}
+	return matching
+}
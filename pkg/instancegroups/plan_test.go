@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroups
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/cloudinstances"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func addTestPod(t *testing.T, c *RollingUpdateCluster, name string, nodeName string, labels map[string]string) {
+	pod := &v1.Pod{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    labels,
+		},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+		},
+	}
+	require.NoError(t, c.K8sClient.(*fake.Clientset).Tracker().Add(pod))
+}
+
+func addTestPDB(t *testing.T, c *RollingUpdateCluster, name string, app string, disruptionsAllowed int32) {
+	minAvailable := intstr.FromInt(1)
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &v1meta.LabelSelector{
+				MatchLabels: map[string]string{"app": app},
+			},
+		},
+		Status: policyv1.PodDisruptionBudgetStatus{
+			DisruptionsAllowed: disruptionsAllowed,
+		},
+	}
+	require.NoError(t, c.K8sClient.(*fake.Clientset).Tracker().Add(pdb))
+}
+
+func TestPlanNoBlockers(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 3)
+	addTestPod(t, c, "web-a", "node-1a.local", map[string]string{"app": "web"})
+	addTestPod(t, c, "web-b", "node-1b.local", map[string]string{"app": "web"})
+	addTestPDB(t, c, "web", "web", 1)
+
+	plan, err := c.Plan(groups)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Groups, 1)
+	group := plan.Groups[0]
+	assert.Equal(t, 1, group.MaxConcurrency)
+	require.Len(t, group.Nodes, 3)
+	for i, node := range group.Nodes {
+		assert.Equal(t, i, node.Batch, "batch of node %q", node.NodeName)
+	}
+	assert.Empty(t, plan.Blockers())
+}
+
+func TestPlanBlockedByPDB(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 3)
+	addTestPod(t, c, "db-0", "node-1a.local", map[string]string{"app": "db"})
+	addTestPDB(t, c, "db", "db", 0)
+
+	plan, err := c.Plan(groups)
+	require.NoError(t, err)
+
+	blockers := plan.Blockers()
+	require.Len(t, blockers, 1)
+	assert.Equal(t, "node-1a.local", blockers[0].NodeName)
+	assert.Equal(t, "default/db-0", blockers[0].Pod)
+	assert.Equal(t, "default/db", blockers[0].PodDisruptionBudget)
+}
+
+func TestPlanBlockedByPDBWithEmptySelector(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 3, 3)
+	addTestPod(t, c, "web-a", "node-1a.local", map[string]string{"app": "web"})
+	minAvailable := intstr.FromInt(1)
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name:      "all",
+			Namespace: "default",
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector:     &v1meta.LabelSelector{},
+		},
+	}
+	require.NoError(t, c.K8sClient.(*fake.Clientset).Tracker().Add(pdb))
+
+	plan, err := c.Plan(groups)
+	require.NoError(t, err)
+
+	blockers := plan.Blockers()
+	require.Len(t, blockers, 1)
+	assert.Equal(t, "default/web-a", blockers[0].Pod)
+	assert.Equal(t, "default/all", blockers[0].PodDisruptionBudget)
+}
+
+func TestPlanBlockedByConcurrentDrains(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 4, 4)
+	maxUnavailable := intstr.FromInt(2)
+	maxSurge := intstr.FromInt(0)
+	groups["node-1"].InstanceGroup.Spec.RollingUpdate = &kopsapi.RollingUpdate{
+		MaxUnavailable: &maxUnavailable,
+		MaxSurge:       &maxSurge,
+	}
+	addTestPod(t, c, "web-a", "node-1a.local", map[string]string{"app": "web"})
+	addTestPod(t, c, "web-b", "node-1b.local", map[string]string{"app": "web"})
+	addTestPod(t, c, "web-c", "node-1c.local", map[string]string{"app": "web"})
+	addTestPDB(t, c, "web", "web", 1)
+
+	plan, err := c.Plan(groups)
+	require.NoError(t, err)
+
+	require.Len(t, plan.Groups, 1)
+	assert.Equal(t, 2, plan.Groups[0].MaxConcurrency)
+
+	// node-1a and node-1b drain together, which evicts two pods where only one disruption is allowed
+	var blockedNodes []string
+	for _, blocker := range plan.Blockers() {
+		blockedNodes = append(blockedNodes, blocker.NodeName)
+	}
+	assert.ElementsMatch(t, []string{"node-1a.local", "node-1b.local"}, blockedNodes)
+}
+
+func TestPlanIgnoresDaemonSetPods(t *testing.T) {
+	c, cloud := getTestSetup()
+
+	groups := make(map[string]*cloudinstances.CloudInstanceGroup)
+	makeGroup(groups, c.K8sClient, cloud, "node-1", kopsapi.InstanceGroupRoleNode, 1, 1)
+	pod := &v1.Pod{
+		ObjectMeta: v1meta.ObjectMeta{
+			Name:      "agent",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "agent"},
+			OwnerReferences: []v1meta.OwnerReference{
+				{Kind: "DaemonSet", Name: "agent", Controller: fi.PtrTo(true)},
+			},
+		},
+		Spec: v1.PodSpec{NodeName: "node-1a.local"},
+	}
+	require.NoError(t, c.K8sClient.(*fake.Clientset).Tracker().Add(pod))
+	addTestPDB(t, c, "agent", "agent", 0)
+
+	plan, err := c.Plan(groups)
+	require.NoError(t, err)
+	require.Len(t, plan.Groups, 1)
+	assert.Equal(t, 0, plan.Groups[0].Nodes[0].EvictedPods)
+	assert.Empty(t, plan.Blockers())
+}
@@ -83,6 +83,15 @@ type RollingUpdateCluster struct {
 	// DrainTimeout is the maximum amount of time to wait while draining a node.
 	DrainTimeout time.Duration
 
+	// Checkpointer persists progress to the state store, if set
+	Checkpointer *Checkpointer
+
+	// EventSink receives structured events for each step of the rolling update, if set
+	EventSink EventSink
+
+	eventsOnce sync.Once
+	events     *eventRecorder
+
 	// Options holds user-specified options
 	Options RollingUpdateOptions
 }
@@ -91,6 +100,9 @@ type RollingUpdateOptions struct {
 	// DeregisterControlPlaneNodes controls if we deregister control plane instances from load balacners etc before draining/terminating.
 	// When a cluster only has a single apiserver, we don't want to do this, as we can't drain after deregistering it.
 	DeregisterControlPlaneNodes bool
+
+	// Resume continues an interrupted rolling update from its checkpoint, instead of starting over.
+	Resume bool
 }
 
 func (o *RollingUpdateOptions) InitDefaults() {
@@ -112,6 +124,57 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		return nil
 	}
 
+	if c.Checkpointer == nil {
+		return c.rollingUpdate(groups)
+	}
+
+	if err := c.Checkpointer.Acquire(c.Ctx, c.Options.Resume); err != nil {
+		return err
+	}
+
+	// If we lose the lease another rolling update may start, so we must stop terminating instances
+	parentCtx := c.Ctx
+	ctx, cancel := context.WithCancelCause(parentCtx)
+	defer cancel(nil)
+	c.Ctx = ctx
+	defer func() { c.Ctx = parentCtx }()
+
+	stopRenewing := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(c.Checkpointer.leaseDuration / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopRenewing:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.Checkpointer.Renew(parentCtx); err != nil {
+					klog.Errorf("failed to renew rolling update lease, stopping rolling update: %v", err)
+					cancel(fmt.Errorf("failed to renew rolling update lease: %w", err))
+					return
+				}
+			}
+		}
+	}()
+
+	err := c.rollingUpdate(groups)
+	close(stopRenewing)
+
+	if cause := context.Cause(ctx); cause != nil && parentCtx.Err() == nil {
+		// The lease may now be held by another process, so leave the checkpoint alone
+		return cause
+	}
+
+	if releaseErr := c.Checkpointer.Release(parentCtx, err == nil); releaseErr != nil {
+		klog.Warningf("failed to release rolling update checkpoint: %v", releaseErr)
+	}
+	return err
+}
+
+func (c *RollingUpdateCluster) rollingUpdate(groups map[string]*cloudinstances.CloudInstanceGroup) error {
+
 	var resultsMutex sync.Mutex
 	results := make(map[string]error)
 
@@ -147,7 +210,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 
 				defer wg.Done()
 
-				err := c.rollingUpdateInstanceGroup(bastionGroups[k], c.BastionInterval)
+				err := c.rollingUpdateCheckpointedGroup(bastionGroups[k], c.BastionInterval)
 
 				resultsMutex.Lock()
 				results[k] = err
@@ -172,7 +235,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		// and we don't want to roll all the control-plane nodes at the same time.  See issue #284
 
 		for _, k := range sortGroups(masterGroups) {
-			err := c.rollingUpdateInstanceGroup(masterGroups[k], c.MasterInterval)
+			err := c.rollingUpdateCheckpointedGroup(masterGroups[k], c.MasterInterval)
 			// Do not continue update if control-plane node(s) failed; cluster is potentially in an unhealthy state.
 			if err != nil {
 				return fmt.Errorf("control-plane node not healthy after update, stopping rolling-update: %q", err)
@@ -187,7 +250,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		}
 
 		for _, k := range sortGroups(apiServerGroups) {
-			err := c.rollingUpdateInstanceGroup(apiServerGroups[k], c.NodeInterval)
+			err := c.rollingUpdateCheckpointedGroup(apiServerGroups[k], c.NodeInterval)
 			results[k] = err
 			if err != nil {
 				klog.Errorf("failed to roll InstanceGroup %q: %v", k, err)
@@ -212,7 +275,7 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 		}
 
 		for _, k := range sortGroups(nodeGroups) {
-			err := c.rollingUpdateInstanceGroup(nodeGroups[k], c.NodeInterval)
+			err := c.rollingUpdateCheckpointedGroup(nodeGroups[k], c.NodeInterval)
 			results[k] = err
 			if err != nil {
 				klog.Errorf("failed to roll InstanceGroup %q: %v", k, err)
@@ -235,6 +298,31 @@ func (c *RollingUpdateCluster) RollingUpdate(groups map[string]*cloudinstances.C
 	return errors.NewAggregate(errs)
 }
 
+// rollingUpdateCheckpointedGroup updates an instance group, skipping it if a resumed rolling update already completed it.
+func (c *RollingUpdateCluster) rollingUpdateCheckpointedGroup(group *cloudinstances.CloudInstanceGroup, sleepAfterTerminate time.Duration) error {
+	if c.Checkpointer == nil {
+		return c.rollingUpdateInstanceGroup(group, sleepAfterTerminate)
+	}
+
+	name := group.InstanceGroup.ObjectMeta.Name
+	if c.Checkpointer.IsGroupCompleted(name) {
+		klog.Infof("Skipping InstanceGroup %q, which was completed by a previous rolling update.", name)
+		return nil
+	}
+
+	if err := c.rollingUpdateInstanceGroup(group, sleepAfterTerminate); err != nil {
+		return err
+	}
+
+	if err := c.Checkpointer.GroupCompleted(c.Ctx, name); err != nil {
+		if stderrors.Is(err, ErrLeaseLost) {
+			return err
+		}
+		klog.Warningf("failed to record completion of InstanceGroup %q: %v", name, err)
+	}
+	return nil
+}
+
 func sortGroups(groupMap map[string]*cloudinstances.CloudInstanceGroup) []string {
 	groups := make([]string, 0, len(groupMap))
 	for group := range groupMap {
@@ -249,7 +337,8 @@ func sortGroups(groupMap map[string]*cloudinstances.CloudInstanceGroup) []string
 //
 // For example, if a cluster is unable to be validated by the deadline, then it
 // is unlikely that it will validate on the next instance roll, so an early exit as a
-// warning to the user is more appropriate.
+// warning to the user is more appropriate. Likewise, a failed canary means the new
+// specification should not be rolled out any further.
 func isExitableError(err error) bool {
-	return stderrors.Is(err, &ValidationTimeoutError{})
+	return stderrors.Is(err, &ValidationTimeoutError{}) || stderrors.Is(err, &CanaryFailedError{})
 }
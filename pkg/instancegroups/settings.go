@@ -40,6 +40,12 @@ func resolveSettings(cluster *kops.Cluster, group *kops.InstanceGroup, numInstan
 		if rollingUpdate.MaxSurge == nil {
 			rollingUpdate.MaxSurge = def.MaxSurge
 		}
+		if rollingUpdate.MaintenanceWindow == nil {
+			rollingUpdate.MaintenanceWindow = def.MaintenanceWindow
+		}
+		if rollingUpdate.Canary == nil {
+			rollingUpdate.Canary = def.Canary
+		}
 	}
 
 	if rollingUpdate.DrainAndTerminate == nil {
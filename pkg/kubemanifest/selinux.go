@@ -24,7 +24,9 @@ import (
 // AddHostPathSELinuxContext allows a non-privileged pod to access any file on
 // the Host via HostPath volumes when SELinux is enabled.
 func AddHostPathSELinuxContext(pod *v1.Pod, cfg *nodeup.Config) {
-	if cfg.ContainerdConfig == nil || !cfg.ContainerdConfig.SeLinuxEnabled {
+	containerdSELinux := cfg.ContainerdConfig != nil && cfg.ContainerdConfig.SeLinuxEnabled
+	crioSELinux := cfg.CrioConfig != nil && cfg.CrioConfig.SeLinuxEnabled
+	if !containerdSELinux && !crioSELinux {
 		return
 	}
 
@@ -85,6 +85,16 @@ func (b *VMScaleSetModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 				// Storage Blob Data Contributor
 				RoleDefID: to.Ptr("ba92f5b4-2d11-453d-a403-e96b0029c9fe"),
 			})
+			if encryption := b.Cluster.Spec.ConfigStore.Encryption; encryption != nil && encryption.AzureKeyVault != nil && ig.IsControlPlane() {
+				c.AddTask(&azuretasks.RoleAssignment{
+					Name:       to.Ptr(fmt.Sprintf("%s-%s", *vmss.Name, "keyvault")),
+					Lifecycle:  b.Lifecycle,
+					Scope:      to.Ptr(encryption.AzureKeyVault.VaultID),
+					VMScaleSet: vmss,
+					// Key Vault Crypto User
+					RoleDefID: to.Ptr("12338af0-0e69-4776-bea7-57ae8d297424"),
+				})
+			}
 		}
 	}
 
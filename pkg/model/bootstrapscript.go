@@ -30,6 +30,7 @@ import (
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/model/resources"
 	"k8s.io/kops/pkg/wellknownservices"
 	"k8s.io/kops/upup/pkg/fi"
@@ -38,6 +39,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi/fitasks"
 	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kops/util/pkg/architectures"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 type NodeUpConfigBuilder interface {
@@ -53,6 +55,8 @@ type BootstrapScriptBuilder struct {
 	Lifecycle           fi.Lifecycle
 	NodeUpAssets        map[architectures.Architecture]*assets.MirroredAsset
 	NodeUpConfigBuilder NodeUpConfigBuilder
+	// NodeUpImage is the container image of nodeup, for instance groups that run Bottlerocket
+	NodeUpImage string
 }
 
 type BootstrapScript struct {
@@ -79,14 +83,14 @@ var (
 	_ fi.CloudupHasDependencies = &BootstrapScript{}
 )
 
-// kubeEnv returns the boot config for the instance group
-func (b *BootstrapScript) kubeEnv(ig *kops.InstanceGroup, c *fi.CloudupContext) (*nodeup.BootConfig, error) {
+// kubeEnv returns the nodeup config and boot config for the instance group
+func (b *BootstrapScript) kubeEnv(ig *kops.InstanceGroup, c *fi.CloudupContext) (*nodeup.Config, *nodeup.BootConfig, error) {
 	wellKnownAddresses := make(WellKnownAddresses)
 
 	for _, hasAddress := range b.hasAddressTasks {
 		addresses, err := hasAddress.FindAddresses(c)
 		if err != nil {
-			return nil, fmt.Errorf("error finding address for %v: %v", hasAddress, err)
+			return nil, nil, fmt.Errorf("error finding address for %v: %v", hasAddress, err)
 		}
 		if len(addresses) == 0 {
 			// Such tasks won't have an address in dry-run mode, until the resource is created
@@ -110,24 +114,24 @@ func (b *BootstrapScript) kubeEnv(ig *kops.InstanceGroup, c *fi.CloudupContext)
 		name := *caTask.Name
 		keyset := caTask.Keyset()
 		if keyset == nil {
-			return nil, fmt.Errorf("failed to get keyset from %q", name)
+			return nil, nil, fmt.Errorf("failed to get keyset from %q", name)
 		}
 		keysets[name] = keyset
 	}
 	config, bootConfig, err := b.builder.NodeUpConfigBuilder.BuildConfig(ig, wellKnownAddresses, keysets)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	configData, err := utils.YamlMarshal(config)
 	if err != nil {
-		return nil, fmt.Errorf("error converting nodeup config to yaml: %v", err)
+		return nil, nil, fmt.Errorf("error converting nodeup config to yaml: %v", err)
 	}
 	sum256 := sha256.Sum256(configData)
 	bootConfig.NodeupConfigHash = base64.StdEncoding.EncodeToString(sum256[:])
 	b.nodeupConfig.Resource = fi.NewBytesResource(configData)
 
-	return bootConfig, nil
+	return config, bootConfig, nil
 }
 
 func (b *BootstrapScript) buildEnvironmentVariables() (map[string]string, error) {
@@ -148,6 +152,13 @@ func (b *BootstrapScript) buildEnvironmentVariables() (map[string]string, error)
 		}
 	}
 
+	// Nodes log in to Vault with the configured auth method, never with the user's token
+	for _, name := range vfs.VaultEnvVars {
+		if os.Getenv(name) != "" {
+			env[name] = os.Getenv(name)
+		}
+	}
+
 	if cluster.Spec.GetCloudProvider() == kops.CloudProviderOpenstack {
 
 		osEnvs := []string{
@@ -315,11 +326,26 @@ func (b *BootstrapScript) Run(c *fi.CloudupContext) error {
 		return nil
 	}
 
-	bootConfig, err := b.kubeEnv(b.ig, c)
+	config, bootConfig, err := b.kubeEnv(b.ig, c)
 	if err != nil {
 		return err
 	}
 
+	if b.ig.IsBottlerocket() {
+		settings := &resources.BottlerocketSettings{
+			BootConfig:   bootConfig,
+			NodeupConfig: config,
+			APIServer:    "https://" + b.cluster.APIInternalName(),
+			NodeUpImage:  b.builder.NodeUpImage,
+		}
+		userData, err := settings.Build()
+		if err != nil {
+			return err
+		}
+		b.resource.Resource = fi.NewBytesResource(userData)
+		return nil
+	}
+
 	var nodeupScript resources.NodeUpScript
 	nodeupScript.NodeUpAssets = b.builder.NodeUpAssets
 	nodeupScript.BootConfig = bootConfig
@@ -358,6 +384,17 @@ func (b *BootstrapScript) Run(c *fi.CloudupContext) error {
 
 	nodeupScript.CloudProvider = string(c.T.Cluster.Spec.GetCloudProvider())
 
+	// Instances that read the keystore and secret store directly need the age identity that decrypts them
+	if config.ConfigStore != nil && config.ConfigStore.Encryption != nil && config.ConfigStore.Encryption.Age != nil {
+		age := config.ConfigStore.Encryption.Age
+		identity, err := envelope.ReadAgeIdentity(age)
+		if err != nil {
+			return err
+		}
+		nodeupScript.AgeIdentity = identity
+		nodeupScript.AgeIdentityFile = age.IdentityFile
+	}
+
 	nodeupScriptResource, err := nodeupScript.Build()
 	if err != nil {
 		return err
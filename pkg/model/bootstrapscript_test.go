@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -211,6 +212,94 @@ func TestBootstrapUserData(t *testing.T) {
 	}
 }
 
+func TestBootstrapUserDataBottlerocket(t *testing.T) {
+	cluster := makeTestCluster(nil, nil)
+	cluster.ObjectMeta.Name = "bottlerocket.example.com"
+	cluster.Spec.Hooks = nil
+	cluster.Spec.FileAssets = nil
+	cluster.Spec.Containerd = &kops.ContainerdConfig{
+		RegistryMirrors: map[string][]string{
+			"docker.io": {"https://mirror.example.com"},
+		},
+	}
+
+	group := makeTestInstanceGroup(kops.InstanceGroupRoleNode, nil, nil)
+	group.Spec.Image = "amazon/bottlerocket-aws-k8s-1.30-x86_64-v1.20.3-5d9ac849"
+	group.Spec.ImageFamily = kops.ImageFamilyBottlerocket
+	group.Spec.Hooks = nil
+	group.Spec.FileAssets = nil
+	group.Spec.Taints = []string{"dedicated=bottlerocket:NoSchedule"}
+
+	c := &fi.CloudupModelBuilderContext{
+		Tasks: make(map[string]fi.CloudupTask),
+	}
+	c.AddTask(&fitasks.Keypair{
+		Name:    fi.PtrTo(fi.CertificateIDCA),
+		Subject: "cn=kubernetes",
+		Type:    "ca",
+	})
+	for _, keypair := range []string{
+		"apiserver-aggregator-ca",
+		"etcd-clients-ca",
+		"etcd-manager-ca-events",
+		"etcd-manager-ca-main",
+		"etcd-peers-ca-events",
+		"etcd-peers-ca-main",
+		"service-account",
+	} {
+		c.AddTask(&fitasks.Keypair{
+			Name:    fi.PtrTo(keypair),
+			Subject: "cn=" + keypair,
+			Type:    "ca",
+		})
+	}
+
+	bs := &BootstrapScriptBuilder{
+		KopsModelContext: &KopsModelContext{
+			IAMModelContext: iam.IAMModelContext{Cluster: cluster},
+			InstanceGroups:  []*kops.InstanceGroup{group},
+		},
+		NodeUpConfigBuilder: &bottlerocketNodeupConfigBuilder{cluster: cluster},
+		NodeUpImage:         "registry.k8s.io/kops/nodeup:1.30.0",
+	}
+
+	res, err := bs.ResourceNodeUp(c, group)
+	require.NoError(t, err, "creating nodeup resource")
+
+	require.Contains(t, c.Tasks, "BootstrapScript/testIG")
+	err = c.Tasks["BootstrapScript/testIG"].Run(&fi.CloudupContext{T: fi.CloudupSubContext{Cluster: cluster}})
+	require.NoError(t, err, "running task")
+
+	actual, err := fi.ResourceAsString(res)
+	require.NoError(t, err, "rendering nodeup resource")
+
+	golden.AssertMatchesFile(t, actual, "tests/data/bootstrapscript_bottlerocket.txt")
+}
+
+// bottlerocketNodeupConfigBuilder builds a config holding the cluster CA and the settings Bottlerocket takes from the kubelet config
+type bottlerocketNodeupConfigBuilder struct {
+	cluster *kops.Cluster
+}
+
+func (n *bottlerocketNodeupConfigBuilder) BuildConfig(ig *kops.InstanceGroup, wellKnownAddresses WellKnownAddresses, keysets map[string]*fi.Keyset) (*nodeup.Config, *nodeup.BootConfig, error) {
+	config, bootConfig := nodeup.NewConfig(n.cluster, ig)
+	config.CAs[fi.CertificateIDCA] = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	config.KubeletConfig.ClusterDNS = "100.64.0.10"
+	config.KubeletConfig.ClusterDomain = "cluster.local"
+	config.KubeletConfig.MaxPods = fi.PtrTo(int32(110))
+	config.KubeletConfig.Taints = ig.Spec.Taints
+	config.KubeletConfig.LogLevel = fi.PtrTo(int32(2))
+	config.KubeletConfig.KubeReserved = map[string]string{"cpu": "100m", "memory": "256Mi"}
+	config.KubeletConfig.EvictionHard = fi.PtrTo("memory.available<100Mi,nodefs.available<10%")
+	config.KubeletConfig.EvictionSoft = "memory.available<200Mi"
+	config.KubeletConfig.EvictionSoftGracePeriod = "memory.available=1m30s"
+	config.KubeletConfig.FeatureGates = map[string]string{"InTreePluginAWSUnregister": "true"}
+	config.KubeletConfig.ShutdownGracePeriod = &v1.Duration{Duration: 30 * time.Second}
+	config.KubeletConfig.ShutdownGracePeriodCriticalPods = &v1.Duration{Duration: 10 * time.Second}
+	config.ContainerdConfig = n.cluster.Spec.Containerd
+	return config, bootConfig, nil
+}
+
 func makeTestCluster(hookSpecRoles []kops.InstanceGroupRole, fileAssetSpecRoles []kops.InstanceGroupRole) *kops.Cluster {
 	return &kops.Cluster{
 		Spec: kops.ClusterSpec{
@@ -33,6 +33,11 @@ var _ loader.OptionsBuilder = &ContainerdOptionsBuilder{}
 func (b *ContainerdOptionsBuilder) BuildOptions(o interface{}) error {
 	clusterSpec := o.(*kops.ClusterSpec)
 
+	// CRI-O replaces containerd, so there is nothing to configure
+	if clusterSpec.UsesCrio() {
+		return nil
+	}
+
 	if clusterSpec.Containerd == nil {
 		clusterSpec.Containerd = &kops.ContainerdConfig{}
 	}
@@ -0,0 +1,69 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/loader"
+)
+
+// CrioOptionsBuilder adds options for CRI-O to the model
+type CrioOptionsBuilder struct {
+	*OptionsContext
+}
+
+var _ loader.OptionsBuilder = &CrioOptionsBuilder{}
+
+// BuildOptions is responsible for filling in the default settings for the CRI-O daemon
+func (b *CrioOptionsBuilder) BuildOptions(o interface{}) error {
+	clusterSpec := o.(*kops.ClusterSpec)
+
+	if !clusterSpec.UsesCrio() {
+		return nil
+	}
+
+	if clusterSpec.Crio == nil {
+		clusterSpec.Crio = &kops.CrioConfig{}
+	}
+
+	crio := clusterSpec.Crio
+
+	// CRI-O minor versions follow Kubernetes minor versions
+	if fi.ValueOf(crio.Version) == "" {
+		switch {
+		case b.IsKubernetesLT("1.29"):
+			crio.Version = fi.PtrTo("1.28.11")
+		case b.IsKubernetesLT("1.30"):
+			crio.Version = fi.PtrTo("1.29.9")
+		case b.IsKubernetesLT("1.31"):
+			crio.Version = fi.PtrTo("1.30.6")
+		default:
+			crio.Version = fi.PtrTo("1.31.1")
+		}
+	}
+
+	if crio.LogLevel == nil {
+		crio.LogLevel = fi.PtrTo("info")
+	}
+
+	if crio.DefaultRuntime == nil {
+		crio.DefaultRuntime = fi.PtrTo("runc")
+	}
+
+	return nil
+}
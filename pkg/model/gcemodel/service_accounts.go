@@ -125,5 +125,18 @@ func (b *ServiceAccountsBuilder) addInstanceGroupServiceAccountPermissions(c *fi
 			Role:    s("roles/compute.viewer"),
 		})
 	}
+
+	// The control plane reads the keystore and secret store, so it needs to unwrap their data keys
+	if encryption := b.Cluster.Spec.ConfigStore.Encryption; encryption != nil && encryption.GCPKMS != nil && role == kops.InstanceGroupRoleControlPlane {
+		c.AddTask(&gcetasks.CryptoKeyIAM{
+			Name:      s("serviceaccount-control-plane-kms"),
+			Lifecycle: b.Lifecycle,
+
+			CryptoKey: s(encryption.GCPKMS.KeyName),
+			Member:    s(member),
+			Role:      s("roles/cloudkms.cryptoKeyEncrypterDecrypter"),
+		})
+	}
+
 	return nil
 }
@@ -559,6 +559,12 @@ func (b *PolicyBuilder) AddS3Permissions(p *Policy) error {
 			iamS3path := "placeholder-read-bucket/" + strings.TrimPrefix(path.Path(), "file://")
 			b.buildS3GetStatements(p, iamS3path)
 			s3Buckets.Insert("placeholder-read-bucket")
+		case *vfs.VaultPath:
+			// Access is granted by Vault policies, to the role that the instance logs in as
+			klog.V(4).Infof("not granting IAM permissions for Vault path %q", root)
+		case *vfs.OCIPath:
+			// Access is granted by the registry, not by IAM
+			klog.V(4).Infof("not granting IAM permissions for OCI path %q", root)
 		default:
 			// We could implement this approach, but it seems better to
 			// get all clouds using cluster-readable storage
@@ -587,6 +593,10 @@ func (b *PolicyBuilder) AddS3Permissions(p *Policy) error {
 			iamS3path := "placeholder-read-bucket/" + strings.TrimPrefix(path.Path(), "file://")
 			b.buildS3WriteStatements(p, iamS3path)
 			s3Buckets.Insert("placeholder-read-bucket")
+		case *vfs.VaultPath:
+			// Access is granted by Vault policies, to the role that the instance logs in as
+		case *vfs.OCIPath:
+			// Access is granted by the registry, not by IAM
 		default:
 			return fmt.Errorf("unknown writeable path, can't apply IAM policy: %q", vfsPath)
 		}
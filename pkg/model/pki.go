@@ -17,6 +17,7 @@ limitations under the License.
 package model
 
 import (
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/tokens"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/fitasks"
@@ -68,6 +69,15 @@ func (b *PKIModelBuilder) Build(c *fi.CloudupModelBuilderContext) error {
 		c.AddTask(&fitasks.Secret{Name: fi.PtrTo(x), Lifecycle: b.Lifecycle})
 	}
 
+	// Create the keys for volume mounts encrypted with a key from the secret store
+	for _, ig := range b.InstanceGroups {
+		for _, volumeMount := range ig.Spec.VolumeMounts {
+			if e := volumeMount.Encryption; e != nil && e.KeySource == kops.VolumeEncryptionKeySourceSecret {
+				c.EnsureTask(&fitasks.Secret{Name: fi.PtrTo(e.SecretName), Lifecycle: b.Lifecycle})
+			}
+		}
+	}
+
 	{
 		mirrorPath, err := vfs.Context.BuildVfsPath(b.Cluster.Spec.ConfigStore.Secrets)
 		if err != nil {
@@ -0,0 +1,274 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml"
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/utils"
+)
+
+// BottlerocketBootstrapContainer is the name of the bootstrap container that runs nodeup on Bottlerocket
+const BottlerocketBootstrapContainer = "kops-nodeup"
+
+// BottlerocketSettings is responsible for creating the user-data of Bottlerocket instances.
+// Bottlerocket does not run scripts: its user-data holds TOML settings, which configure the kubelet
+// and containerd that are part of the image, and a bootstrap container that runs nodeup before the kubelet starts.
+type BottlerocketSettings struct {
+	BootConfig   *nodeup.BootConfig
+	NodeupConfig *nodeup.Config
+	// APIServer is the URL the kubelet uses to connect to the API server
+	APIServer string
+	// NodeUpImage is the container image of nodeup
+	NodeUpImage string
+}
+
+func (b *BottlerocketSettings) Build() ([]byte, error) {
+	if b.NodeUpImage == "" {
+		return nil, fmt.Errorf("nodeup image is required for Bottlerocket")
+	}
+
+	bootConfigData, err := utils.YamlMarshal(b.BootConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error converting boot config to yaml: %w", err)
+	}
+
+	// When nodes get their config from kops-controller, the CA is only in the boot config
+	ca := b.NodeupConfig.CAs[fi.CertificateIDCA]
+	if b.BootConfig.ConfigServer != nil {
+		ca = b.BootConfig.ConfigServer.CACertificates
+	}
+	if ca == "" {
+		return nil, fmt.Errorf("cluster CA is required for Bottlerocket")
+	}
+
+	kubelet := b.NodeupConfig.KubeletConfig
+
+	kubernetes := map[string]interface{}{
+		"api-server":          b.APIServer,
+		"cluster-name":        b.BootConfig.ClusterName,
+		"cluster-certificate": base64.StdEncoding.EncodeToString([]byte(ca)),
+		// nodeup writes the kubelet client certificate it gets from kops-controller before the kubelet starts
+		"authentication-mode": "tls",
+		// kops names AWS nodes after their instance ID
+		"hostname-override-source": "instance-id",
+		// kops does not approve the CSRs of kubelet serving certificates
+		"server-tls-bootstrap": false,
+	}
+	if kubelet.ClusterDNS != "" {
+		kubernetes["cluster-dns-ip"] = kubelet.ClusterDNS
+	}
+	if kubelet.ClusterDomain != "" {
+		kubernetes["cluster-domain"] = kubelet.ClusterDomain
+	}
+	if kubelet.MaxPods != nil {
+		kubernetes["max-pods"] = int64(*kubelet.MaxPods)
+	}
+	if kubelet.PodInfraContainerImage != "" {
+		kubernetes["pod-infra-container-image"] = kubelet.PodInfraContainerImage
+	}
+	if len(kubelet.Taints) != 0 {
+		taints, err := bottlerocketTaints(kubelet.Taints)
+		if err != nil {
+			return nil, err
+		}
+		kubernetes["node-taints"] = taints
+	}
+	if kubelet.LogLevel != nil {
+		kubernetes["log-level"] = int64(*kubelet.LogLevel)
+	}
+	if len(kubelet.KubeReserved) != 0 {
+		kubernetes["kube-reserved"] = stringMap(kubelet.KubeReserved)
+	}
+	if len(kubelet.SystemReserved) != 0 {
+		kubernetes["system-reserved"] = stringMap(kubelet.SystemReserved)
+	}
+	if kubelet.EvictionHard != nil && *kubelet.EvictionHard != "" {
+		thresholds, err := bottlerocketEvictionThresholds(*kubelet.EvictionHard, "<")
+		if err != nil {
+			return nil, fmt.Errorf("invalid evictionHard: %w", err)
+		}
+		kubernetes["eviction-hard"] = thresholds
+	}
+	if kubelet.EvictionSoft != "" {
+		thresholds, err := bottlerocketEvictionThresholds(kubelet.EvictionSoft, "<")
+		if err != nil {
+			return nil, fmt.Errorf("invalid evictionSoft: %w", err)
+		}
+		kubernetes["eviction-soft"] = thresholds
+	}
+	if kubelet.EvictionSoftGracePeriod != "" {
+		periods, err := bottlerocketEvictionThresholds(kubelet.EvictionSoftGracePeriod, "=")
+		if err != nil {
+			return nil, fmt.Errorf("invalid evictionSoftGracePeriod: %w", err)
+		}
+		kubernetes["eviction-soft-grace-period"] = periods
+	}
+	if kubelet.EvictionMaxPodGracePeriod != 0 {
+		kubernetes["eviction-max-pod-grace-period"] = int64(kubelet.EvictionMaxPodGracePeriod)
+	}
+	if len(kubelet.FeatureGates) != 0 {
+		featureGates := make(map[string]interface{})
+		for name, value := range kubelet.FeatureGates {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value %q for feature gate %q: %w", value, name, err)
+			}
+			featureGates[name] = enabled
+		}
+		kubernetes["feature-gates"] = featureGates
+	}
+	if kubelet.CpuManagerPolicy != "" {
+		kubernetes["cpu-manager-policy"] = kubelet.CpuManagerPolicy
+	}
+	if kubelet.CPUCFSQuota != nil {
+		kubernetes["cpu-cfs-quota-enforced"] = *kubelet.CPUCFSQuota
+	}
+	if kubelet.TopologyManagerPolicy != "" {
+		kubernetes["topology-manager-policy"] = kubelet.TopologyManagerPolicy
+	}
+	if kubelet.RegistryPullQPS != nil {
+		kubernetes["registry-qps"] = int64(*kubelet.RegistryPullQPS)
+	}
+	if kubelet.RegistryBurst != nil {
+		kubernetes["registry-burst"] = int64(*kubelet.RegistryBurst)
+	}
+	if kubelet.EventQPS != nil {
+		kubernetes["event-qps"] = int64(*kubelet.EventQPS)
+	}
+	if kubelet.EventBurst != nil {
+		kubernetes["event-burst"] = int64(*kubelet.EventBurst)
+	}
+	if kubelet.ContainerLogMaxSize != "" {
+		kubernetes["container-log-max-size"] = kubelet.ContainerLogMaxSize
+	}
+	if kubelet.ContainerLogMaxFiles != nil {
+		kubernetes["container-log-max-files"] = int64(*kubelet.ContainerLogMaxFiles)
+	}
+	if kubelet.ImageGCHighThresholdPercent != nil {
+		kubernetes["image-gc-high-threshold-percent"] = int64(*kubelet.ImageGCHighThresholdPercent)
+	}
+	if kubelet.ImageGCLowThresholdPercent != nil {
+		kubernetes["image-gc-low-threshold-percent"] = int64(*kubelet.ImageGCLowThresholdPercent)
+	}
+	if sysctls := append(append([]string{}, kubelet.ExperimentalAllowedUnsafeSysctls...), kubelet.AllowedUnsafeSysctls...); len(sysctls) != 0 {
+		kubernetes["allowed-unsafe-sysctls"] = sysctls
+	}
+	if kubelet.PodPidsLimit != nil {
+		kubernetes["pod-pids-limit"] = *kubelet.PodPidsLimit
+	}
+	if kubelet.SeccompDefault != nil {
+		kubernetes["seccomp-default"] = *kubelet.SeccompDefault
+	}
+	if kubelet.ShutdownGracePeriod != nil {
+		kubernetes["shutdown-grace-period"] = kubelet.ShutdownGracePeriod.Duration.String()
+	}
+	if kubelet.ShutdownGracePeriodCriticalPods != nil {
+		kubernetes["shutdown-grace-period-for-critical-pods"] = kubelet.ShutdownGracePeriodCriticalPods.Duration.String()
+	}
+
+	settings := map[string]interface{}{
+		"kubernetes": kubernetes,
+		"bootstrap-containers": map[string]interface{}{
+			BottlerocketBootstrapContainer: map[string]interface{}{
+				"source":    b.NodeUpImage,
+				"mode":      "always",
+				"essential": true,
+				"user-data": base64.StdEncoding.EncodeToString(bootConfigData),
+			},
+		},
+	}
+
+	if containerd := b.NodeupConfig.ContainerdConfig; containerd != nil && len(containerd.RegistryMirrors) != 0 {
+		var registries []string
+		for registry := range containerd.RegistryMirrors {
+			registries = append(registries, registry)
+		}
+		sort.Strings(registries)
+
+		var mirrors []map[string]interface{}
+		for _, registry := range registries {
+			mirrors = append(mirrors, map[string]interface{}{
+				"registry": registry,
+				"endpoint": containerd.RegistryMirrors[registry],
+			})
+		}
+		settings["container-registry"] = map[string]interface{}{
+			"mirrors": mirrors,
+		}
+	}
+
+	tree, err := toml.TreeFromMap(map[string]interface{}{"settings": settings})
+	if err != nil {
+		return nil, fmt.Errorf("error building Bottlerocket settings: %w", err)
+	}
+	return []byte(strings.TrimPrefix(tree.String(), "\n")), nil
+}
+
+// bottlerocketTaints converts taints from key=value:effect to the form of Bottlerocket's node-taints setting,
+// which maps each key to a list of value:effect
+func bottlerocketTaints(taints []string) (map[string]interface{}, error) {
+	byKey := make(map[string][]string)
+	for _, taint := range taints {
+		keyValue, effect, found := strings.Cut(taint, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid taint %q", taint)
+		}
+		key, value, _ := strings.Cut(keyValue, "=")
+		byKey[key] = append(byKey[key], value+":"+effect)
+	}
+
+	result := make(map[string]interface{})
+	for key, values := range byKey {
+		result[key] = values
+	}
+	return result, nil
+}
+
+// bottlerocketEvictionThresholds converts eviction thresholds from signal<quantity pairs (or signal=duration pairs
+// for grace periods) separated by commas to the form of Bottlerocket's eviction settings, which map each signal to its value
+func bottlerocketEvictionThresholds(thresholds string, separator string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, threshold := range strings.Split(thresholds, ",") {
+		threshold = strings.TrimSpace(threshold)
+		if threshold == "" {
+			continue
+		}
+		signal, value, found := strings.Cut(threshold, separator)
+		if !found {
+			return nil, fmt.Errorf("invalid threshold %q", threshold)
+		}
+		result[strings.TrimSpace(signal)] = strings.TrimSpace(value)
+	}
+	return result, nil
+}
+
+// stringMap converts a map of strings to the map type that the TOML encoder accepts
+func stringMap(m map[string]string) map[string]interface{} {
+	result := make(map[string]interface{})
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
@@ -161,6 +161,12 @@ function download-release() {
 
 echo "== nodeup node config starting =="
 ensure-install-dir
+{{- if AgeIdentity }}
+
+echo "== writing the age identity for the config store =="
+mkdir -p "$(dirname "{{ AgeIdentityFile }}")"
+(umask 077; echo "{{ AgeIdentity }}" | base64 -d > "{{ AgeIdentityFile }}")
+{{- end }}
 
 {{ if CompressUserData -}}
 echo "{{ GzipBase64 KubeEnv }}" | base64 -d | gzip -d > conf/kube_env.yaml
@@ -183,6 +189,10 @@ type NodeUpScript struct {
 	CloudProvider        string
 	ProxyEnv             func() (string, error)
 	EnvironmentVariables func() (string, error)
+
+	// AgeIdentity is the age identity that decrypts the config store, written to AgeIdentityFile before nodeup runs.
+	AgeIdentity     []byte
+	AgeIdentityFile string
 }
 
 func funcEmptyString() (string, error) {
@@ -248,6 +258,17 @@ func (b *NodeUpScript) Build() (fi.Resource, error) {
 			return b.SetSysctls
 		},
 
+		"AgeIdentity": func() string {
+			if len(b.AgeIdentity) == 0 {
+				return ""
+			}
+			return base64.StdEncoding.EncodeToString(b.AgeIdentity)
+		},
+
+		"AgeIdentityFile": func() string {
+			return b.AgeIdentityFile
+		},
+
 		"ProxyEnv":             b.ProxyEnv,
 		"EnvironmentVariables": b.EnvironmentVariables,
 	}
@@ -17,8 +17,12 @@ limitations under the License.
 package resources
 
 import (
+	"encoding/base64"
 	"strings"
 	"testing"
+
+	"k8s.io/kops/pkg/apis/nodeup"
+	"k8s.io/kops/upup/pkg/fi"
 )
 
 func Test_NodeUpTabs(t *testing.T) {
@@ -28,3 +32,27 @@ func Test_NodeUpTabs(t *testing.T) {
 		}
 	}
 }
+
+func Test_NodeUpAgeIdentity(t *testing.T) {
+	script := &NodeUpScript{
+		BootConfig:      &nodeup.BootConfig{},
+		AgeIdentity:     []byte("AGE-SECRET-KEY-1TEST\n"),
+		AgeIdentityFile: "/etc/kops/age-identity.txt",
+	}
+	res, err := script.Build()
+	if err != nil {
+		t.Fatalf("error building nodeup script: %v", err)
+	}
+	actual, err := fi.ResourceAsString(res)
+	if err != nil {
+		t.Fatalf("error rendering nodeup script: %v", err)
+	}
+
+	expected := `(umask 077; echo "` + base64.StdEncoding.EncodeToString(script.AgeIdentity) + `" | base64 -d > "/etc/kops/age-identity.txt")`
+	if !strings.Contains(actual, expected) {
+		t.Errorf("expected nodeup script to write the age identity with %q, got:\n%s", expected, actual)
+	}
+	if strings.Index(actual, expected) > strings.Index(actual, "download-release\necho") {
+		t.Errorf("expected the age identity to be written before nodeup runs")
+	}
+}
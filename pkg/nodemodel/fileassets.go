@@ -42,7 +42,11 @@ type FileAssets struct {
 	// NodeUpAssets are the assets for downloading nodeup
 	NodeUpAssets map[architectures.Architecture]*assets.MirroredAsset
 
-	Cluster *kops.Cluster
+	// NodeUpImage is the container image of nodeup, set only if an instance group runs Bottlerocket
+	NodeUpImage string
+
+	Cluster        *kops.Cluster
+	InstanceGroups []*kops.InstanceGroup
 }
 
 // AddFileAssets adds the file assets within the assetBuilder
@@ -131,6 +135,15 @@ func (c *FileAssets) AddFileAssets(assetBuilder *assets.AssetBuilder) error {
 			}
 		}
 
+		// The CRI-O bundle includes CNI plugins, so it comes before the CNI asset for that to take precedence
+		if c.Cluster.Spec.UsesCrio() && (c.Cluster.Spec.Crio == nil || !c.Cluster.Spec.Crio.SkipInstall) {
+			crioAsset, err := wellknownassets.FindCrioAsset(c.Cluster, assetBuilder, arch)
+			if err != nil {
+				return err
+			}
+			c.Assets[arch] = append(c.Assets[arch], assets.BuildMirroredAsset(crioAsset))
+		}
+
 		{
 			cniAsset, err := wellknownassets.FindCNIAssets(c.Cluster, assetBuilder, arch)
 			if err != nil {
@@ -139,7 +152,7 @@ func (c *FileAssets) AddFileAssets(assetBuilder *assets.AssetBuilder) error {
 			c.Assets[arch] = append(c.Assets[arch], assets.BuildMirroredAsset(cniAsset))
 		}
 
-		if c.Cluster.Spec.Containerd == nil || !c.Cluster.Spec.Containerd.SkipInstall {
+		if !c.Cluster.Spec.UsesCrio() && (c.Cluster.Spec.Containerd == nil || !c.Cluster.Spec.Containerd.SkipInstall) {
 			containerdAsset, err := wellknownassets.FindContainerdAsset(c.Cluster, assetBuilder, arch)
 			if err != nil {
 				return err
@@ -179,6 +192,17 @@ func (c *FileAssets) AddFileAssets(assetBuilder *assets.AssetBuilder) error {
 		c.NodeUpAssets[arch] = asset
 	}
 
+	for _, ig := range c.InstanceGroups {
+		if ig.IsBottlerocket() {
+			image, err := wellknownassets.NodeUpImage(assetBuilder)
+			if err != nil {
+				return err
+			}
+			c.NodeUpImage = image
+			break
+		}
+	}
+
 	return nil
 }
 
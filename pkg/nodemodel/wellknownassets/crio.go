@@ -0,0 +1,87 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wellknownassets
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/blang/semver/v4"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/architectures"
+)
+
+const (
+	// CRI-O static bundle URLs, which include crio, conmon, pinns, crun and runc
+	crioBundleUrl = "https://storage.googleapis.com/cri-o/artifacts/cri-o.%s.v%s.tar.gz"
+)
+
+func FindCrioAsset(c *kops.Cluster, assetBuilder *assets.AssetBuilder, arch architectures.Architecture) (*assets.FileAsset, error) {
+	if c.Spec.Crio == nil {
+		return nil, fmt.Errorf("unable to find CRI-O config")
+	}
+	crio := c.Spec.Crio
+
+	canonicalURL := ""
+	knownHash := ""
+
+	if crio.Packages != nil {
+		if arch == architectures.ArchitectureAmd64 && crio.Packages.UrlAmd64 != nil && crio.Packages.HashAmd64 != nil {
+			canonicalURL = fi.ValueOf(crio.Packages.UrlAmd64)
+			knownHash = fi.ValueOf(crio.Packages.HashAmd64)
+		}
+		if arch == architectures.ArchitectureArm64 && crio.Packages.UrlArm64 != nil && crio.Packages.HashArm64 != nil {
+			canonicalURL = fi.ValueOf(crio.Packages.UrlArm64)
+			knownHash = fi.ValueOf(crio.Packages.HashArm64)
+		}
+	}
+
+	if canonicalURL == "" {
+		version := fi.ValueOf(crio.Version)
+		if version == "" {
+			return nil, fmt.Errorf("unable to find CRI-O version")
+		}
+
+		assetURL, err := findCrioVersionUrl(arch, version)
+		if err != nil {
+			return nil, err
+		}
+		canonicalURL = assetURL.String()
+	}
+
+	return buildFileAsset(assetBuilder, canonicalURL, knownHash)
+}
+
+func findCrioVersionUrl(arch architectures.Architecture, version string) (*url.URL, error) {
+	sv, err := semver.ParseTolerant(version)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse version string: %q", version)
+	}
+	if sv.LT(semver.MustParse("1.28.0")) {
+		return nil, fmt.Errorf("unsupported legacy CRI-O version: %q", version)
+	}
+
+	switch arch {
+	case architectures.ArchitectureAmd64, architectures.ArchitectureArm64:
+		return url.Parse(fmt.Sprintf(crioBundleUrl, arch, sv.String()))
+	default:
+		return nil, fmt.Errorf("unknown arch: %q", arch)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops"
@@ -96,12 +97,29 @@ func NodeUpAsset(assetsBuilder *assets.AssetBuilder, arch architectures.Architec
 	if err != nil {
 		return nil, err
 	}
+	if asset.DownloadURL.Scheme == "oci" {
+		// The bootstrap script downloads nodeup with curl or wget, which can't fetch from an OCI registry
+		httpAsset := *asset
+		httpAsset.DownloadURL = asset.CanonicalURL
+		asset = &httpAsset
+	}
 	nodeUpAsset[arch] = assets.BuildMirroredAsset(asset)
 	klog.V(8).Infof("Using default nodeup location for %s: %q", arch, asset.DownloadURL.String())
 
 	return nodeUpAsset[arch], nil
 }
 
+// NodeUpImage returns the container image of nodeup, which Bottlerocket runs as a bootstrap container
+func NodeUpImage(assetsBuilder *assets.AssetBuilder) (string, error) {
+	// + is valid in semver, but not in image tags
+	tag := strings.ReplaceAll(kops.Version, "+", "-")
+	image, err := assetsBuilder.RemapImage("registry.k8s.io/kops/nodeup:" + tag)
+	if err != nil {
+		return "", fmt.Errorf("unable to remap nodeup image: %w", err)
+	}
+	return image, nil
+}
+
 // ProtokubeAsset returns the url and hash of the protokube binary
 func ProtokubeAsset(assetsBuilder *assets.AssetBuilder, arch architectures.Architecture) (*assets.MirroredAsset, error) {
 	if protokubeAsset == nil {
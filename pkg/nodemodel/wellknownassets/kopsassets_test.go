@@ -18,13 +18,22 @@ package wellknownassets
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"strings"
 	"testing"
 
 	"k8s.io/kops"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/model/resources"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/architectures"
 	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 func Test_BuildMirroredAsset(t *testing.T) {
@@ -70,3 +79,67 @@ func Test_BuildMirroredAsset(t *testing.T) {
 		})
 	}
 }
+
+func Test_NodeUpAssetOCIFileRepository(t *testing.T) {
+	h := "0000000000000000000000000000000000000000000000000000000000000000"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/nodeup.sha256") {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, h)
+	}))
+	defer server.Close()
+
+	defaultTransport := http.DefaultClient.Transport
+	http.DefaultClient.Transport = server.Client().Transport
+	defer func() { http.DefaultClient.Transport = defaultTransport }()
+
+	baseURL, err := url.Parse(server.URL + "/kops/" + kops.Version + "/")
+	if err != nil {
+		t.Fatalf("cannot parse URL: %v", err)
+	}
+	kopsBaseURL = baseURL
+	nodeUpAsset = nil
+	defer func() {
+		kopsBaseURL = nil
+		nodeUpAsset = nil
+	}()
+
+	assetsSpec := &kopsapi.AssetsSpec{
+		FileRepository: fi.PtrTo("oci://registry.example.com/kops/files"),
+	}
+	assetBuilder := assets.NewAssetBuilder(vfs.Context, assetsSpec, "1.30.0", true)
+
+	nodeUpAssets := make(map[architectures.Architecture]*assets.MirroredAsset)
+	for _, arch := range architectures.GetSupported() {
+		asset, err := NodeUpAsset(assetBuilder, arch)
+		if err != nil {
+			t.Fatalf("error building nodeup asset: %v", err)
+		}
+		nodeUpAssets[arch] = asset
+	}
+
+	script := &resources.NodeUpScript{
+		NodeUpAssets: nodeUpAssets,
+		BootConfig:   &nodeup.BootConfig{},
+	}
+	res, err := script.Build()
+	if err != nil {
+		t.Fatalf("error building nodeup script: %v", err)
+	}
+	actual, err := fi.ResourceAsString(res)
+	if err != nil {
+		t.Fatalf("error rendering nodeup script: %v", err)
+	}
+
+	for _, arch := range architectures.GetSupported() {
+		expected := fmt.Sprintf("NODEUP_URL_%s=%s/kops/%s/linux/%s/nodeup", strings.ToUpper(string(arch)), server.URL, kops.Version, arch)
+		if !strings.Contains(actual, expected) {
+			t.Errorf("expected nodeup script to contain %q, got:\n%s", expected, actual)
+		}
+	}
+	if strings.Contains(actual, "oci://") {
+		t.Errorf("expected nodeup script not to download from the OCI registry, got:\n%s", actual)
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+// DefaultFileName is the name of the policy file in the root of the state store.
+const DefaultFileName = "policy.yaml"
+
+// Severity is the severity of a policy rule.
+type Severity string
+
+const (
+	// SeverityDeny rejects specs that violate the rule.
+	SeverityDeny Severity = "deny"
+	// SeverityWarn logs a warning for specs that violate the rule.
+	SeverityWarn Severity = "warn"
+)
+
+// Target is the kind of object a policy rule is evaluated against.
+type Target string
+
+const (
+	TargetCluster       Target = "Cluster"
+	TargetInstanceGroup Target = "InstanceGroup"
+)
+
+// Policy is a set of rules that cluster and instance group specs must comply with.
+type Policy struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Rule is a CEL expression that must evaluate to true for a spec to comply.
+type Rule struct {
+	// Name identifies the rule.
+	Name string `json:"name"`
+	// Target is the kind of object the rule is evaluated against; Cluster (the default) or InstanceGroup.
+	// The cluster is available to expressions as `cluster`, and the instance group as `instanceGroup`.
+	Target Target `json:"target,omitempty"`
+	// Expression is the CEL expression.
+	Expression string `json:"expression"`
+	// Message describes a violation of the rule.
+	Message string `json:"message,omitempty"`
+	// Field is the path of the field checked by the rule, such as spec.kubelet.anonymousAuth, used when reporting violations.
+	Field string `json:"field,omitempty"`
+	// Severity is deny (the default) or warn.
+	Severity Severity `json:"severity,omitempty"`
+
+	program cel.Program
+}
+
+// Parse parses and compiles a policy.
+func Parse(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("error parsing policy: %w", err)
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable("cluster", cel.DynType),
+		cel.Variable("instanceGroup", cel.DynType),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error building CEL environment: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for i, rule := range policy.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("policy rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate policy rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+
+		switch rule.Target {
+		case "":
+			rule.Target = TargetCluster
+		case TargetCluster, TargetInstanceGroup:
+		default:
+			return nil, fmt.Errorf("policy rule %q has unsupported target %q", rule.Name, rule.Target)
+		}
+
+		switch rule.Severity {
+		case "":
+			rule.Severity = SeverityDeny
+		case SeverityDeny, SeverityWarn:
+		default:
+			return nil, fmt.Errorf("policy rule %q has unsupported severity %q", rule.Name, rule.Severity)
+		}
+
+		ast, issues := env.Compile(rule.Expression)
+		if issues != nil && issues.Err() != nil {
+			return nil, fmt.Errorf("error compiling policy rule %q: %w", rule.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+			return nil, fmt.Errorf("policy rule %q must evaluate to a bool, not %v", rule.Name, ast.OutputType())
+		}
+		rule.program, err = env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("error building policy rule %q: %w", rule.Name, err)
+		}
+	}
+
+	return policy, nil
+}
+
+// Load reads the policy at location, which may be a local path or a VFS path.
+// If location is empty, the policy is read from the root of the state store, if it exists there.
+// A nil policy is returned if there is no policy.
+func Load(ctx context.Context, vfsContext *vfs.VFSContext, location string, stateStore string) (*Policy, error) {
+	var data []byte
+	if location != "" {
+		b, err := vfsContext.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("error reading policy %q: %w", location, err)
+		}
+		data = b
+	} else {
+		if stateStore == "" {
+			return nil, nil
+		}
+		base, err := vfsContext.BuildVfsPath(stateStore)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing state store %q: %w", stateStore, err)
+		}
+		p := base.Join(DefaultFileName)
+		location = p.Path()
+		b, err := p.ReadFile(ctx)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				klog.V(2).Infof("no policy found at %s", location)
+				return nil, nil
+			}
+			return nil, fmt.Errorf("error reading policy %q: %w", location, err)
+		}
+		data = b
+	}
+
+	policy, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("error loading policy %q: %w", location, err)
+	}
+	klog.V(2).Infof("loaded %d policy rules from %s", len(policy.Rules), location)
+	return policy, nil
+}
+
+// Validate evaluates the policy against a cluster and its instance groups.
+// Violations of rules with warn severity are logged; violations of rules with deny severity are returned as errors.
+// A nil policy has no rules.
+func (p *Policy) Validate(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) field.ErrorList {
+	allErrs := p.ValidateCluster(cluster)
+	allErrs = append(allErrs, p.ValidateInstanceGroups(cluster, instanceGroups)...)
+	return allErrs
+}
+
+// ValidateCluster evaluates only the cluster rules of the policy.
+func (p *Policy) ValidateCluster(cluster *kops.Cluster) field.ErrorList {
+	return p.validate(TargetCluster, cluster, nil)
+}
+
+// ValidateInstanceGroups evaluates only the instance group rules of the policy.
+func (p *Policy) ValidateInstanceGroups(cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) field.ErrorList {
+	return p.validate(TargetInstanceGroup, cluster, instanceGroups)
+}
+
+func (p *Policy) validate(target Target, cluster *kops.Cluster, instanceGroups []*kops.InstanceGroup) field.ErrorList {
+	if p == nil || len(p.Rules) == 0 {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	clusterValue, err := toValue(cluster)
+	if err != nil {
+		return append(allErrs, field.InternalError(nil, err))
+	}
+
+	for _, rule := range p.Rules {
+		if rule.Target != target {
+			continue
+		}
+		switch rule.Target {
+		case TargetCluster:
+			vars := map[string]interface{}{"cluster": clusterValue}
+			allErrs = append(allErrs, rule.evaluate(vars, nil, "cluster "+cluster.ObjectMeta.Name)...)
+
+		case TargetInstanceGroup:
+			for _, ig := range instanceGroups {
+				igValue, err := toValue(ig)
+				if err != nil {
+					allErrs = append(allErrs, field.InternalError(nil, err))
+					continue
+				}
+				vars := map[string]interface{}{"cluster": clusterValue, "instanceGroup": igValue}
+				allErrs = append(allErrs, rule.evaluate(vars, field.NewPath("instanceGroups").Key(ig.ObjectMeta.Name), "instance group "+ig.ObjectMeta.Name)...)
+			}
+		}
+	}
+	return allErrs
+}
+
+// evaluate runs the rule, returning an error if it is violated and has deny severity.
+// fldPath is the path of the object the rule is evaluated against, or nil for the cluster.
+func (r *Rule) evaluate(vars map[string]interface{}, fldPath *field.Path, object string) field.ErrorList {
+	message := r.Message
+	if message == "" {
+		message = fmt.Sprintf("must satisfy %s", r.Expression)
+	}
+
+	out, _, err := r.program.Eval(vars)
+	if err != nil {
+		message = fmt.Sprintf("error evaluating rule: %v", err)
+	} else if ok, isBool := out.Value().(bool); !isBool {
+		message = fmt.Sprintf("rule evaluated to %v, not a bool", out.Value())
+	} else if ok {
+		return nil
+	}
+
+	fieldName := r.Field
+	if fieldName == "" {
+		fieldName = "spec"
+	}
+	for _, child := range strings.Split(fieldName, ".") {
+		if fldPath == nil {
+			fldPath = field.NewPath(child)
+		} else {
+			fldPath = fldPath.Child(child)
+		}
+	}
+
+	detail := fmt.Sprintf("violates policy rule %q: %s", r.Name, message)
+	if r.Severity == SeverityWarn {
+		klog.Warningf("%s %s: %s", object, fldPath, detail)
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(fldPath, detail)}
+}
+
+// toValue converts an object to the generic form used by CEL expressions, matching its serialized form.
+func toValue(obj interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing %T for policy evaluation: %w", obj, err)
+	}
+	value := make(map[string]interface{})
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("error deserializing %T for policy evaluation: %w", obj, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const testPolicy = `
+rules:
+- name: no-public-workers
+  target: InstanceGroup
+  expression: instanceGroup.spec.role != 'Node' || !has(instanceGroup.spec.associatePublicIP) || !instanceGroup.spec.associatePublicIP
+  message: worker nodes must not have public IPs
+  field: spec.associatePublicIP
+- name: kubelet-anonymous-auth
+  expression: has(cluster.spec.kubelet) && has(cluster.spec.kubelet.anonymousAuth) && !cluster.spec.kubelet.anonymousAuth
+  message: kubelet anonymous auth must be disabled
+  field: spec.kubelet.anonymousAuth
+  severity: warn
+- name: imdsv2
+  target: InstanceGroup
+  expression: instanceGroup.spec.instanceMetadata.httpTokens == 'required'
+`
+
+func TestParse(t *testing.T) {
+	grid := []struct {
+		name     string
+		policy   string
+		expected string
+	}{
+		{
+			name:   "valid",
+			policy: testPolicy,
+		},
+		{
+			name:     "missing name",
+			policy:   "rules:\n- expression: 'true'\n",
+			expected: "policy rule 0 has no name",
+		},
+		{
+			name:     "unknown field",
+			policy:   "rules:\n- name: a\n  expresion: 'true'\n",
+			expected: "error parsing policy",
+		},
+		{
+			name:     "invalid expression",
+			policy:   "rules:\n- name: a\n  expression: 'cluster.spec +'\n",
+			expected: "error compiling policy rule \"a\"",
+		},
+		{
+			name:     "not a bool",
+			policy:   "rules:\n- name: a\n  expression: '1 + 1'\n",
+			expected: "policy rule \"a\" must evaluate to a bool",
+		},
+		{
+			name:     "unsupported severity",
+			policy:   "rules:\n- name: a\n  expression: 'true'\n  severity: error\n",
+			expected: "policy rule \"a\" has unsupported severity \"error\"",
+		},
+		{
+			name:     "unsupported target",
+			policy:   "rules:\n- name: a\n  expression: 'true'\n  target: Node\n",
+			expected: "policy rule \"a\" has unsupported target \"Node\"",
+		},
+		{
+			name:     "duplicate",
+			policy:   "rules:\n- name: a\n  expression: 'true'\n- name: a\n  expression: 'true'\n",
+			expected: "duplicate policy rule \"a\"",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			_, err := Parse([]byte(g.policy))
+			if g.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, g.expected)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	policy, err := Parse([]byte(testPolicy))
+	require.NoError(t, err)
+
+	cluster := &kops.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "minimal.example.com"},
+		Spec: kops.ClusterSpec{
+			Kubelet: &kops.KubeletConfigSpec{AnonymousAuth: fi.PtrTo(true)},
+		},
+	}
+	instanceGroups := []*kops.InstanceGroup{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "control-plane"},
+			Spec: kops.InstanceGroupSpec{
+				Role:              kops.InstanceGroupRoleControlPlane,
+				AssociatePublicIP: fi.PtrTo(true),
+				InstanceMetadata:  &kops.InstanceMetadataOptions{HTTPTokens: fi.PtrTo("required")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "nodes"},
+			Spec: kops.InstanceGroupSpec{
+				Role:              kops.InstanceGroupRoleNode,
+				AssociatePublicIP: fi.PtrTo(true),
+			},
+		},
+	}
+
+	errs := policy.Validate(cluster, instanceGroups)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		`instanceGroups[nodes].spec.associatePublicIP: Forbidden: violates policy rule "no-public-workers": worker nodes must not have public IPs`,
+		`instanceGroups[nodes].spec: Forbidden: violates policy rule "imdsv2": error evaluating rule: no such key: instanceMetadata`,
+	}, messages)
+
+	var nilPolicy *Policy
+	assert.Empty(t, nilPolicy.Validate(cluster, instanceGroups))
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.TODO()
+	vfsContext := vfs.NewTestingVFSContext()
+
+	policy, err := Load(ctx, vfsContext, "", "memfs://tests")
+	require.NoError(t, err)
+	assert.Nil(t, policy, "missing default policy")
+
+	base, err := vfsContext.BuildVfsPath("memfs://tests")
+	require.NoError(t, err)
+	require.NoError(t, base.Join(DefaultFileName).WriteFile(ctx, strings.NewReader(testPolicy), nil))
+
+	policy, err = Load(ctx, vfsContext, "", "memfs://tests")
+	require.NoError(t, err)
+	assert.Len(t, policy.Rules, 3)
+
+	_, err = Load(ctx, vfsContext, "memfs://tests/missing.yaml", "memfs://tests")
+	assert.Error(t, err, "missing explicit policy")
+}
@@ -170,6 +170,12 @@ func (v *clusterValidatorImpl) Validate() (*ValidationCluster, error) {
 		return nil, fmt.Errorf("cannot get pod health for %q: %v", v.cluster.Name, err)
 	}
 
+	if v.cluster.Spec.Validation != nil {
+		if err := validation.collectCustomFailures(ctx, v.k8sClient, v.cluster.Spec.Validation); err != nil {
+			return nil, fmt.Errorf("cannot run custom validation for %q: %v", v.cluster.Name, err)
+		}
+	}
+
 	return validation, nil
 }
 
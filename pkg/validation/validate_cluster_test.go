@@ -69,12 +69,17 @@ func (c *MockCloud) GetCloudGroups(cluster *kopsapi.Cluster, instancegroups []*k
 }
 
 func testValidate(t *testing.T, groups map[string]*cloudinstances.CloudInstanceGroup, objects []runtime.Object) (*ValidationCluster, error) {
+	return testValidateCluster(t, nil, groups, objects, nil)
+}
+
+func testValidateCluster(t *testing.T, validationSpec *kopsapi.ClusterValidationSpec, groups map[string]*cloudinstances.CloudInstanceGroup, objects []runtime.Object, configureClient func(*fake.Clientset)) (*ValidationCluster, error) {
 	cluster := &kopsapi.Cluster{
 		ObjectMeta: metav1.ObjectMeta{Name: "testcluster.k8s.local"},
 		Spec: kopsapi.ClusterSpec{
 			ExternalDNS: &kopsapi.ExternalDNSConfig{
 				Provider: kopsapi.ExternalDNSProviderDNSController,
 			},
+			Validation: validationSpec,
 		},
 	}
 
@@ -126,7 +131,12 @@ func testValidate(t *testing.T, groups map[string]*cloudinstances.CloudInstanceG
 
 	mockcloud := BuildMockCloud(t, groups, cluster, instanceGroups)
 
-	validator, err := NewClusterValidator(cluster, mockcloud, &kopsapi.InstanceGroupList{Items: instanceGroups}, "https://api.testcluster.k8s.local", fake.NewSimpleClientset(objects...))
+	k8sClient := fake.NewSimpleClientset(objects...)
+	if configureClient != nil {
+		configureClient(k8sClient)
+	}
+
+	validator, err := NewClusterValidator(cluster, mockcloud, &kopsapi.InstanceGroupList{Items: instanceGroups}, "https://api.testcluster.k8s.local", k8sClient)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/pager"
+	"k8s.io/kops/pkg/apis/kops"
+)
+
+// httpProbeTimeout is the timeout for each HTTP probe.
+const httpProbeTimeout = 10 * time.Second
+
+// collectCustomFailures runs the checks configured in the cluster's validation spec.
+// Their failures are not associated with an instance group, so they apply to the whole cluster.
+func (v *ValidationCluster) collectCustomFailures(ctx context.Context, client kubernetes.Interface, spec *kops.ClusterValidationSpec) error {
+	for _, workload := range spec.Workloads {
+		if err := v.validateWorkload(ctx, client, workload); err != nil {
+			return err
+		}
+	}
+
+	for _, namespace := range spec.CrashLoopNamespaces {
+		if err := v.collectCrashLoopingPods(ctx, client, namespace); err != nil {
+			return err
+		}
+	}
+
+	for _, probe := range spec.HTTPProbes {
+		v.runHTTPProbe(ctx, client, probe)
+	}
+
+	return nil
+}
+
+func (v *ValidationCluster) validateWorkload(ctx context.Context, client kubernetes.Interface, workload kops.ValidationWorkload) error {
+	name := workload.Namespace + "/" + workload.Name
+
+	var message string
+	switch workload.Kind {
+	case "Deployment":
+		deployment, err := client.AppsV1().Deployments(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			message = fmt.Sprintf("deployment %q not found", name)
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error getting deployment %q: %v", name, err)
+		}
+
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		status := deployment.Status
+		if status.ObservedGeneration < deployment.Generation || status.UpdatedReplicas < replicas || status.AvailableReplicas < replicas {
+			message = fmt.Sprintf("deployment %q has %d of %d replicas updated and %d available", name, status.UpdatedReplicas, replicas, status.AvailableReplicas)
+		}
+
+	case "DaemonSet":
+		daemonSet, err := client.AppsV1().DaemonSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			message = fmt.Sprintf("daemonset %q not found", name)
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error getting daemonset %q: %v", name, err)
+		}
+
+		status := daemonSet.Status
+		if status.ObservedGeneration < daemonSet.Generation || status.UpdatedNumberScheduled < status.DesiredNumberScheduled || status.NumberAvailable < status.DesiredNumberScheduled {
+			message = fmt.Sprintf("daemonset %q has %d of %d pods updated and %d available", name, status.UpdatedNumberScheduled, status.DesiredNumberScheduled, status.NumberAvailable)
+		}
+
+	default:
+		return fmt.Errorf("unknown workload kind %q for %q", workload.Kind, name)
+	}
+
+	if message != "" {
+		v.addError(&ValidationError{
+			Kind:    workload.Kind,
+			Name:    name,
+			Message: message,
+		})
+	}
+	return nil
+}
+
+func (v *ValidationCluster) collectCrashLoopingPods(ctx context.Context, client kubernetes.Interface, namespace string) error {
+	err := pager.New(pager.SimplePageFunc(func(opts metav1.ListOptions) (runtime.Object, error) {
+		return client.CoreV1().Pods(namespace).List(ctx, opts)
+	})).EachListItem(ctx, metav1.ListOptions{}, func(obj runtime.Object) error {
+		pod := obj.(*v1.Pod)
+
+		var statuses []v1.ContainerStatus
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+		for _, container := range statuses {
+			if container.State.Waiting != nil && container.State.Waiting.Reason == "CrashLoopBackOff" {
+				v.addError(&ValidationError{
+					Kind:    "Pod",
+					Name:    pod.Namespace + "/" + pod.Name,
+					Message: fmt.Sprintf("pod %q is crash-looping (container %s restarted %d times)", pod.Namespace+"/"+pod.Name, container.Name, container.RestartCount),
+				})
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error listing Pods in namespace %q: %v", namespace, err)
+	}
+	return nil
+}
+
+func (v *ValidationCluster) runHTTPProbe(ctx context.Context, client kubernetes.Interface, probe kops.ValidationHTTPProbe) {
+	scheme := probe.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+	path := probe.Path
+	if path == "" {
+		path = "/"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, httpProbeTimeout)
+	defer cancel()
+
+	_, err := client.CoreV1().Services(probe.Namespace).ProxyGet(scheme, probe.Service, probe.Port, path, nil).DoRaw(ctx)
+	if err != nil {
+		v.addError(&ValidationError{
+			Kind:    "HTTPProbe",
+			Name:    probe.Name,
+			Message: fmt.Sprintf("HTTP probe %q of service %q failed: %v", probe.Name, probe.Namespace+"/"+probe.Service, err),
+		})
+	}
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	testingclient "k8s.io/client-go/testing"
+	kopsapi "k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+type fakeProxyResponse struct {
+	err error
+}
+
+func (r *fakeProxyResponse) DoRaw(context.Context) ([]byte, error) {
+	return nil, r.err
+}
+
+func (r *fakeProxyResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return nil, r.err
+}
+
+func Test_ValidateWorkloads(t *testing.T) {
+	objects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "available", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: fi.PtrTo(int32(2))},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "unavailable", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: fi.PtrTo(int32(2))},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "rolling", Generation: 3},
+			Spec:       appsv1.DeploymentSpec{Replicas: fi.PtrTo(int32(2))},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "mesh", Name: "available"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 3},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "mesh", Name: "updating"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberAvailable: 3},
+		},
+	}
+	spec := &kopsapi.ClusterValidationSpec{
+		Workloads: []kopsapi.ValidationWorkload{
+			{Kind: "Deployment", Namespace: "ingress", Name: "available"},
+			{Kind: "Deployment", Namespace: "ingress", Name: "unavailable"},
+			{Kind: "Deployment", Namespace: "ingress", Name: "rolling"},
+			{Kind: "Deployment", Namespace: "ingress", Name: "missing"},
+			{Kind: "DaemonSet", Namespace: "mesh", Name: "available"},
+			{Kind: "DaemonSet", Namespace: "mesh", Name: "updating"},
+		},
+	}
+
+	v, err := testValidateCluster(t, spec, nil, objects, nil)
+	require.NoError(t, err)
+	if !assert.ElementsMatch(t, []*ValidationError{
+		{
+			Kind:    "Deployment",
+			Name:    "ingress/unavailable",
+			Message: `deployment "ingress/unavailable" has 2 of 2 replicas updated and 1 available`,
+		},
+		{
+			Kind:    "Deployment",
+			Name:    "ingress/rolling",
+			Message: `deployment "ingress/rolling" has 2 of 2 replicas updated and 2 available`,
+		},
+		{
+			Kind:    "Deployment",
+			Name:    "ingress/missing",
+			Message: `deployment "ingress/missing" not found`,
+		},
+		{
+			Kind:    "DaemonSet",
+			Name:    "mesh/updating",
+			Message: `daemonset "mesh/updating" has 2 of 3 pods updated and 3 available`,
+		},
+	}, v.Failures) {
+		printDebug(t, v)
+	}
+}
+
+func Test_ValidateCrashLoopNamespaces(t *testing.T) {
+	crashLooping := v1.ContainerStatus{
+		Name:         "app",
+		RestartCount: 5,
+		State: v1.ContainerState{
+			Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+		},
+	}
+	objects := []runtime.Object{
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "healthy"},
+			Status:     v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{{Name: "app", Ready: true}}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ingress", Name: "crashing"},
+			Status:     v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{crashLooping}},
+		},
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "crashing"},
+			Status:     v1.PodStatus{ContainerStatuses: []v1.ContainerStatus{crashLooping}},
+		},
+	}
+	spec := &kopsapi.ClusterValidationSpec{
+		CrashLoopNamespaces: []string{"ingress"},
+	}
+
+	v, err := testValidateCluster(t, spec, nil, objects, nil)
+	require.NoError(t, err)
+	if !assert.ElementsMatch(t, []*ValidationError{
+		{
+			Kind:    "Pod",
+			Name:    "ingress/crashing",
+			Message: `pod "ingress/crashing" is crash-looping (container app restarted 5 times)`,
+		},
+	}, v.Failures) {
+		printDebug(t, v)
+	}
+}
+
+func Test_ValidateHTTPProbes(t *testing.T) {
+	spec := &kopsapi.ClusterValidationSpec{
+		HTTPProbes: []kopsapi.ValidationHTTPProbe{
+			{Name: "healthy", Namespace: "app", Service: "healthy", Port: "http", Path: "/healthz"},
+			{Name: "unhealthy", Namespace: "app", Service: "unhealthy", Port: "8080"},
+		},
+	}
+
+	var requests []string
+	configureClient := func(client *fake.Clientset) {
+		client.PrependProxyReactor("services", func(action testingclient.Action) (bool, restclient.ResponseWrapper, error) {
+			proxy := action.(testingclient.ProxyGetAction)
+			requests = append(requests, proxy.GetScheme()+"://"+proxy.GetName()+":"+proxy.GetPort()+proxy.GetPath())
+			if proxy.GetName() == "unhealthy" {
+				return true, &fakeProxyResponse{err: errors.New("service unavailable")}, nil
+			}
+			return true, &fakeProxyResponse{}, nil
+		})
+	}
+
+	v, err := testValidateCluster(t, spec, nil, nil, configureClient)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"http://healthy:http/healthz", "http://unhealthy:8080/"}, requests)
+	if !assert.ElementsMatch(t, []*ValidationError{
+		{
+			Kind:    "HTTPProbe",
+			Name:    "unhealthy",
+			Message: `HTTP probe "unhealthy" of service "app/unhealthy" failed: service unavailable`,
+		},
+	}, v.Failures) {
+		printDebug(t, v)
+	}
+}
@@ -20,6 +20,9 @@ const (
 	// KubeAPIServer is the port where kube-apiserver listens.
 	KubeAPIServer = 443
 
+	// NodeupAuditMetrics is the port where nodeup serves metrics in audit and reconcile modes.
+	NodeupAuditMetrics = 3986
+
 	// NodeupChallenge is the port where nodeup listens for challenges.
 	NodeupChallenge = 3987
 
@@ -76,3 +76,8 @@ if [[ -z "${KUBE_APISERVER_HEALTHCHECK_TAG}" ]]; then
   KUBE_APISERVER_HEALTHCHECK_TAG="${PROTOKUBE_TAG}"
 fi
 echo "STABLE_KUBE_APISERVER_HEALTHCHECK_TAG ${KUBE_APISERVER_HEALTHCHECK_TAG}"
+
+if [[ -z "${NODEUP_IMAGE_TAG}" ]]; then
+  NODEUP_IMAGE_TAG="${PROTOKUBE_TAG}"
+fi
+echo "STABLE_NODEUP_IMAGE_TAG ${NODEUP_IMAGE_TAG}"
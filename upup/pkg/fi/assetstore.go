@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/assets/cosign"
 	"k8s.io/kops/upup/pkg/fi/utils"
 	"k8s.io/kops/util/pkg/hashing"
 )
@@ -93,6 +94,9 @@ func (r *assetResource) GetSource() *Source {
 type AssetStore struct {
 	cacheDir string
 	assets   []*asset
+
+	// Verifier verifies the signatures of downloaded assets, if set
+	Verifier *cosign.Verifier
 }
 
 func NewAssetStore(cacheDir string) *AssetStore {
@@ -250,9 +254,16 @@ func (a *AssetStore) addURLs(urls []string, hash *hashing.Hash) error {
 		if err != nil {
 			klog.Warningf("error downloading url %q: %v", url, err)
 			continue
-		} else {
-			break
 		}
+		if a.Verifier != nil {
+			// The signature is published beside the file, so we verify against the url we downloaded from
+			err = a.Verifier.VerifyFile(localFile, url)
+			if err != nil {
+				klog.Warningf("error verifying url %q: %v", url, err)
+				continue
+			}
+		}
+		break
 	}
 	if err != nil {
 		return err
@@ -50,6 +50,7 @@ import (
 	"k8s.io/kops/pkg/model/openstackmodel"
 	"k8s.io/kops/pkg/model/scalewaymodel"
 	"k8s.io/kops/pkg/nodemodel"
+	"k8s.io/kops/pkg/policy"
 	"k8s.io/kops/pkg/templates"
 	"k8s.io/kops/upup/models"
 	"k8s.io/kops/upup/pkg/fi"
@@ -104,6 +105,12 @@ type ApplyClusterCmd struct {
 	// DryRun is true if this is only a dry run
 	DryRun bool
 
+	// GenerateImports looks up existing cloud resources, and emits terraform import blocks for them. Only valid with the terraform target.
+	GenerateImports bool
+
+	// TerraformModule writes the terraform output as a root module that calls a reusable module, with input variables and outputs. Only valid with the terraform target.
+	TerraformModule bool
+
 	// AllowKopsDowngrade permits applying with a kops version older than what was last used to apply to the cluster.
 	AllowKopsDowngrade bool
 
@@ -124,6 +131,12 @@ type ApplyClusterCmd struct {
 	// GetAssets is whether this is called just to obtain the list of assets.
 	GetAssets bool
 
+	// QuietDryRun suppresses the report printed by the dry-run target, for callers that report the changes themselves.
+	QuietDryRun bool
+
+	// Policy holds the rules the cluster and instance groups must comply with; it may be nil.
+	Policy *policy.Policy
+
 	// TaskMap is the map of tasks that we built (output)
 	TaskMap map[string]fi.CloudupTask
 
@@ -287,6 +300,10 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		return nil, err
 	}
 
+	if errs := c.Policy.Validate(c.Cluster, c.InstanceGroups); len(errs) != 0 {
+		return nil, errs.ToAggregate()
+	}
+
 	if cluster.Spec.KubernetesVersion == "" {
 		return nil, fmt.Errorf("KubernetesVersion not set")
 	}
@@ -380,7 +397,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		}
 	}
 
-	fileAssets := &nodemodel.FileAssets{Cluster: cluster}
+	fileAssets := &nodemodel.FileAssets{Cluster: cluster, InstanceGroups: c.InstanceGroups}
 	if err := fileAssets.AddFileAssets(assetBuilder); err != nil {
 		return nil, err
 	}
@@ -510,6 +527,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		Lifecycle:           clusterLifecycle,
 		NodeUpConfigBuilder: configBuilder,
 		NodeUpAssets:        fileAssets.NodeUpAssets,
+		NodeUpImage:         fileAssets.NodeUpImage,
 	}
 
 	{
@@ -723,6 +741,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 	case TargetTerraform:
 		outDir := c.OutDir
 		tf := terraform.NewTerraformTarget(cloud, project, outDir, cluster.Spec.Target)
+		tf.Module = c.TerraformModule
 
 		// We include a few "util" variables in the TF output
 		if err := tf.AddOutputVariable("region", terraformWriter.LiteralFromStringValue(cloud.Region())); err != nil {
@@ -754,7 +773,7 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 
 	case TargetDryRun:
 		var out io.Writer = os.Stdout
-		if c.GetAssets {
+		if c.GetAssets || c.QuietDryRun {
 			out = io.Discard
 		}
 		target = fi.NewCloudupDryRunTarget(assetBuilder, out)
@@ -791,6 +810,16 @@ func (c *ApplyClusterCmd) Run(ctx context.Context) (*ApplyResults, error) {
 		return nil, fmt.Errorf("error running tasks: %v", err)
 	}
 
+	if c.GenerateImports {
+		tf, ok := target.(*terraform.TerraformTarget)
+		if !ok {
+			return nil, fmt.Errorf("generating imports is only supported with the %q target", TargetTerraform)
+		}
+		if err := tf.FindImports(context, c.TaskMap); err != nil {
+			return nil, fmt.Errorf("error generating terraform imports: %w", err)
+		}
+	}
+
 	if !cluster.PublishesDNSRecords() {
 		shouldPrecreateDNS = false
 	}
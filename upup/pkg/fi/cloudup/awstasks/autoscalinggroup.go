@@ -906,6 +906,18 @@ type terraformASGTag struct {
 	PropagateAtLaunch *bool   `cty:"propagate_at_launch"`
 }
 
+// terraformDynamicASGTag renders a tag block for each of the tags in a variable, in module mode.
+type terraformDynamicASGTag struct {
+	ForEach *terraformWriter.Literal       `cty:"for_each"`
+	Content *terraformDynamicASGTagContent `cty:"content"`
+}
+
+type terraformDynamicASGTagContent struct {
+	Key               *terraformWriter.Literal `cty:"key"`
+	Value             *terraformWriter.Literal `cty:"value"`
+	PropagateAtLaunch *bool                    `cty:"propagate_at_launch"`
+}
+
 type terraformAutoscalingLaunchTemplateSpecification struct {
 	// LaunchTemplateID is the ID of the template to use.
 	LaunchTemplateID *terraformWriter.Literal `cty:"id"`
@@ -930,6 +942,17 @@ type terraformAutoscalingMixedInstancesPolicyLaunchTemplate struct {
 	LaunchTemplateSpecification []*terraformAutoscalingMixedInstancesPolicyLaunchTemplateSpecification `cty:"launch_template_specification"`
 	// Override the is machine type override
 	Override []*terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride `cty:"override"`
+	// DynamicOverride renders an override for each of the machine types in a variable, in module mode
+	DynamicOverride *terraformDynamicMixedInstancesPolicyOverride `cty:"dynamic \"override\""`
+}
+
+type terraformDynamicMixedInstancesPolicyOverride struct {
+	ForEach *terraformWriter.Literal                             `cty:"for_each"`
+	Content *terraformDynamicMixedInstancesPolicyOverrideContent `cty:"content"`
+}
+
+type terraformDynamicMixedInstancesPolicyOverrideContent struct {
+	InstanceType *terraformWriter.Literal `cty:"instance_type"`
 }
 
 type terraformAutoscalingInstanceDistribution struct {
@@ -955,19 +978,20 @@ type terraformMixedInstancesPolicy struct {
 }
 
 type terraformWarmPool struct {
-	MinSize *int32 `cty:"min_size"`
-	MaxSize *int32 `cty:"max_group_prepared_capacity"`
+	MinSize *terraformWriter.Literal `cty:"min_size"`
+	MaxSize *terraformWriter.Literal `cty:"max_group_prepared_capacity"`
 }
 
 type terraformAutoscalingGroup struct {
 	Name                    *string                                          `cty:"name"`
 	LaunchConfigurationName *terraformWriter.Literal                         `cty:"launch_configuration"`
 	LaunchTemplate          *terraformAutoscalingLaunchTemplateSpecification `cty:"launch_template"`
-	MaxSize                 *int32                                           `cty:"max_size"`
-	MinSize                 *int32                                           `cty:"min_size"`
+	MaxSize                 *terraformWriter.Literal                         `cty:"max_size"`
+	MinSize                 *terraformWriter.Literal                         `cty:"min_size"`
 	MixedInstancesPolicy    []*terraformMixedInstancesPolicy                 `cty:"mixed_instances_policy"`
 	VPCZoneIdentifier       []*terraformWriter.Literal                       `cty:"vpc_zone_identifier"`
 	Tags                    []*terraformASGTag                               `cty:"tag"`
+	DynamicTags             *terraformDynamicASGTag                          `cty:"dynamic \"tag\""`
 	MetricsGranularity      *string                                          `cty:"metrics_granularity"`
 	EnabledMetrics          []*string                                        `cty:"enabled_metrics"`
 	SuspendedProcesses      []*string                                        `cty:"suspended_processes"`
@@ -981,10 +1005,18 @@ type terraformAutoscalingGroup struct {
 
 // RenderTerraform is responsible for rendering the terraform codebase
 func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *AutoscalingGroup) error {
+	var minSize, maxSize *terraformWriter.Literal
+	if e.MinSize != nil {
+		minSize = terraformWriter.LiteralFromIntValue(*e.MinSize)
+	}
+	if e.MaxSize != nil {
+		maxSize = terraformWriter.LiteralFromIntValue(*e.MaxSize)
+	}
+
 	tf := &terraformAutoscalingGroup{
 		Name:                e.Name,
-		MinSize:             e.MinSize,
-		MaxSize:             e.MaxSize,
+		MinSize:             t.AddVariable(*e.Name, "min_size", fmt.Sprintf("The minimum size of the %s autoscaling group", *e.Name), "number", minSize),
+		MaxSize:             t.AddVariable(*e.Name, "max_size", fmt.Sprintf("The maximum size of the %s autoscaling group", *e.Name), "number", maxSize),
 		MetricsGranularity:  e.Granularity,
 		EnabledMetrics:      aws.StringSlice(e.Metrics),
 		InstanceProtection:  e.InstanceProtection,
@@ -996,13 +1028,24 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 		tf.VPCZoneIdentifier = append(tf.VPCZoneIdentifier, s.TerraformLink())
 	}
 
-	for _, k := range maps.SortedKeys(e.Tags) {
-		v := e.Tags[k]
-		tf.Tags = append(tf.Tags, &terraformASGTag{
-			Key:               fi.PtrTo(k),
-			Value:             fi.PtrTo(v),
-			PropagateAtLaunch: fi.PtrTo(true),
-		})
+	if tags := instanceGroupTagsVariable(t, *e.Name, e.Tags); tags != nil {
+		tf.DynamicTags = &terraformDynamicASGTag{
+			ForEach: tags,
+			Content: &terraformDynamicASGTagContent{
+				Key:               terraformWriter.LiteralTokens("tag", "key"),
+				Value:             terraformWriter.LiteralTokens("tag", "value"),
+				PropagateAtLaunch: fi.PtrTo(true),
+			},
+		}
+	} else {
+		for _, k := range maps.SortedKeys(e.Tags) {
+			v := e.Tags[k]
+			tf.Tags = append(tf.Tags, &terraformASGTag{
+				Key:               fi.PtrTo(k),
+				Value:             fi.PtrTo(v),
+				PropagateAtLaunch: fi.PtrTo(true),
+			})
+		}
 	}
 
 	for _, k := range e.LoadBalancers {
@@ -1046,8 +1089,21 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 			},
 		}
 
+		var instanceTypes []*terraformWriter.Literal
 		for _, x := range e.MixedInstanceOverrides {
-			tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override = append(tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override, &terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride{InstanceType: fi.PtrTo(x)})
+			instanceTypes = append(instanceTypes, terraformWriter.LiteralFromStringValue(x))
+		}
+		if len(instanceTypes) != 0 && t.Module {
+			tf.MixedInstancesPolicy[0].LaunchTemplate[0].DynamicOverride = &terraformDynamicMixedInstancesPolicyOverride{
+				ForEach: t.AddVariable(*e.Name, "mixed_instance_types", fmt.Sprintf("The instance types of the mixed instances policy of the %s autoscaling group", *e.Name), "list(string)", terraformWriter.LiteralListExpression(instanceTypes...)),
+				Content: &terraformDynamicMixedInstancesPolicyOverrideContent{
+					InstanceType: terraformWriter.LiteralTokens("override", "value"),
+				},
+			}
+		} else {
+			for _, x := range e.MixedInstanceOverrides {
+				tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override = append(tf.MixedInstancesPolicy[0].LaunchTemplate[0].Override, &terraformAutoscalingMixedInstancesPolicyLaunchTemplateOverride{InstanceType: fi.PtrTo(x)})
+			}
 		}
 	} else if e.LaunchTemplate != nil {
 		tf.LaunchTemplate = &terraformAutoscalingLaunchTemplateSpecification{
@@ -1101,20 +1157,41 @@ func (_ *AutoscalingGroup) RenderTerraform(t *terraform.TerraformTarget, a, e, c
 	tf.SuspendedProcesses = processes
 
 	if e.WarmPool != nil && *e.WarmPool.Enabled {
+		var warmPoolMaxSize *terraformWriter.Literal
+		if e.WarmPool.MaxSize != nil {
+			warmPoolMaxSize = terraformWriter.LiteralFromIntValue(*e.WarmPool.MaxSize)
+		}
 		tf.WarmPool = &terraformWarmPool{
-			MinSize: &e.WarmPool.MinSize,
-			MaxSize: e.WarmPool.MaxSize,
+			MinSize: t.AddVariable(*e.Name, "warm_pool_min_size", fmt.Sprintf("The minimum size of the warm pool of the %s autoscaling group", *e.Name), "number", terraformWriter.LiteralFromIntValue(e.WarmPool.MinSize)),
+			MaxSize: t.AddVariable(*e.Name, "warm_pool_max_size", fmt.Sprintf("The maximum prepared capacity of the warm pool of the %s autoscaling group", *e.Name), "number", warmPoolMaxSize),
 		}
 	}
 
 	return t.RenderResource("aws_autoscaling_group", *e.Name, tf)
 }
 
+// instanceGroupTagsVariable returns a reference to the TF input variable for the tags of the autoscaling group
+// and launch template of an instance group, which share the same name and tags. Outside of module mode, it returns nil.
+func instanceGroupTagsVariable(t *terraform.TerraformTarget, name string, tags map[string]string) *terraformWriter.Literal {
+	return t.AddMapVariable(name, "tags", fmt.Sprintf("The tags of the %s autoscaling group and launch template, and of their instances and volumes", name), tags)
+}
+
 // TerraformLink fills in the property
 func (e *AutoscalingGroup) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_autoscaling_group", fi.ValueOf(e.Name), "id")
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *AutoscalingGroup) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*AutoscalingGroup)
+	if a.Name == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_autoscaling_group", ResourceName: *e.Name, ID: *a.Name},
+	}
+}
+
 func (e *AutoscalingGroup) FindDeletions(context *fi.CloudupContext) ([]fi.CloudupDeletion, error) {
 	return e.deletions, nil
 }
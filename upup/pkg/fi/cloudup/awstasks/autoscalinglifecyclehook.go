@@ -165,6 +165,17 @@ func (_ *AutoscalingLifecycleHook) RenderTerraform(t *terraform.TerraformTarget,
 	return t.RenderResource("aws_autoscaling_lifecycle_hook", *e.Name, tf)
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *AutoscalingLifecycleHook) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*AutoscalingLifecycleHook)
+	if a.AutoscalingGroup == nil || a.AutoscalingGroup.Name == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_autoscaling_lifecycle_hook", ResourceName: *e.Name, ID: *a.AutoscalingGroup.Name + "/" + fi.ValueOf(a.GetHookName())},
+	}
+}
+
 func (h *AutoscalingLifecycleHook) GetHookName() *string {
 	if h.HookName != nil {
 		return h.HookName
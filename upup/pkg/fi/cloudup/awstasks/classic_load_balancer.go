@@ -764,3 +764,14 @@ func (e *ClassicLoadBalancer) TerraformLink(params ...string) *terraformWriter.L
 	}
 	return terraformWriter.LiteralProperty("aws_elb", *e.Name, prop)
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *ClassicLoadBalancer) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*ClassicLoadBalancer)
+	if a.LoadBalancerName == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_elb", ResourceName: *e.Name, ID: *a.LoadBalancerName},
+	}
+}
@@ -194,3 +194,14 @@ func (_ *DHCPOptions) RenderTerraform(t *terraform.TerraformTarget, a, e, change
 func (e *DHCPOptions) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_vpc_dhcp_options", *e.Name, "id")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *DHCPOptions) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*DHCPOptions)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_vpc_dhcp_options", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
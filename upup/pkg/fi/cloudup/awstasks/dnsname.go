@@ -286,3 +286,16 @@ func (_ *DNSName) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *D
 func (e *DNSName) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralSelfLink("aws_route53_record", *e.Name)
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *DNSName) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*DNSName)
+	if a.Zone == nil || a.Zone.ZoneID == nil {
+		return nil
+	}
+	zoneID := strings.TrimPrefix(*a.Zone.ZoneID, "/hostedzone/")
+	name := strings.TrimSuffix(fi.ValueOf(a.ResourceName), ".")
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_route53_record", ResourceName: *e.Name, ID: zoneID + "_" + name + "_" + fi.ValueOf(a.ResourceType)},
+	}
+}
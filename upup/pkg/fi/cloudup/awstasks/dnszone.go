@@ -301,3 +301,9 @@ func (e *DNSZone) TerraformLink() *terraformWriter.Literal {
 
 	return terraformWriter.LiteralSelfLink("aws_route53_zone", *e.Name)
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *DNSZone) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	// Hosted zones are never managed by terraform, and the VPC association is only rendered if it does not exist yet.
+	return nil
+}
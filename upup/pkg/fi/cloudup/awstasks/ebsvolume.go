@@ -236,6 +236,18 @@ func (e *EBSVolume) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralSelfLink("aws_ebs_volume", tfName)
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *EBSVolume) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*EBSVolume)
+	if a.ID == nil {
+		return nil
+	}
+	tfName, _ := e.TerraformName()
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_ebs_volume", ResourceName: tfName, ID: *a.ID},
+	}
+}
+
 // TerraformName returns the terraform-safe name, along with a boolean indicating of whether name-prefixing was needed.
 func (e *EBSVolume) TerraformName() (string, bool) {
 	usedPrefix := false
@@ -223,3 +223,14 @@ func (e *EgressOnlyInternetGateway) TerraformLink() *terraformWriter.Literal {
 
 	return terraformWriter.LiteralProperty("aws_egress_only_internet_gateway", *e.Name, "id")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *EgressOnlyInternetGateway) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*EgressOnlyInternetGateway)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_egress_only_internet_gateway", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
@@ -305,3 +305,14 @@ func (e *ElasticIP) TerraformLink() *terraformWriter.Literal {
 
 	return terraformWriter.LiteralProperty("aws_eip", *e.Name, "id")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *ElasticIP) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*ElasticIP)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_eip", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
@@ -155,3 +155,14 @@ func (_ *EventBridgeRule) RenderTerraform(t *terraform.TerraformTarget, a, e, ch
 func (eb *EventBridgeRule) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_cloudwatch_event_rule", fi.ValueOf(eb.Name), "id")
 }
+
+// TerraformImports implements terraform.Importable.
+func (eb *EventBridgeRule) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*EventBridgeRule)
+	if a.Name == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_cloudwatch_event_rule", ResourceName: *eb.Name, ID: *a.Name},
+	}
+}
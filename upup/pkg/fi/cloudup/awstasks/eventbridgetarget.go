@@ -141,3 +141,14 @@ func (_ *EventBridgeTarget) RenderTerraform(t *terraform.TerraformTarget, a, e,
 
 	return t.RenderResource("aws_cloudwatch_event_target", *e.Name, tf)
 }
+
+// TerraformImports implements terraform.Importable.
+func (eb *EventBridgeTarget) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*EventBridgeTarget)
+	if a.ID == nil || a.Rule == nil || a.Rule.Name == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_cloudwatch_event_target", ResourceName: *eb.Name, ID: *a.Rule.Name + "/" + *a.ID},
+	}
+}
@@ -187,3 +187,14 @@ func (e *IAMInstanceProfile) TerraformLink() *terraformWriter.Literal {
 	}
 	return terraformWriter.LiteralProperty("aws_iam_instance_profile", *e.Name, "id")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *IAMInstanceProfile) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*IAMInstanceProfile)
+	if a.Name == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_iam_instance_profile", ResourceName: *e.Name, ID: *a.Name},
+	}
+}
@@ -124,3 +124,15 @@ func (_ *IAMInstanceProfileRole) RenderTerraform(t *terraform.TerraformTarget, a
 
 	return t.RenderResource("aws_iam_instance_profile", *e.InstanceProfile.Name, tf)
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *IAMInstanceProfileRole) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*IAMInstanceProfileRole)
+	if a.InstanceProfile == nil || a.InstanceProfile.Name == nil {
+		return nil
+	}
+	// The role is rendered as part of the instance profile resource
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_iam_instance_profile", ResourceName: *e.InstanceProfile.Name, ID: *a.InstanceProfile.Name},
+	}
+}
@@ -248,3 +248,14 @@ func (p *IAMOIDCProvider) RenderTerraform(t *terraform.TerraformTarget, a, e, ch
 func (e *IAMOIDCProvider) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_iam_openid_connect_provider", *e.Name, "arn")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *IAMOIDCProvider) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*IAMOIDCProvider)
+	if a.arn == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_iam_openid_connect_provider", ResourceName: *e.Name, ID: *a.arn},
+	}
+}
@@ -369,3 +369,14 @@ func (_ *IAMRole) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *I
 func (e *IAMRole) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_iam_role", *e.Name, "name")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *IAMRole) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*IAMRole)
+	if a.Name == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_iam_role", ResourceName: *e.Name, ID: *a.Name},
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/diff"
 	"k8s.io/kops/upup/pkg/fi"
@@ -313,11 +314,7 @@ type terraformIAMRolePolicy struct {
 func (_ *IAMRolePolicy) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *IAMRolePolicy) error {
 	if e.ExternalPolicies != nil && len(*e.ExternalPolicies) > 0 {
 		for _, policy := range *e.ExternalPolicies {
-			// create a hash of the arn
-			h := fnv.New32a()
-			h.Write([]byte(policy))
-
-			name := fmt.Sprintf("%s-%d", *e.Name, h.Sum32())
+			name := externalPolicyTerraformName(*e.Name, policy)
 
 			tf := &terraformIAMRolePolicy{
 				Role:      e.Role.TerraformLink(),
@@ -355,6 +352,48 @@ func (_ *IAMRolePolicy) RenderTerraform(t *terraform.TerraformTarget, a, e, chan
 	return t.RenderResource("aws_iam_role_policy", *e.Name, tf)
 }
 
+// externalPolicyTerraformName returns the name of the terraform resource that attaches an external policy.
+func externalPolicyTerraformName(name string, policy string) string {
+	// create a hash of the arn
+	h := fnv.New32a()
+	h.Write([]byte(policy))
+
+	return fmt.Sprintf("%s-%d", name, h.Sum32())
+}
+
 func (e *IAMRolePolicy) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralSelfLink("aws_iam_role_policy", *e.Name)
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *IAMRolePolicy) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*IAMRolePolicy)
+	if a.Role == nil || a.Role.Name == nil {
+		return nil
+	}
+
+	if a.Managed {
+		var imports []*terraformWriter.Import
+		if e.ExternalPolicies != nil && a.ExternalPolicies != nil {
+			attached := sets.New(*a.ExternalPolicies...)
+			for _, policy := range *e.ExternalPolicies {
+				if !attached.Has(policy) {
+					continue
+				}
+				imports = append(imports, &terraformWriter.Import{
+					ResourceType: "aws_iam_role_policy_attachment",
+					ResourceName: externalPolicyTerraformName(*e.Name, policy),
+					ID:           *a.Role.Name + "/" + policy,
+				})
+			}
+		}
+		return imports
+	}
+
+	if a.Name == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_iam_role_policy", ResourceName: *e.Name, ID: *a.Role.Name + ":" + *a.Name},
+	}
+}
@@ -236,3 +236,14 @@ func (e *InternetGateway) TerraformLink() *terraformWriter.Literal {
 
 	return terraformWriter.LiteralProperty("aws_internet_gateway", *e.Name, "id")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *InternetGateway) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*InternetGateway)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_internet_gateway", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
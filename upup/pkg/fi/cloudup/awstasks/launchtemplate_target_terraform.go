@@ -17,6 +17,8 @@ limitations under the License.
 package awstasks
 
 import (
+	"fmt"
+
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
@@ -113,8 +115,8 @@ type terraformLaunchTemplateCreditSpecification struct {
 type terraformLaunchTemplateTagSpecification struct {
 	// ResourceType is the type of resource to tag.
 	ResourceType *string `cty:"resource_type"`
-	// Tags are the tags to apply to the resource.
-	Tags map[string]string `cty:"tags"`
+	// Tags are the tags to apply to the resource, either a map[string]string or a variable reference in module mode.
+	Tags interface{} `cty:"tags"`
 }
 
 type terraformLaunchTemplateInstanceMetadata struct {
@@ -143,9 +145,9 @@ type terraformLaunchTemplate struct {
 	// IAMInstanceProfile is the IAM profile to assign to the nodes
 	IAMInstanceProfile []*terraformLaunchTemplateIAMProfile `cty:"iam_instance_profile"`
 	// ImageID is the ami to use for the instances
-	ImageID *string `cty:"image_id"`
+	ImageID *terraformWriter.Literal `cty:"image_id"`
 	// InstanceType is the type of instance
-	InstanceType *ec2types.InstanceType `cty:"instance_type"`
+	InstanceType *terraformWriter.Literal `cty:"instance_type"`
 	// KeyName is the ssh key to use
 	KeyName *terraformWriter.Literal `cty:"key_name"`
 	// MarketOptions are the spot pricing options
@@ -158,8 +160,8 @@ type terraformLaunchTemplate struct {
 	NetworkInterfaces []*terraformLaunchTemplateNetworkInterface `cty:"network_interfaces"`
 	// Placement are the tenancy options
 	Placement []*terraformLaunchTemplatePlacement `cty:"placement"`
-	// Tags is a map of tags applied to the launch template itself
-	Tags map[string]string `cty:"tags"`
+	// Tags are the tags applied to the launch template itself, either a map[string]string or a variable reference in module mode.
+	Tags interface{} `cty:"tags"`
 	// TagSpecifications are the tags to apply to a resource when it is created.
 	TagSpecifications []*terraformLaunchTemplateTagSpecification `cty:"tag_specifications"`
 	// UserData is the user data for the instances
@@ -171,6 +173,17 @@ func (t *LaunchTemplate) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_launch_template", fi.ValueOf(t.Name), "id")
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *LaunchTemplate) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*LaunchTemplate)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_launch_template", ResourceName: fi.ValueOf(e.Name), ID: *a.ID},
+	}
+}
+
 // VersionLink returns the terraform version reference
 func (t *LaunchTemplate) VersionLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_launch_template", fi.ValueOf(t.Name), "latest_version")
@@ -182,20 +195,29 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 
 	cloud := target.Cloud.(awsup.AWSCloud)
 
-	var image *string
+	name := fi.ValueOf(e.Name)
+
+	var image *terraformWriter.Literal
 	if e.ImageID != nil {
 		im, err := cloud.ResolveImage(fi.ValueOf(e.ImageID))
 		if err != nil {
 			return err
 		}
-		image = im.ImageId
+		if im.ImageId != nil {
+			image = terraformWriter.LiteralFromStringValue(*im.ImageId)
+		}
+	}
+
+	var instanceType *terraformWriter.Literal
+	if e.InstanceType != nil {
+		instanceType = terraformWriter.LiteralFromStringValue(string(*e.InstanceType))
 	}
 
 	tf := terraformLaunchTemplate{
 		Name:         e.Name,
 		EBSOptimized: e.RootVolumeOptimization,
-		ImageID:      image,
-		InstanceType: e.InstanceType,
+		ImageID:      target.AddVariable(name, "image_id", fmt.Sprintf("The AMI of the %s launch template", name), "string", image),
+		InstanceType: target.AddVariable(name, "instance_type", fmt.Sprintf("The instance type of the %s launch template", name), "string", instanceType),
 		Lifecycle:    &terraform.Lifecycle{CreateBeforeDestroy: fi.PtrTo(true)},
 		MetadataOptions: &terraformLaunchTemplateInstanceMetadata{
 			// See issue https://github.com/hashicorp/terraform-provider-aws/issues/12564.
@@ -315,16 +337,20 @@ func (t *LaunchTemplate) RenderTerraform(target *terraform.TerraformTarget, a, e
 	}
 
 	if e.Tags != nil {
+		var tags interface{} = e.Tags
+		if v := instanceGroupTagsVariable(target, name, e.Tags); v != nil {
+			tags = v
+		}
 		tf.TagSpecifications = append(tf.TagSpecifications, &terraformLaunchTemplateTagSpecification{
 			ResourceType: fi.PtrTo("instance"),
-			Tags:         e.Tags,
+			Tags:         tags,
 		})
 		tf.TagSpecifications = append(tf.TagSpecifications, &terraformLaunchTemplateTagSpecification{
 			ResourceType: fi.PtrTo("volume"),
-			Tags:         e.Tags,
+			Tags:         tags,
 		})
-		tf.Tags = e.Tags
+		tf.Tags = tags
 	}
 
-	return target.RenderResource("aws_launch_template", fi.ValueOf(e.Name), tf)
+	return target.RenderResource("aws_launch_template", name, tf)
 }
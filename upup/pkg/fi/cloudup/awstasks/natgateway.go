@@ -401,3 +401,14 @@ func (e *NatGateway) TerraformLink() *terraformWriter.Literal {
 
 	return terraformWriter.LiteralProperty("aws_nat_gateway", *e.Name, "id")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *NatGateway) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*NatGateway)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_nat_gateway", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
@@ -685,6 +685,17 @@ func (_ *NetworkLoadBalancer) RenderTerraform(t *terraform.TerraformTarget, a, e
 	return nil
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *NetworkLoadBalancer) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*NetworkLoadBalancer)
+	if a.loadBalancerArn == "" {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_lb", ResourceName: e.TerraformName(), ID: a.loadBalancerArn},
+	}
+}
+
 func (e *NetworkLoadBalancer) TerraformName() string {
 	tfName := strings.Replace(fi.ValueOf(e.Name), ".", "-", -1)
 	return tfName
@@ -257,6 +257,17 @@ func (_ *NetworkLoadBalancerListener) RenderTerraform(t *terraform.TerraformTarg
 	return nil
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *NetworkLoadBalancerListener) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*NetworkLoadBalancerListener)
+	if a.listenerArn == "" {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_lb_listener", ResourceName: e.TerraformName(), ID: a.listenerArn},
+	}
+}
+
 func (e *NetworkLoadBalancerListener) TerraformName() string {
 	tfName := fmt.Sprintf("%v-%v", e.NetworkLoadBalancer.TerraformName(), e.Port)
 	return tfName
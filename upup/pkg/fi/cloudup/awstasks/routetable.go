@@ -219,3 +219,14 @@ func (_ *RouteTable) RenderTerraform(t *terraform.TerraformTarget, a, e, changes
 func (e *RouteTable) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_route_table", *e.Name, "id")
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *RouteTable) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*RouteTable)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_route_table", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
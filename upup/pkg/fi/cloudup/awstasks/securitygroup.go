@@ -226,6 +226,17 @@ func (e *SecurityGroup) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_security_group", *e.Name, "id")
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *SecurityGroup) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*SecurityGroup)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_security_group", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
+
 // deleteSecurityGroupRule tracks a securitygrouprule that we're going to delete
 // It implements fi.CloudupDeletion
 type deleteSecurityGroupRule struct {
@@ -400,3 +400,51 @@ func (_ *SecurityGroupRule) RenderTerraform(t *terraform.TerraformTarget, a, e,
 
 	return t.RenderResource("aws_security_group_rule", *e.Name, tf)
 }
+
+// TerraformImports implements terraform.Importable.
+// The import ID has the form SECURITYGROUPID_TYPE_PROTOCOL_FROMPORT_TOPORT_SOURCE, and uses the same values as RenderTerraform.
+func (e *SecurityGroupRule) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*SecurityGroupRule)
+	if a.SecurityGroup == nil || a.SecurityGroup.ID == nil {
+		return nil
+	}
+
+	ruleType := "ingress"
+	if fi.ValueOf(e.Egress) {
+		ruleType = "egress"
+	}
+
+	protocol := fi.ValueOf(e.Protocol)
+	fromPort := fi.ValueOf(e.FromPort)
+	toPort := int32(65535)
+	if e.ToPort != nil {
+		toPort = *e.ToPort
+	}
+	if e.Protocol == nil {
+		protocol = "-1"
+		fromPort = 0
+		toPort = 0
+	}
+
+	var source string
+	switch {
+	case a.SourceGroup != nil && a.SourceGroup.ID != nil:
+		source = *a.SourceGroup.ID
+	case e.CIDR != nil:
+		source = *e.CIDR
+	case e.IPv6CIDR != nil:
+		source = *e.IPv6CIDR
+	case e.PrefixList != nil:
+		source = *e.PrefixList
+	default:
+		return nil
+	}
+
+	return []*terraformWriter.Import{
+		{
+			ResourceType: "aws_security_group_rule",
+			ResourceName: *e.Name,
+			ID:           fmt.Sprintf("%s_%s_%s_%d_%d_%s", *a.SecurityGroup.ID, ruleType, protocol, fromPort, toPort, source),
+		},
+	}
+}
@@ -219,6 +219,17 @@ func (e *SQS) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_sqs_queue", *e.Name, "arn")
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *SQS) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*SQS)
+	if a.URL == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_sqs_queue", ResourceName: *e.Name, ID: *a.URL},
+	}
+}
+
 // intersectSQSTags does the same thing as intersectTags, but takes different input because SQS tags are listed differently
 func intersectSQSTags(tags map[string]string, desired map[string]string) map[string]string {
 	if tags == nil {
@@ -229,6 +229,18 @@ func (e *SSHKey) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_key_pair", tfName, "id")
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *SSHKey) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*SSHKey)
+	if a.Name == nil {
+		return nil
+	}
+	tfName := strings.Replace(*e.Name, ":", "", -1)
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_key_pair", ResourceName: tfName, ID: *a.Name},
+	}
+}
+
 func (e *SSHKey) NoSSHKey() bool {
 	return e.ID == nil && e.Name == nil && e.PublicKey == nil && e.KeyFingerprint == nil
 }
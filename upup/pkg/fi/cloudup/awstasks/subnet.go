@@ -473,6 +473,17 @@ func (e *Subnet) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_subnet", *e.Name, "id")
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *Subnet) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*Subnet)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_subnet", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
+
 func (e *Subnet) FindDeletions(c *fi.CloudupContext) ([]fi.CloudupDeletion, error) {
 	if e.ID == nil || aws.ToBool(e.Shared) {
 		return nil, nil
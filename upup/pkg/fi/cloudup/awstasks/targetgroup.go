@@ -484,6 +484,17 @@ func (e *TargetGroup) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_lb_target_group", *e.Name, "id")
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *TargetGroup) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*TargetGroup)
+	if a.ARN == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_lb_target_group", ResourceName: *e.Name, ID: *a.ARN},
+	}
+}
+
 var _ fi.CloudupProducesDeletions = &TargetGroup{}
 
 // FindDeletions is responsible for finding launch templates which can be deleted
@@ -368,6 +368,17 @@ func (e *VPC) TerraformLink() *terraformWriter.Literal {
 	return terraformWriter.LiteralProperty("aws_vpc", *e.Name, "id")
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *VPC) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*VPC)
+	if a.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_vpc", ResourceName: *e.Name, ID: *a.ID},
+	}
+}
+
 type deleteVPCCIDRBlock struct {
 	vpcID         *string
 	cidrBlock     *string
@@ -116,3 +116,14 @@ func (_ *VPCDHCPOptionsAssociation) RenderTerraform(t *terraform.TerraformTarget
 
 	return t.RenderResource("aws_vpc_dhcp_options_association", *e.Name, tf)
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *VPCDHCPOptionsAssociation) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*VPCDHCPOptionsAssociation)
+	if a.VPC == nil || a.VPC.ID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_vpc_dhcp_options_association", ResourceName: *e.Name, ID: *a.VPC.ID},
+	}
+}
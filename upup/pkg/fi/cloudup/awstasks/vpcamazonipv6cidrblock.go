@@ -26,6 +26,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // +kops:fitask
@@ -113,6 +114,12 @@ func (_ *VPCAmazonIPv6CIDRBlock) RenderTerraform(t *terraform.TerraformTarget, a
 	return nil
 }
 
+// TerraformImports implements terraform.Importable.
+func (e *VPCAmazonIPv6CIDRBlock) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	// The CIDR block is part of the aws_vpc resource
+	return nil
+}
+
 func findVPCIPv6CIDR(cloud awsup.AWSCloud, vpcID *string) (*string, error) {
 	vpc, err := cloud.DescribeVPC(aws.ToString(vpcID))
 	if err != nil {
@@ -39,6 +39,9 @@ type VPCCIDRBlock struct {
 
 	// Shared is set if this is a shared VPC
 	Shared *bool
+
+	// associationID is the ID of the association between the CIDR block and the VPC, once found
+	associationID *string
 }
 
 func (e *VPCCIDRBlock) Find(c *fi.CloudupContext) (*VPCCIDRBlock, error) {
@@ -56,7 +59,7 @@ func (e *VPCCIDRBlock) Find(c *fi.CloudupContext) (*VPCCIDRBlock, error) {
 		return nil, err
 	}
 
-	found := false
+	var associationID *string
 	if e.CIDRBlock != nil {
 		for _, cba := range vpc.CidrBlockAssociationSet {
 			if cba.CidrBlockState == nil {
@@ -69,18 +72,19 @@ func (e *VPCCIDRBlock) Find(c *fi.CloudupContext) (*VPCCIDRBlock, error) {
 			}
 
 			if aws.ToString(cba.CidrBlock) == aws.ToString(e.CIDRBlock) {
-				found = true
+				associationID = cba.AssociationId
 				break
 			}
 		}
 	}
-	if !found {
+	if associationID == nil {
 		return nil, nil
 	}
 
 	actual := &VPCCIDRBlock{
-		VPC:       &VPC{ID: vpc.VpcId},
-		CIDRBlock: e.CIDRBlock,
+		VPC:           &VPC{ID: vpc.VpcId},
+		CIDRBlock:     e.CIDRBlock,
+		associationID: associationID,
 	}
 
 	// Prevent spurious changes
@@ -165,3 +169,14 @@ func (_ *VPCCIDRBlock) RenderTerraform(t *terraform.TerraformTarget, a, e, chang
 	name := fmt.Sprintf("cidr-%v", *e.Name)
 	return t.RenderResource("aws_vpc_ipv4_cidr_block_association", name, tf)
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *VPCCIDRBlock) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	a := actual.(*VPCCIDRBlock)
+	if a.associationID == nil {
+		return nil
+	}
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_vpc_ipv4_cidr_block_association", ResourceName: fmt.Sprintf("cidr-%v", *e.Name), ID: *a.associationID},
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
 // WarmPool provdes the definition for an ASG warm pool in aws.
@@ -149,3 +150,9 @@ func (*WarmPool) RenderAWS(t *awsup.AWSAPITarget, a, e, changes *WarmPool) error
 func (_ *WarmPool) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *WarmPool) error {
 	return nil
 }
+
+// TerraformImports implements terraform.Importable.
+func (e *WarmPool) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	// The warm pool is part of the aws_autoscaling_group resource
+	return nil
+}
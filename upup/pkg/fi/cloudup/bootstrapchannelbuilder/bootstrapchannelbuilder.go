@@ -631,7 +631,10 @@ func (b *BootstrapChannelBuilder) buildAddons(c *fi.CloudupModelBuilderContext)
 		}
 	}
 
-	nvidia := b.Cluster.Spec.Containerd.NvidiaGPU
+	var nvidia *kops.NvidiaGPUConfig
+	if b.Cluster.Spec.Containerd != nil {
+		nvidia = b.Cluster.Spec.Containerd.NvidiaGPU
+	}
 	igNvidia := false
 	for _, ig := range b.KopsModelContext.InstanceGroups {
 		if ig.Spec.Containerd != nil && ig.Spec.Containerd.NvidiaGPU != nil && fi.ValueOf(ig.Spec.Containerd.NvidiaGPU.Enabled) {
@@ -26,6 +26,7 @@ import (
 	"sync"
 
 	"golang.org/x/oauth2/google"
+	cloudkms "google.golang.org/api/cloudkms/v1"
 	"google.golang.org/api/cloudresourcemanager/v1"
 	compute "google.golang.org/api/compute/v1"
 	oauth2 "google.golang.org/api/oauth2/v2"
@@ -55,6 +56,9 @@ type GCECloud interface {
 
 	// CloudResourceManager returns the client for the cloudresourcemanager API
 	CloudResourceManager() *cloudresourcemanager.Service
+
+	// CloudKMS returns the client for the Cloud KMS API
+	CloudKMS() *cloudkms.Service
 }
 
 // MutexForProjectIAM returns a mutex to prevent local concurrent operations on project IAM.
@@ -71,6 +75,9 @@ type gceCloudImplementation struct {
 	// cloudResourceManager is the client for the cloudresourcemanager API
 	cloudResourceManager *cloudresourcemanager.Service
 
+	// cloudKMS is the client for the Cloud KMS API
+	cloudKMS *cloudkms.Service
+
 	region  string
 	project string
 
@@ -166,6 +173,12 @@ func NewGCECloud(region string, project string, labels map[string]string) (GCECl
 	}
 	c.cloudResourceManager = cloudResourceManager
 
+	cloudKMS, err := cloudkms.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error building cloudkms API client: %w", err)
+	}
+	c.cloudKMS = cloudKMS
+
 	CacheGCECloudInstance(region, project, c)
 
 	{
@@ -227,6 +240,11 @@ func (c *gceCloudImplementation) CloudResourceManager() *cloudresourcemanager.Se
 	return c.cloudResourceManager
 }
 
+// CloudKMS returns the client for the Cloud KMS API
+func (c *gceCloudImplementation) CloudKMS() *cloudkms.Service {
+	return c.cloudKMS
+}
+
 // Region returns private struct element region.
 func (c *gceCloudImplementation) Region() string {
 	return c.region
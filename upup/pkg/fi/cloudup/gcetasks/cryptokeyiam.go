@@ -0,0 +1,170 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcetasks
+
+import (
+	"context"
+	"fmt"
+
+	cloudkms "google.golang.org/api/cloudkms/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/gce"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+)
+
+// CryptoKeyIAM represents an IAM rule on a Cloud KMS crypto key
+// +kops:fitask
+type CryptoKeyIAM struct {
+	Name      *string
+	Lifecycle fi.Lifecycle
+
+	// CryptoKey is the resource name of the key, projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>
+	CryptoKey *string
+	Member    *string
+	Role      *string
+}
+
+var _ fi.CompareWithID = &CryptoKeyIAM{}
+
+func (e *CryptoKeyIAM) CompareWithID() *string {
+	return e.Name
+}
+
+func (e *CryptoKeyIAM) Find(c *fi.CloudupContext) (*CryptoKeyIAM, error) {
+	ctx := context.TODO()
+
+	cloud := c.T.Cloud.(gce.GCECloud)
+
+	cryptoKey := fi.ValueOf(e.CryptoKey)
+	member := fi.ValueOf(e.Member)
+	role := fi.ValueOf(e.Role)
+
+	klog.V(2).Infof("Checking KMS crypto key IAM for %s for %s", cryptoKey, member)
+	policy, err := cloud.CloudKMS().Projects.Locations.KeyRings.CryptoKeys.GetIamPolicy(cryptoKey).Context(ctx).Do()
+	if err != nil {
+		if gce.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error checking KMS crypto key IAM for %s: %w", cryptoKey, err)
+	}
+
+	changed := patchCryptoKeyPolicy(policy, member, role)
+	if changed {
+		return nil, nil
+	}
+
+	actual := &CryptoKeyIAM{}
+	actual.CryptoKey = e.CryptoKey
+	actual.Member = e.Member
+	actual.Role = e.Role
+
+	// Ignore "system" fields
+	actual.Name = e.Name
+	actual.Lifecycle = e.Lifecycle
+
+	return actual, nil
+}
+
+func (e *CryptoKeyIAM) Run(c *fi.CloudupContext) error {
+	return fi.CloudupDefaultDeltaRunMethod(e, c)
+}
+
+func (_ *CryptoKeyIAM) CheckChanges(a, e, changes *CryptoKeyIAM) error {
+	if fi.ValueOf(e.CryptoKey) == "" {
+		return fi.RequiredField("CryptoKey")
+	}
+	if fi.ValueOf(e.Member) == "" {
+		return fi.RequiredField("Member")
+	}
+	if fi.ValueOf(e.Role) == "" {
+		return fi.RequiredField("Role")
+	}
+	return nil
+}
+
+func (_ *CryptoKeyIAM) RenderGCE(t *gce.GCEAPITarget, a, e, changes *CryptoKeyIAM) error {
+	ctx := context.TODO()
+
+	cryptoKey := fi.ValueOf(e.CryptoKey)
+	member := fi.ValueOf(e.Member)
+	role := fi.ValueOf(e.Role)
+
+	klog.V(2).Infof("Creating KMS crypto key IAM for %s for %s as %s", cryptoKey, member, role)
+
+	cryptoKeys := t.Cloud.CloudKMS().Projects.Locations.KeyRings.CryptoKeys
+	policy, err := cryptoKeys.GetIamPolicy(cryptoKey).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("error getting IAM policy for KMS crypto key %s: %w", cryptoKey, err)
+	}
+
+	changed := patchCryptoKeyPolicy(policy, member, role)
+
+	if !changed {
+		klog.Warningf("did not need to change policy (concurrent change?)")
+		return nil
+	}
+
+	// The etag of the policy we read makes the update fail on a concurrent change
+	request := &cloudkms.SetIamPolicyRequest{Policy: policy}
+	if _, err := cryptoKeys.SetIamPolicy(cryptoKey, request).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("error updating KMS crypto key IAM for %s: %w", cryptoKey, err)
+	}
+
+	return nil
+}
+
+// terraformCryptoKeyIAM is the model for a terraform google_kms_crypto_key_iam_member rule
+type terraformCryptoKeyIAM struct {
+	CryptoKeyID string `cty:"crypto_key_id"`
+	Role        string `cty:"role"`
+	Member      string `cty:"member"`
+}
+
+func (_ *CryptoKeyIAM) RenderTerraform(t *terraform.TerraformTarget, a, e, changes *CryptoKeyIAM) error {
+	tf := &terraformCryptoKeyIAM{
+		CryptoKeyID: fi.ValueOf(e.CryptoKey),
+		Role:        fi.ValueOf(e.Role),
+		Member:      fi.ValueOf(e.Member),
+	}
+
+	return t.RenderResource("google_kms_crypto_key_iam_member", *e.Name, tf)
+}
+
+func patchCryptoKeyPolicy(policy *cloudkms.Policy, wantMember string, wantRole string) bool {
+	for _, binding := range policy.Bindings {
+		if binding.Condition != nil {
+			continue
+		}
+		if binding.Role != wantRole {
+			continue
+		}
+		for _, member := range binding.Members {
+			if member == wantMember {
+				return false
+			}
+		}
+		binding.Members = append(binding.Members, wantMember)
+		return true
+	}
+
+	policy.Bindings = append(policy.Bindings, &cloudkms.Binding{
+		Members: []string{wantMember},
+		Role:    wantRole,
+	})
+	return true
+}
@@ -409,6 +409,9 @@ func (tf *TemplateFunctions) AddTo(dest template.FuncMap, secretStore fi.SecretS
 	dest["KopsVersion"] = func() string { return kopsroot.KOPS_RELEASE_VERSION }
 
 	dest["ContainerdSELinuxEnabled"] = func() bool {
+		if cluster.Spec.UsesCrio() {
+			return cluster.Spec.Crio != nil && cluster.Spec.Crio.SeLinuxEnabled
+		}
 		if cluster.Spec.Containerd != nil {
 			return cluster.Spec.Containerd.SeLinuxEnabled
 		}
@@ -671,10 +674,22 @@ func (tf *TemplateFunctions) KopsControllerConfig() (string, error) {
 		SecretStore: cluster.Spec.ConfigStore.Secrets,
 	}
 
+	if keypairs := cluster.Spec.ConfigStore.Keypairs; keypairs != "" && keypairs != strings.TrimSuffix(cluster.Spec.ConfigStore.Base, "/")+"/pki" {
+		config.Keystore = keypairs
+	}
+
 	if featureflag.CacheNodeidentityInfo.Enabled() {
 		config.CacheNodeidentityInfo = true
 	}
 
+	if encryption := cluster.Spec.ConfigStore.Encryption; encryption != nil {
+		config.SecretStoreEncryption = encryption.DeepCopy()
+		if encryption.Age != nil {
+			// nodeup copies the identity into the kops-controller pki directory
+			config.SecretStoreEncryption.Age.IdentityFile = "/etc/kubernetes/kops-controller/pki/age-identity.txt"
+		}
+	}
+
 	{
 		certNames := []string{"kubelet", "kubelet-server"}
 		signingCAs := []string{fi.CertificateIDCA}
@@ -80,6 +80,9 @@ func (o *object) Write(buffer *bytes.Buffer, indent int, key string) {
 var literalType = reflect.TypeOf(terraformWriter.Literal{})
 
 func toElement(item interface{}) element {
+	if item == nil {
+		return nil
+	}
 	if literal, ok := item.(*terraformWriter.Literal); ok {
 		if literal == nil {
 			return nil
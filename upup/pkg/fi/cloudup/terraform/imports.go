@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+	"k8s.io/kops/util/pkg/reflectutils"
+)
+
+// importsRequiredVersion is the minimum terraform version that supports import blocks.
+const importsRequiredVersion = ">= 1.5.0"
+
+// Importable is implemented by tasks whose existing cloud resources can be imported into terraform state.
+type Importable interface {
+	// TerraformImports returns the imports for the terraform resources rendered by the task,
+	// given the existing resource returned by the task's Find.
+	TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import
+}
+
+// FindImports looks up the existing cloud resource for each task, and records an import block
+// for each of the terraform resources the task renders.
+// It returns an error if an existing resource cannot be imported, because terraform would otherwise
+// plan to create a duplicate of it.
+func (t *TerraformTarget) FindImports(c *fi.CloudupContext, tasks map[string]fi.CloudupTask) error {
+	found := 0
+	var unsupported []string
+	for _, key := range sortedKeysForMap(tasks) {
+		task := tasks[key]
+		if hl, ok := task.(fi.HasLifecycle); ok && hl.GetLifecycle() == fi.LifecycleIgnore {
+			continue
+		}
+
+		importable, ok := task.(Importable)
+		if !ok && (!rendersTerraform(task) || isShared(task)) {
+			// Nothing is rendered to terraform, so there is nothing to import
+			continue
+		}
+
+		actual, err := findTask(c, task)
+		if err != nil {
+			return fmt.Errorf("error finding existing resource for %s: %w", key, err)
+		}
+		if actual == nil {
+			klog.V(2).Infof("no existing resource found for %s", key)
+			continue
+		}
+
+		if !ok {
+			unsupported = append(unsupported, key)
+			continue
+		}
+
+		for _, i := range importable.TerraformImports(actual) {
+			t.AddImport(i.ResourceType, i.ResourceName, i.ID)
+			found++
+		}
+	}
+
+	if len(unsupported) != 0 {
+		return fmt.Errorf("import blocks are not supported for the existing resources of %d tasks, and terraform would plan to create them again: %s", len(unsupported), strings.Join(unsupported, ", "))
+	}
+
+	klog.Infof("found %d existing cloud resources to import into terraform", found)
+	return nil
+}
+
+// rendersTerraform returns true if the task has a RenderTerraform method.
+func rendersTerraform(task fi.CloudupTask) bool {
+	return reflect.ValueOf(task).MethodByName("RenderTerraform").IsValid()
+}
+
+// isShared returns true if the task has a Shared field that is set, meaning that the resource is not managed by kOps.
+func isShared(task fi.CloudupTask) bool {
+	v := reflect.ValueOf(task)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	f := v.FieldByName("Shared")
+	if !f.IsValid() {
+		return false
+	}
+	shared, ok := f.Interface().(*bool)
+	return ok && fi.ValueOf(shared)
+}
+
+// findTask calls the Find method of the task by reflection.
+func findTask(c *fi.CloudupContext, task fi.CloudupTask) (fi.CloudupTask, error) {
+	rv, err := reflectutils.InvokeMethod(task, "Find", c)
+	if err != nil {
+		return nil, err
+	}
+	if !rv[1].IsNil() {
+		return nil, rv[1].Interface().(error)
+	}
+	if rv[0].IsNil() {
+		return nil, nil
+	}
+	return rv[0].Interface().(fi.CloudupTask), nil
+}
+
+// writeImports creates an import block for each existing resource
+// Example:
+//
+//	import {
+//	  to = aws_vpc.minimal-example-com
+//	  id = "vpc-12345678"
+//	}
+func writeImports(buf *bytes.Buffer, imports map[string]string) {
+	addresses := make([]string, 0, len(imports))
+	for address := range imports {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		buf.WriteString("import {\n")
+		buf.WriteString(fmt.Sprintf("  to = %s\n", address))
+		buf.WriteString(fmt.Sprintf("  id = %q\n", imports[address]))
+		buf.WriteString("}\n\n")
+	}
+}
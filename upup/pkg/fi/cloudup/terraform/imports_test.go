@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package terraform
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
+)
+
+// unimportableTask renders a terraform resource, but does not support import blocks.
+type unimportableTask struct {
+	Name   *string
+	Shared *bool
+
+	exists bool
+}
+
+func (e *unimportableTask) Run(c *fi.CloudupContext) error {
+	return nil
+}
+
+func (e *unimportableTask) Find(c *fi.CloudupContext) (*unimportableTask, error) {
+	if !e.exists {
+		return nil, nil
+	}
+	return &unimportableTask{Name: e.Name}, nil
+}
+
+func (_ *unimportableTask) RenderTerraform(t *TerraformTarget, a, e, changes *unimportableTask) error {
+	return nil
+}
+
+// importableTask renders a terraform resource that can be imported.
+type importableTask struct {
+	unimportableTask
+}
+
+func (e *importableTask) Find(c *fi.CloudupContext) (*importableTask, error) {
+	if !e.exists {
+		return nil, nil
+	}
+	return &importableTask{unimportableTask{Name: e.Name}}, nil
+}
+
+func (e *importableTask) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	return []*terraformWriter.Import{
+		{ResourceType: "aws_vpc", ResourceName: *e.Name, ID: "vpc-12345678"},
+	}
+}
+
+// localTask does not render anything to terraform.
+type localTask struct {
+	Name *string
+}
+
+func (e *localTask) Run(c *fi.CloudupContext) error {
+	return nil
+}
+
+func (e *localTask) Find(c *fi.CloudupContext) (*localTask, error) {
+	return e, nil
+}
+
+func TestFindImports(t *testing.T) {
+	grid := []struct {
+		name          string
+		tasks         map[string]fi.CloudupTask
+		expectedError string
+	}{
+		{
+			name: "importable existing resource",
+			tasks: map[string]fi.CloudupTask{
+				"VPC/test": &importableTask{unimportableTask{Name: fi.PtrTo("test"), exists: true}},
+			},
+		},
+		{
+			name: "unimportable missing resource",
+			tasks: map[string]fi.CloudupTask{
+				"Route/test": &unimportableTask{Name: fi.PtrTo("test")},
+			},
+		},
+		{
+			name: "unimportable shared resource",
+			tasks: map[string]fi.CloudupTask{
+				"Route/test": &unimportableTask{Name: fi.PtrTo("test"), Shared: fi.PtrTo(true), exists: true},
+			},
+		},
+		{
+			name: "task not rendered to terraform",
+			tasks: map[string]fi.CloudupTask{
+				"Keypair/test": &localTask{Name: fi.PtrTo("test")},
+			},
+		},
+		{
+			name: "unimportable existing resource",
+			tasks: map[string]fi.CloudupTask{
+				"VPC/test":   &importableTask{unimportableTask{Name: fi.PtrTo("test"), exists: true}},
+				"Route/test": &unimportableTask{Name: fi.PtrTo("test"), exists: true},
+			},
+			expectedError: "import blocks are not supported for the existing resources of 1 tasks, and terraform would plan to create them again: Route/test",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			target := NewTerraformTarget(nil, "", "", nil)
+			err := target.FindImports(&fi.CloudupContext{}, g.tasks)
+			if g.expectedError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), g.expectedError) {
+				t.Fatalf("expected error %q, got %v", g.expectedError, err)
+			}
+		})
+	}
+}
@@ -19,7 +19,9 @@ package terraform
 import (
 	"bytes"
 	"fmt"
+	"path"
 	"sort"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
@@ -31,13 +33,19 @@ import (
 func (t *TerraformTarget) finishHCL2() error {
 	buf := &bytes.Buffer{}
 
+	// In module mode, the outputs and providers are written to separate files
+	outputsBuf, providersBuf := buf, buf
+	if t.Module {
+		outputsBuf, providersBuf = &bytes.Buffer{}, &bytes.Buffer{}
+	}
+
 	outputs, err := t.GetOutputs()
 	if err != nil {
 		return err
 	}
-	writeLocalsOutputs(buf, outputs)
+	writeLocalsOutputs(buf, outputsBuf, outputs)
 
-	t.writeProviders(buf)
+	t.writeProviders(providersBuf)
 
 	resourcesByType, err := t.GetResourcesByType()
 	if err != nil {
@@ -53,18 +61,128 @@ func (t *TerraformTarget) finishHCL2() error {
 
 	t.writeDataSources(buf, dataSourcesByType)
 
-	t.writeTerraform(buf)
+	imports, err := t.GetImports()
+	if err != nil {
+		return err
+	}
+
+	if t.Module {
+		t.finishModule(buf, outputsBuf, providersBuf, outputs, imports)
+		return nil
+	}
+
+	t.writeTerraform(buf, len(imports) != 0, true)
 
 	t.Files["kubernetes.tf"] = buf.Bytes()
 
+	if len(imports) != 0 {
+		importsBuf := &bytes.Buffer{}
+		writeImports(importsBuf, imports)
+		t.Files["imports.tf"] = importsBuf.Bytes()
+	}
+
 	return nil
 }
 
+const (
+	// moduleName is the name of the module block that calls the kOps module, in module mode.
+	moduleName = "kubernetes"
+	// moduleDir is the directory of the kOps module, relative to the output directory.
+	moduleDir = "modules/kubernetes"
+)
+
+// finishModule lays out the output as a root module, which configures the providers and calls the kOps module.
+// The kOps module, in moduleDir, holds the resources, the input variables and the outputs.
+func (t *TerraformTarget) finishModule(resourcesBuf, outputsBuf, providersBuf *bytes.Buffer, outputs map[string]terraformWriter.OutputValue, imports map[string]string) {
+	files := make(map[string][]byte)
+	for relativePath, contents := range t.Files {
+		files[path.Join(moduleDir, relativePath)] = contents
+	}
+
+	variablesBuf := &bytes.Buffer{}
+	writeVariables(variablesBuf, t.GetVariables())
+
+	moduleVersionsBuf := &bytes.Buffer{}
+	t.writeTerraform(moduleVersionsBuf, false, true)
+
+	files[path.Join(moduleDir, "kubernetes.tf")] = resourcesBuf.Bytes()
+	files[path.Join(moduleDir, "variables.tf")] = variablesBuf.Bytes()
+	files[path.Join(moduleDir, "outputs.tf")] = outputsBuf.Bytes()
+	files[path.Join(moduleDir, "versions.tf")] = moduleVersionsBuf.Bytes()
+
+	mainBuf := &bytes.Buffer{}
+	t.writeModule(mainBuf)
+
+	rootOutputsBuf := &bytes.Buffer{}
+	for _, tfName := range sortedKeysForMap(outputs) {
+		toElement(&output{Value: terraformWriter.LiteralTokens("module", moduleName, tfName)}).Write(rootOutputsBuf, 0, fmt.Sprintf("output %q", tfName))
+		rootOutputsBuf.WriteString("\n")
+	}
+
+	rootVersionsBuf := &bytes.Buffer{}
+	t.writeTerraform(rootVersionsBuf, len(imports) != 0, false)
+
+	files["main.tf"] = mainBuf.Bytes()
+	files["outputs.tf"] = rootOutputsBuf.Bytes()
+	files["providers.tf"] = providersBuf.Bytes()
+	files["versions.tf"] = rootVersionsBuf.Bytes()
+
+	if len(imports) != 0 {
+		// Import blocks are only allowed in the root module
+		moduleImports := make(map[string]string, len(imports))
+		for address, id := range imports {
+			moduleImports["module."+moduleName+"."+address] = id
+		}
+		importsBuf := &bytes.Buffer{}
+		writeImports(importsBuf, moduleImports)
+		files["imports.tf"] = importsBuf.Bytes()
+	}
+
+	t.Files = files
+}
+
+// writeModule creates the module block that calls the kOps module, passing the providers to it
+// Example:
+//
+//	module "kubernetes" {
+//	  source = "./modules/kubernetes"
+//
+//	  providers = {
+//	    aws       = aws
+//	    aws.files = aws.files
+//	  }
+//	}
+func (t *TerraformTarget) writeModule(buf *bytes.Buffer) {
+	providers := []string{t.providerName()}
+	if t.Cloud.ProviderID() == kops.CloudProviderAWS && featureflag.Spotinst.Enabled() {
+		providers = append(providers, "spotinst")
+	}
+	for _, key := range sortedKeysForMap(t.TerraformWriter.Providers) {
+		providers = append(providers, t.TerraformWriter.Providers[key].Name+".files")
+	}
+
+	maxLen := 0
+	for _, provider := range providers {
+		maxLen = max(maxLen, len(provider))
+	}
+
+	buf.WriteString(fmt.Sprintf("module %q {\n", moduleName))
+	buf.WriteString(fmt.Sprintf("  source = %q\n", "./"+moduleDir))
+	buf.WriteString("\n")
+	buf.WriteString("  providers = {\n")
+	for _, provider := range providers {
+		buf.WriteString(fmt.Sprintf("    %s%s = %s\n", provider, strings.Repeat(" ", maxLen-len(provider)), provider))
+	}
+	buf.WriteString("  }\n")
+	buf.WriteString("}\n")
+}
+
 type output struct {
 	Value *terraformWriter.Literal
 }
 
 // writeLocalsOutputs creates the locals block and output blocks for all output variables
+// The locals are written to buf, and the outputs to outputsBuf.
 // Example:
 //
 //	locals {
@@ -79,7 +197,7 @@ type output struct {
 //	output "key2" {
 //	  value = "value2"
 //	}
-func writeLocalsOutputs(buf *bytes.Buffer, outputs map[string]terraformWriter.OutputValue) {
+func writeLocalsOutputs(buf *bytes.Buffer, outputsBuf *bytes.Buffer, outputs map[string]terraformWriter.OutputValue) {
 	if len(outputs) == 0 {
 		return
 	}
@@ -105,20 +223,57 @@ func writeLocalsOutputs(buf *bytes.Buffer, outputs map[string]terraformWriter.Ou
 	buf.WriteString("\n")
 
 	for _, tfName := range outputNames {
-		toElement(&output{Value: locals[tfName]}).Write(buf, 0, fmt.Sprintf("output %q", tfName))
-		buf.WriteString("\n")
+		toElement(&output{Value: locals[tfName]}).Write(outputsBuf, 0, fmt.Sprintf("output %q", tfName))
+		outputsBuf.WriteString("\n")
 	}
 	return
 }
 
-func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
-	providerName := string(t.Cloud.ProviderID())
-	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
-		providerName = "google"
+// writeVariables creates a variable block for each input variable
+// Example:
+//
+//	variable "nodes_example_com_instance_type" {
+//	  default     = "t3.medium"
+//	  description = "The instance type of the nodes.example.com launch template"
+//	  type        = string
+//	}
+func writeVariables(buf *bytes.Buffer, variables []*terraformWriter.InputVariable) {
+	for _, v := range variables {
+		o := &object{field: map[string]element{
+			"description": terraformWriter.LiteralFromStringValue(v.Description),
+			"type":        terraformWriter.LiteralTokens(v.Type),
+		}}
+		switch d := v.Default.(type) {
+		case map[string]string:
+			if len(d) == 0 {
+				o.field["default"] = terraformWriter.LiteralTokens("{}")
+			} else {
+				o.field["default"] = mapToElement(d)
+			}
+		default:
+			if e := toElement(d); e != nil {
+				o.field["default"] = e
+			}
+		}
+		o.Write(buf, 0, fmt.Sprintf("variable %q", v.Name))
+		buf.WriteString("\n")
 	}
-	if t.Cloud.ProviderID() == kops.CloudProviderHetzner {
-		providerName = "hcloud"
+}
+
+// providerName returns the name of the TF provider for the cloud.
+func (t *TerraformTarget) providerName() string {
+	switch t.Cloud.ProviderID() {
+	case kops.CloudProviderGCE:
+		return "google"
+	case kops.CloudProviderHetzner:
+		return "hcloud"
+	default:
+		return string(t.Cloud.ProviderID())
 	}
+}
+
+func (t *TerraformTarget) writeProviders(buf *bytes.Buffer) {
+	providerName := t.providerName()
 	providerBody := map[string]string{}
 	if t.Cloud.ProviderID() == kops.CloudProviderGCE {
 		providerBody["project"] = t.Project
@@ -207,9 +362,15 @@ func (t *TerraformTarget) writeDataSources(buf *bytes.Buffer, dataSourcesByType
 	}
 }
 
-func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
+// writeTerraform creates the terraform block with the required versions. The aliases of the providers
+// are declared as configuration aliases if configurationAliases is true.
+func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer, hasImports bool, configurationAliases bool) {
+	requiredVersion := ">= 0.15.0"
+	if hasImports {
+		requiredVersion = importsRequiredVersion
+	}
 	buf.WriteString("terraform {\n")
-	buf.WriteString("  required_version = \">= 0.15.0\"\n")
+	buf.WriteString(fmt.Sprintf("  required_version = %q\n", requiredVersion))
 	buf.WriteString("  required_providers {\n")
 
 	providers := make(map[string]bool)
@@ -231,7 +392,9 @@ func (t *TerraformTarget) writeTerraform(buf *bytes.Buffer) {
 
 	for _, tfProvider := range t.TerraformWriter.Providers {
 		providers[tfProvider.Name] = true
-		providerAliases[tfProvider.Name] = append(providerAliases[tfProvider.Name], "files")
+		if configurationAliases {
+			providerAliases[tfProvider.Name] = append(providerAliases[tfProvider.Name], "files")
+		}
 	}
 
 	providerKeys := sortedKeysForMap(providers)
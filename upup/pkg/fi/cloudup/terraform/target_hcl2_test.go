@@ -18,10 +18,13 @@ package terraform
 
 import (
 	"bytes"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
 	"k8s.io/kops/pkg/diff"
+	"k8s.io/kops/upup/pkg/fi/cloudup/awsup"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 )
 
@@ -74,7 +77,7 @@ output "key1" {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			buf := &bytes.Buffer{}
-			writeLocalsOutputs(buf, tc.values)
+			writeLocalsOutputs(buf, buf, tc.values)
 			actual := strings.TrimSpace(buf.String())
 			expected := strings.TrimSpace(tc.expected)
 			if actual != expected {
@@ -85,3 +88,178 @@ output "key1" {
 		})
 	}
 }
+
+func TestWriteImports(t *testing.T) {
+	imports := map[string]string{
+		"aws_vpc.minimal-example-com":               "vpc-12345678",
+		"aws_subnet.us-test-1a-minimal-example-com": "subnet-12345678",
+	}
+	expected := `
+import {
+  to = aws_subnet.us-test-1a-minimal-example-com
+  id = "subnet-12345678"
+}
+
+import {
+  to = aws_vpc.minimal-example-com
+  id = "vpc-12345678"
+}`
+
+	buf := &bytes.Buffer{}
+	writeImports(buf, imports)
+	actual := strings.TrimSpace(buf.String())
+	expected = strings.TrimSpace(expected)
+	if actual != expected {
+		diffString := diff.FormatDiff(expected, actual)
+		t.Logf("diff:\n%s\n", diffString)
+		t.Errorf("expected: '%s', got: '%s'\n", expected, actual)
+	}
+}
+
+func TestWriteVariables(t *testing.T) {
+	variables := []*terraformWriter.InputVariable{
+		{
+			Name:        "nodes_example_com_max_size",
+			Description: "The maximum size of the nodes.example.com autoscaling group",
+			Type:        "number",
+			Default:     terraformWriter.LiteralFromIntValue(2),
+		},
+		{
+			Name:        "nodes_example_com_tags",
+			Description: "The tags of the nodes.example.com launch template",
+			Type:        "map(string)",
+			Default:     map[string]string{"KubernetesCluster": "example.com", "Name": "nodes.example.com"},
+		},
+	}
+	expected := `
+variable "nodes_example_com_max_size" {
+  default     = 2
+  description = "The maximum size of the nodes.example.com autoscaling group"
+  type        = number
+}
+
+variable "nodes_example_com_tags" {
+  default = {
+    "KubernetesCluster" = "example.com"
+    "Name"              = "nodes.example.com"
+  }
+  description = "The tags of the nodes.example.com launch template"
+  type        = map(string)
+}`
+
+	buf := &bytes.Buffer{}
+	writeVariables(buf, variables)
+	actual := strings.TrimSpace(buf.String())
+	expected = strings.TrimSpace(expected)
+	if actual != expected {
+		diffString := diff.FormatDiff(expected, actual)
+		t.Logf("diff:\n%s\n", diffString)
+		t.Errorf("expected: '%s', got: '%s'\n", expected, actual)
+	}
+}
+
+func TestFinishModule(t *testing.T) {
+	target := NewTerraformTarget(awsup.BuildMockAWSCloud("us-test-1", "a"), "", "", nil)
+	target.Module = true
+	target.EnsureTerraformProvider("aws", map[string]string{"region": "us-test-1"})
+
+	vpc := struct {
+		CIDRBlock *terraformWriter.Literal `cty:"cidr_block"`
+	}{
+		CIDRBlock: target.AddVariable("minimal.example.com", "cidr_block", "The CIDR block of the VPC", "string", terraformWriter.LiteralFromStringValue("172.20.0.0/16")),
+	}
+	if err := target.RenderResource("aws_vpc", "minimal-example-com", vpc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := target.AddOutputVariable("vpc_id", terraformWriter.LiteralProperty("aws_vpc", "minimal-example-com", "id")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := target.AddFileBytes("aws_s3_object", "cluster-completed-spec", "content", []byte("spec"), false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	target.AddImport("aws_vpc", "minimal-example-com", "vpc-12345678")
+
+	if err := target.finishHCL2(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var files []string
+	for f := range target.Files {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	expectedFiles := []string{
+		"imports.tf",
+		"main.tf",
+		"modules/kubernetes/data/aws_s3_object_cluster-completed-spec_content",
+		"modules/kubernetes/kubernetes.tf",
+		"modules/kubernetes/outputs.tf",
+		"modules/kubernetes/variables.tf",
+		"modules/kubernetes/versions.tf",
+		"outputs.tf",
+		"providers.tf",
+		"versions.tf",
+	}
+	if !reflect.DeepEqual(files, expectedFiles) {
+		t.Fatalf("expected files %v, got %v", expectedFiles, files)
+	}
+
+	expected := map[string]string{
+		"main.tf": `
+module "kubernetes" {
+  source = "./modules/kubernetes"
+
+  providers = {
+    aws       = aws
+    aws.files = aws.files
+  }
+}`,
+		"outputs.tf": `
+output "vpc_id" {
+  value = module.kubernetes.vpc_id
+}`,
+		"imports.tf": `
+import {
+  to = module.kubernetes.aws_vpc.minimal-example-com
+  id = "vpc-12345678"
+}`,
+		"providers.tf": `
+provider "aws" {
+  region = "us-test-1"
+}
+
+provider "aws" {
+  alias  = "files"
+  region = "us-test-1"
+}`,
+		"modules/kubernetes/outputs.tf": `
+output "vpc_id" {
+  value = aws_vpc.minimal-example-com.id
+}`,
+		"modules/kubernetes/variables.tf": `
+variable "minimal_example_com_cidr_block" {
+  default     = "172.20.0.0/16"
+  description = "The CIDR block of the VPC"
+  type        = string
+}`,
+	}
+	for file, expectedContents := range expected {
+		actual := strings.TrimSpace(string(target.Files[file]))
+		expectedContents = strings.TrimSpace(expectedContents)
+		if actual != expectedContents {
+			diffString := diff.FormatDiff(expectedContents, actual)
+			t.Logf("diff:\n%s\n", diffString)
+			t.Errorf("%s: expected: '%s', got: '%s'\n", file, expectedContents, actual)
+		}
+	}
+
+	if !strings.Contains(string(target.Files["modules/kubernetes/versions.tf"]), `"configuration_aliases" = [aws.files]`) {
+		t.Errorf("expected the module to declare the files provider alias, got:\n%s", target.Files["modules/kubernetes/versions.tf"])
+	}
+	if strings.Contains(string(target.Files["versions.tf"]), "configuration_aliases") {
+		t.Errorf("expected the root module not to declare configuration aliases, got:\n%s", target.Files["versions.tf"])
+	}
+	if strings.Contains(string(target.Files["modules/kubernetes/kubernetes.tf"]), "provider \"aws\"") {
+		t.Errorf("expected the module not to configure providers, got:\n%s", target.Files["modules/kubernetes/kubernetes.tf"])
+	}
+}
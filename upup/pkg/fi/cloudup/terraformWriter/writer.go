@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"path"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -36,6 +37,15 @@ type TerraformWriter struct {
 	resources []*terraformResource
 	// outputs is a list of our TF output variables
 	outputs map[string]*terraformOutputVariable
+	// imports is a list of existing cloud resources that should be imported into TF state
+	imports []*Import
+	// variables is a map of our TF input variables, only used in module mode
+	variables map[string]*InputVariable
+
+	// Module is true if the resources are written to a reusable TF module, which is called by a root module
+	// that configures the providers. Values that are commonly customized, such as instance counts,
+	// machine types, images and tags, become input variables with the kOps values as defaults.
+	Module bool
 
 	// Providers is a list of TF Providers we need for writing files
 	Providers map[string]*TerraformProvider
@@ -61,6 +71,23 @@ type terraformResource struct {
 	Item         interface{}
 }
 
+// Import identifies an existing cloud resource to import into the TF resource at ResourceType.ResourceName.
+type Import struct {
+	ResourceType string
+	ResourceName string
+	ID           string
+}
+
+// InputVariable is a TF input variable.
+type InputVariable struct {
+	Name        string
+	Description string
+	// Type is the TF type constraint of the variable.
+	Type string
+	// Default is the default value of the variable, either a *Literal or a map[string]string.
+	Default interface{}
+}
+
 type terraformOutputVariable struct {
 	Key        string
 	Value      *Literal
@@ -79,6 +106,7 @@ func sanitizeName(name string) string {
 func (t *TerraformWriter) InitTerraformWriter() {
 	t.Files = make(map[string][]byte)
 	t.outputs = make(map[string]*terraformOutputVariable)
+	t.variables = make(map[string]*InputVariable)
 }
 
 func (t *TerraformWriter) AddFileBytes(resourceType string, resourceName string, key string, data []byte, base64 bool) (*Literal, error) {
@@ -165,6 +193,70 @@ func (t *TerraformWriter) RenderResource(resourceType string, resourceName strin
 	return nil
 }
 
+// AddImport records that the existing cloud resource with the given ID should be imported into a TF resource.
+func (t *TerraformWriter) AddImport(resourceType string, resourceName string, id string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.imports = append(t.imports, &Import{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		ID:           id,
+	})
+}
+
+// VariableName returns the name of the TF input variable for an attribute of a resource.
+func VariableName(resourceName string, attribute string) string {
+	return strings.ReplaceAll(sanitizeName(resourceName), "-", "_") + "_" + attribute
+}
+
+// AddVariable returns a reference to a TF input variable for an attribute of a resource, defaulting to value.
+// Outside of module mode, no variable is created and value is returned as is.
+func (t *TerraformWriter) AddVariable(resourceName string, attribute string, description string, varType string, value *Literal) *Literal {
+	if !t.Module || value == nil {
+		return value
+	}
+	return t.addVariable(resourceName, attribute, description, varType, value)
+}
+
+// AddMapVariable returns a reference to a TF input variable of type map(string) for an attribute of a resource,
+// defaulting to value. Outside of module mode, no variable is created and nil is returned.
+func (t *TerraformWriter) AddMapVariable(resourceName string, attribute string, description string, value map[string]string) *Literal {
+	if !t.Module {
+		return nil
+	}
+	return t.addVariable(resourceName, attribute, description, "map(string)", value)
+}
+
+func (t *TerraformWriter) addVariable(resourceName string, attribute string, description string, varType string, value interface{}) *Literal {
+	name := VariableName(resourceName, attribute)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if existing := t.variables[name]; existing != nil && !reflect.DeepEqual(existing.Default, value) {
+		klog.Warningf("TF variable %q has conflicting defaults, using the first", name)
+	} else if existing == nil {
+		t.variables[name] = &InputVariable{
+			Name:        name,
+			Description: description,
+			Type:        varType,
+			Default:     value,
+		}
+	}
+	return LiteralTokens("var", name)
+}
+
+// GetVariables returns the TF input variables, sorted by name.
+func (t *TerraformWriter) GetVariables() []*InputVariable {
+	var variables []*InputVariable
+	for _, v := range t.variables {
+		variables = append(variables, v)
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i].Name < variables[j].Name })
+	return variables
+}
+
 func (t *TerraformWriter) AddOutputVariable(key string, literal *Literal) error {
 	v := &terraformOutputVariable{
 		Key:   key,
@@ -263,3 +355,26 @@ func (t *TerraformWriter) GetOutputs() (map[string]OutputValue, error) {
 	}
 	return values, nil
 }
+
+// GetImports returns the imports for the TF resources that have been rendered, by TF address.
+// Imports for resources that were not rendered, such as shared resources, are dropped.
+func (t *TerraformWriter) GetImports() (map[string]string, error) {
+	rendered := make(map[string]bool)
+	for _, res := range t.resources {
+		rendered[res.ResourceType+"."+sanitizeName(res.ResourceName)] = true
+	}
+
+	imports := make(map[string]string)
+	for _, i := range t.imports {
+		address := i.ResourceType + "." + sanitizeName(i.ResourceName)
+		if !rendered[address] {
+			klog.V(2).Infof("not importing %s, as it is not managed by terraform", address)
+			continue
+		}
+		if existing, found := imports[address]; found && existing != i.ID {
+			return nil, fmt.Errorf("conflicting imports found for %s: %q and %q", address, existing, i.ID)
+		}
+		imports[address] = i.ID
+	}
+	return imports, nil
+}
@@ -118,3 +118,50 @@ func TestGetOutputs(t *testing.T) {
 		})
 	}
 }
+
+func TestGetImports(t *testing.T) {
+	w := &TerraformWriter{}
+	w.InitTerraformWriter()
+
+	require.NoError(t, w.RenderResource("aws_vpc", "minimal.example.com", struct{}{}))
+	require.NoError(t, w.RenderResource("aws_subnet", "us-test-1a.minimal.example.com", struct{}{}))
+	w.AddImport("aws_vpc", "minimal.example.com", "vpc-12345678")
+	w.AddImport("aws_subnet", "us-test-1a.minimal.example.com", "subnet-12345678")
+	w.AddImport("aws_internet_gateway", "shared", "igw-12345678")
+
+	imports, err := w.GetImports()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"aws_vpc.minimal-example-com":               "vpc-12345678",
+		"aws_subnet.us-test-1a-minimal-example-com": "subnet-12345678",
+	}, imports)
+
+	w.AddImport("aws_vpc", "minimal.example.com", "vpc-87654321")
+	_, err = w.GetImports()
+	assert.Error(t, err, "conflicting imports")
+}
+
+func TestAddVariable(t *testing.T) {
+	w := &TerraformWriter{}
+	w.InitTerraformWriter()
+
+	value := LiteralFromStringValue("t3.medium")
+	assert.Equal(t, value, w.AddVariable("nodes.minimal.example.com", "instance_type", "The instance type", "string", value))
+	assert.Nil(t, w.AddMapVariable("nodes.minimal.example.com", "tags", "The tags", map[string]string{"Name": "nodes"}))
+	assert.Empty(t, w.GetVariables())
+
+	w.Module = true
+	assert.Equal(t, "var.nodes_minimal_example_com_instance_type", w.AddVariable("nodes.minimal.example.com", "instance_type", "The instance type", "string", value).String)
+	assert.Equal(t, "var.nodes_minimal_example_com_tags", w.AddMapVariable("nodes.minimal.example.com", "tags", "The tags", map[string]string{"Name": "nodes"}).String)
+	assert.Nil(t, w.AddVariable("nodes.minimal.example.com", "image_id", "The image", "string", nil))
+
+	variables := w.GetVariables()
+	require.Len(t, variables, 2)
+	assert.Equal(t, &InputVariable{
+		Name:        "nodes_minimal_example_com_instance_type",
+		Description: "The instance type",
+		Type:        "string",
+		Default:     value,
+	}, variables[0])
+	assert.Equal(t, "nodes_minimal_example_com_tags", variables[1].Name)
+}
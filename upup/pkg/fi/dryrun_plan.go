@@ -0,0 +1,124 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PlanOperation is the operation a dry-run would perform on a task.
+type PlanOperation string
+
+const (
+	PlanOperationCreate PlanOperation = "create"
+	PlanOperationUpdate PlanOperation = "update"
+	PlanOperationDelete PlanOperation = "delete"
+)
+
+// Plan is a structured report of the changes found by a DryRunTarget.
+type Plan struct {
+	Changes []PlanChange `json:"changes"`
+}
+
+// PlanChange is a change to a single task.
+type PlanChange struct {
+	// Kind is the type of the task, for example SecurityGroupRule.
+	Kind string `json:"kind"`
+	// Name is the name of the task, or the item to be deleted.
+	Name string `json:"name"`
+	// Lifecycle is the lifecycle of the task, if known.
+	Lifecycle Lifecycle `json:"lifecycle,omitempty"`
+	// Operation is the operation that would be performed.
+	Operation PlanOperation `json:"operation"`
+	// Deferred is set on deletions that are only performed when pruning.
+	Deferred bool `json:"deferred,omitempty"`
+	// Fields are the fields that would be set or changed.
+	Fields []PlanFieldChange `json:"fields,omitempty"`
+}
+
+// PlanFieldChange is a change to a single field of a task.
+type PlanFieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// Plan returns the changes that have been recorded, in the same order as PrintReport.
+func (t *DryRunTarget[T]) Plan(taskMap map[string]Task[T]) (*Plan, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	plan := &Plan{Changes: []PlanChange{}}
+
+	var creates []*render[T]
+	var updates []*render[T]
+	for _, r := range t.changes {
+		if r.aIsNil {
+			creates = append(creates, r)
+		} else {
+			updates = append(updates, r)
+		}
+	}
+	sort.Sort(ByTaskKey[T](creates))
+	sort.Sort(ByTaskKey[T](updates))
+
+	for _, r := range creates {
+		c := newPlanChange(taskMap, r, PlanOperationCreate)
+		for _, field := range buildCreateFieldList(r.changes) {
+			c.Fields = append(c.Fields, PlanFieldChange{Field: field.FieldName, New: field.New})
+		}
+		plan.Changes = append(plan.Changes, c)
+	}
+
+	for _, r := range updates {
+		changeList, err := buildChangeList(r.a, r.e, r.changes)
+		if err != nil {
+			return nil, fmt.Errorf("error building changes for %s: %w", buildTaskKey(r.e), err)
+		}
+		c := newPlanChange(taskMap, r, PlanOperationUpdate)
+		for _, field := range changeList {
+			c.Fields = append(c.Fields, PlanFieldChange{Field: field.FieldName, Old: field.Old, New: field.New})
+		}
+		plan.Changes = append(plan.Changes, c)
+	}
+
+	deletions := append([]Deletion[T](nil), t.deletions...)
+	sort.Sort(DeletionByTaskName[T](deletions))
+	for _, d := range deletions {
+		plan.Changes = append(plan.Changes, PlanChange{
+			Kind:      d.TaskName(),
+			Name:      d.Item(),
+			Operation: PlanOperationDelete,
+			Deferred:  d.DeferDeletion(),
+		})
+	}
+
+	return plan, nil
+}
+
+func newPlanChange[T SubContext](taskMap map[string]Task[T], r *render[T], operation PlanOperation) PlanChange {
+	c := PlanChange{
+		Kind:      getTaskName(r.changes),
+		Name:      idForTask(taskMap, r.e),
+		Operation: operation,
+	}
+	if hl, ok := r.e.(HasLifecycle); ok {
+		c.Lifecycle = hl.GetLifecycle()
+	}
+	return c
+}
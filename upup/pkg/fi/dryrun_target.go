@@ -161,56 +161,8 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 				taskName := getTaskName(r.changes)
 				fmt.Fprintf(b, "  %s/%s\n", taskName, idForTask(taskMap, r.e))
 
-				changes := reflect.ValueOf(r.changes)
-				if changes.Kind() == reflect.Ptr && !changes.IsNil() {
-					changes = changes.Elem()
-				}
-
-				if changes.Kind() == reflect.Struct {
-					for i := 0; i < changes.NumField(); i++ {
-
-						field := changes.Field(i)
-
-						fieldName := changes.Type().Field(i).Name
-						if changes.Type().Field(i).PkgPath != "" {
-							// Not exported
-							continue
-						}
-
-						fieldValue := reflectutils.ValueAsString(field)
-
-						shouldPrint := true
-						if fieldName == "Name" {
-							// The field name is already printed above, no need to repeat it.
-							shouldPrint = false
-						}
-						if fieldName == "Lifecycle" {
-							// Lifecycle is a "system" field; no need to show it
-							shouldPrint = false
-						}
-						if fieldValue == "<nil>" || fieldValue == "<resource>" {
-							// Uninformative
-							shouldPrint = false
-						}
-						if fieldValue == "id:<nil>" {
-							// Uninformative, but we can often print the name instead
-							name := ""
-							if field.CanInterface() {
-								hasName, ok := field.Interface().(HasName)
-								if ok {
-									name = ValueOf(hasName.GetName())
-								}
-							}
-							if name != "" {
-								fieldValue = "name:" + name
-							} else {
-								shouldPrint = false
-							}
-						}
-						if shouldPrint {
-							fmt.Fprintf(b, "  \t%-20s\t%s\n", fieldName, fieldValue)
-						}
-					}
+				for _, field := range buildCreateFieldList(r.changes) {
+					fmt.Fprintf(b, "  \t%-20s\t%s\n", field.FieldName, field.Description)
 				}
 
 				fmt.Fprintf(b, "\n")
@@ -306,6 +258,67 @@ func (t *DryRunTarget[T]) PrintReport(taskMap map[string]Task[T], out io.Writer)
 type change struct {
 	FieldName   string
 	Description string
+	// Old and New are the actual and expected values of the field
+	Old string
+	New string
+}
+
+// buildCreateFieldList returns the informative fields of a task that will be created.
+func buildCreateFieldList[T SubContext](changes Task[T]) []change {
+	var fields []change
+
+	valC := reflect.ValueOf(changes)
+	if valC.Kind() == reflect.Ptr && !valC.IsNil() {
+		valC = valC.Elem()
+	}
+	if valC.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < valC.NumField(); i++ {
+		field := valC.Field(i)
+
+		fieldName := valC.Type().Field(i).Name
+		if valC.Type().Field(i).PkgPath != "" {
+			// Not exported
+			continue
+		}
+
+		fieldValue := reflectutils.ValueAsString(field)
+
+		shouldPrint := true
+		if fieldName == "Name" {
+			// The field name is already printed above, no need to repeat it.
+			shouldPrint = false
+		}
+		if fieldName == "Lifecycle" {
+			// Lifecycle is a "system" field; no need to show it
+			shouldPrint = false
+		}
+		if fieldValue == "<nil>" || fieldValue == "<resource>" {
+			// Uninformative
+			shouldPrint = false
+		}
+		if fieldValue == "id:<nil>" {
+			// Uninformative, but we can often print the name instead
+			name := ""
+			if field.CanInterface() {
+				hasName, ok := field.Interface().(HasName)
+				if ok {
+					name = ValueOf(hasName.GetName())
+				}
+			}
+			if name != "" {
+				fieldValue = "name:" + name
+			} else {
+				shouldPrint = false
+			}
+		}
+		if shouldPrint {
+			fields = append(fields, change{FieldName: fieldName, Description: fieldValue, New: fieldValue})
+		}
+	}
+	return fields
 }
 
 func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
@@ -354,6 +367,8 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 
 			description := ""
 			ignored := false
+			oldValue := ""
+			newValue := ""
 			if fieldValE.CanInterface() {
 
 				switch fieldValE.Interface().(type) {
@@ -364,17 +379,19 @@ func buildChangeList[T SubContext](a, e, changes Task[T]) ([]change, error) {
 					resE, okE := tryResourceAsString(fieldValE)
 					if okA && okE {
 						description = diff.FormatDiff(resA, resE)
+						oldValue, newValue = resA, resE
 					}
 				}
 
 				if !ignored && description == "" {
-					description = fmt.Sprintf(" %v -> %v", reflectutils.ValueAsString(fieldValA), reflectutils.ValueAsString(fieldValE))
+					oldValue, newValue = reflectutils.ValueAsString(fieldValA), reflectutils.ValueAsString(fieldValE)
+					description = fmt.Sprintf(" %v -> %v", oldValue, newValue)
 				}
 			}
 			if ignored {
 				continue
 			}
-			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description})
+			changeList = append(changeList, change{FieldName: valC.Type().Field(i).Name, Description: description, Old: oldValue, New: newValue})
 		}
 	} else {
 		return nil, fmt.Errorf("unhandled change type: %v", valC.Type())
@@ -448,6 +465,18 @@ func (t *DryRunTarget[T]) Changes() (map[string]Task[T], map[string]Task[T]) {
 	return creates, updates
 }
 
+// ChangedTasks returns the tasks that would have been created or updated, as they appear in the task map
+func (t *DryRunTarget[T]) ChangedTasks() []Task[T] {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var tasks []Task[T]
+	for _, r := range t.changes {
+		tasks = append(tasks, r.e)
+	}
+	return tasks
+}
+
 // HasChanges returns true iff any changes would have been made
 func (t *DryRunTarget[T]) HasChanges() bool {
 	return len(t.changes)+len(t.deletions) != 0
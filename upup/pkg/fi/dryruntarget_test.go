@@ -91,3 +91,64 @@ func Test_DryrunTarget_PrintReport(t *testing.T) {
 	err = target.PrintReport(tasks, &out)
 	assert.NoError(t, err, "target.PrintReport()")
 }
+
+type testDeletion struct{}
+
+func (*testDeletion) Delete(_ CloudupTarget) error { panic("not implemented") }
+func (*testDeletion) TaskName() string             { return "SecurityGroupRule" }
+func (*testDeletion) Item() string                 { return "sg-1234 ingress tcp 22" }
+func (*testDeletion) DeferDeletion() bool          { return true }
+
+func Test_DryrunTarget_Plan(t *testing.T) {
+	builder := assets.NewAssetBuilder(vfs.Context, nil, "1.17.3", false)
+	target := newDryRunTarget[CloudupSubContext](builder, &bytes.Buffer{})
+	tasks := map[string]CloudupTask{}
+
+	created := &testTask{
+		Name: PtrTo("Created"),
+		Tags: map[string]string{"key": "value"},
+	}
+	var nilTask *testTask
+	assert.NoError(t, target.Render(nilTask, created, created), "target.Render()")
+	tasks["testTask/Created"] = created
+
+	a := &testTask{
+		Name: PtrTo("Updated"),
+		Tags: map[string]string{"key": "old"},
+	}
+	e := &testTask{
+		Name: PtrTo("Updated"),
+		Tags: map[string]string{"key": "new"},
+	}
+	changes := &testTask{}
+	assert.True(t, BuildChanges(a, e, changes), "BuildChanges()")
+	assert.NoError(t, target.Render(a, e, changes), "target.Render()")
+	tasks["testTask/Updated"] = e
+
+	assert.NoError(t, target.RecordDeletion(&testDeletion{}), "target.RecordDeletion()")
+
+	plan, err := target.Plan(tasks)
+	assert.NoError(t, err, "target.Plan()")
+	assert.Equal(t, &Plan{
+		Changes: []PlanChange{
+			{
+				Kind:      "testTask",
+				Name:      "Created",
+				Operation: PlanOperationCreate,
+				Fields:    []PlanFieldChange{{Field: "Tags", New: "{key: value}"}},
+			},
+			{
+				Kind:      "testTask",
+				Name:      "Updated",
+				Operation: PlanOperationUpdate,
+				Fields:    []PlanFieldChange{{Field: "Tags", Old: "{key: old}", New: "{key: new}"}},
+			},
+			{
+				Kind:      "SecurityGroupRule",
+				Name:      "sg-1234 ingress tcp 22",
+				Operation: PlanOperationDelete,
+				Deferred:  true,
+			},
+		},
+	}, plan)
+}
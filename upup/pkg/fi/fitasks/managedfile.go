@@ -28,6 +28,7 @@ import (
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/terraform"
+	"k8s.io/kops/upup/pkg/fi/cloudup/terraformWriter"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
@@ -47,6 +48,9 @@ type ManagedFile struct {
 	// PublicACL controls whether the _object_ has an ACL which grants world-readable status.
 	// Note that the _bucket_ may itself have a grant for world-readable; that is separate.
 	PublicACL *bool
+
+	// path is the location of the existing file, once found
+	path vfs.Path
 }
 
 func (e *ManagedFile) Find(c *fi.CloudupContext) (*ManagedFile, error) {
@@ -77,6 +81,7 @@ func (e *ManagedFile) Find(c *fi.CloudupContext) (*ManagedFile, error) {
 		Base:     e.Base,
 		Location: e.Location,
 		Contents: fi.NewBytesResource(existingData),
+		path:     filePath,
 	}
 
 	if s3file, ok := filePath.(*vfs.S3Path); ok {
@@ -231,3 +236,18 @@ func (f *ManagedFile) RenderTerraform(c *fi.CloudupContext, t *terraform.Terrafo
 
 	return terraformPath.RenderTerraform(&t.TerraformWriter, *e.Name, reader, acl)
 }
+
+// TerraformImports implements terraform.Importable.
+func (f *ManagedFile) TerraformImports(actual fi.CloudupTask) []*terraformWriter.Import {
+	if !featureflag.TerraformManagedFiles.Enabled() {
+		// The file is written directly to the state store, and is not managed by terraform
+		return nil
+	}
+	a := actual.(*ManagedFile)
+	if s3file, ok := a.path.(*vfs.S3Path); ok {
+		if i := s3file.TerraformImport(*f.Name); i != nil {
+			return []*terraformWriter.Import{i}
+		}
+	}
+	return nil
+}
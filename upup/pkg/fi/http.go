@@ -24,10 +24,12 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"k8s.io/klog/v2"
 	"k8s.io/kops/util/pkg/hashing"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 // DownloadURL will download the file at the given url and store it as dest.
@@ -81,6 +83,18 @@ func downloadURLAlways(url string, destPath string, dirMode os.FileMode) error {
 
 	klog.V(2).Infof("Downloading %q", url)
 
+	// Assets staged in an OCI registry are read through the vfs, as they are registry blobs rather than plain URLs
+	if strings.HasPrefix(url, "oci://") {
+		p, err := vfs.Context.BuildVfsPath(url)
+		if err != nil {
+			return fmt.Errorf("error parsing %q: %v", url, err)
+		}
+		if _, err := p.WriteTo(output); err != nil {
+			return fmt.Errorf("error downloading %q: %v", url, err)
+		}
+		return nil
+	}
+
 	// Create a client with custom timeouts
 	// to avoid idle downloads to hang the program
 	httpClient := &http.Client{
@@ -0,0 +1,363 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/local"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+	"k8s.io/kops/util/pkg/vfs"
+)
+
+const (
+	// ModeApply configures the node once; it is how nodeup runs at boot.
+	ModeApply = "apply"
+	// ModeAudit periodically reports the tasks whose state on the node differs from the configuration.
+	ModeAudit = "audit"
+	// ModeReconcile is ModeAudit, but also re-applies the tasks that differ.
+	ModeReconcile = "reconcile"
+)
+
+// NodeConditionConfigurationDrift is the node condition set by nodeup in audit and reconcile modes.
+// It is True when the node differs from its configuration.
+const NodeConditionConfigurationDrift v1.NodeConditionType = "KopsConfigurationDrift"
+
+// maxDriftConditionTasks is the number of drifted tasks listed in the message of the node condition.
+const maxDriftConditionTasks = 10
+
+// AuditOptions configures NodeUpCommand.Audit.
+type AuditOptions struct {
+	// Interval is how often the node is audited.
+	Interval time.Duration
+	// Reconcile re-applies the tasks that differ from the configuration.
+	Reconcile bool
+	// MetricsAddress is the address to serve Prometheus metrics on; metrics are not served if it is empty.
+	MetricsAddress string
+}
+
+// auditResult is the outcome of a single audit.
+type auditResult struct {
+	// Drifted are the keys of the tasks that differ from the configuration.
+	Drifted []string
+	// Reconciled is the number of tasks that were re-applied.
+	Reconciled int
+}
+
+// auditMetrics are the Prometheus metrics reported by Audit.
+type auditMetrics struct {
+	registry        *prometheus.Registry
+	driftedTasks    *prometheus.GaugeVec
+	lastAudit       prometheus.Gauge
+	auditErrors     prometheus.Counter
+	reconciledTasks prometheus.Counter
+}
+
+func newAuditMetrics() *auditMetrics {
+	m := &auditMetrics{
+		registry: prometheus.NewRegistry(),
+		driftedTasks: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "kops_nodeup",
+			Name:      "drifted_tasks",
+			Help:      "Tasks whose state on the node differed from the configuration at the last audit, labelled with the task and its type.",
+		}, []string{"task", "type"}),
+		lastAudit: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "kops_nodeup",
+			Name:      "audit_last_success_timestamp_seconds",
+			Help:      "The time of the last audit that completed.",
+		}),
+		auditErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "kops_nodeup",
+			Name:      "audit_errors_total",
+			Help:      "Audits that did not complete.",
+		}),
+		reconciledTasks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "kops_nodeup",
+			Name:      "reconciled_tasks_total",
+			Help:      "Drifted tasks that were re-applied.",
+		}),
+	}
+	m.registry.MustRegister(m.driftedTasks, m.lastAudit, m.auditErrors, m.reconciledTasks)
+	return m
+}
+
+// Audit runs the node's tasks against a dry-run target every interval, to find where the node
+// has drifted from its configuration, for example because a file under /etc/kubernetes was edited by hand.
+// The tasks are built once and reused, as building them has side effects such as loading kernel modules
+// and mounting volumes; if they cannot be built, building them is retried at the next interval.
+// The drifted tasks are reported as the KopsConfigurationDrift node condition and as Prometheus metrics,
+// and the dry-run report is written to out.
+// With Reconcile, the drifted tasks are then re-applied.
+// Audit runs until ctx is done.
+func (c *NodeUpCommand) Audit(ctx context.Context, out io.Writer, options AuditOptions) error {
+	if options.Interval <= 0 {
+		return fmt.Errorf("audit interval must be positive")
+	}
+
+	metrics := newAuditMetrics()
+	if options.MetricsAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{}))
+		server := &http.Server{Addr: options.MetricsAddress, Handler: mux}
+		go func() {
+			if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				klog.Errorf("error serving metrics on %q: %v", options.MetricsAddress, err)
+			}
+		}()
+		defer server.Close()
+	}
+
+	var tasks *nodeupTasks
+	for {
+		var result *auditResult
+		var err error
+		if tasks == nil {
+			tasks, err = c.buildTasks(ctx)
+			if err != nil {
+				err = fmt.Errorf("error building tasks: %w", err)
+			}
+		}
+		if tasks != nil {
+			result, err = c.auditOnce(ctx, out, options, tasks)
+		}
+		if err != nil {
+			klog.Warningf("error auditing node: %v", err)
+			metrics.auditErrors.Inc()
+		} else {
+			klog.Infof("audit found %d drifted tasks, reconciled %d", len(result.Drifted), result.Reconciled)
+			metrics.lastAudit.SetToCurrentTime()
+			metrics.reconciledTasks.Add(float64(result.Reconciled))
+			metrics.driftedTasks.Reset()
+			for _, key := range result.Drifted {
+				metrics.driftedTasks.WithLabelValues(key, taskType(key)).Set(1)
+			}
+		}
+
+		if tasks != nil {
+			if err := setDriftCondition(ctx, tasks, result, err); err != nil {
+				klog.Warningf("error setting %s node condition: %v", NodeConditionConfigurationDrift, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(options.Interval):
+		}
+	}
+}
+
+// auditOnce finds the tasks that have drifted, and re-applies them if options.Reconcile is set.
+func (c *NodeUpCommand) auditOnce(ctx context.Context, out io.Writer, options AuditOptions, tasks *nodeupTasks) (*auditResult, error) {
+	auditable := nodetasks.AuditableTasks(tasks.taskMap)
+
+	var report bytes.Buffer
+	assetBuilder := assets.NewAssetBuilder(vfs.Context, nil, tasks.nodeupConfig.KubernetesVersion, false)
+	target := fi.NewNodeupDryRunTarget(assetBuilder, &report)
+	if err := runNodeupTasks(ctx, target, tasks, auditable, options.Interval); err != nil {
+		return nil, fmt.Errorf("error auditing tasks: %w", err)
+	}
+
+	result := &auditResult{}
+	drifted := make(map[string]fi.NodeupTask)
+	for _, task := range target.ChangedTasks() {
+		for key, t := range auditable {
+			if t == task {
+				drifted[key] = task
+				result.Drifted = append(result.Drifted, key)
+			}
+		}
+	}
+	sort.Strings(result.Drifted)
+
+	if len(result.Drifted) == 0 {
+		return result, nil
+	}
+	if _, err := out.Write(report.Bytes()); err != nil {
+		return nil, err
+	}
+
+	if options.Reconcile {
+		// The drifted tasks are run along with the tasks they depend on, which have not drifted and so do nothing
+		reconcile := withDependencies(auditable, drifted)
+		klog.Infof("re-applying %d drifted tasks", len(drifted))
+		target := &local.LocalTarget{
+			CacheDir:      c.CacheDir,
+			Cloud:         tasks.cloud,
+			AssetVerifier: tasks.assetVerifier,
+		}
+		if err := runNodeupTasks(ctx, target, tasks, reconcile, options.Interval); err != nil {
+			return nil, fmt.Errorf("error re-applying drifted tasks: %w", err)
+		}
+		result.Reconciled = len(drifted)
+	}
+
+	return result, nil
+}
+
+// runNodeupTasks runs taskMap against target, giving up on tasks that are still failing after maxTaskDuration.
+func runNodeupTasks(ctx context.Context, target fi.NodeupTarget, tasks *nodeupTasks, taskMap map[string]fi.NodeupTask, maxTaskDuration time.Duration) error {
+	nodeupContext, err := fi.NewNodeupContext(ctx, target, tasks.keyStore, tasks.bootConfig, tasks.nodeupConfig, taskMap)
+	if err != nil {
+		return fmt.Errorf("error building context: %w", err)
+	}
+
+	var options fi.RunTasksOptions
+	options.InitDefaults()
+	if maxTaskDuration < options.MaxTaskDuration {
+		options.MaxTaskDuration = maxTaskDuration
+	}
+	if err := nodeupContext.RunTasks(options); err != nil {
+		return err
+	}
+	return target.Finish(taskMap)
+}
+
+// withDependencies returns the tasks in selected, along with all the tasks in taskMap that they depend on.
+func withDependencies(taskMap map[string]fi.NodeupTask, selected map[string]fi.NodeupTask) map[string]fi.NodeupTask {
+	dependencies := fi.FindTaskDependencies(taskMap)
+
+	result := make(map[string]fi.NodeupTask)
+	var add func(key string)
+	add = func(key string) {
+		if _, found := result[key]; found {
+			return
+		}
+		result[key] = taskMap[key]
+		for _, dependency := range dependencies[key] {
+			add(dependency)
+		}
+	}
+	for key := range selected {
+		add(key)
+	}
+	return result
+}
+
+// taskType returns the type of a task from its key, such as File for File//etc/kubernetes/manifests/etcd.manifest
+func taskType(key string) string {
+	taskType, _, _ := strings.Cut(key, "/")
+	return taskType
+}
+
+// buildDriftCondition returns the KopsConfigurationDrift condition for the result of an audit.
+// previous is the current condition on the node, if any, and is used to keep the last transition time.
+func buildDriftCondition(result *auditResult, auditErr error, previous *v1.NodeCondition, now metav1.Time) v1.NodeCondition {
+	condition := v1.NodeCondition{
+		Type:              NodeConditionConfigurationDrift,
+		LastHeartbeatTime: now,
+	}
+	switch {
+	case auditErr != nil:
+		condition.Status = v1.ConditionUnknown
+		condition.Reason = "AuditFailed"
+		condition.Message = auditErr.Error()
+	case len(result.Drifted) == 0:
+		condition.Status = v1.ConditionFalse
+		condition.Reason = "NoDrift"
+		condition.Message = "The node matches its configuration"
+	default:
+		condition.Status = v1.ConditionTrue
+		condition.Reason = "ConfigurationDrifted"
+		if result.Reconciled != 0 {
+			condition.Reason = "ConfigurationReconciled"
+		}
+		listed := result.Drifted
+		if len(listed) > maxDriftConditionTasks {
+			listed = listed[:maxDriftConditionTasks]
+		}
+		condition.Message = fmt.Sprintf("%d tasks differ from the configuration: %s", len(result.Drifted), strings.Join(listed, ", "))
+		if len(listed) < len(result.Drifted) {
+			condition.Message += fmt.Sprintf(" and %d more", len(result.Drifted)-len(listed))
+		}
+		if result.Reconciled != 0 {
+			condition.Message += fmt.Sprintf("; re-applied %d tasks", result.Reconciled)
+		}
+	}
+
+	condition.LastTransitionTime = now
+	if previous != nil && previous.Status == condition.Status {
+		condition.LastTransitionTime = previous.LastTransitionTime
+	}
+	return condition
+}
+
+// setDriftCondition sets the KopsConfigurationDrift condition on the node, using the kubelet's credentials.
+func setDriftCondition(ctx context.Context, tasks *nodeupTasks, result *auditResult, auditErr error) error {
+	kubeconfig := tasks.modelContext.KubeletKubeConfig()
+	if _, err := os.Stat(kubeconfig); err != nil {
+		// The kubelet has not yet joined the cluster
+		klog.V(2).Infof("not setting node condition: %v", err)
+		return nil
+	}
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return fmt.Errorf("error loading kubeconfig %q: %w", kubeconfig, err)
+	}
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("error building kubernetes client: %w", err)
+	}
+
+	nodeName, err := tasks.modelContext.NodeName()
+	if err != nil {
+		return err
+	}
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("error getting node %q: %w", nodeName, err)
+	}
+	var previous *v1.NodeCondition
+	for i := range node.Status.Conditions {
+		if node.Status.Conditions[i].Type == NodeConditionConfigurationDrift {
+			previous = &node.Status.Conditions[i]
+		}
+	}
+
+	condition := buildDriftCondition(result, auditErr, previous, metav1.Now())
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"conditions": []v1.NodeCondition{condition},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := client.CoreV1().Nodes().PatchStatus(ctx, nodeName, patch); err != nil {
+		return fmt.Errorf("error patching status of node %q: %w", nodeName, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeup
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/nodetasks"
+)
+
+func TestBuildDriftCondition(t *testing.T) {
+	earlier := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+
+	var drifted []string
+	for i := 0; i < 12; i++ {
+		drifted = append(drifted, fmt.Sprintf("File//etc/kubernetes/manifests/%02d.manifest", i))
+	}
+
+	grid := []struct {
+		name               string
+		result             *auditResult
+		auditErr           error
+		previous           *v1.NodeCondition
+		status             v1.ConditionStatus
+		reason             string
+		message            string
+		lastTransitionTime metav1.Time
+	}{
+		{
+			name:               "no drift",
+			result:             &auditResult{},
+			status:             v1.ConditionFalse,
+			reason:             "NoDrift",
+			lastTransitionTime: now,
+		},
+		{
+			name:               "drift, unchanged status",
+			result:             &auditResult{Drifted: []string{"Service/kubelet.service"}},
+			previous:           &v1.NodeCondition{Type: NodeConditionConfigurationDrift, Status: v1.ConditionTrue, LastTransitionTime: earlier},
+			status:             v1.ConditionTrue,
+			reason:             "ConfigurationDrifted",
+			message:            "1 tasks differ from the configuration: Service/kubelet.service",
+			lastTransitionTime: earlier,
+		},
+		{
+			name:               "reconciled, truncated",
+			result:             &auditResult{Drifted: drifted, Reconciled: 12},
+			previous:           &v1.NodeCondition{Type: NodeConditionConfigurationDrift, Status: v1.ConditionFalse, LastTransitionTime: earlier},
+			status:             v1.ConditionTrue,
+			reason:             "ConfigurationReconciled",
+			message:            "File//etc/kubernetes/manifests/09.manifest and 2 more; re-applied 12 tasks",
+			lastTransitionTime: now,
+		},
+		{
+			name:               "error",
+			auditErr:           fmt.Errorf("error auditing tasks"),
+			status:             v1.ConditionUnknown,
+			reason:             "AuditFailed",
+			message:            "error auditing tasks",
+			lastTransitionTime: now,
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.name, func(t *testing.T) {
+			condition := buildDriftCondition(g.result, g.auditErr, g.previous, now)
+			if condition.Type != NodeConditionConfigurationDrift || condition.Status != g.status || condition.Reason != g.reason {
+				t.Errorf("unexpected condition %+v", condition)
+			}
+			if !strings.Contains(condition.Message, g.message) {
+				t.Errorf("expected message to contain %q, got %q", g.message, condition.Message)
+			}
+			if !condition.LastTransitionTime.Equal(&g.lastTransitionTime) || !condition.LastHeartbeatTime.Equal(&now) {
+				t.Errorf("unexpected times in condition %+v", condition)
+			}
+		})
+	}
+}
+
+func TestWithDependencies(t *testing.T) {
+	tasks := map[string]fi.NodeupTask{
+		"UserTask/etcd":        &nodetasks.UserTask{Name: "etcd"},
+		"File//etc/kubernetes": &nodetasks.File{Path: "/etc/kubernetes", Type: nodetasks.FileType_Directory},
+		"File//etc/kubernetes/etcd.conf": &nodetasks.File{
+			Path:     "/etc/kubernetes/etcd.conf",
+			Contents: fi.NewStringResource("config"),
+			Type:     nodetasks.FileType_File,
+			Owner:    fi.PtrTo("etcd"),
+		},
+		"File//etc/hosts.extra": &nodetasks.File{Path: "/etc/hosts.extra", Contents: fi.NewStringResource(""), Type: nodetasks.FileType_File},
+	}
+
+	selected := map[string]fi.NodeupTask{
+		"File//etc/kubernetes/etcd.conf": tasks["File//etc/kubernetes/etcd.conf"],
+	}
+	result := withDependencies(tasks, selected)
+	if len(result) != 3 || result["UserTask/etcd"] == nil || result["File//etc/kubernetes"] == nil {
+		t.Errorf("expected the file, its owner and its directory, got %v", result)
+	}
+}
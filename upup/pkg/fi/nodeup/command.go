@@ -47,9 +47,11 @@ import (
 	kopsmodel "k8s.io/kops/pkg/apis/kops/model"
 	"k8s.io/kops/pkg/apis/nodeup"
 	"k8s.io/kops/pkg/assets"
+	"k8s.io/kops/pkg/assets/cosign"
 	"k8s.io/kops/pkg/bootstrap"
 	"k8s.io/kops/pkg/bootstrap/pkibootstrap"
 	"k8s.io/kops/pkg/configserver"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/kopscontrollerclient"
 	"k8s.io/kops/pkg/wellknownports"
 	"k8s.io/kops/upup/pkg/fi"
@@ -79,35 +81,46 @@ type NodeUpCommand struct {
 	Target         string
 }
 
-// Run is responsible for perform the nodeup process
-func (c *NodeUpCommand) Run(out io.Writer) error {
-	ctx := context.Background()
+// nodeupTasks is the task graph built from the node's configuration, along with what is needed to run it.
+type nodeupTasks struct {
+	bootConfig   *nodeup.BootConfig
+	nodeupConfig *nodeup.Config
+	modelContext *model.NodeupModelContext
+	cloud        fi.Cloud
+	keyStore     fi.KeystoreReader
+	taskMap      map[string]fi.NodeupTask
+	// assetVerifier verifies the signatures of assets and images, if asset verification is configured
+	assetVerifier *cosign.Verifier
+}
+
+// buildTasks loads the node's configuration and builds the tasks that configure the node.
+func (c *NodeUpCommand) buildTasks(ctx context.Context) (*nodeupTasks, error) {
 
 	var bootConfig nodeup.BootConfig
 	if c.ConfigLocation != "" {
 		b, err := vfs.Context.ReadFile(c.ConfigLocation)
 		if err != nil {
-			return fmt.Errorf("error loading configuration %q: %v", c.ConfigLocation, err)
+			return nil, fmt.Errorf("error loading configuration %q: %v", c.ConfigLocation, err)
 		}
 
 		err = utils.YamlUnmarshal(b, &bootConfig)
 		if err != nil {
-			return fmt.Errorf("error parsing configuration %q: %v", c.ConfigLocation, err)
+			return nil, fmt.Errorf("error parsing configuration %q: %v", c.ConfigLocation, err)
 		}
 	} else {
-		return fmt.Errorf("ConfigLocation is required")
+		return nil, fmt.Errorf("ConfigLocation is required")
 	}
 
 	if c.CacheDir == "" {
-		return fmt.Errorf("CacheDir is required")
+		return nil, fmt.Errorf("CacheDir is required")
 	}
 
 	region, err := getRegion(ctx, &bootConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if err = seedRNG(ctx, &bootConfig, region); err != nil {
-		return err
+		return nil, err
 	}
 
 	var configBase vfs.Path
@@ -118,24 +131,24 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	if bootConfig.ConfigServer != nil && len(bootConfig.ConfigServer.Servers) > 0 {
 		response, err := getNodeConfigFromServers(ctx, &bootConfig, region)
 		if err != nil {
-			return fmt.Errorf("failed to get node config from server: %w", err)
+			return nil, fmt.Errorf("failed to get node config from server: %w", err)
 		}
 		nodeConfig = response.NodeConfig
 	} else if fi.ValueOf(bootConfig.ConfigBase) != "" {
 		var err error
 		configBase, err = vfs.Context.BuildVfsPath(*bootConfig.ConfigBase)
 		if err != nil {
-			return fmt.Errorf("cannot parse ConfigBase %q: %v", *bootConfig.ConfigBase, err)
+			return nil, fmt.Errorf("cannot parse ConfigBase %q: %v", *bootConfig.ConfigBase, err)
 		}
 	} else {
-		return fmt.Errorf("ConfigBase or ConfigServer is required")
+		return nil, fmt.Errorf("ConfigBase or ConfigServer is required")
 	}
 
 	var nodeupConfig nodeup.Config
 	var nodeupConfigHash [32]byte
 	if nodeConfig != nil {
 		if err := utils.YamlUnmarshal([]byte(nodeConfig.NodeupConfig), &nodeupConfig); err != nil {
-			return fmt.Errorf("error parsing BootConfig config response: %v", err)
+			return nil, fmt.Errorf("error parsing BootConfig config response: %v", err)
 		}
 		nodeupConfigHash = sha256.Sum256([]byte(nodeConfig.NodeupConfig))
 		nodeupConfig.CAs[fi.CertificateIDCA] = bootConfig.ConfigServer.CACertificates
@@ -144,44 +157,49 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 
 		b, err := nodeupConfigLocation.ReadFile(ctx)
 		if err != nil {
-			return fmt.Errorf("error loading NodeupConfig %q: %v", nodeupConfigLocation, err)
+			return nil, fmt.Errorf("error loading NodeupConfig %q: %v", nodeupConfigLocation, err)
 		}
 
 		if err = utils.YamlUnmarshal(b, &nodeupConfig); err != nil {
-			return fmt.Errorf("error parsing NodeupConfig %q: %v", nodeupConfigLocation, err)
+			return nil, fmt.Errorf("error parsing NodeupConfig %q: %v", nodeupConfigLocation, err)
 		}
 		nodeupConfigHash = sha256.Sum256(b)
 	} else {
-		return fmt.Errorf("no instance group defined in nodeup config")
+		return nil, fmt.Errorf("no instance group defined in nodeup config")
 	}
 
 	if bootConfig.NodeupConfigHash != "" {
 		if want, got := bootConfig.NodeupConfigHash, base64.StdEncoding.EncodeToString(nodeupConfigHash[:]); got != want {
-			return fmt.Errorf("nodeup config hash mismatch (was %q, expected %q)", got, want)
+			return nil, fmt.Errorf("nodeup config hash mismatch (was %q, expected %q)", got, want)
 		}
 	}
 
 	err = evaluateSpec(&nodeupConfig, bootConfig.CloudProvider)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	architecture, err := architectures.FindArchitecture()
 	if err != nil {
-		return fmt.Errorf("error determining OS architecture: %v", err)
+		return nil, fmt.Errorf("error determining OS architecture: %v", err)
 	}
 
 	distribution, err := distributions.FindDistribution("/")
 	if err != nil {
-		return fmt.Errorf("error determining OS distribution: %v", err)
+		return nil, fmt.Errorf("error determining OS distribution: %v", err)
 	}
 
 	configAssets := nodeupConfig.Assets[architecture]
+	assetVerifier, err := cosign.NewVerifier(nodeupConfig.AssetVerification)
+	if err != nil {
+		return nil, fmt.Errorf("error building asset verifier: %v", err)
+	}
 	assetStore := fi.NewAssetStore(c.CacheDir)
+	assetStore.Verifier = assetVerifier
 	for _, asset := range configAssets {
 		err := assetStore.Add(asset)
 		if err != nil {
-			return fmt.Errorf("error adding asset %q: %v", asset, err)
+			return nil, fmt.Errorf("error adding asset %q: %v", asset, err)
 		}
 	}
 
@@ -190,7 +208,7 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	if bootConfig.CloudProvider == api.CloudProviderAWS {
 		awsCloud, err := awsup.NewAWSCloud(region, nil)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		cloud = awsCloud
 	}
@@ -207,19 +225,26 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 
 	var secretStore fi.SecretStoreReader
 	var keyStore fi.KeystoreReader
+	var encrypter *envelope.Encrypter
+	if nodeConfig == nil && nodeupConfig.ConfigStore != nil {
+		encrypter, err = envelope.New(nodeupConfig.ConfigStore.Encryption)
+		if err != nil {
+			return nil, fmt.Errorf("error building config store encryption: %v", err)
+		}
+	}
 	if nodeConfig != nil {
 		modelContext.SecretStore = configserver.NewSecretStore(nodeConfig.NodeSecrets)
 	} else if nodeupConfig.ConfigStore != nil && nodeupConfig.ConfigStore.Secrets != "" {
 		klog.Infof("Building SecretStore at %q", nodeupConfig.ConfigStore.Secrets)
 		p, err := vfs.Context.BuildVfsPath(nodeupConfig.ConfigStore.Secrets)
 		if err != nil {
-			return fmt.Errorf("error building secret store path: %v", err)
+			return nil, fmt.Errorf("error building secret store path: %v", err)
 		}
 
-		secretStore = secrets.NewVFSSecretStoreReader(p)
+		secretStore = secrets.NewVFSSecretStoreReader(p, encrypter)
 		modelContext.SecretStore = secretStore
 	} else {
-		return fmt.Errorf("SecretStore not set")
+		return nil, fmt.Errorf("SecretStore not set")
 	}
 
 	if nodeConfig != nil {
@@ -228,23 +253,23 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		klog.Infof("Building KeyStore at %q", nodeupConfig.ConfigStore.Keypairs)
 		p, err := vfs.Context.BuildVfsPath(nodeupConfig.ConfigStore.Keypairs)
 		if err != nil {
-			return fmt.Errorf("error building key store path: %v", err)
+			return nil, fmt.Errorf("error building key store path: %v", err)
 		}
 
-		modelContext.KeyStore = fi.NewVFSKeystoreReader(p)
+		modelContext.KeyStore = fi.NewVFSKeystoreReader(p, encrypter)
 		keyStore = modelContext.KeyStore
 	} else {
-		return fmt.Errorf("KeyStore not set")
+		return nil, fmt.Errorf("KeyStore not set")
 	}
 
 	if err := modelContext.Init(); err != nil {
-		return err
+		return nil, err
 	}
 
 	if bootConfig.CloudProvider == api.CloudProviderAWS {
 		instanceIDBytes, err := vfs.Context.ReadFile("metadata://aws/meta-data/instance-id")
 		if err != nil {
-			return fmt.Errorf("error reading instance-id from AWS metadata: %v", err)
+			return nil, fmt.Errorf("error reading instance-id from AWS metadata: %v", err)
 		}
 		modelContext.InstanceID = string(instanceIDBytes)
 
@@ -252,23 +277,22 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		if len(modelContext.NodeupConfig.WarmPoolImages) > 0 {
 			modelContext.ConfigurationMode, err = getAWSConfigurationMode(ctx, modelContext)
 			if err != nil {
-				return err
+				return nil, err
 			}
 		}
 
 		modelContext.MachineType, err = getMachineType(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to get machine type: %w", err)
+			return nil, fmt.Errorf("failed to get machine type: %w", err)
 		}
 
 		// If Nvidia is enabled in the cluster, check if this instance has support for it.
-		nvidia := modelContext.NodeupConfig.ContainerdConfig.NvidiaGPU
-		if nvidia != nil && fi.ValueOf(nvidia.Enabled) {
+		if containerd := modelContext.NodeupConfig.ContainerdConfig; containerd != nil && containerd.NvidiaGPU != nil && fi.ValueOf(containerd.NvidiaGPU.Enabled) {
 			awsCloud := cloud.(awsup.AWSCloud)
 			// Get the instance type's detailed information.
 			instanceType, err := awsup.GetMachineTypeInfo(awsCloud, ec2types.InstanceType(modelContext.MachineType))
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			if instanceType.GPU {
@@ -284,17 +308,42 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		}
 	}
 
+	loader := &Loader{}
+	if distribution == distributions.DistributionBottlerocket {
+		// The root filesystem of Bottlerocket is read-only, and the image provides the kubelet, containerd and kernel modules;
+		// nodeup only provides what the image lacks.
+		loader.Builders = append(loader.Builders, &model.BottlerocketBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.KubeProxyBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &networking.CommonBuilder{NodeupModelContext: modelContext})
+		loader.Builders = append(loader.Builders, &model.BootstrapClientBuilder{NodeupModelContext: modelContext})
+
+		taskMap, err := loader.Build()
+		if err != nil {
+			return nil, fmt.Errorf("error building loader: %v", err)
+		}
+
+		return &nodeupTasks{
+			bootConfig:    &bootConfig,
+			nodeupConfig:  &nodeupConfig,
+			modelContext:  modelContext,
+			cloud:         cloud,
+			keyStore:      keyStore,
+			taskMap:       taskMap,
+			assetVerifier: assetVerifier,
+		}, nil
+	}
+
 	if err := loadKernelModules(modelContext); err != nil {
-		return err
+		return nil, err
 	}
 
-	loader := &Loader{}
 	loader.Builders = append(loader.Builders, &model.EtcHostsBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.NTPBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.DirectoryBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.UpdateServiceBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.VolumesBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.ContainerdBuilder{NodeupModelContext: modelContext})
+	loader.Builders = append(loader.Builders, &model.CrioBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.ProtokubeBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.CloudConfigBuilder{NodeupModelContext: modelContext})
 	loader.Builders = append(loader.Builders, &model.FileAssetsBuilder{NodeupModelContext: modelContext})
@@ -328,33 +377,55 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 	loader.Builders = append(loader.Builders, &model.BootstrapClientBuilder{NodeupModelContext: modelContext})
 	taskMap, err := loader.Build()
 	if err != nil {
-		return fmt.Errorf("error building loader: %v", err)
+		return nil, fmt.Errorf("error building loader: %v", err)
 	}
 
 	for i, image := range nodeupConfig.Images[architecture] {
 		taskMap["LoadImage."+strconv.Itoa(i)] = &nodetasks.LoadImageTask{
 			Sources: image.Sources,
 			Hash:    image.Hash,
+			Runtime: nodeupConfig.ContainerRuntime,
 		}
 	}
 	// Protokube load image task is in ProtokubeBuilder
 
+	return &nodeupTasks{
+		bootConfig:    &bootConfig,
+		nodeupConfig:  &nodeupConfig,
+		modelContext:  modelContext,
+		cloud:         cloud,
+		keyStore:      keyStore,
+		taskMap:       taskMap,
+		assetVerifier: assetVerifier,
+	}, nil
+}
+
+// Run is responsible for perform the nodeup process
+func (c *NodeUpCommand) Run(out io.Writer) error {
+	ctx := context.Background()
+
+	tasks, err := c.buildTasks(ctx)
+	if err != nil {
+		return err
+	}
+
 	var target fi.NodeupTarget
 
 	switch c.Target {
 	case "direct":
 		target = &local.LocalTarget{
-			CacheDir: c.CacheDir,
-			Cloud:    cloud,
+			CacheDir:      c.CacheDir,
+			Cloud:         tasks.cloud,
+			AssetVerifier: tasks.assetVerifier,
 		}
 	case "dryrun":
-		assetBuilder := assets.NewAssetBuilder(vfs.Context, nil, nodeupConfig.KubernetesVersion, false)
+		assetBuilder := assets.NewAssetBuilder(vfs.Context, nil, tasks.nodeupConfig.KubernetesVersion, false)
 		target = fi.NewNodeupDryRunTarget(assetBuilder, out)
 	default:
 		return fmt.Errorf("unsupported target type %q", c.Target)
 	}
 
-	context, err := fi.NewNodeupContext(ctx, target, keyStore, &bootConfig, &nodeupConfig, taskMap)
+	context, err := fi.NewNodeupContext(ctx, target, tasks.keyStore, tasks.bootConfig, tasks.nodeupConfig, tasks.taskMap)
 	if err != nil {
 		klog.Exitf("error building context: %v", err)
 	}
@@ -367,14 +438,14 @@ func (c *NodeUpCommand) Run(out io.Writer) error {
 		klog.Exitf("error running tasks: %v", err)
 	}
 
-	err = target.Finish(taskMap)
+	err = target.Finish(tasks.taskMap)
 	if err != nil {
 		klog.Exitf("error closing target: %v", err)
 	}
 
-	if nodeupConfig.EnableLifecycleHook {
-		if bootConfig.CloudProvider == api.CloudProviderAWS {
-			err := completeWarmingLifecycleAction(ctx, cloud.(awsup.AWSCloud), modelContext)
+	if tasks.nodeupConfig.EnableLifecycleHook {
+		if tasks.bootConfig.CloudProvider == api.CloudProviderAWS {
+			err := completeWarmingLifecycleAction(ctx, tasks.cloud.(awsup.AWSCloud), tasks.modelContext)
 			if err != nil {
 				return fmt.Errorf("failed to complete lifecylce action: %w", err)
 			}
@@ -19,12 +19,15 @@ package local
 import (
 	"os/exec"
 
+	"k8s.io/kops/pkg/assets/cosign"
 	"k8s.io/kops/upup/pkg/fi"
 )
 
 type LocalTarget struct {
 	CacheDir string
 	Cloud    fi.Cloud
+	// AssetVerifier verifies the signatures of downloaded assets and pulled images, if set
+	AssetVerifier *cosign.Verifier
 }
 
 var _ fi.NodeupTarget = &LocalTarget{}
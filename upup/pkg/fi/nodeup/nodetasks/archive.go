@@ -153,6 +153,11 @@ func (_ *Archive) RenderLocal(t *local.LocalTarget, a, e, changes *Archive) erro
 		if _, err := fi.DownloadURL(e.Source, localFile, hash); err != nil {
 			return err
 		}
+		if t.AssetVerifier != nil {
+			if err := t.AssetVerifier.VerifyFile(localFile, e.Source); err != nil {
+				return err
+			}
+		}
 
 		if len(e.MapFiles) == 0 {
 			targetDir := e.TargetDir
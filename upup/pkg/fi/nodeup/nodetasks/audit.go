@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"k8s.io/klog/v2"
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+// AuditableTasks returns the tasks that can be checked for drift by running them against a dry-run target.
+// Tasks are not auditable if running them has side effects on any target, or if they cannot tell whether they
+// have been applied. Tasks that produce different output every time they run, such as newly issued certificates,
+// are not auditable either, and nor are the tasks that use their output, such as the files holding the certificates.
+// Services are the exception: they depend on every file, but only check their own unit and state.
+func AuditableTasks(tasks map[string]fi.NodeupTask) map[string]fi.NodeupTask {
+	excluded := make(map[string]bool)
+	unpredictable := make(map[string]bool)
+	for key, task := range tasks {
+		switch task.(type) {
+		case *File, *Service, *Package, *UserTask, *GroupTask, *BindMount, *Archive, *AptSource, *Prefix, *UpdateEtcHostsTask:
+			// auditable
+		case *IssueCert, *BootstrapClientTask, *KubeConfig:
+			// These issue new credentials, or build kubeconfigs from them, when they run against any target
+			excluded[key] = true
+			unpredictable[key] = true
+		case *PullImageTask:
+			// This pulls the image when it runs, even against a dry-run target
+			excluded[key] = true
+		case *LoadImageTask, *UpdatePackages, *Chattr:
+			// These always report that they need to run
+			excluded[key] = true
+		default:
+			klog.Warningf("Unhandled type %T in AuditableTasks: %v", task, task)
+			excluded[key] = true
+		}
+	}
+
+	dependencies := fi.FindTaskDependencies(tasks)
+	for changed := true; changed; {
+		changed = false
+		for key, task := range tasks {
+			if unpredictable[key] {
+				continue
+			}
+			if _, ok := task.(*Service); ok {
+				continue
+			}
+			for _, dependency := range dependencies[key] {
+				if unpredictable[dependency] {
+					klog.V(2).Infof("not auditing %q, as it depends on %q", key, dependency)
+					excluded[key] = true
+					unpredictable[key] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	auditable := make(map[string]fi.NodeupTask)
+	for key, task := range tasks {
+		if !excluded[key] {
+			auditable[key] = task
+		}
+	}
+	return auditable
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodetasks
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s.io/kops/upup/pkg/fi"
+)
+
+func TestAuditableTasks(t *testing.T) {
+	issueCert := &IssueCert{Name: "kubelet"}
+	certResource, _, _ := issueCert.GetResources()
+
+	tasks := map[string]fi.NodeupTask{
+		"IssueCert/kubelet": issueCert,
+		"File//srv/kubernetes/kubelet/kubelet.crt": &File{
+			Path:     "/srv/kubernetes/kubelet/kubelet.crt",
+			Contents: certResource,
+			Type:     FileType_File,
+		},
+		"File//etc/kubernetes/manifests/etcd.manifest": &File{
+			Path:     "/etc/kubernetes/manifests/etcd.manifest",
+			Contents: fi.NewStringResource("manifest"),
+			Type:     FileType_File,
+		},
+		"Service/kubelet.service":  &Service{Name: "kubelet.service"},
+		"Package/conntrack":        &Package{Name: "conntrack"},
+		"UpdatePackages/":          &UpdatePackages{},
+		"LoadImageTask/protokube":  &LoadImageTask{Name: "protokube"},
+		"PullImageTask/pause":      &PullImageTask{Name: "pause"},
+		"Chattr//etc/kubernetes/x": &Chattr{File: "/etc/kubernetes/x"},
+	}
+
+	auditable := AuditableTasks(tasks)
+
+	var keys []string
+	for key := range auditable {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	expected := []string{
+		"File//etc/kubernetes/manifests/etcd.manifest",
+		"Package/conntrack",
+		"Service/kubelet.service",
+	}
+	if strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("unexpected auditable tasks %v, expected %v", keys, expected)
+	}
+
+	// The remaining tasks must be runnable on their own
+	dependencies := fi.FindTaskDependencies(auditable)
+	for key, deps := range dependencies {
+		for _, dep := range deps {
+			if auditable[dep] == nil {
+				t.Errorf("auditable task %q depends on excluded task %q", key, dep)
+			}
+		}
+	}
+}
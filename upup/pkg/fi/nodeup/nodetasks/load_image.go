@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/backoff"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/nodeup/local"
@@ -54,6 +55,9 @@ func (t *LoadImageTask) GetDependencies(tasks map[string]fi.NodeupTask) []fi.Nod
 		if svc, ok := v.(*Service); ok && svc.Name == containerdService {
 			deps = append(deps, v)
 		}
+		if svc, ok := v.(*Service); ok && svc.Name == crioService {
+			deps = append(deps, v)
+		}
 		if svc, ok := v.(*Service); ok && svc.Name == dockerService {
 			deps = append(deps, v)
 		}
@@ -100,6 +104,11 @@ func (_ *LoadImageTask) RenderLocal(t *local.LocalTarget, a, e, changes *LoadIma
 
 	// We assume the first url is the "main" url, and download to a local file based on that _name_, wherever we get it from
 	primaryURL := urls[0]
+
+	// CRI-O has no equivalent of "ctr images import"
+	if e.Runtime == kops.ContainerRuntimeCrio {
+		return fmt.Errorf("cannot load container image %s, as loading images is not supported with CRI-O", primaryURL)
+	}
 	key := path.Base(primaryURL)
 	localFile := filepath.Join(t.CacheDir, hash.String()+"_"+utils.SanitizeString(key))
 
@@ -108,9 +117,16 @@ func (_ *LoadImageTask) RenderLocal(t *local.LocalTarget, a, e, changes *LoadIma
 		if err != nil {
 			klog.Warningf("error downloading url %q: %v", url, err)
 			continue
-		} else {
-			break
 		}
+		if t.AssetVerifier != nil {
+			// The image is distributed as a file asset, so its signature is published beside it
+			err = t.AssetVerifier.VerifyFile(localFile, url)
+			if err != nil {
+				klog.Warningf("error verifying url %q: %v", url, err)
+				continue
+			}
+		}
+		break
 	}
 	if err != nil {
 		// Hack to try to avoid failed downloads causing massive bandwidth bills
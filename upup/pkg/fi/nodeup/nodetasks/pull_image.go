@@ -22,12 +22,15 @@ import (
 	"strings"
 
 	"k8s.io/klog/v2"
+	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/upup/pkg/fi"
+	"k8s.io/kops/upup/pkg/fi/nodeup/local"
 )
 
 // PullImageTask is responsible for pulling a docker image
 type PullImageTask struct {
-	Name string
+	Name    string
+	Runtime string
 }
 
 var (
@@ -41,7 +44,7 @@ func (t *PullImageTask) GetDependencies(tasks map[string]fi.NodeupTask) []fi.Nod
 	// configured.
 	var deps []fi.NodeupTask
 	for _, v := range tasks {
-		if svc, ok := v.(*Service); ok && svc.Name == containerdService {
+		if svc, ok := v.(*Service); ok && (svc.Name == containerdService || svc.Name == crioService) {
 			deps = append(deps, v)
 		}
 	}
@@ -56,8 +59,36 @@ func (t *PullImageTask) GetName() *string {
 }
 
 func (e *PullImageTask) Run(c *fi.NodeupContext) error {
+	image := e.Name
+	if t, ok := c.Target.(*local.LocalTarget); ok && t.AssetVerifier != nil && t.AssetVerifier.VerifiesImages() {
+		// Pull by the verified digest, so that the tag cannot be moved to another image in the meantime
+		verified, err := t.AssetVerifier.VerifyImage(e.Name)
+		if err != nil {
+			return err
+		}
+		image = verified
+	}
+
 	// Pull the container image
-	args := []string{"ctr", "--namespace", "k8s.io", "images", "pull", e.Name}
+	args := []string{"ctr", "--namespace", "k8s.io", "images", "pull", image}
+	if e.Runtime == kops.ContainerRuntimeCrio {
+		args = []string{"crictl", "pull", image}
+	}
+	if err := runPullImageCommand(args); err != nil {
+		return err
+	}
+
+	// Tag the verified image with the name the kubelet will use; crictl cannot tag images, so with CRI-O only the layers are cached
+	if image != e.Name && e.Runtime != kops.ContainerRuntimeCrio {
+		if err := runPullImageCommand([]string{"ctr", "--namespace", "k8s.io", "images", "tag", "--force", image, e.Name}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runPullImageCommand(args []string) error {
 	human := strings.Join(args, " ")
 
 	klog.Infof("running command %s", human)
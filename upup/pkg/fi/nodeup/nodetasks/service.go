@@ -42,6 +42,7 @@ const (
 	containerosSystemdSystemPath = "/etc/systemd/system"
 
 	containerdService = "containerd.service"
+	crioService       = "crio.service"
 	dockerService     = "docker.service"
 	kubeletService    = "kubelet.service"
 	protokubeService  = "protokube.service"
@@ -17,7 +17,6 @@ limitations under the License.
 package secrets
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -30,6 +29,7 @@ import (
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
 	kopsinternalversion "k8s.io/kops/pkg/client/clientset_generated/clientset/typed/kops/internalversion"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/vfs"
@@ -63,6 +63,11 @@ func (c *ClientsetSecretStore) MirrorTo(ctx context.Context, basedir vfs.Path) e
 		return fmt.Errorf("error listing keysets: %v", err)
 	}
 
+	encrypter, err := envelope.ForCluster(c.cluster)
+	if err != nil {
+		return err
+	}
+
 	for i := range list.Items {
 		keyset := &list.Items[i]
 
@@ -91,7 +96,7 @@ func (c *ClientsetSecretStore) MirrorTo(ctx context.Context, basedir vfs.Path) e
 			return err
 		}
 
-		if err := p.WriteFile(ctx, bytes.NewReader(data), acl); err != nil {
+		if err := encrypter.WriteFile(ctx, p, data, acl); err != nil {
 			return fmt.Errorf("error writing secret to %q: %v", p, err)
 		}
 	}
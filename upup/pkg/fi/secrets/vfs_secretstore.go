@@ -17,7 +17,6 @@ limitations under the License.
 package secrets
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -26,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/vfs"
 )
@@ -37,10 +37,11 @@ type VFSSecretStore struct {
 
 var _ fi.SecretStore = &VFSSecretStore{}
 
-func NewVFSSecretStore(cluster *kops.Cluster, basedir vfs.Path) fi.SecretStore {
+func NewVFSSecretStore(cluster *kops.Cluster, basedir vfs.Path, encrypter *envelope.Encrypter) fi.SecretStore {
 	c := &VFSSecretStore{
 		VFSSecretStoreReader: VFSSecretStoreReader{
-			basedir: basedir,
+			basedir:   basedir,
+			encrypter: encrypter,
 		},
 		cluster: cluster,
 	}
@@ -78,7 +79,7 @@ func (c *VFSSecretStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
 
 		klog.Infof("mirroring secret %s -> %s", name, p)
 
-		err = createSecret(ctx, secret, p, acl, true)
+		err = createSecret(ctx, c.encrypter, secret, p, acl, true)
 		if err != nil {
 			return fmt.Errorf("error writing secret %q for mirror: %v", name, err)
 		}
@@ -115,21 +116,28 @@ func (c *VFSSecretStore) GetOrCreateSecret(ctx context.Context, id string, secre
 	p := c.buildSecretPath(id)
 
 	for i := 0; i < 2; i++ {
-		s, err := c.FindSecret(id)
+		s, sealed, err := c.loadSecretData(ctx, p)
 		if err != nil {
 			return nil, false, err
 		}
 
-		if s != nil {
-			return s, false, nil
-		}
-
 		acl, err := acls.GetACL(ctx, p, c.cluster)
 		if err != nil {
 			return nil, false, err
 		}
 
-		err = createSecret(ctx, secret, p, acl, false)
+		if s != nil {
+			// Rewrite secrets stored before encryption was enabled, so that they are encrypted
+			if c.encrypter.Enabled() && !sealed {
+				klog.Infof("encrypting secret %q", id)
+				if err := createSecret(ctx, c.encrypter, s, p, acl, true); err != nil {
+					return nil, false, fmt.Errorf("error encrypting secret %q: %v", id, err)
+				}
+			}
+			return s, false, nil
+		}
+
+		err = createSecret(ctx, c.encrypter, secret, p, acl, false)
 		if err != nil {
 			if os.IsExist(err) && i == 0 {
 				klog.Infof("Got already-exists error when writing secret; likely due to concurrent creation.  Will retry")
@@ -163,7 +171,7 @@ func (c *VFSSecretStore) ReplaceSecret(id string, secret *fi.Secret) (*fi.Secret
 		return nil, err
 	}
 
-	err = createSecret(ctx, secret, p, acl, true)
+	err = createSecret(ctx, c.encrypter, secret, p, acl, true)
 	if err != nil {
 		return nil, fmt.Errorf("unable to write secret: %v", err)
 	}
@@ -176,16 +184,16 @@ func (c *VFSSecretStore) ReplaceSecret(id string, secret *fi.Secret) (*fi.Secret
 	return s, nil
 }
 
-// createSecret will create the Secret, overwriting an existing secret if replace is true
-func createSecret(ctx context.Context, s *fi.Secret, p vfs.Path, acl vfs.ACL, replace bool) error {
+// createSecret will create the Secret, overwriting an existing secret if replace is true.
+// The secret is encrypted if encrypter is not nil.
+func createSecret(ctx context.Context, encrypter *envelope.Encrypter, s *fi.Secret, p vfs.Path, acl vfs.ACL, replace bool) error {
 	data, err := json.Marshal(s)
 	if err != nil {
 		return fmt.Errorf("error serializing secret: %v", err)
 	}
 
-	rs := bytes.NewReader(data)
 	if replace {
-		return p.WriteFile(ctx, rs, acl)
+		return encrypter.WriteFile(ctx, p, data, acl)
 	}
-	return p.CreateFile(ctx, rs, acl)
+	return encrypter.CreateFile(ctx, p, data, acl)
 }
@@ -22,19 +22,23 @@ import (
 	"fmt"
 	"os"
 
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
 type VFSSecretStoreReader struct {
 	basedir vfs.Path
+	// encrypter decrypts secrets; secrets are stored as plaintext if it is nil.
+	encrypter *envelope.Encrypter
 }
 
 var _ fi.SecretStoreReader = &VFSSecretStoreReader{}
 
-func NewVFSSecretStoreReader(basedir vfs.Path) fi.SecretStoreReader {
+func NewVFSSecretStoreReader(basedir vfs.Path, encrypter *envelope.Encrypter) fi.SecretStoreReader {
 	c := &VFSSecretStoreReader{
-		basedir: basedir,
+		basedir:   basedir,
+		encrypter: encrypter,
 	}
 	return c
 }
@@ -74,16 +78,27 @@ func (c *VFSSecretStoreReader) Secret(id string) (*fi.Secret, error) {
 }
 
 func (c *VFSSecretStoreReader) loadSecret(ctx context.Context, p vfs.Path) (*fi.Secret, error) {
+	s, _, err := c.loadSecretData(ctx, p)
+	return s, err
+}
+
+// loadSecretData loads the secret at p, additionally returning whether it was stored encrypted.
+func (c *VFSSecretStoreReader) loadSecretData(ctx context.Context, p vfs.Path) (*fi.Secret, bool, error) {
 	data, err := p.ReadFile(ctx)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil
+			return nil, false, nil
 		}
 	}
+	sealed := envelope.IsSealed(data)
+	data, err = c.encrypter.Open(ctx, data)
+	if err != nil {
+		return nil, false, fmt.Errorf("decrypting secret from %q: %v", p, err)
+	}
 	s := &fi.Secret{}
 	err = json.Unmarshal(data, s)
 	if err != nil {
-		return nil, fmt.Errorf("parsing secret from %q: %v", p, err)
+		return nil, false, fmt.Errorf("parsing secret from %q: %v", p, err)
 	}
-	return s, nil
+	return s, sealed, nil
 }
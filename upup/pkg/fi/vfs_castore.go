@@ -30,6 +30,7 @@ import (
 	"k8s.io/kops/pkg/acls"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/v1alpha2"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/pkg/sshcredentials"
 	"k8s.io/kops/util/pkg/vfs"
@@ -45,10 +46,11 @@ var (
 	_ SSHCredentialStore = &VFSCAStore{}
 )
 
-func NewVFSCAStore(cluster *kops.Cluster, basedir vfs.Path) *VFSCAStore {
+func NewVFSCAStore(cluster *kops.Cluster, basedir vfs.Path, encrypter *envelope.Encrypter) *VFSCAStore {
 	c := &VFSCAStore{
 		VFSKeystoreReader: VFSKeystoreReader{
-			basedir: basedir,
+			basedir:   basedir,
+			encrypter: encrypter,
 		},
 		cluster: cluster,
 	}
@@ -119,7 +121,8 @@ func (k *Keyset) ToAPIObject(name string) (*kops.Keyset, error) {
 }
 
 // writeKeysetBundle writes a Keyset bundle to VFS.
-func writeKeysetBundle(ctx context.Context, cluster *kops.Cluster, p vfs.Path, name string, keyset *Keyset) error {
+// The bundle is encrypted if encrypter is not nil.
+func writeKeysetBundle(ctx context.Context, cluster *kops.Cluster, encrypter *envelope.Encrypter, p vfs.Path, name string, keyset *Keyset) error {
 	p = p.Join("keyset.yaml")
 
 	o, err := keyset.ToAPIObject(name)
@@ -136,7 +139,7 @@ func writeKeysetBundle(ctx context.Context, cluster *kops.Cluster, p vfs.Path, n
 	if err != nil {
 		return err
 	}
-	return p.WriteFile(ctx, bytes.NewReader(objectData), acl)
+	return encrypter.WriteFile(ctx, p, objectData, acl)
 }
 
 // serializeKeysetBundle converts a Keyset bundle to yaml, for writing to VFS.
@@ -206,7 +209,7 @@ func (c *VFSCAStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
 	}
 
 	for name, keyset := range keysets {
-		if err := mirrorKeyset(ctx, c.cluster, basedir, name, keyset); err != nil {
+		if err := mirrorKeyset(ctx, c.cluster, c.encrypter, basedir, name, keyset); err != nil {
 			return err
 		}
 	}
@@ -226,8 +229,8 @@ func (c *VFSCAStore) MirrorTo(ctx context.Context, basedir vfs.Path) error {
 }
 
 // mirrorKeyset writes Keyset bundles for the certificates & privatekeys.
-func mirrorKeyset(ctx context.Context, cluster *kops.Cluster, basedir vfs.Path, name string, keyset *Keyset) error {
-	if err := writeKeysetBundle(ctx, cluster, basedir.Join("private"), name, keyset); err != nil {
+func mirrorKeyset(ctx context.Context, cluster *kops.Cluster, encrypter *envelope.Encrypter, basedir vfs.Path, name string, keyset *Keyset) error {
+	if err := writeKeysetBundle(ctx, cluster, encrypter, basedir.Join("private"), name, keyset); err != nil {
 		return fmt.Errorf("writing private bundle: %v", err)
 	}
 
@@ -275,7 +278,7 @@ func (c *VFSCAStore) StoreKeyset(ctx context.Context, name string, keyset *Keyse
 
 	{
 		p := c.buildPrivateKeyPoolPath(name)
-		if err := writeKeysetBundle(ctx, c.cluster, p, name, keyset); err != nil {
+		if err := writeKeysetBundle(ctx, c.cluster, c.encrypter, p, name, keyset); err != nil {
 			return fmt.Errorf("writing private bundle: %v", err)
 		}
 	}
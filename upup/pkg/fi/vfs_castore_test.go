@@ -18,12 +18,18 @@ package fi
 
 import (
 	"context"
+	"crypto/x509/pkix"
 	"math/big"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"filippo.io/age"
+	"k8s.io/kops/pkg/apis/kops"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/pki"
 	"k8s.io/kops/util/pkg/vfs"
 )
@@ -158,3 +164,84 @@ spec:
 		}
 	}
 }
+
+func TestVFSCAStoreEncrypted(t *testing.T) {
+	ctx := context.TODO()
+
+	vfs.Context.ResetMemfsContext(true)
+
+	basePath, err := vfs.Context.BuildVfsPath("memfs://tests")
+	if err != nil {
+		t.Fatalf("error building vfspath: %v", err)
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatalf("error generating age identity: %v", err)
+	}
+	identityFile := filepath.Join(t.TempDir(), "identity.txt")
+	if err := os.WriteFile(identityFile, []byte(identity.String()), 0o600); err != nil {
+		t.Fatalf("error writing age identity: %v", err)
+	}
+	t.Setenv(envelope.EnvAgeIdentityFile, identityFile)
+
+	encrypter, err := envelope.New(&kops.ConfigStoreEncryptionSpec{
+		Age: &kops.AgeEncryptionSpec{Recipient: identity.Recipient().String()},
+	})
+	if err != nil {
+		t.Fatalf("error building encrypter: %v", err)
+	}
+
+	cert, privateKey, _, err := pki.IssueCert(ctx, &pki.IssueCertRequest{
+		Type:    "ca",
+		Subject: pkix.Name{CommonName: "etcd-clients-ca"},
+		Serial:  big.NewInt(1),
+	}, nil)
+	if err != nil {
+		t.Fatalf("error issuing certificate: %v", err)
+	}
+	keyset, err := NewKeyset(cert, privateKey)
+	if err != nil {
+		t.Fatalf("error building keyset: %v", err)
+	}
+
+	// A keyset stored before encryption was enabled is readable, and flagged to be rewritten
+	if err := NewVFSCAStore(nil, basePath, nil).StoreKeyset(ctx, "etcd-clients-ca", keyset); err != nil {
+		t.Fatalf("error from StoreKeyset: %v", err)
+	}
+	s := NewVFSCAStore(nil, basePath, encrypter)
+	loaded, err := s.FindKeyset(ctx, "etcd-clients-ca")
+	if err != nil {
+		t.Fatalf("error reading plaintext keyset: %v", err)
+	}
+	if !loaded.LegacyFormat {
+		t.Errorf("expected plaintext keyset to be flagged for rewriting")
+	}
+
+	if err := s.StoreKeyset(ctx, "etcd-clients-ca", keyset); err != nil {
+		t.Fatalf("error from StoreKeyset: %v", err)
+	}
+	data, err := basePath.Join("private", "etcd-clients-ca", "keyset.yaml").ReadFile(ctx)
+	if err != nil {
+		t.Fatalf("error reading keyset.yaml: %v", err)
+	}
+	if !envelope.IsSealed(data) || strings.Contains(string(data), "privateMaterial") {
+		t.Fatalf("expected keyset.yaml to be encrypted, was %q", string(data))
+	}
+
+	loaded, err = s.FindKeyset(ctx, "etcd-clients-ca")
+	if err != nil {
+		t.Fatalf("error reading encrypted keyset: %v", err)
+	}
+	if loaded.LegacyFormat {
+		t.Errorf("did not expect encrypted keyset to be flagged for rewriting")
+	}
+	if loaded.Primary.Id != keyset.Primary.Id {
+		t.Errorf("unexpected primary id %q, expected %q", loaded.Primary.Id, keyset.Primary.Id)
+	}
+
+	// An encrypted keyset cannot be read without encryption configured
+	if _, err := NewVFSCAStore(nil, basePath, nil).FindKeyset(ctx, "etcd-clients-ca"); err == nil {
+		t.Errorf("expected error reading encrypted keyset without encryption configured")
+	}
+}
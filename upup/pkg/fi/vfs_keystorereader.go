@@ -25,12 +25,15 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/apis/kops"
 	"k8s.io/kops/pkg/apis/kops/v1alpha2"
+	"k8s.io/kops/pkg/envelope"
 	"k8s.io/kops/pkg/kopscodecs"
 	"k8s.io/kops/util/pkg/vfs"
 )
 
 type VFSKeystoreReader struct {
 	basedir vfs.Path
+	// encrypter decrypts keysets; keysets are stored as plaintext if it is nil.
+	encrypter *envelope.Encrypter
 
 	mutex    sync.Mutex
 	cachedCA *Keyset
@@ -38,9 +41,10 @@ type VFSKeystoreReader struct {
 
 var _ KeystoreReader = &VFSKeystoreReader{}
 
-func NewVFSKeystoreReader(basedir vfs.Path) *VFSKeystoreReader {
+func NewVFSKeystoreReader(basedir vfs.Path, encrypter *envelope.Encrypter) *VFSKeystoreReader {
 	k := &VFSKeystoreReader{
-		basedir: basedir,
+		basedir:   basedir,
+		encrypter: encrypter,
 	}
 
 	return k
@@ -86,6 +90,11 @@ func (c *VFSKeystoreReader) loadKeyset(ctx context.Context, p vfs.Path) (*Keyset
 		}
 		return nil, fmt.Errorf("unable to read bundle %q: %v", p, err)
 	}
+	sealed := envelope.IsSealed(data)
+	data, err = c.encrypter.Open(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt bundle %q: %v", p, err)
+	}
 
 	o, legacyFormat, err := c.parseKeysetYaml(data)
 	if err != nil {
@@ -97,7 +106,8 @@ func (c *VFSKeystoreReader) loadKeyset(ctx context.Context, p vfs.Path) (*Keyset
 		return nil, fmt.Errorf("error mapping bundle %q: %v", p, err)
 	}
 
-	keyset.LegacyFormat = legacyFormat
+	// Rewrite keysets stored before encryption was enabled, so that they are encrypted
+	keyset.LegacyFormat = legacyFormat || (c.encrypter.Enabled() && !sealed)
 	return keyset, nil
 }
 
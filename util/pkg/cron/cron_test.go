@@ -0,0 +1,140 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"* * * foo *",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("expected error parsing %q", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	grid := []struct {
+		expr     string
+		from     string
+		expected string
+	}{
+		{
+			expr:     "* * * * *",
+			from:     "2024-01-01T10:00:30Z",
+			expected: "2024-01-01T10:01:00Z",
+		},
+		{
+			expr:     "0 2 * * *",
+			from:     "2024-01-01T02:00:00Z",
+			expected: "2024-01-02T02:00:00Z",
+		},
+		{
+			expr:     "*/15 9-17 * * mon-fri",
+			from:     "2024-01-05T17:50:00Z", // Friday
+			expected: "2024-01-08T09:00:00Z",
+		},
+		{
+			expr:     "30 22 * * SAT,sun",
+			from:     "2024-01-01T00:00:00Z", // Monday
+			expected: "2024-01-06T22:30:00Z",
+		},
+		{
+			expr:     "0 0 * * 7",
+			from:     "2024-01-01T00:00:00Z",
+			expected: "2024-01-07T00:00:00Z",
+		},
+		{
+			expr:     "0 0 29 feb *",
+			from:     "2024-03-01T00:00:00Z",
+			expected: "2028-02-29T00:00:00Z",
+		},
+		{
+			// When both day fields are restricted, either may match
+			expr:     "0 0 15 * 1",
+			from:     "2024-01-02T00:00:00Z",
+			expected: "2024-01-08T00:00:00Z",
+		},
+		{
+			expr:     "0-30/10 1 * * *",
+			from:     "2024-01-01T01:25:00Z",
+			expected: "2024-01-01T01:30:00Z",
+		},
+		{
+			expr:     "0 0 31 2 *",
+			from:     "2024-01-01T00:00:00Z",
+			expected: "",
+		},
+	}
+
+	for _, g := range grid {
+		t.Run(g.expr, func(t *testing.T) {
+			s, err := Parse(g.expr)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", g.expr, err)
+			}
+			from, err := time.Parse(time.RFC3339, g.from)
+			if err != nil {
+				t.Fatalf("error parsing time: %v", err)
+			}
+
+			actual := s.Next(from)
+			if g.expected == "" {
+				if !actual.IsZero() {
+					t.Errorf("expected no match, got %s", actual.Format(time.RFC3339))
+				}
+				return
+			}
+			if actual.Format(time.RFC3339) != g.expected {
+				t.Errorf("expected %s, got %s", g.expected, actual.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestNextInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+
+	s, err := Parse("0 2 * * *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	from := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	actual := s.Next(from.In(loc))
+	expected := time.Date(2024, 1, 2, 7, 0, 0, 0, time.UTC)
+	if !actual.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, actual.UTC())
+	}
+}
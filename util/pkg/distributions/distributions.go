@@ -52,6 +52,7 @@ var (
 	DistributionRocky9          = Distribution{packageFormat: "rpm", project: "rocky", id: "rocky9", version: 9}
 	DistributionFlatcar         = Distribution{packageFormat: "", project: "flatcar", id: "flatcar", version: 0}
 	DistributionContainerOS     = Distribution{packageFormat: "", project: "containeros", id: "containeros", version: 0}
+	DistributionBottlerocket    = Distribution{packageFormat: "", project: "bottlerocket", id: "bottlerocket", version: 0}
 )
 
 // IsDebianFamily returns true if this distribution uses deb packages and generally follows debian package names
@@ -89,6 +90,8 @@ func (d *Distribution) DefaultUsers() ([]string, error) {
 		return []string{"rocky"}, nil
 	case "flatcar":
 		return []string{"core"}, nil
+	case "bottlerocket":
+		return []string{"ec2-user"}, nil
 	default:
 		return nil, fmt.Errorf("unknown distro %v", d)
 	}
@@ -67,6 +67,9 @@ func FindDistribution(rootfs string) (Distribution, error) {
 	}
 
 	// Some distros have a more verbose VERSION_ID
+	if strings.HasPrefix(distro, "bottlerocket-") {
+		return DistributionBottlerocket, nil
+	}
 	if strings.HasPrefix(distro, "cos-") {
 		return DistributionContainerOS, nil
 	}
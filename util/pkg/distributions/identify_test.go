@@ -39,6 +39,11 @@ func TestFindDistribution(t *testing.T) {
 			err:      nil,
 			expected: DistributionAmazonLinux2023,
 		},
+		{
+			rootfs:   "bottlerocket",
+			err:      nil,
+			expected: DistributionBottlerocket,
+		},
 		{
 			rootfs:   "centos7",
 			err:      fmt.Errorf("unsupported distro: centos-7"),
@@ -25,6 +25,7 @@ import (
 	"k8s.io/kops/upup/pkg/fi"
 	"k8s.io/kops/upup/pkg/fi/cloudup/scaleway"
 	"k8s.io/kops/util/pkg/proxy"
+	"k8s.io/kops/util/pkg/vfs"
 )
 
 type EnvVars map[string]string
@@ -49,6 +50,10 @@ func BuildSystemComponentEnvVars(spec *kops.ClusterSpec) EnvVars {
 	vars.addEnvVariableIfExist("S3_ACCESS_KEY_ID")
 	vars.addEnvVariableIfExist("S3_SECRET_ACCESS_KEY")
 
+	// Notifications of changes to the S3 and GCS state stores
+	vars.addEnvVariableIfExist(vfs.EnvS3EventsQueueURL)
+	vars.addEnvVariableIfExist(vfs.EnvGCSNotificationsSubscription)
+
 	// Openstack related values
 	vars.addEnvVariableIfExist("OS_TENANT_ID")
 	vars.addEnvVariableIfExist("OS_TENANT_NAME")
@@ -74,6 +79,11 @@ func BuildSystemComponentEnvVars(spec *kops.ClusterSpec) EnvVars {
 	// Azure related values.
 	vars.addEnvVariableIfExist("AZURE_STORAGE_ACCOUNT")
 
+	// HashiCorp Vault related values.
+	for _, name := range vfs.VaultEnvVars {
+		vars.addEnvVariableIfExist(name)
+	}
+
 	// Scaleway related values.
 	profile, err := scaleway.CreateValidScalewayProfile()
 	if err == nil {
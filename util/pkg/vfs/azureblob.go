@@ -20,15 +20,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path"
 	"strings"
 	"sync"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/util/pkg/hashing"
@@ -43,8 +47,10 @@ type AzureBlobPath struct {
 }
 
 var (
-	_ Path    = &AzureBlobPath{}
-	_ HasHash = &AzureBlobPath{}
+	_ Path                = &AzureBlobPath{}
+	_ HasHash             = &AzureBlobPath{}
+	_ HasConditionalWrite = &AzureBlobPath{}
+	_ HasConditionalRead  = &AzureBlobPath{}
 )
 
 // NewAzureBlobPath returns a new AzureBlobPath.
@@ -103,29 +109,62 @@ func (p *AzureBlobPath) Join(relativePath ...string) Path {
 
 // ReadFile returns the content of the blob.
 func (p *AzureBlobPath) ReadFile(ctx context.Context) ([]byte, error) {
+	b, _, err := p.ReadFileVersion(ctx)
+	return b, err
+}
+
+// ReadFileVersion returns the content of the blob, along with its ETag.
+func (p *AzureBlobPath) ReadFileVersion(ctx context.Context) ([]byte, string, error) {
+	return p.download(ctx, nil)
+}
+
+// ReadFileIfModified implements HasConditionalRead::ReadFileIfModified
+// The blob is only downloaded if its ETag no longer matches.
+func (p *AzureBlobPath) ReadFileIfModified(ctx context.Context, version string) ([]byte, string, error) {
+	etag := azcore.ETag(version)
+	return p.download(ctx, &azblob.DownloadStreamOptions{
+		AccessConditions: &azblob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{
+				IfNoneMatch: &etag,
+			},
+		},
+	})
+}
+
+// download returns the content of the blob, along with its ETag.
+// If the options make the download conditional, ErrNotModified is returned when the condition is not met.
+func (p *AzureBlobPath) download(ctx context.Context, opts *azblob.DownloadStreamOptions) ([]byte, string, error) {
 	klog.V(8).Infof("Reading file: %s - %s", p.container, p.key)
 
 	client, err := p.getClient(ctx)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	get, err := client.DownloadStream(ctx, p.container, p.key, nil)
+	get, err := client.DownloadStream(ctx, p.container, p.key, opts)
 	if err != nil {
 		if bloberror.HasCode(err, bloberror.ContainerNotFound) || bloberror.HasCode(err, bloberror.BlobNotFound) {
-			return nil, os.ErrNotExist
+			return nil, "", os.ErrNotExist
 		}
-		return nil, err
+		var responseError *azcore.ResponseError
+		if errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotModified {
+			return nil, "", ErrNotModified
+		}
+		return nil, "", err
 	}
 
 	b := &bytes.Buffer{}
 	retryReader := get.NewRetryReader(ctx, &azblob.RetryReaderOptions{})
 	_, err = b.ReadFrom(retryReader)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	return b.Bytes(), nil
+	var etag string
+	if get.ETag != nil {
+		etag = string(*get.ETag)
+	}
+	return b.Bytes(), etag, nil
 }
 
 // WriteTo writes the content of the blob to the writer.
@@ -187,6 +226,38 @@ func (p *AzureBlobPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl A
 	return err
 }
 
+// WriteFileIfVersion writes the blob, only if its ETag matches the given version.
+func (p *AzureBlobPath) WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	klog.V(8).Infof("Writing file: %s - %s (if ETag %s)", p.container, p.key, version)
+
+	client, err := p.getClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	etag := azcore.ETag(version)
+	opts := &azblob.UploadStreamOptions{
+		AccessConditions: &azblob.AccessConditions{
+			ModifiedAccessConditions: &blob.ModifiedAccessConditions{
+				IfMatch: &etag,
+			},
+		},
+	}
+	response, err := client.UploadStream(ctx, p.container, p.key, data, opts)
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.ConditionNotMet, bloberror.BlobNotFound, bloberror.ContainerNotFound) {
+			return "", ErrVersionConflict
+		}
+		return "", err
+	}
+
+	var newVersion string
+	if response.ETag != nil {
+		newVersion = string(*response.ETag)
+	}
+	return newVersion, nil
+}
+
 // Remove deletes the blob.
 func (p *AzureBlobPath) Remove(ctx context.Context) error {
 	klog.V(8).Infof("Removing file: %q - %q", p.container, p.key)
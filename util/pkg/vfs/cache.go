@@ -17,65 +17,171 @@ limitations under the License.
 package vfs
 
 import (
+	"container/list"
 	"context"
+	"errors"
+	"os"
 	"sync"
 	"time"
 )
 
-// NewCache is a constructor for a Cache
+// DefaultCacheMaxEntries is the number of files held by a Cache built with NewCache.
+const DefaultCacheMaxEntries = 1000
+
+// NewCache is a constructor for a Cache holding up to DefaultCacheMaxEntries files
 func NewCache() *Cache {
+	return NewBoundedCache(DefaultCacheMaxEntries)
+}
+
+// NewBoundedCache is a constructor for a Cache holding up to maxEntries files
+func NewBoundedCache(maxEntries int) *Cache {
 	return &Cache{
-		cache: make(map[string]cacheEntry),
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
 	}
 }
 
-// Cache is a simple cache for vfs files.
+// Cache is a cache for vfs files.
 //
-// Currently we never expire the cache, so this is only safe for a
-// relatively bounded set of files - but it would not be too hard to
-// fix this.
+// When the cache is full, the least recently used file is evicted.
+// Once a file has been cached for longer than the ttl passed to Read, it is revalidated:
+// paths that implement HasConditionalRead only download the file again if it has changed,
+// using the version recorded when it was read.
 type Cache struct {
-	mutex sync.Mutex
-	cache map[string]cacheEntry
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	// lru holds the entries, most recently used first
+	lru   *list.List
+	stats CacheStats
+}
+
+// CacheStats counts the outcomes of reads from a Cache.
+type CacheStats struct {
+	// Hits is the number of reads served from the cache, without contacting the store.
+	Hits uint64
+	// Revalidations is the number of reads of expired files that the store confirmed had not changed.
+	Revalidations uint64
+	// Misses is the number of reads that downloaded the file, because it was not cached or had changed.
+	Misses uint64
+	// Evictions is the number of files evicted to keep the cache within its size.
+	Evictions uint64
+	// Entries is the number of files in the cache.
+	Entries int
 }
 
 type cacheEntry struct {
-	Added    time.Time
-	Contents []byte
+	key      string
+	added    time.Time
+	contents []byte
+	version  string
 }
 
+// Stats returns the number of hits, revalidations, misses and evictions since the cache was built.
+func (c *Cache) Stats() CacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// Read returns the contents of the file, from the cache if it was cached less than ttl ago.
 func (c *Cache) Read(p Path, ttl time.Duration) ([]byte, error) {
 	ctx := context.TODO()
 
 	key := p.Path()
 
 	c.mutex.Lock()
-	entry, found := c.cache[key]
+	var entry *cacheEntry
+	if element, found := c.entries[key]; found {
+		c.lru.MoveToFront(element)
+		entry = element.Value.(*cacheEntry)
+		if time.Since(entry.added) < ttl {
+			c.stats.Hits++
+			c.mutex.Unlock()
+			return entry.contents, nil
+		}
+	}
 	c.mutex.Unlock()
 
-	// Treat expired as not-found
-	if found {
-		expiresAt := entry.Added.Add(ttl)
-		if time.Now().After(expiresAt) {
-			found = false
+	conditional, canRevalidate := p.(HasConditionalRead)
+	if entry != nil && entry.version != "" && canRevalidate {
+		b, version, err := conditional.ReadFileIfModified(ctx, entry.version)
+		if errors.Is(err, ErrNotModified) {
+			c.add(key, entry.contents, entry.version, true)
+			return entry.contents, nil
+		}
+		if err != nil {
+			if os.IsNotExist(err) {
+				c.remove(key)
+			}
+			return nil, err
 		}
+		c.add(key, b, version, false)
+		return b, nil
 	}
 
-	if found {
-		return entry.Contents, nil
+	var b []byte
+	var version string
+	var err error
+	if versioned, ok := p.(HasConditionalWrite); ok && canRevalidate {
+		b, version, err = versioned.ReadFileVersion(ctx)
+	} else {
+		b, err = p.ReadFile(ctx)
 	}
-
-	b, err := p.ReadFile(ctx)
 	if err != nil {
+		if os.IsNotExist(err) {
+			c.remove(key)
+		}
 		return nil, err
 	}
 
-	entry.Contents = b
-	entry.Added = time.Now()
+	c.add(key, b, version, false)
+	return b, nil
+}
 
+// add records a read of the file, evicting the least recently used files if the cache is full.
+func (c *Cache) add(key string, contents []byte, version string, revalidated bool) {
 	c.mutex.Lock()
-	c.cache[key] = entry
-	c.mutex.Unlock()
+	defer c.mutex.Unlock()
 
-	return b, nil
+	if revalidated {
+		c.stats.Revalidations++
+	} else {
+		c.stats.Misses++
+	}
+
+	entry := &cacheEntry{
+		key:      key,
+		added:    time.Now(),
+		contents: contents,
+		version:  version,
+	}
+	if element, found := c.entries[key]; found {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+	} else {
+		c.entries[key] = c.lru.PushFront(entry)
+	}
+
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.stats.Evictions++
+	}
+}
+
+// remove removes the file from the cache.
+func (c *Cache) remove(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[key]; found {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+	}
 }
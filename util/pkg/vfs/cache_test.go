@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	vfsContext := NewTestingVFSContext()
+	base, err := vfsContext.BuildVfsPath("memfs://cache-test")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	write := func(p Path, contents string) {
+		if err := p.WriteFile(ctx, bytes.NewReader([]byte(contents)), nil); err != nil {
+			t.Fatalf("error writing %s: %v", p, err)
+		}
+	}
+	read := func(cache *Cache, p Path, ttl time.Duration, expected string) {
+		b, err := cache.Read(p, ttl)
+		if err != nil {
+			t.Fatalf("error reading %s: %v", p, err)
+		}
+		if string(b) != expected {
+			t.Fatalf("expected %s to be %q, was %q", p, expected, string(b))
+		}
+	}
+	checkStats := func(cache *Cache, expected CacheStats) {
+		if actual := cache.Stats(); actual != expected {
+			t.Fatalf("expected stats %+v, got %+v", expected, actual)
+		}
+	}
+
+	cache := NewBoundedCache(2)
+	a := base.Join("a")
+	b := base.Join("b")
+	c := base.Join("c")
+	write(a, "a1")
+	write(b, "b1")
+	write(c, "c1")
+
+	read(cache, a, time.Hour, "a1")
+	checkStats(cache, CacheStats{Misses: 1, Entries: 1})
+
+	// Within the ttl, changes are not seen
+	write(a, "a2")
+	read(cache, a, time.Hour, "a1")
+	checkStats(cache, CacheStats{Hits: 1, Misses: 1, Entries: 1})
+
+	// After the ttl, a changed file is downloaded again
+	read(cache, a, 0, "a2")
+	checkStats(cache, CacheStats{Hits: 1, Misses: 2, Entries: 1})
+
+	// After the ttl, an unchanged file is revalidated
+	read(cache, a, 0, "a2")
+	checkStats(cache, CacheStats{Hits: 1, Revalidations: 1, Misses: 2, Entries: 1})
+
+	// The least recently used file is evicted
+	read(cache, b, time.Hour, "b1")
+	read(cache, a, time.Hour, "a2")
+	read(cache, c, time.Hour, "c1")
+	checkStats(cache, CacheStats{Hits: 2, Revalidations: 1, Misses: 4, Evictions: 1, Entries: 2})
+	read(cache, a, time.Hour, "a2")
+	read(cache, b, time.Hour, "b1")
+	checkStats(cache, CacheStats{Hits: 3, Revalidations: 1, Misses: 5, Evictions: 2, Entries: 2})
+
+	// Removed files are not served from the cache once it expires
+	if err := b.Remove(ctx); err != nil {
+		t.Fatalf("error removing %s: %v", b, err)
+	}
+	if _, err := cache.Read(b, 0); !os.IsNotExist(err) {
+		t.Fatalf("expected not found reading removed file, got %v", err)
+	}
+	checkStats(cache, CacheStats{Hits: 3, Revalidations: 1, Misses: 5, Evictions: 2, Entries: 1})
+}
+
+func TestCacheWithoutConditionalRead(t *testing.T) {
+	p := NewFSPath(t.TempDir()).Join("file")
+	if err := p.WriteFile(context.Background(), bytes.NewReader([]byte("contents")), nil); err != nil {
+		t.Fatalf("error writing %s: %v", p, err)
+	}
+
+	// Paths that cannot check for changes are always downloaded again after the ttl
+	cache := NewCache()
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Read(p, 0); err != nil {
+			t.Fatalf("error reading %s: %v", p, err)
+		}
+	}
+	if stats := cache.Stats(); stats.Misses != 2 || stats.Revalidations != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/feature/ec2/imds"
 	"github.com/gophercloud/gophercloud"
+	vault "github.com/hashicorp/vault/api"
 	"google.golang.org/api/option"
 	storage "google.golang.org/api/storage/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -43,6 +44,9 @@ type VFSContext struct {
 	// mutex guards state
 	mutex sync.Mutex
 
+	// ociMutex serializes updates to OCI directory manifests within this process, so that they do not conflict with each other
+	ociMutex sync.Mutex
+
 	// vfsContextState makes it easier to copy the state
 	vfsContextState
 }
@@ -59,6 +63,10 @@ type vfsContextState struct {
 	swiftClient *gophercloud.ServiceClient
 
 	azureClient *azblob.Client
+
+	// vaultClient is the client for HashiCorp Vault, and vaultTokenExpiry is when its login token expires
+	vaultClient      *vault.Client
+	vaultTokenExpiry time.Time
 }
 
 // Context holds the global VFS state.
@@ -209,6 +217,14 @@ func (c *VFSContext) BuildVfsPath(p string) (Path, error) {
 		return c.buildSCWPath(p)
 	}
 
+	if strings.HasPrefix(p, "vault://") {
+		return c.buildVaultPath(p)
+	}
+
+	if strings.HasPrefix(p, "oci://") {
+		return c.buildOCIPath(p)
+	}
+
 	return nil, fmt.Errorf("unknown / unhandled path type: %q", p)
 }
 
@@ -518,3 +534,43 @@ func (c *VFSContext) buildSCWPath(p string) (*S3Path, error) {
 	s3path := newS3Path(c.s3Context, u.Scheme, bucket, u.Path, false)
 	return s3path, nil
 }
+
+func (c *VFSContext) buildVaultPath(p string) (*VaultPath, error) {
+	u, err := url.Parse(p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vault path: %q", p)
+	}
+	if u.Scheme != "vault" {
+		return nil, fmt.Errorf("invalid vault path: %q", p)
+	}
+
+	mount := strings.TrimSuffix(u.Host, "/")
+	if mount == "" {
+		return nil, fmt.Errorf("no secrets engine mount specified: %q", p)
+	}
+
+	return NewVaultPath(c, mount, u.Path), nil
+}
+
+func (c *VFSContext) buildOCIPath(p string) (*OCIPath, error) {
+	u, err := url.Parse(p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oci path: %q", p)
+	}
+	if u.Scheme != "oci" {
+		return nil, fmt.Errorf("invalid oci path: %q", p)
+	}
+
+	registry := u.Host
+	if registry == "" {
+		return nil, fmt.Errorf("no registry specified: %q", p)
+	}
+
+	repository, key, _ := strings.Cut(u.Path+"/", ociKeySeparator)
+	repository = strings.Trim(repository, "/")
+	if repository == "" {
+		return nil, fmt.Errorf("no repository specified: %q", p)
+	}
+
+	return NewOCIPath(c, registry, repository, key), nil
+}
@@ -17,15 +17,20 @@ limitations under the License.
 package vfs
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"k8s.io/klog/v2"
 	"k8s.io/kops/pkg/try"
 	"k8s.io/kops/util/pkg/hashing"
@@ -36,8 +41,10 @@ type FSPath struct {
 }
 
 var (
-	_ Path    = &FSPath{}
-	_ HasHash = &FSPath{}
+	_ Path                   = &FSPath{}
+	_ HasHash                = &FSPath{}
+	_ HasConditionalWrite    = &FSPath{}
+	_ HasChangeNotifications = &FSPath{}
 )
 
 func NewFSPath(location string) *FSPath {
@@ -123,6 +130,102 @@ func (p *FSPath) ReadFile(ctx context.Context) ([]byte, error) {
 	return file, err
 }
 
+// ReadFileVersion implements HasConditionalWrite::ReadFileVersion
+// The version of a local file is the hash of its contents.
+func (p *FSPath) ReadFileVersion(ctx context.Context) ([]byte, string, error) {
+	data, err := p.ReadFile(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	version, err := fileVersion(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	return data, version, nil
+}
+
+// WriteFileIfVersion implements HasConditionalWrite::WriteFileIfVersion
+// Concurrent writers, including other processes, are serialized by a lock file alongside the file.
+func (p *FSPath) WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	unlock, err := p.lock(ctx)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// The directory does not exist, so neither does the file
+			return "", ErrVersionConflict
+		}
+		return "", err
+	}
+	defer unlock()
+
+	_, current, err := p.ReadFileVersion(ctx)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrVersionConflict
+		}
+		return "", err
+	}
+	if current != version {
+		return "", ErrVersionConflict
+	}
+
+	newVersion, err := fileVersion(data)
+	if err != nil {
+		return "", err
+	}
+	if _, err := data.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("error seeking to start of data stream for write to %s: %w", p, err)
+	}
+	if err := p.WriteFile(ctx, data, acl); err != nil {
+		return "", err
+	}
+	return newVersion, nil
+}
+
+// fsLockStaleAge is the age after which a lock file is assumed to have been left behind by a process that died.
+const fsLockStaleAge = time.Minute
+
+// lock takes an exclusive lock on the file, by creating a hidden lock file in the same directory.
+// It returns a function that releases the lock.
+func (p *FSPath) lock(ctx context.Context) (func(), error) {
+	lockPath := path.Join(path.Dir(p.location), "."+path.Base(p.location)+".lock")
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			try.CloseFile(f)
+			return func() {
+				if err := os.Remove(lockPath); err != nil {
+					klog.Warningf("unable to remove lock file %q: %v", lockPath, err)
+				}
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("error creating lock file %q: %w", lockPath, err)
+		}
+
+		if stat, err := os.Stat(lockPath); err == nil && time.Since(stat.ModTime()) > fsLockStaleAge {
+			klog.Warningf("removing stale lock file %q", lockPath)
+			if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+				return nil, fmt.Errorf("error removing stale lock file %q: %w", lockPath, err)
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for lock file %q: %w", lockPath, ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func fileVersion(r io.Reader) (string, error) {
+	hash, err := hashing.HashAlgorithmSHA256.Hash(r)
+	if err != nil {
+		return "", err
+	}
+	return hash.Hex(), nil
+}
+
 // WriteTo implements io.WriterTo
 func (p *FSPath) WriteTo(out io.Writer) (int64, error) {
 	f, err := os.Open(p.location)
@@ -224,3 +327,76 @@ func (p *FSPath) Hash(a hashing.HashAlgorithm) (*hashing.Hash, error) {
 
 	return a.HashFile(p.location)
 }
+
+// NotifyChanges implements HasChangeNotifications, using inotify (or the platform's equivalent).
+func (p *FSPath) NotifyChanges(ctx context.Context) (<-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("error creating watcher: %w", err)
+	}
+
+	// Watches are not recursive, so we watch every directory, including those created later
+	addDirectories := func(base string) error {
+		return filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return watcher.Add(p)
+			}
+			return nil
+		})
+	}
+	if err := addDirectories(p.location); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("error watching %q: %w", p.location, err)
+	}
+
+	notifications := make(chan struct{}, 1)
+	go func() {
+		defer close(notifications)
+		defer watcher.Close()
+
+		// We wait for events to settle before notifying, so that a write is not seen while only its temp file exists
+		settle := time.NewTimer(0)
+		<-settle.C
+		defer settle.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-settle.C:
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Create) {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						if err := addDirectories(event.Name); err != nil {
+							klog.Warningf("error watching %q: %v", event.Name, err)
+						}
+					}
+				}
+				if !settle.Stop() {
+					select {
+					case <-settle.C:
+					default:
+					}
+				}
+				settle.Reset(100 * time.Millisecond)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				klog.Warningf("error watching %q: %v", p.location, err)
+			}
+		}
+	}()
+
+	return notifications, nil
+}
@@ -18,6 +18,7 @@ package vfs
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"path"
 	"testing"
@@ -100,3 +101,65 @@ func TestWriteTo(t *testing.T) {
 		}
 	}
 }
+
+func TestFSConditionalWrite(t *testing.T) {
+	p := NewFSPath(path.Join(t.TempDir(), "SubDir", "config"))
+	testConditionalWrite(t, p)
+
+	// The lock file must have been released
+	if _, err := os.Stat(path.Join(path.Dir(p.location), ".config.lock")); !os.IsNotExist(err) {
+		t.Errorf("Expected lock file to be removed, got: %v", err)
+	}
+}
+
+// testConditionalWrite checks that a conditional write only succeeds if the file is at the expected version.
+func testConditionalWrite(t *testing.T, p Path) {
+	ctx := testcontext.ForTest(t)
+
+	conditional, ok := p.(HasConditionalWrite)
+	if !ok {
+		t.Fatalf("%T does not implement HasConditionalWrite", p)
+	}
+
+	if _, _, err := conditional.ReadFileVersion(ctx); !os.IsNotExist(err) {
+		t.Errorf("Expected to get os.ErrNotExist reading missing file, got: %v", err)
+	}
+	if _, err := conditional.WriteFileIfVersion(ctx, bytes.NewReader([]byte("data")), nil, "1"); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Expected to get ErrVersionConflict writing missing file, got: %v", err)
+	}
+
+	if err := p.WriteFile(ctx, bytes.NewReader([]byte("first")), nil); err != nil {
+		t.Fatalf("Error writing file %s: %v", p, err)
+	}
+	data, version, err := conditional.ReadFileVersion(ctx)
+	if err != nil {
+		t.Fatalf("Error reading file %s: %v", p, err)
+	}
+	if string(data) != "first" {
+		t.Errorf("Expected file content %q, got %q", "first", data)
+	}
+
+	newVersion, err := conditional.WriteFileIfVersion(ctx, bytes.NewReader([]byte("second")), nil, version)
+	if err != nil {
+		t.Fatalf("Error writing file %s at version %q: %v", p, version, err)
+	}
+	if newVersion == version {
+		t.Errorf("Expected version to change from %q", version)
+	}
+
+	// A writer that read the first version must not overwrite the second
+	if _, err := conditional.WriteFileIfVersion(ctx, bytes.NewReader([]byte("stale")), nil, version); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Expected to get ErrVersionConflict writing stale version, got: %v", err)
+	}
+
+	data, currentVersion, err := conditional.ReadFileVersion(ctx)
+	if err != nil {
+		t.Fatalf("Error reading file %s: %v", p, err)
+	}
+	if string(data) != "second" {
+		t.Errorf("Expected file content %q, got %q", "second", data)
+	}
+	if currentVersion != newVersion {
+		t.Errorf("Expected version %q, got %q", newVersion, currentVersion)
+	}
+}
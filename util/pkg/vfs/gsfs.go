@@ -20,11 +20,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -49,9 +51,11 @@ type GSPath struct {
 }
 
 var (
-	_ Path          = &GSPath{}
-	_ TerraformPath = &GSPath{}
-	_ HasHash       = &GSPath{}
+	_ Path                = &GSPath{}
+	_ TerraformPath       = &GSPath{}
+	_ HasHash             = &GSPath{}
+	_ HasConditionalWrite = &GSPath{}
+	_ HasConditionalRead  = &GSPath{}
 )
 
 // gcsReadBackoff is the backoff strategy for GCS read retries
@@ -173,11 +177,33 @@ func (p *GSPath) Join(relativePath ...string) Path {
 }
 
 func (p *GSPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	_, err := p.writeObject(ctx, data, acl, nil)
+	return err
+}
+
+// WriteFileIfVersion implements HasConditionalWrite::WriteFileIfVersion
+// The version of a GCS object is its generation, which is checked by GCS using ifGenerationMatch.
+func (p *GSPath) WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	generation, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid generation %q for %s: %w", version, p, err)
+	}
+	obj, err := p.writeObject(ctx, data, acl, &generation)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(obj.Generation, 10), nil
+}
+
+// writeObject writes the object, only if it is at ifGeneration when that is not nil.
+func (p *GSPath) writeObject(ctx context.Context, data io.ReadSeeker, acl ACL, ifGeneration *int64) (*storage.Object, error) {
 	md5Hash, err := hashing.HashAlgorithmMD5.Hash(data)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var written *storage.Object
+
 	done, err := RetryWithBackoff(gcsWriteBackoff, func() (bool, error) {
 		obj := &storage.Object{
 			Name:    p.key,
@@ -204,20 +230,28 @@ func (p *GSPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) err
 			return false, err
 		}
 
-		_, err = client.Objects.Insert(p.bucket, obj).Context(ctx).Media(data).Do()
+		call := client.Objects.Insert(p.bucket, obj).Context(ctx).Media(data)
+		if ifGeneration != nil {
+			call = call.IfGenerationMatch(*ifGeneration)
+		}
+		written, err = call.Do()
 		if err != nil {
+			if ifGeneration != nil && (isGCSPreconditionFailed(err) || isGCSNotFound(err)) {
+				// Not recoverable
+				return true, ErrVersionConflict
+			}
 			return false, fmt.Errorf("error writing %s: %v", p, err)
 		}
 
 		return true, nil
 	})
 	if err != nil {
-		return err
+		return nil, err
 	} else if done {
-		return nil
+		return written, nil
 	} else {
 		// Shouldn't happen - we always return a non-nil error with false
-		return wait.ErrWaitTimeout
+		return nil, wait.ErrWaitTimeout
 	}
 }
 
@@ -246,12 +280,37 @@ func (p *GSPath) CreateFile(ctx context.Context, data io.ReadSeeker, acl ACL) er
 
 // ReadFile implements Path::ReadFile
 func (p *GSPath) ReadFile(ctx context.Context) ([]byte, error) {
+	data, _, err := p.readObject(ctx)
+	return data, err
+}
+
+// ReadFileVersion implements HasConditionalWrite::ReadFileVersion
+func (p *GSPath) ReadFileVersion(ctx context.Context) ([]byte, string, error) {
+	return p.readObject(ctx)
+}
+
+// ReadFileIfModified implements HasConditionalRead::ReadFileIfModified
+// The object is only downloaded if its generation has changed.
+func (p *GSPath) ReadFileIfModified(ctx context.Context, version string) ([]byte, string, error) {
+	generation, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid generation %q for %s", version, p)
+	}
+	return p.readObject(ctx, func(call *storage.ObjectsGetCall) {
+		call.IfGenerationNotMatch(generation)
+	})
+}
+
+// readObject returns the contents of the object, along with its generation.
+func (p *GSPath) readObject(ctx context.Context, options ...func(*storage.ObjectsGetCall)) ([]byte, string, error) {
 	var b bytes.Buffer
+	var generation string
 	done, err := RetryWithBackoff(gcsReadBackoff, func() (bool, error) {
 		b.Reset()
-		_, err := p.WriteTo(&b)
+		var err error
+		_, generation, err = p.download(ctx, &b, options...)
 		if err != nil {
-			if os.IsNotExist(err) {
+			if os.IsNotExist(err) || errors.Is(err, ErrNotModified) {
 				// Not recoverable
 				return true, err
 			}
@@ -261,12 +320,12 @@ func (p *GSPath) ReadFile(ctx context.Context) ([]byte, error) {
 		return true, nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	} else if done {
-		return b.Bytes(), nil
+		return b.Bytes(), generation, nil
 	} else {
 		// Shouldn't happen - we always return a non-nil error with false
-		return nil, wait.ErrWaitTimeout
+		return nil, "", wait.ErrWaitTimeout
 	}
 }
 
@@ -274,26 +333,41 @@ func (p *GSPath) ReadFile(ctx context.Context) ([]byte, error) {
 func (p *GSPath) WriteTo(out io.Writer) (int64, error) {
 	ctx := context.TODO()
 
+	n, _, err := p.download(ctx, out)
+	return n, err
+}
+
+// download copies the contents of the object to out, returning the generation of the object.
+// The options can make the download conditional, in which case ErrNotModified is returned if the condition is not met.
+func (p *GSPath) download(ctx context.Context, out io.Writer, options ...func(*storage.ObjectsGetCall)) (int64, string, error) {
 	klog.V(4).Infof("Reading file %q", p)
 
 	client, err := p.getStorageClient(ctx)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
-	response, err := client.Objects.Get(p.bucket, p.key).Context(ctx).Download()
+	call := client.Objects.Get(p.bucket, p.key).Context(ctx)
+	for _, option := range options {
+		option(call)
+	}
+	response, err := call.Download()
 	if err != nil {
 		if isGCSNotFound(err) {
-			return 0, os.ErrNotExist
+			return 0, "", os.ErrNotExist
+		}
+		if isGCSNotModified(err) {
+			return 0, "", ErrNotModified
 		}
-		return 0, fmt.Errorf("error reading %s: %v", p, err)
+		return 0, "", fmt.Errorf("error reading %s: %v", p, err)
 	}
 	if response == nil {
-		return 0, fmt.Errorf("no response returned from reading %s", p)
+		return 0, "", fmt.Errorf("no response returned from reading %s", p)
 	}
 	defer response.Body.Close()
 
-	return io.Copy(out, response.Body)
+	n, err := io.Copy(out, response.Body)
+	return n, response.Header.Get("X-Goog-Generation"), err
 }
 
 // ReadDir implements Path::ReadDir
@@ -525,6 +599,22 @@ func isGCSNotFound(err error) bool {
 	return ok && ae.Code == http.StatusNotFound
 }
 
+func isGCSNotModified(err error) bool {
+	if err == nil {
+		return false
+	}
+	ae, ok := err.(*googleapi.Error)
+	return ok && ae.Code == http.StatusNotModified
+}
+
+func isGCSPreconditionFailed(err error) bool {
+	if err == nil {
+		return false
+	}
+	ae, ok := err.(*googleapi.Error)
+	return ok && ae.Code == http.StatusPreconditionFailed
+}
+
 func (p *GSPath) getStorageClient(ctx context.Context) (*storage.Service, error) {
 	return p.vfsContext.getGCSClient(ctx)
 }
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/option"
+	pubsub "google.golang.org/api/pubsub/v1"
+	"k8s.io/klog/v2"
+)
+
+// EnvGCSNotificationsSubscription is the Pub/Sub subscription, as projects/<project>/subscriptions/<name>,
+// that receives the Cloud Storage notifications for the state store bucket.
+const EnvGCSNotificationsSubscription = "KOPS_STATE_STORE_GCS_SUBSCRIPTION"
+
+var _ HasChangeNotifications = &GSPath{}
+
+// NotifyChanges implements HasChangeNotifications.
+// When EnvGCSNotificationsSubscription is set, it pulls the OBJECT_FINALIZE and OBJECT_DELETE notifications that
+// Cloud Storage publishes for the bucket. Messages are acknowledged once received, so when several watchers share
+// a subscription each of them only sees some of the notifications; Watch still finds the other changes on its next poll.
+func (p *GSPath) NotifyChanges(ctx context.Context) (<-chan struct{}, error) {
+	subscription := os.Getenv(EnvGCSNotificationsSubscription)
+	if subscription == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(subscription, "projects/") || !strings.Contains(subscription, "/subscriptions/") {
+		return nil, fmt.Errorf("invalid %s %q: expected projects/<project>/subscriptions/<name>", EnvGCSNotificationsSubscription, subscription)
+	}
+
+	client, err := pubsub.NewService(ctx, option.WithScopes(pubsub.PubsubScope))
+	if err != nil {
+		return nil, fmt.Errorf("error building Pub/Sub client: %w", err)
+	}
+
+	notifications := make(chan struct{}, 1)
+	go func() {
+		defer close(notifications)
+
+		for ctx.Err() == nil {
+			response, err := client.Projects.Subscriptions.Pull(subscription, &pubsub.PullRequest{
+				MaxMessages: 10,
+			}).Context(ctx).Do()
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				klog.Warningf("error pulling messages from %q: %v", subscription, err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(10 * time.Second):
+				}
+				continue
+			}
+
+			changed := false
+			var ackIDs []string
+			for _, message := range response.ReceivedMessages {
+				if message.Message != nil && p.isChangeNotification(message.Message.Attributes) {
+					changed = true
+				}
+				ackIDs = append(ackIDs, message.AckId)
+			}
+			if len(ackIDs) != 0 {
+				if _, err := client.Projects.Subscriptions.Acknowledge(subscription, &pubsub.AcknowledgeRequest{
+					AckIds: ackIDs,
+				}).Context(ctx).Do(); err != nil {
+					klog.Warningf("error acknowledging messages from %q: %v", subscription, err)
+				}
+			}
+			if changed {
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return notifications, nil
+}
+
+// isChangeNotification returns true if attributes are those of a Cloud Storage notification for an object under the path.
+func (p *GSPath) isChangeNotification(attributes map[string]string) bool {
+	if attributes["bucketId"] != p.bucket {
+		return false
+	}
+	if eventType := attributes["eventType"]; eventType != "OBJECT_FINALIZE" && eventType != "OBJECT_DELETE" {
+		return false
+	}
+	prefix := strings.TrimSuffix(p.key, "/")
+	object := attributes["objectId"]
+	return prefix == "" || object == prefix || strings.HasPrefix(object, prefix+"/")
+}
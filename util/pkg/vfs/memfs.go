@@ -22,6 +22,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -36,11 +37,16 @@ type MemFSPath struct {
 	mutex    sync.Mutex
 	contents []byte
 	children map[string]*MemFSPath
+
+	// generation is incremented on every write, and is used as the version for conditional writes
+	generation int64
 }
 
 var (
-	_ Path          = &MemFSPath{}
-	_ TerraformPath = &MemFSPath{}
+	_ Path                = &MemFSPath{}
+	_ TerraformPath       = &MemFSPath{}
+	_ HasConditionalWrite = &MemFSPath{}
+	_ HasConditionalRead  = &MemFSPath{}
 )
 
 type MemFSContext struct {
@@ -112,9 +118,50 @@ func (p *MemFSPath) WriteFile(ctx context.Context, r io.ReadSeeker, acl ACL) err
 	}
 	p.contents = data
 	p.acl = acl
+	p.generation++
 	return nil
 }
 
+// ReadFileVersion implements HasConditionalWrite::ReadFileVersion
+func (p *MemFSPath) ReadFileVersion(ctx context.Context) ([]byte, string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.contents == nil {
+		return nil, "", os.ErrNotExist
+	}
+	return p.contents, strconv.FormatInt(p.generation, 10), nil
+}
+
+// ReadFileIfModified implements HasConditionalRead::ReadFileIfModified
+func (p *MemFSPath) ReadFileIfModified(ctx context.Context, version string) ([]byte, string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.contents == nil {
+		return nil, "", os.ErrNotExist
+	}
+	current := strconv.FormatInt(p.generation, 10)
+	if current == version {
+		return nil, "", ErrNotModified
+	}
+	return p.contents, current, nil
+}
+
+// WriteFileIfVersion implements HasConditionalWrite::WriteFileIfVersion
+func (p *MemFSPath) WriteFileIfVersion(ctx context.Context, r io.ReadSeeker, acl ACL, version string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.contents == nil || strconv.FormatInt(p.generation, 10) != version {
+		return "", ErrVersionConflict
+	}
+	if err := p.WriteFile(ctx, r, acl); err != nil {
+		return "", err
+	}
+	return strconv.FormatInt(p.generation, 10), nil
+}
+
 func (p *MemFSPath) CreateFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
 	// Check if exists
 	if p.contents != nil {
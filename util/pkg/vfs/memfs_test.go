@@ -169,3 +169,7 @@ func TestMemFsReadTree(t *testing.T) {
 		}
 	}
 }
+
+func TestMemFsConditionalWrite(t *testing.T) {
+	testConditionalWrite(t, NewMemFSPath(NewMemFSContext(), "/root/subdir/config"))
+}
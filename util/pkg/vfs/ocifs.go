@@ -0,0 +1,701 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kops/util/pkg/hashing"
+)
+
+const (
+	// ociKeySeparator separates the repository from the key within it in an oci:// path.
+	// A lone "-" is not a valid repository path component, so the split is never ambiguous.
+	ociKeySeparator = "/-/"
+
+	// ociDirectoryMediaType is the config media type of the manifest that describes a directory,
+	// which marks the manifest as a kOps VFS artifact.
+	ociDirectoryMediaType types.MediaType = "application/vnd.kubernetes.kops.vfs.directory.v1+json"
+	// ociFileMediaType is the media type of the blobs that hold file contents.
+	ociFileMediaType types.MediaType = "application/vnd.kubernetes.kops.vfs.file.v1"
+
+	// ociTitleAnnotation holds the file name on each layer of a directory manifest.
+	ociTitleAnnotation = "org.opencontainers.image.title"
+	// ociPathAnnotation holds the key of the directory a manifest describes.
+	ociPathAnnotation = "io.k8s.kops.vfs.path"
+	// ociDirectoriesAnnotation holds the JSON list of subdirectory names of a directory.
+	ociDirectoriesAnnotation = "io.k8s.kops.vfs.directories"
+
+	// ociDirectoryTagPrefix prefixes the tag of each directory manifest;
+	// the rest of the tag is the sha256 of the directory key, as keys are not valid tags.
+	ociDirectoryTagPrefix = "kops-vfs-"
+)
+
+const (
+	// ociUpdateAttempts is the number of times a directory manifest update is attempted when it conflicts with another writer.
+	ociUpdateAttempts = 10
+	// ociUpdateBackoff is the base delay between attempts.
+	ociUpdateBackoff = 100 * time.Millisecond
+)
+
+// ociEmptyConfig is the config blob of every directory manifest.
+var ociEmptyConfig = []byte("{}")
+
+// OCIPath is a path in the VFS space backed by a repository in an OCI registry.
+// Each file is stored as a blob, and each directory as a manifest whose layers are the files in that directory.
+// The manifest is tagged with a tag derived from the directory key, and lists the names of its subdirectories
+// in an annotation, so that a tree can be walked from any directory.
+type OCIPath struct {
+	vfsContext *VFSContext
+	registry   string
+	repository string
+	key        string
+}
+
+var (
+	_ Path               = &OCIPath{}
+	_ HasHash            = &OCIPath{}
+	_ HasClusterReadable = &OCIPath{}
+)
+
+// NewOCIPath returns a new OCIPath for the key in the repository in the registry.
+func NewOCIPath(vfsContext *VFSContext, registry string, repository string, key string) *OCIPath {
+	return &OCIPath{
+		vfsContext: vfsContext,
+		registry:   registry,
+		repository: strings.Trim(repository, "/"),
+		key:        strings.Trim(key, "/"),
+	}
+}
+
+// Registry returns the host (and port) of the registry.
+func (p *OCIPath) Registry() string {
+	return p.registry
+}
+
+// Repository returns the name of the repository within the registry.
+func (p *OCIPath) Repository() string {
+	return p.repository
+}
+
+// Key returns the path of the file within the repository.
+func (p *OCIPath) Key() string {
+	return p.key
+}
+
+// Path returns a string representing the full path.
+func (p *OCIPath) Path() string {
+	s := "oci://" + p.registry + "/" + p.repository
+	if p.key != "" {
+		s += ociKeySeparator + p.key
+	}
+	return s
+}
+
+func (p *OCIPath) String() string {
+	return p.Path()
+}
+
+// Base returns the base name (last element).
+func (p *OCIPath) Base() string {
+	if p.key == "" {
+		return path.Base(p.repository)
+	}
+	return path.Base(p.key)
+}
+
+// Join returns a new path that joins the current path and given relative paths.
+func (p *OCIPath) Join(relativePath ...string) Path {
+	args := []string{p.key}
+	args = append(args, relativePath...)
+	joined := path.Join(args...)
+	return p.withKey(strings.Trim(joined, "/"))
+}
+
+// IsClusterReadable implements HasClusterReadable.
+// Registries in the environments we target allow nodes to pull, as they already pull the container images from them.
+func (p *OCIPath) IsClusterReadable() bool {
+	return true
+}
+
+// ReadFile implements Path::ReadFile
+func (p *OCIPath) ReadFile(ctx context.Context) ([]byte, error) {
+	klog.V(8).Infof("Reading file: %s", p)
+
+	r, err := p.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", p, err)
+	}
+	return b, nil
+}
+
+// WriteTo implements io.WriterTo
+// The blob is streamed, so large files are not held in memory.
+func (p *OCIPath) WriteTo(out io.Writer) (int64, error) {
+	ctx := context.TODO()
+
+	r, err := p.open(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	n, err := io.Copy(out, r)
+	if err != nil {
+		return n, fmt.Errorf("error reading %s: %w", p, err)
+	}
+	return n, nil
+}
+
+// open returns a reader for the blob holding the contents of the file.
+func (p *OCIPath) open(ctx context.Context) (io.ReadCloser, error) {
+	file, err := p.descriptor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := p.repo()
+	if err != nil {
+		return nil, err
+	}
+	layer, err := remote.Layer(repo.Digest(file.Digest.String()), p.remoteOptions(ctx)...)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", p, err)
+	}
+	r, err := layer.Compressed()
+	if err != nil {
+		if isOCINotFound(err) {
+			return nil, os.ErrNotExist
+		}
+		return nil, fmt.Errorf("error reading %s: %w", p, err)
+	}
+	return r, nil
+}
+
+// WriteFile implements Path::WriteFile
+func (p *OCIPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	p.vfsContext.ociMutex.Lock()
+	defer p.vfsContext.ociMutex.Unlock()
+
+	return p.writeFile(ctx, data, false)
+}
+
+// CreateFile implements Path::CreateFile
+func (p *OCIPath) CreateFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	p.vfsContext.ociMutex.Lock()
+	defer p.vfsContext.ociMutex.Unlock()
+
+	_, err := p.descriptor(ctx)
+	if err == nil {
+		return os.ErrExist
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	return p.writeFile(ctx, data, true)
+}
+
+// writeFile uploads the contents as a blob, and then adds it to the manifest of its directory.
+// If create is true and the file has been added to the directory in the meantime, err = os.ErrExist
+// The caller must hold ociMutex, which serializes the updates of a manifest within this process;
+// updateDirectory guards against other writers.
+func (p *OCIPath) writeFile(ctx context.Context, data io.ReadSeeker, create bool) error {
+	klog.V(4).Infof("Writing file %s", p)
+
+	if p.key == "" {
+		return fmt.Errorf("cannot write to the root of %s", p)
+	}
+
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return fmt.Errorf("error reading data for %s: %w", p, err)
+	}
+
+	repo, err := p.repo()
+	if err != nil {
+		return err
+	}
+	layer := static.NewLayer(b, ociFileMediaType)
+	if err := remote.WriteLayer(repo, layer, p.remoteOptions(ctx)...); err != nil {
+		return fmt.Errorf("error writing %s: %w", p, err)
+	}
+	digest, err := layer.Digest()
+	if err != nil {
+		return err
+	}
+
+	dir, fileName := path.Split(p.key)
+	dir = strings.TrimSuffix(dir, "/")
+
+	err = p.updateDirectory(ctx, dir, func(manifest *v1.Manifest) (bool, error) {
+		var layers []v1.Descriptor
+		for _, l := range manifest.Layers {
+			if l.Annotations[ociTitleAnnotation] != fileName {
+				layers = append(layers, l)
+			} else if create {
+				return false, os.ErrExist
+			}
+		}
+		layers = append(layers, v1.Descriptor{
+			MediaType:   ociFileMediaType,
+			Size:        int64(len(b)),
+			Digest:      digest,
+			Annotations: map[string]string{ociTitleAnnotation: fileName},
+		})
+		sort.Slice(layers, func(i, j int) bool {
+			return layers[i].Annotations[ociTitleAnnotation] < layers[j].Annotations[ociTitleAnnotation]
+		})
+		manifest.Layers = layers
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.addToParents(ctx, dir)
+}
+
+// addToParents records dir as a subdirectory of its parent, and so on up to the root of the repository,
+// stopping at the first directory that is already recorded.
+func (p *OCIPath) addToParents(ctx context.Context, dir string) error {
+	for dir != "" {
+		parent, name := path.Split(dir)
+		parent = strings.TrimSuffix(parent, "/")
+
+		added := false
+		err := p.updateDirectory(ctx, parent, func(manifest *v1.Manifest) (bool, error) {
+			subdirectories, err := ociSubdirectories(manifest)
+			if err != nil {
+				return false, err
+			}
+			for _, subdirectory := range subdirectories {
+				if subdirectory == name {
+					return false, nil
+				}
+			}
+			subdirectories = append(subdirectories, name)
+			sort.Strings(subdirectories)
+
+			encoded, err := json.Marshal(subdirectories)
+			if err != nil {
+				return false, err
+			}
+			manifest.Annotations[ociDirectoriesAnnotation] = string(encoded)
+			added = true
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+		if !added {
+			return nil
+		}
+
+		dir = parent
+	}
+	return nil
+}
+
+// Remove implements Path::Remove
+// Only the reference from the directory manifest is removed; the blob is left for the registry garbage collector.
+func (p *OCIPath) Remove(ctx context.Context) error {
+	klog.V(8).Infof("Removing file: %s", p)
+
+	p.vfsContext.ociMutex.Lock()
+	defer p.vfsContext.ociMutex.Unlock()
+
+	dir, fileName := path.Split(p.key)
+	dir = strings.TrimSuffix(dir, "/")
+
+	return p.updateDirectory(ctx, dir, func(manifest *v1.Manifest) (bool, error) {
+		var layers []v1.Descriptor
+		for _, l := range manifest.Layers {
+			if l.Annotations[ociTitleAnnotation] != fileName {
+				layers = append(layers, l)
+			}
+		}
+		if len(layers) == len(manifest.Layers) {
+			return false, os.ErrNotExist
+		}
+		manifest.Layers = layers
+		return true, nil
+	})
+}
+
+// RemoveAll implements Path::RemoveAll
+func (p *OCIPath) RemoveAll(ctx context.Context) error {
+	tree, err := p.ReadTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range tree {
+		if err := file.Remove(ctx); err != nil {
+			return fmt.Errorf("error removing file %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveAllVersions implements Path::RemoveAllVersions
+// Registries do not keep earlier versions of a tag, so this is the same as RemoveAll.
+func (p *OCIPath) RemoveAllVersions(ctx context.Context) error {
+	return p.RemoveAll(ctx)
+}
+
+// ReadDir implements Path::ReadDir
+func (p *OCIPath) ReadDir() ([]Path, error) {
+	ctx := context.TODO()
+
+	manifest, err := p.readDirectory(ctx, p.key)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []Path
+	for _, l := range manifest.Layers {
+		paths = append(paths, p.withKey(path.Join(p.key, l.Annotations[ociTitleAnnotation])))
+	}
+	subdirectories, err := ociSubdirectories(manifest)
+	if err != nil {
+		return nil, err
+	}
+	for _, subdirectory := range subdirectories {
+		paths = append(paths, p.withKey(path.Join(p.key, subdirectory)))
+	}
+	return paths, nil
+}
+
+// ReadTree implements Path::ReadTree
+func (p *OCIPath) ReadTree(ctx context.Context) ([]Path, error) {
+	var paths []Path
+
+	queue := []string{p.key}
+	for len(queue) != 0 {
+		dir := queue[0]
+		queue = queue[1:]
+
+		manifest, err := p.readDirectory(ctx, dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, l := range manifest.Layers {
+			paths = append(paths, p.withKey(path.Join(dir, l.Annotations[ociTitleAnnotation])))
+		}
+		subdirectories, err := ociSubdirectories(manifest)
+		if err != nil {
+			return nil, err
+		}
+		for _, subdirectory := range subdirectories {
+			queue = append(queue, path.Join(dir, subdirectory))
+		}
+	}
+
+	return paths, nil
+}
+
+// PreferredHash implements HasHash
+func (p *OCIPath) PreferredHash() (*hashing.Hash, error) {
+	return p.Hash(hashing.HashAlgorithmSHA256)
+}
+
+// Hash implements HasHash
+// The hash is the digest of the blob, so it is available without downloading the file.
+func (p *OCIPath) Hash(a hashing.HashAlgorithm) (*hashing.Hash, error) {
+	if a != hashing.HashAlgorithmSHA256 {
+		return nil, nil
+	}
+
+	file, err := p.descriptor(context.TODO())
+	if err != nil {
+		return nil, err
+	}
+	if file.Digest.Algorithm != "sha256" {
+		return nil, nil
+	}
+	return hashing.FromString(file.Digest.Hex)
+}
+
+// descriptor returns the layer describing the file in the manifest of its directory.
+func (p *OCIPath) descriptor(ctx context.Context) (*v1.Descriptor, error) {
+	dir, fileName := path.Split(p.key)
+	dir = strings.TrimSuffix(dir, "/")
+
+	manifest, err := p.readDirectory(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	for i := range manifest.Layers {
+		if manifest.Layers[i].Annotations[ociTitleAnnotation] == fileName {
+			return &manifest.Layers[i], nil
+		}
+	}
+	return nil, os.ErrNotExist
+}
+
+// readDirectory returns the manifest describing dir.
+// If the directory does not exist, err = os.ErrNotExist
+func (p *OCIPath) readDirectory(ctx context.Context, dir string) (*v1.Manifest, error) {
+	manifest, _, err := p.readDirectoryVersion(ctx, dir)
+	return manifest, err
+}
+
+// readDirectoryVersion returns the manifest describing dir, along with its digest.
+// If the directory does not exist, err = os.ErrNotExist
+func (p *OCIPath) readDirectoryVersion(ctx context.Context, dir string) (*v1.Manifest, v1.Hash, error) {
+	repo, err := p.repo()
+	if err != nil {
+		return nil, v1.Hash{}, err
+	}
+	tag := repo.Tag(ociDirectoryTag(dir))
+
+	desc, err := remote.Get(tag, p.remoteOptions(ctx)...)
+	if err != nil {
+		if isOCINotFound(err) {
+			return nil, v1.Hash{}, os.ErrNotExist
+		}
+		return nil, v1.Hash{}, fmt.Errorf("error reading directory %s: %w", p.withKey(dir), err)
+	}
+
+	manifest, err := v1.ParseManifest(bytes.NewReader(desc.Manifest))
+	if err != nil {
+		return nil, v1.Hash{}, fmt.Errorf("error parsing manifest for directory %s: %w", p.withKey(dir), err)
+	}
+	if manifest.Config.MediaType != ociDirectoryMediaType {
+		return nil, v1.Hash{}, fmt.Errorf("%s is not a kOps state store: unexpected config media type %q", tag, manifest.Config.MediaType)
+	}
+	if manifest.Annotations == nil {
+		manifest.Annotations = make(map[string]string)
+	}
+	return manifest, desc.Digest, nil
+}
+
+// updateDirectory applies update to the manifest describing dir, creating the directory if it does not exist,
+// and pushes the result if update reports a change.
+// The push is conditional on the digest that was read, so that concurrent updates from other processes are not lost;
+// on a conflict the manifest is read again and update is retried.
+// Registries that ignore the conditional headers accept every push, so they do not offer this protection.
+func (p *OCIPath) updateDirectory(ctx context.Context, dir string, update func(manifest *v1.Manifest) (bool, error)) error {
+	var err error
+	for attempt := 0; attempt < ociUpdateAttempts; attempt++ {
+		if attempt != 0 {
+			klog.V(2).Infof("directory %s was changed concurrently, retrying: %v", p.withKey(dir), err)
+			time.Sleep(wait.Jitter(ociUpdateBackoff, 1.0))
+		}
+
+		manifest, digest, readErr := p.readDirectoryVersion(ctx, dir)
+		if readErr != nil {
+			if !os.IsNotExist(readErr) {
+				return readErr
+			}
+			manifest = newOCIDirectoryManifest(dir)
+		}
+
+		changed, updateErr := update(manifest)
+		if updateErr != nil {
+			return updateErr
+		}
+		if !changed {
+			return nil
+		}
+
+		err = p.writeDirectory(ctx, dir, manifest, digest)
+		if !errors.Is(err, ErrVersionConflict) {
+			return err
+		}
+	}
+	return fmt.Errorf("error writing directory %s: %w", p.withKey(dir), err)
+}
+
+// writeDirectory pushes the manifest describing dir.
+// The push only succeeds if the manifest currently has the digest previous, or if previous is empty, if it does not exist;
+// otherwise err = ErrVersionConflict
+func (p *OCIPath) writeDirectory(ctx context.Context, dir string, manifest *v1.Manifest, previous v1.Hash) error {
+	repo, err := p.repo()
+	if err != nil {
+		return err
+	}
+
+	// Registries reject manifests that reference blobs they do not have
+	config := static.NewLayer(ociEmptyConfig, ociDirectoryMediaType)
+	if err := remote.WriteLayer(repo, config, p.remoteOptions(ctx)...); err != nil {
+		return fmt.Errorf("error writing directory %s: %w", p.withKey(dir), err)
+	}
+
+	raw, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	tag := repo.Tag(ociDirectoryTag(dir))
+	conditional := &ociConditionalTransport{inner: remote.DefaultTransport}
+	if previous == (v1.Hash{}) {
+		conditional.header, conditional.value = "If-None-Match", "*"
+	} else {
+		conditional.header, conditional.value = "If-Match", `"`+previous.String()+`"`
+	}
+	options := append(p.remoteOptions(ctx), remote.WithTransport(conditional))
+	if err := remote.Put(tag, &ociRawManifest{raw: raw}, options...); err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusPreconditionFailed {
+			return ErrVersionConflict
+		}
+		return fmt.Errorf("error writing directory %s: %w", p.withKey(dir), err)
+	}
+	return nil
+}
+
+// ociConditionalTransport adds a precondition header to manifest pushes.
+type ociConditionalTransport struct {
+	inner  http.RoundTripper
+	header string
+	value  string
+}
+
+func (t *ociConditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPut && strings.Contains(req.URL.Path, "/manifests/") {
+		req = req.Clone(req.Context())
+		req.Header.Set(t.header, t.value)
+	}
+	return t.inner.RoundTrip(req)
+}
+
+func (p *OCIPath) repo() (name.Repository, error) {
+	repo, err := name.NewRepository(p.registry+"/"+p.repository, name.StrictValidation)
+	if err != nil {
+		return name.Repository{}, fmt.Errorf("invalid repository in %s: %w", p, err)
+	}
+	return repo, nil
+}
+
+func (p *OCIPath) remoteOptions(ctx context.Context) []remote.Option {
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+}
+
+func (p *OCIPath) withKey(key string) *OCIPath {
+	return &OCIPath{
+		vfsContext: p.vfsContext,
+		registry:   p.registry,
+		repository: p.repository,
+		key:        key,
+	}
+}
+
+// newOCIDirectoryManifest returns the manifest for an empty directory.
+func newOCIDirectoryManifest(dir string) *v1.Manifest {
+	return &v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		Config: v1.Descriptor{
+			MediaType: ociDirectoryMediaType,
+			Size:      int64(len(ociEmptyConfig)),
+			Digest:    ociEmptyConfigDigest(),
+		},
+		Layers:      []v1.Descriptor{},
+		Annotations: map[string]string{ociPathAnnotation: dir},
+	}
+}
+
+func ociEmptyConfigDigest() v1.Hash {
+	h := sha256.Sum256(ociEmptyConfig)
+	return v1.Hash{Algorithm: "sha256", Hex: hex.EncodeToString(h[:])}
+}
+
+// ociDirectoryTag returns the tag of the manifest describing dir.
+func ociDirectoryTag(dir string) string {
+	h := sha256.Sum256([]byte(dir))
+	return ociDirectoryTagPrefix + hex.EncodeToString(h[:])
+}
+
+// ociSubdirectories returns the names of the subdirectories recorded in a directory manifest.
+func ociSubdirectories(manifest *v1.Manifest) ([]string, error) {
+	s := manifest.Annotations[ociDirectoriesAnnotation]
+	if s == "" {
+		return nil, nil
+	}
+	var subdirectories []string
+	if err := json.Unmarshal([]byte(s), &subdirectories); err != nil {
+		return nil, fmt.Errorf("error parsing %s annotation: %w", ociDirectoriesAnnotation, err)
+	}
+	return subdirectories, nil
+}
+
+// ociRawManifest implements remote.Taggable for a manifest we have already serialized.
+type ociRawManifest struct {
+	raw []byte
+}
+
+func (m *ociRawManifest) RawManifest() ([]byte, error) {
+	return m.raw, nil
+}
+
+func (m *ociRawManifest) MediaType() (types.MediaType, error) {
+	return types.OCIManifestSchema1, nil
+}
+
+func isOCINotFound(err error) bool {
+	var terr *transport.Error
+	if !errors.As(err, &terr) {
+		return false
+	}
+	if terr.StatusCode == http.StatusNotFound {
+		return true
+	}
+	for _, diagnostic := range terr.Errors {
+		switch diagnostic.Code {
+		case transport.ManifestUnknownErrorCode, transport.NameUnknownErrorCode, transport.BlobUnknownErrorCode:
+			return true
+		}
+	}
+	return false
+}
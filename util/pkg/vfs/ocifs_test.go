@@ -0,0 +1,286 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/registry"
+)
+
+func newTestOCIPath(t *testing.T) *OCIPath {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+
+	return newTestOCIPathForServer(t, server, NewVFSContext())
+}
+
+func newTestOCIPathForServer(t *testing.T, server *httptest.Server, vfsContext *VFSContext) *OCIPath {
+	base := "oci://" + strings.TrimPrefix(server.URL, "http://") + "/kops/state"
+	p, err := vfsContext.BuildVfsPath(base)
+	if err != nil {
+		t.Fatalf("error building path %q: %v", base, err)
+	}
+	return p.(*OCIPath)
+}
+
+// conditionalRegistry wraps a registry to honor If-Match and If-None-Match on manifest pushes.
+type conditionalRegistry struct {
+	mutex    sync.Mutex
+	registry http.Handler
+}
+
+func (r *conditionalRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ifMatch := req.Header.Get("If-Match")
+	ifNoneMatch := req.Header.Get("If-None-Match")
+	if req.Method != http.MethodPut || !strings.Contains(req.URL.Path, "/manifests/") || (ifMatch == "" && ifNoneMatch == "") {
+		r.registry.ServeHTTP(w, req)
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	head := httptest.NewRecorder()
+	r.registry.ServeHTTP(head, httptest.NewRequest(http.MethodHead, req.URL.String(), nil))
+	current := ""
+	if head.Code == http.StatusOK {
+		current = `"` + head.Header().Get("Docker-Content-Digest") + `"`
+	}
+	if (ifMatch != "" && ifMatch != current) || (ifNoneMatch == "*" && current != "") {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+	r.registry.ServeHTTP(w, req)
+}
+
+func TestOCIPathParse(t *testing.T) {
+	grid := []struct {
+		path       string
+		registry   string
+		repository string
+		key        string
+	}{
+		{
+			path:       "oci://registry.example.com/kops",
+			registry:   "registry.example.com",
+			repository: "kops",
+		},
+		{
+			path:       "oci://registry.example.com:5000/project/kops/-/cluster.example.com/config",
+			registry:   "registry.example.com:5000",
+			repository: "project/kops",
+			key:        "cluster.example.com/config",
+		},
+	}
+	for _, g := range grid {
+		t.Run(g.path, func(t *testing.T) {
+			p, err := NewVFSContext().BuildVfsPath(g.path)
+			if err != nil {
+				t.Fatalf("error building path: %v", err)
+			}
+			ociPath := p.(*OCIPath)
+			if ociPath.Registry() != g.registry || ociPath.Repository() != g.repository || ociPath.Key() != g.key {
+				t.Errorf("unexpected parse of %q: registry=%q repository=%q key=%q", g.path, ociPath.Registry(), ociPath.Repository(), ociPath.Key())
+			}
+			if p.Path() != g.path {
+				t.Errorf("expected path %q to round-trip, got %q", g.path, p.Path())
+			}
+		})
+	}
+}
+
+func TestOCIPathReadWrite(t *testing.T) {
+	ctx := context.TODO()
+	base := newTestOCIPath(t)
+
+	config := base.Join("cluster.example.com", "config")
+	if _, err := config.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected os.ErrNotExist reading missing file, got %v", err)
+	}
+
+	if err := config.WriteFile(ctx, bytes.NewReader([]byte("v1")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	if err := config.CreateFile(ctx, bytes.NewReader([]byte("v2")), nil); !os.IsExist(err) {
+		t.Fatalf("expected os.ErrExist creating existing file, got %v", err)
+	}
+	if err := config.WriteFile(ctx, bytes.NewReader([]byte("v2")), nil); err != nil {
+		t.Fatalf("error overwriting file: %v", err)
+	}
+
+	b, err := config.ReadFile(ctx)
+	if err != nil {
+		t.Fatalf("error reading file: %v", err)
+	}
+	if string(b) != "v2" {
+		t.Errorf("unexpected contents %q", string(b))
+	}
+
+	hash, err := config.(HasHash).PreferredHash()
+	if err != nil {
+		t.Fatalf("error getting hash: %v", err)
+	}
+	expected := sha256.Sum256([]byte("v2"))
+	if hash.Hex() != hex.EncodeToString(expected[:]) {
+		t.Errorf("unexpected hash %s", hash)
+	}
+
+	if err := config.Remove(ctx); err != nil {
+		t.Fatalf("error removing file: %v", err)
+	}
+	if _, err := config.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected os.ErrNotExist reading removed file, got %v", err)
+	}
+	if err := config.Remove(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected os.ErrNotExist removing removed file, got %v", err)
+	}
+}
+
+func TestOCIPathReadDirAndTree(t *testing.T) {
+	ctx := context.TODO()
+	base := newTestOCIPath(t)
+
+	for _, key := range []string{
+		"cluster.example.com/config",
+		"cluster.example.com/instancegroup/nodes",
+		"cluster.example.com/pki/private/kubernetes-ca/keyset.yaml",
+		"other.example.com/config",
+	} {
+		if err := base.Join(key).WriteFile(ctx, bytes.NewReader([]byte(key)), nil); err != nil {
+			t.Fatalf("error writing %s: %v", key, err)
+		}
+	}
+
+	dir, err := base.Join("cluster.example.com").ReadDir()
+	if err != nil {
+		t.Fatalf("error reading dir: %v", err)
+	}
+	if got := ociTestKeys(dir); got != "cluster.example.com/config,cluster.example.com/instancegroup,cluster.example.com/pki" {
+		t.Errorf("unexpected ReadDir result %s", got)
+	}
+
+	root, err := base.ReadDir()
+	if err != nil {
+		t.Fatalf("error reading root: %v", err)
+	}
+	if got := ociTestKeys(root); got != "cluster.example.com,other.example.com" {
+		t.Errorf("unexpected ReadDir result for root %s", got)
+	}
+
+	tree, err := base.Join("cluster.example.com").ReadTree(ctx)
+	if err != nil {
+		t.Fatalf("error reading tree: %v", err)
+	}
+	if got := ociTestKeys(tree); got != "cluster.example.com/config,cluster.example.com/instancegroup/nodes,cluster.example.com/pki/private/kubernetes-ca/keyset.yaml" {
+		t.Errorf("unexpected ReadTree result %s", got)
+	}
+
+	if err := base.Join("cluster.example.com").RemoveAll(ctx); err != nil {
+		t.Fatalf("error removing tree: %v", err)
+	}
+	tree, err = base.ReadTree(ctx)
+	if err != nil {
+		t.Fatalf("error reading tree: %v", err)
+	}
+	if got := ociTestKeys(tree); got != "other.example.com/config" {
+		t.Errorf("unexpected ReadTree result after RemoveAll %s", got)
+	}
+}
+
+func TestOCIPathConcurrentWriters(t *testing.T) {
+	ctx := context.TODO()
+	server := httptest.NewServer(&conditionalRegistry{registry: registry.New()})
+	t.Cleanup(server.Close)
+
+	// Each writer has its own VFSContext, as separate processes would, so only the conditional pushes keep them apart
+	var expected []string
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for writer := 0; writer < 4; writer++ {
+		base := newTestOCIPathForServer(t, server, NewVFSContext())
+		var keys []string
+		for i := 0; i < 5; i++ {
+			keys = append(keys, fmt.Sprintf("cluster.example.com/writer-%d/file-%d", writer, i), fmt.Sprintf("cluster.example.com/file-%d-%d", writer, i))
+		}
+		expected = append(expected, keys...)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, key := range keys {
+				if err := base.Join(key).WriteFile(ctx, bytes.NewReader([]byte(key)), nil); err != nil {
+					errs <- fmt.Errorf("error writing %s: %w", key, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	tree, err := newTestOCIPathForServer(t, server, NewVFSContext()).ReadTree(ctx)
+	if err != nil {
+		t.Fatalf("error reading tree: %v", err)
+	}
+	sort.Strings(expected)
+	if got := ociTestKeys(tree); got != strings.Join(expected, ",") {
+		t.Errorf("unexpected ReadTree result after concurrent writes:\nActual: %s\nExpect: %s", got, strings.Join(expected, ","))
+	}
+}
+
+func TestOCIPathWriteTo(t *testing.T) {
+	ctx := context.TODO()
+	base := newTestOCIPath(t)
+
+	data := bytes.Repeat([]byte("0123456789"), 100000)
+	file := base.Join("files", "nodeup")
+	if err := file.WriteFile(ctx, bytes.NewReader(data), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	var out bytes.Buffer
+	n, err := file.WriteTo(&out)
+	if err != nil {
+		t.Fatalf("error streaming file: %v", err)
+	}
+	if n != int64(len(data)) || !bytes.Equal(out.Bytes(), data) {
+		t.Errorf("unexpected contents streamed: %d bytes", n)
+	}
+}
+
+func ociTestKeys(paths []Path) string {
+	var keys []string
+	for _, p := range paths {
+		keys = append(keys, p.(*OCIPath).Key())
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
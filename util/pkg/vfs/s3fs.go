@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path"
 	"strings"
@@ -32,6 +33,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/klog/v2"
@@ -54,9 +56,11 @@ type S3Path struct {
 }
 
 var (
-	_ Path          = &S3Path{}
-	_ TerraformPath = &S3Path{}
-	_ HasHash       = &S3Path{}
+	_ Path                = &S3Path{}
+	_ TerraformPath       = &S3Path{}
+	_ HasHash             = &S3Path{}
+	_ HasConditionalWrite = &S3Path{}
+	_ HasConditionalRead  = &S3Path{}
 )
 
 // S3Acl is an ACL implementation for objects on S3
@@ -304,9 +308,27 @@ func (p *S3Path) WriteFile(ctx context.Context, data io.ReadSeeker, aclObj ACL)
 	ctx, span := tracer.Start(ctx, "S3Path::WriteFile", trace.WithAttributes(attribute.String("path", p.String())))
 	defer span.End()
 
+	_, err := p.putObject(ctx, data, aclObj)
+	return err
+}
+
+// WriteFileIfVersion implements HasConditionalWrite::WriteFileIfVersion
+// The version of an S3 object is its ETag, which is checked by S3 using If-Match.
+func (p *S3Path) WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, aclObj ACL, version string) (string, error) {
+	ctx, span := tracer.Start(ctx, "S3Path::WriteFileIfVersion", trace.WithAttributes(attribute.String("path", p.String())))
+	defer span.End()
+
+	response, err := p.putObject(ctx, data, aclObj, s3.WithAPIOptions(smithyhttp.AddHeaderValue("If-Match", version)))
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(response.ETag), nil
+}
+
+func (p *S3Path) putObject(ctx context.Context, data io.ReadSeeker, aclObj ACL, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
 	client, err := p.client(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	klog.V(4).Infof("Writing file %q", p)
@@ -321,7 +343,7 @@ func (p *S3Path) WriteFile(ctx context.Context, data io.ReadSeeker, aclObj ACL)
 
 	acl, err := p.getRequestACL(aclObj)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if acl != nil {
 		request.ACL = *acl
@@ -331,15 +353,21 @@ func (p *S3Path) WriteFile(ctx context.Context, data io.ReadSeeker, aclObj ACL)
 
 	klog.V(8).Infof("Calling S3 PutObject Bucket=%q Key=%q SSE=%q ACL=%q", p.bucket, p.key, sseLog, request.ACL)
 
-	_, err = client.PutObject(ctx, request)
+	response, err := client.PutObject(ctx, request, optFns...)
 	if err != nil {
+		switch AWSErrorCode(err) {
+		case "PreconditionFailed", "ConditionalRequestConflict", "NoSuchKey":
+			if len(optFns) != 0 {
+				return nil, ErrVersionConflict
+			}
+		}
 		if len(request.ACL) > 0 {
-			return fmt.Errorf("error writing %s (with ACL=%q): %v", p, request.ACL, err)
+			return nil, fmt.Errorf("error writing %s (with ACL=%q): %v", p, request.ACL, err)
 		}
-		return fmt.Errorf("error writing %s: %v", p, err)
+		return nil, fmt.Errorf("error writing %s: %v", p, err)
 	}
 
-	return nil
+	return response, nil
 }
 
 // To prevent concurrent creates on the same file while maintaining atomicity of writes,
@@ -378,6 +406,33 @@ func (p *S3Path) ReadFile(ctx context.Context) ([]byte, error) {
 	return b.Bytes(), nil
 }
 
+// ReadFileVersion implements HasConditionalWrite::ReadFileVersion
+func (p *S3Path) ReadFileVersion(ctx context.Context) ([]byte, string, error) {
+	ctx, span := tracer.Start(ctx, "S3Path::ReadFileVersion", trace.WithAttributes(attribute.String("path", p.String())))
+	defer span.End()
+
+	var b bytes.Buffer
+	_, etag, err := p.getObject(ctx, &b, "")
+	if err != nil {
+		return nil, "", err
+	}
+	return b.Bytes(), etag, nil
+}
+
+// ReadFileIfModified implements HasConditionalRead::ReadFileIfModified
+// The object is only downloaded if its ETag no longer matches.
+func (p *S3Path) ReadFileIfModified(ctx context.Context, version string) ([]byte, string, error) {
+	ctx, span := tracer.Start(ctx, "S3Path::ReadFileIfModified", trace.WithAttributes(attribute.String("path", p.String())))
+	defer span.End()
+
+	var b bytes.Buffer
+	_, etag, err := p.getObject(ctx, &b, version)
+	if err != nil {
+		return nil, "", err
+	}
+	return b.Bytes(), etag, nil
+}
+
 // WriteTo implements io.WriterTo
 func (p *S3Path) WriteTo(out io.Writer) (int64, error) {
 	ctx := context.TODO()
@@ -386,9 +441,16 @@ func (p *S3Path) WriteTo(out io.Writer) (int64, error) {
 
 // WriteToWithContext implements io.WriterTo, but adds a context
 func (p *S3Path) WriteToWithContext(ctx context.Context, out io.Writer) (int64, error) {
+	n, _, err := p.getObject(ctx, out, "")
+	return n, err
+}
+
+// getObject copies the contents of the object to out, returning the ETag of the object.
+// If ifNoneMatch is set and the object still has that ETag, ErrNotModified is returned.
+func (p *S3Path) getObject(ctx context.Context, out io.Writer, ifNoneMatch string) (int64, string, error) {
 	client, err := p.client(ctx)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
 	klog.V(4).Infof("Reading file %q", p)
@@ -396,21 +458,27 @@ func (p *S3Path) WriteToWithContext(ctx context.Context, out io.Writer) (int64,
 	request := &s3.GetObjectInput{}
 	request.Bucket = aws.String(p.bucket)
 	request.Key = aws.String(p.key)
+	if ifNoneMatch != "" {
+		request.IfNoneMatch = aws.String(ifNoneMatch)
+	}
 
 	response, err := client.GetObject(ctx, request)
 	if err != nil {
 		if AWSErrorCode(err) == "NoSuchKey" {
-			return 0, os.ErrNotExist
+			return 0, "", os.ErrNotExist
 		}
-		return 0, fmt.Errorf("error fetching %s: %v", p, err)
+		if ifNoneMatch != "" && awsHTTPStatusCode(err) == http.StatusNotModified {
+			return 0, "", ErrNotModified
+		}
+		return 0, "", fmt.Errorf("error fetching %s: %v", p, err)
 	}
 	defer response.Body.Close()
 
 	n, err := io.Copy(out, response.Body)
 	if err != nil {
-		return n, fmt.Errorf("error reading %s: %v", p, err)
+		return n, "", fmt.Errorf("error reading %s: %v", p, err)
 	}
-	return n, nil
+	return n, aws.ToString(response.ETag), nil
 }
 
 func (p *S3Path) ReadDir() ([]Path, error) {
@@ -761,6 +829,14 @@ func (p *S3Path) RenderTerraform(w *terraformWriter.TerraformWriter, name string
 
 }
 
+// TerraformImport returns the import for the object rendered by RenderTerraform, or nil if it cannot be imported.
+func (p *S3Path) TerraformImport(name string) *terraformWriter.Import {
+	if p.scheme == "do" || p.scheme == "scw" {
+		return nil
+	}
+	return &terraformWriter.Import{ResourceType: "aws_s3_object", ResourceName: name, ID: p.Bucket() + "/" + p.Key()}
+}
+
 // AWSErrorCode returns the aws error code, if it is an smity.APIError, otherwise ""
 func AWSErrorCode(err error) string {
 	var apiErr smithy.APIError
@@ -769,3 +845,12 @@ func AWSErrorCode(err error) string {
 	}
 	return ""
 }
+
+// awsHTTPStatusCode returns the HTTP status code of the response that caused err, or 0 if it is unknown.
+func awsHTTPStatusCode(err error) int {
+	var responseError *smithyhttp.ResponseError
+	if errors.As(err, &responseError) {
+		return responseError.HTTPStatusCode()
+	}
+	return 0
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"k8s.io/klog/v2"
+)
+
+// EnvS3EventsQueueURL is the URL of an SQS queue that receives the EventBridge events for the state store bucket.
+const EnvS3EventsQueueURL = "KOPS_STATE_STORE_S3_EVENTS_QUEUE_URL"
+
+var _ HasChangeNotifications = &S3Path{}
+
+// s3Event is the subset of an EventBridge event for an S3 object that we use.
+type s3Event struct {
+	Source     string `json:"source"`
+	DetailType string `json:"detail-type"`
+	Detail     struct {
+		Bucket struct {
+			Name string `json:"name"`
+		} `json:"bucket"`
+		Object struct {
+			Key string `json:"key"`
+		} `json:"object"`
+	} `json:"detail"`
+}
+
+// NotifyChanges implements HasChangeNotifications.
+// When EnvS3EventsQueueURL is set, it receives the "Object Created" and "Object Deleted" events that EventBridge
+// sends to that queue for the bucket. Messages are deleted once received, so when several watchers share a queue
+// each of them only sees some of the events; Watch still finds the other changes on its next poll.
+func (p *S3Path) NotifyChanges(ctx context.Context) (<-chan struct{}, error) {
+	queueURL := os.Getenv(EnvS3EventsQueueURL)
+	if queueURL == "" {
+		return nil, nil
+	}
+
+	region, err := sqsQueueRegion(queueURL)
+	if err != nil {
+		return nil, err
+	}
+	config, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("error loading AWS config: %w", err)
+	}
+	client := sqs.NewFromConfig(config)
+
+	notifications := make(chan struct{}, 1)
+	go func() {
+		defer close(notifications)
+
+		for ctx.Err() == nil {
+			response, err := client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+				QueueUrl:            aws.String(queueURL),
+				MaxNumberOfMessages: 10,
+				WaitTimeSeconds:     20,
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				klog.Warningf("error receiving messages from %q: %v", queueURL, err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(10 * time.Second):
+				}
+				continue
+			}
+
+			changed := false
+			for _, message := range response.Messages {
+				if p.isChangeEvent(aws.ToString(message.Body)) {
+					changed = true
+				}
+				if _, err := client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+					QueueUrl:      aws.String(queueURL),
+					ReceiptHandle: message.ReceiptHandle,
+				}); err != nil {
+					klog.Warningf("error deleting message from %q: %v", queueURL, err)
+				}
+			}
+			if changed {
+				select {
+				case notifications <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	return notifications, nil
+}
+
+// isChangeEvent returns true if body is an EventBridge event for an object under the path.
+func (p *S3Path) isChangeEvent(body string) bool {
+	var event s3Event
+	if err := json.Unmarshal([]byte(body), &event); err != nil {
+		klog.V(2).Infof("ignoring message that is not an EventBridge event: %v", err)
+		return false
+	}
+	if event.Source != "aws.s3" || event.Detail.Bucket.Name != p.bucket {
+		return false
+	}
+	if event.DetailType != "Object Created" && event.DetailType != "Object Deleted" {
+		return false
+	}
+	prefix := strings.TrimSuffix(p.key, "/")
+	return prefix == "" || event.Detail.Object.Key == prefix || strings.HasPrefix(event.Detail.Object.Key, prefix+"/")
+}
+
+// sqsQueueRegion returns the region of a queue URL, such as https://sqs.us-east-1.amazonaws.com/123456789012/name
+func sqsQueueRegion(queueURL string) (string, error) {
+	u, err := url.Parse(queueURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s %q: %w", EnvS3EventsQueueURL, queueURL, err)
+	}
+	tokens := strings.Split(u.Hostname(), ".")
+	if len(tokens) < 3 || tokens[0] != "sqs" {
+		return "", fmt.Errorf("invalid %s %q: expected a host of the form sqs.<region>.amazonaws.com", EnvS3EventsQueueURL, queueURL)
+	}
+	return tokens[1], nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	vault "github.com/hashicorp/vault/api"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// EnvVaultAuthMethod is the Vault auth method used to log in when VAULT_TOKEN is not set: "aws" or "gcp".
+	EnvVaultAuthMethod = "KOPS_VAULT_AUTH_METHOD"
+	// EnvVaultAuthRole is the Vault role to log in as.
+	EnvVaultAuthRole = "KOPS_VAULT_AUTH_ROLE"
+	// EnvVaultAuthMount is the path at which the auth method is mounted; it defaults to the name of the method.
+	EnvVaultAuthMount = "KOPS_VAULT_AUTH_MOUNT"
+	// EnvVaultAWSServerID is the value of the X-Vault-AWS-IAM-Server-ID header, if the aws auth method requires one.
+	EnvVaultAWSServerID = "KOPS_VAULT_AWS_SERVER_ID"
+)
+
+// VaultEnvVars are the environment variables that configure access to Vault without a token,
+// which are passed on to nodes and system components.
+var VaultEnvVars = []string{
+	"VAULT_ADDR",
+	"VAULT_CACERT",
+	"VAULT_NAMESPACE",
+	EnvVaultAuthMethod,
+	EnvVaultAuthRole,
+	EnvVaultAuthMount,
+	EnvVaultAWSServerID,
+}
+
+// vaultTokenRenewBefore is how long before the login token expires that we log in again.
+const vaultTokenRenewBefore = time.Minute
+
+// getVaultClient returns the client for Vault, caching it for future reuse.
+// The client logs in again when its token is about to expire.
+func (c *VFSContext) getVaultClient(ctx context.Context) (*vault.Client, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.vaultClient != nil && (c.vaultTokenExpiry.IsZero() || time.Until(c.vaultTokenExpiry) > vaultTokenRenewBefore) {
+		return c.vaultClient, nil
+	}
+
+	// DefaultConfig and NewClient read VAULT_ADDR, VAULT_CACERT, VAULT_TOKEN, VAULT_NAMESPACE and so on
+	config := vault.DefaultConfig()
+	if config.Error != nil {
+		return nil, fmt.Errorf("error configuring Vault client: %w", config.Error)
+	}
+	client, err := vault.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("error building Vault client: %w", err)
+	}
+
+	var expiry time.Time
+	if client.Token() == "" {
+		expiry, err = c.vaultLogin(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.vaultClient = client
+	c.vaultTokenExpiry = expiry
+	return client, nil
+}
+
+// vaultLogin logs in to Vault with the configured auth method, setting the client's token and returning when it expires.
+func (c *VFSContext) vaultLogin(ctx context.Context, client *vault.Client) (time.Time, error) {
+	method := os.Getenv(EnvVaultAuthMethod)
+	role := os.Getenv(EnvVaultAuthRole)
+	mount := os.Getenv(EnvVaultAuthMount)
+	if mount == "" {
+		mount = method
+	}
+
+	var data map[string]interface{}
+	var err error
+	switch method {
+	case "":
+		return time.Time{}, fmt.Errorf("VAULT_TOKEN or %s must be set to use a vault:// path", EnvVaultAuthMethod)
+	case "aws":
+		data, err = vaultAWSLoginData(ctx, role)
+	case "gcp":
+		data, err = c.vaultGCPLoginData(role)
+	default:
+		return time.Time{}, fmt.Errorf("unsupported Vault auth method %q in %s", method, EnvVaultAuthMethod)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	klog.V(2).Infof("logging in to Vault with auth method %q as role %q", mount, role)
+	secret, err := client.Logical().WriteWithContext(ctx, "auth/"+mount+"/login", data)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error logging in to Vault with %s auth: %w", method, err)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return time.Time{}, fmt.Errorf("logging in to Vault with %s auth did not return a token", method)
+	}
+	client.SetToken(secret.Auth.ClientToken)
+
+	var expiry time.Time
+	if secret.Auth.LeaseDuration > 0 {
+		expiry = time.Now().Add(time.Duration(secret.Auth.LeaseDuration) * time.Second)
+	}
+	return expiry, nil
+}
+
+// vaultAWSLoginData builds the login request for Vault's aws auth method with the iam type,
+// which proves the caller's identity with a signed sts:GetCallerIdentity request.
+func vaultAWSLoginData(ctx context.Context, role string) (map[string]interface{}, error) {
+	config, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	credentials, err := config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting AWS credentials: %w", err)
+	}
+
+	body := []byte("Action=GetCallerIdentity&Version=2011-06-15")
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://sts.amazonaws.com/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	if serverID := os.Getenv(EnvVaultAWSServerID); serverID != "" {
+		request.Header.Set("X-Vault-AWS-IAM-Server-ID", serverID)
+	}
+
+	payloadHash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, request, hex.EncodeToString(payloadHash[:]), "sts", "us-east-1", time.Now()); err != nil {
+		return nil, fmt.Errorf("error signing sts:GetCallerIdentity request: %w", err)
+	}
+	headers, err := json.Marshal(request.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"role":                    role,
+		"iam_http_request_method": request.Method,
+		"iam_request_url":         base64.StdEncoding.EncodeToString([]byte(request.URL.String())),
+		"iam_request_body":        base64.StdEncoding.EncodeToString(body),
+		"iam_request_headers":     base64.StdEncoding.EncodeToString(headers),
+	}, nil
+}
+
+// vaultGCPLoginData builds the login request for Vault's gcp auth method with the gce type,
+// which proves the caller's identity with the instance identity token from the metadata server.
+func (c *VFSContext) vaultGCPLoginData(role string) (map[string]interface{}, error) {
+	identityURL := "http://169.254.169.254/computeMetadata/v1/instance/service-accounts/default/identity?format=full&audience=" +
+		url.QueryEscape("http://vault/"+role)
+	httpHeaders := map[string]string{"Metadata-Flavor": "Google"}
+
+	// Exponential backoff, starting with 500 milliseconds, doubling each time, 5 steps
+	opts := vfsOptions{
+		backoff: wait.Backoff{
+			Duration: 500 * time.Millisecond,
+			Factor:   2,
+			Steps:    5,
+		},
+	}
+	jwt, err := c.readHTTPLocation(identityURL, httpHeaders, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching instance identity token: %w", err)
+	}
+
+	return map[string]interface{}{
+		"role": role,
+		"jwt":  string(jwt),
+	}, nil
+}
@@ -0,0 +1,463 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+	"k8s.io/klog/v2"
+)
+
+// vaultContentKey is the key of the KV v2 secret data that holds the file contents, base64 encoded.
+const vaultContentKey = "content"
+
+// VaultPath is a path in the VFS space backed by the HashiCorp Vault KV v2 secrets engine.
+// Each file is stored as a secret holding its contents, and every write creates a new version.
+type VaultPath struct {
+	vfsContext *VFSContext
+	mount      string
+	key        string
+}
+
+var (
+	_ Path                = &VaultPath{}
+	_ HasClusterReadable  = &VaultPath{}
+	_ HasConditionalWrite = &VaultPath{}
+	_ HasConditionalRead  = &VaultPath{}
+)
+
+// NewVaultPath returns a new VaultPath for the key in the KV v2 secrets engine mounted at mount.
+func NewVaultPath(vfsContext *VFSContext, mount string, key string) *VaultPath {
+	return &VaultPath{
+		vfsContext: vfsContext,
+		mount:      strings.Trim(mount, "/"),
+		key:        strings.Trim(key, "/"),
+	}
+}
+
+// Mount returns the path at which the KV v2 secrets engine is mounted.
+func (p *VaultPath) Mount() string {
+	return p.mount
+}
+
+// Key returns the path of the secret within the secrets engine.
+func (p *VaultPath) Key() string {
+	return p.key
+}
+
+// Path returns a string representing the full path.
+func (p *VaultPath) Path() string {
+	return "vault://" + p.mount + "/" + p.key
+}
+
+func (p *VaultPath) String() string {
+	return p.Path()
+}
+
+// Base returns the base name (last element).
+func (p *VaultPath) Base() string {
+	return path.Base(p.key)
+}
+
+// Join returns a new path that joins the current path and given relative paths.
+func (p *VaultPath) Join(relativePath ...string) Path {
+	args := []string{p.key}
+	args = append(args, relativePath...)
+	joined := path.Join(args...)
+	return &VaultPath{
+		vfsContext: p.vfsContext,
+		mount:      p.mount,
+		key:        joined,
+	}
+}
+
+// IsClusterReadable implements HasClusterReadable.
+// Nodes can only read from Vault if they can log in with one of Vault's cloud auth methods.
+func (p *VaultPath) IsClusterReadable() bool {
+	return os.Getenv(EnvVaultAuthMethod) != ""
+}
+
+func (p *VaultPath) kv(ctx context.Context) (*vault.KVv2, error) {
+	client, err := p.vfsContext.getVaultClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.KVv2(p.mount), nil
+}
+
+// ReadFile implements Path::ReadFile
+func (p *VaultPath) ReadFile(ctx context.Context) ([]byte, error) {
+	b, _, err := p.ReadFileVersion(ctx)
+	return b, err
+}
+
+// ReadFileVersion returns the contents of the latest version of the secret, along with its version number.
+func (p *VaultPath) ReadFileVersion(ctx context.Context) ([]byte, string, error) {
+	klog.V(8).Infof("Reading file: %s", p)
+
+	kv, err := p.kv(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := kv.Get(ctx, p.key)
+	if err != nil {
+		if errors.Is(err, vault.ErrSecretNotFound) {
+			return nil, "", os.ErrNotExist
+		}
+		return nil, "", fmt.Errorf("error reading %s: %w", p, err)
+	}
+	// The data of a deleted version is nil, but its metadata is still returned
+	if secret.Data == nil {
+		return nil, "", os.ErrNotExist
+	}
+
+	encoded, ok := secret.Data[vaultContentKey].(string)
+	if !ok {
+		return nil, "", fmt.Errorf("secret %s does not have a %q field", p, vaultContentKey)
+	}
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, "", fmt.Errorf("error decoding %s: %w", p, err)
+	}
+
+	var version string
+	if secret.VersionMetadata != nil {
+		version = strconv.Itoa(secret.VersionMetadata.Version)
+	}
+	return data, version, nil
+}
+
+// ReadFileIfModified implements HasConditionalRead::ReadFileIfModified
+// The secret's metadata is read first, so the data is only read if there is a newer version.
+func (p *VaultPath) ReadFileIfModified(ctx context.Context, version string) ([]byte, string, error) {
+	kv, err := p.kv(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	metadata, err := kv.GetMetadata(ctx, p.key)
+	if err != nil {
+		if errors.Is(err, vault.ErrSecretNotFound) {
+			return nil, "", os.ErrNotExist
+		}
+		return nil, "", fmt.Errorf("error reading metadata of %s: %w", p, err)
+	}
+	if isVaultVersionDeleted(metadata) {
+		return nil, "", os.ErrNotExist
+	}
+	if strconv.Itoa(metadata.CurrentVersion) == version {
+		return nil, "", ErrNotModified
+	}
+	return p.ReadFileVersion(ctx)
+}
+
+// WriteTo implements io.WriterTo
+func (p *VaultPath) WriteTo(out io.Writer) (int64, error) {
+	data, err := p.ReadFile(context.TODO())
+	if err != nil {
+		return 0, err
+	}
+	n, err := out.Write(data)
+	return int64(n), err
+}
+
+// WriteFile implements Path::WriteFile
+// The ACL is ignored; access to secrets is controlled by Vault policies.
+func (p *VaultPath) WriteFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	klog.V(8).Infof("Writing file: %s", p)
+
+	_, err := p.put(ctx, data)
+	return err
+}
+
+// CreateFile implements Path::CreateFile
+func (p *VaultPath) CreateFile(ctx context.Context, data io.ReadSeeker, acl ACL) error {
+	klog.V(8).Infof("Creating file: %s", p)
+
+	kv, err := p.kv(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A check-and-set of 0 only writes a secret that has never existed,
+	// so a secret whose latest version was removed is replaced at its current version.
+	cas := 0
+	metadata, err := kv.GetMetadata(ctx, p.key)
+	if err != nil && !errors.Is(err, vault.ErrSecretNotFound) {
+		return fmt.Errorf("error reading metadata of %s: %w", p, err)
+	}
+	if metadata != nil {
+		if !isVaultVersionDeleted(metadata) {
+			return os.ErrExist
+		}
+		cas = metadata.CurrentVersion
+	}
+
+	_, err = p.put(ctx, data, vault.WithCheckAndSet(cas))
+	if errors.Is(err, ErrVersionConflict) {
+		return os.ErrExist
+	}
+	return err
+}
+
+// WriteFileIfVersion writes the secret only if its latest version is the given version.
+func (p *VaultPath) WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error) {
+	klog.V(8).Infof("Writing file: %s (if version %s)", p, version)
+
+	cas, err := strconv.Atoi(version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version %q for %s", version, p)
+	}
+	return p.put(ctx, data, vault.WithCheckAndSet(cas))
+}
+
+// put writes a new version of the secret, returning the new version number.
+func (p *VaultPath) put(ctx context.Context, data io.ReadSeeker, opts ...vault.KVOption) (string, error) {
+	kv, err := p.kv(ctx)
+	if err != nil {
+		return "", err
+	}
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return "", fmt.Errorf("error reading data for %s: %w", p, err)
+	}
+
+	secret, err := kv.Put(ctx, p.key, map[string]interface{}{
+		vaultContentKey: base64.StdEncoding.EncodeToString(b),
+	}, opts...)
+	if err != nil {
+		if isVaultCheckAndSetError(err) {
+			return "", ErrVersionConflict
+		}
+		return "", fmt.Errorf("error writing %s: %w", p, err)
+	}
+
+	var version string
+	if secret.VersionMetadata != nil {
+		version = strconv.Itoa(secret.VersionMetadata.Version)
+	}
+	return version, nil
+}
+
+// Remove implements Path::Remove
+// Only the latest version is deleted; earlier versions are kept by Vault and can be restored.
+func (p *VaultPath) Remove(ctx context.Context) error {
+	klog.V(8).Infof("Removing file: %s", p)
+
+	kv, err := p.kv(ctx)
+	if err != nil {
+		return err
+	}
+	if err := kv.Delete(ctx, p.key); err != nil {
+		return fmt.Errorf("error removing %s: %w", p, err)
+	}
+	return nil
+}
+
+// RemoveAll implements Path::RemoveAll
+func (p *VaultPath) RemoveAll(ctx context.Context) error {
+	tree, err := p.ReadTree(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range tree {
+		if err := file.Remove(ctx); err != nil {
+			return fmt.Errorf("error removing file %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// RemoveAllVersions implements Path::RemoveAllVersions
+// The metadata and every version of the secret, and of any secrets under it, are permanently deleted.
+func (p *VaultPath) RemoveAllVersions(ctx context.Context) error {
+	klog.V(8).Infof("Removing all versions of: %s", p)
+
+	kv, err := p.kv(ctx)
+	if err != nil {
+		return err
+	}
+
+	keys, err := p.listKeys(ctx, p.key)
+	if err != nil {
+		return err
+	}
+	keys = append(keys, p.key)
+	for _, key := range keys {
+		if err := kv.DeleteMetadata(ctx, key); err != nil {
+			return fmt.Errorf("error removing all versions of %s: %w", p.withKey(key), err)
+		}
+	}
+	return nil
+}
+
+// ReadDir implements Path::ReadDir
+func (p *VaultPath) ReadDir() ([]Path, error) {
+	ctx := context.TODO()
+
+	client, err := p.vfsContext.getVaultClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	children, err := p.list(ctx, client, p.key)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []Path
+	for _, child := range children {
+		if strings.HasSuffix(child, "/") {
+			continue
+		}
+		key := path.Join(p.key, child)
+		exists, err := p.exists(ctx, client, key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			paths = append(paths, p.withKey(key))
+		}
+	}
+	return paths, nil
+}
+
+// ReadTree implements Path::ReadTree
+func (p *VaultPath) ReadTree(ctx context.Context) ([]Path, error) {
+	client, err := p.vfsContext.getVaultClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := p.listKeys(ctx, p.key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Secrets whose latest version was removed are still listed by Vault
+	var paths []Path
+	for _, key := range keys {
+		exists, err := p.exists(ctx, client, key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			paths = append(paths, p.withKey(key))
+		}
+	}
+	return paths, nil
+}
+
+// listKeys lists the keys of all secrets under prefix, recursively, including those whose latest version was removed.
+func (p *VaultPath) listKeys(ctx context.Context, prefix string) ([]string, error) {
+	client, err := p.vfsContext.getVaultClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	children, err := p.list(ctx, client, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for _, child := range children {
+		key := path.Join(prefix, child)
+		if strings.HasSuffix(child, "/") {
+			subkeys, err := p.listKeys(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, subkeys...)
+		} else {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// list returns the names of the secrets and folders directly under prefix; folder names end with a slash.
+func (p *VaultPath) list(ctx context.Context, client *vault.Client, prefix string) ([]string, error) {
+	listPath := p.mount + "/metadata/" + prefix
+	secret, err := client.Logical().ListWithContext(ctx, listPath)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s: %w", p.withKey(prefix), err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, nil
+	}
+
+	values, ok := secret.Data["keys"].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+	var names []string
+	for _, value := range values {
+		name, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected key %v listing %s", value, p.withKey(prefix))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// exists returns true if the latest version of the secret has not been removed.
+func (p *VaultPath) exists(ctx context.Context, client *vault.Client, key string) (bool, error) {
+	metadata, err := client.KVv2(p.mount).GetMetadata(ctx, key)
+	if err != nil {
+		if errors.Is(err, vault.ErrSecretNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("error reading metadata of %s: %w", p.withKey(key), err)
+	}
+	return !isVaultVersionDeleted(metadata), nil
+}
+
+func (p *VaultPath) withKey(key string) *VaultPath {
+	return &VaultPath{
+		vfsContext: p.vfsContext,
+		mount:      p.mount,
+		key:        key,
+	}
+}
+
+// isVaultVersionDeleted returns true if the latest version of a secret has been deleted or destroyed.
+func isVaultVersionDeleted(metadata *vault.KVMetadata) bool {
+	current, ok := metadata.Versions[strconv.Itoa(metadata.CurrentVersion)]
+	if !ok {
+		return true
+	}
+	return !current.DeletionTime.IsZero() || current.Destroyed
+}
+
+// isVaultCheckAndSetError returns true if a write was rejected because the secret is not at the expected version.
+func isVaultCheckAndSetError(err error) bool {
+	var responseError *vault.ResponseError
+	if !errors.As(err, &responseError) || responseError.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	return strings.Contains(strings.Join(responseError.Errors, " "), "check-and-set")
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeVault implements the subset of the Vault KV v2 API used by VaultPath, for a secrets engine mounted at "secret".
+type fakeVault struct {
+	mutex   sync.Mutex
+	secrets map[string]*fakeVaultSecret
+}
+
+type fakeVaultSecret struct {
+	versions []*fakeVaultVersion
+}
+
+type fakeVaultVersion struct {
+	data    map[string]interface{}
+	created time.Time
+	deleted time.Time
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if r.Header.Get("X-Vault-Token") != "test-token" {
+		writeVaultResponse(w, http.StatusForbidden, map[string]interface{}{"errors": []string{"permission denied"}})
+		return
+	}
+
+	kind, key, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, "/v1/secret/"), "/")
+	if !ok {
+		writeVaultResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+		return
+	}
+	secret := f.secrets[key]
+
+	method := r.Method
+	if method == http.MethodGet && r.URL.Query().Get("list") == "true" {
+		method = "LIST"
+	}
+
+	switch kind + " " + method {
+	case "data GET":
+		if secret == nil {
+			writeVaultResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+			return
+		}
+		version := secret.versions[len(secret.versions)-1]
+		status := http.StatusOK
+		data := version.data
+		if !version.deleted.IsZero() {
+			status = http.StatusNotFound
+			data = nil
+		}
+		writeVaultResponse(w, status, map[string]interface{}{"data": map[string]interface{}{
+			"data":     data,
+			"metadata": version.metadata(len(secret.versions)),
+		}})
+
+	case "data PUT", "data POST":
+		var request struct {
+			Data    map[string]interface{} `json:"data"`
+			Options map[string]interface{} `json:"options"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeVaultResponse(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{err.Error()}})
+			return
+		}
+		if cas, ok := request.Options["cas"].(float64); ok {
+			current := 0
+			if secret != nil {
+				current = len(secret.versions)
+			}
+			if int(cas) != current {
+				writeVaultResponse(w, http.StatusBadRequest, map[string]interface{}{"errors": []string{"check-and-set parameter did not match the current version"}})
+				return
+			}
+		}
+		if secret == nil {
+			secret = &fakeVaultSecret{}
+			f.secrets[key] = secret
+		}
+		version := &fakeVaultVersion{data: request.Data, created: time.Now()}
+		secret.versions = append(secret.versions, version)
+		writeVaultResponse(w, http.StatusOK, map[string]interface{}{"data": version.metadata(len(secret.versions))})
+
+	case "data DELETE":
+		if secret != nil {
+			secret.versions[len(secret.versions)-1].deleted = time.Now()
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case "metadata GET":
+		if secret == nil {
+			writeVaultResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+			return
+		}
+		versions := map[string]interface{}{}
+		for i, version := range secret.versions {
+			versions[strconv.Itoa(i+1)] = version.metadata(i + 1)
+		}
+		writeVaultResponse(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{
+			"current_version": len(secret.versions),
+			"versions":        versions,
+		}})
+
+	case "metadata DELETE":
+		delete(f.secrets, key)
+		w.WriteHeader(http.StatusNoContent)
+
+	case "metadata LIST":
+		prefix := strings.TrimSuffix(key, "/")
+		if prefix != "" {
+			prefix += "/"
+		}
+		names := map[string]bool{}
+		for k := range f.secrets {
+			if rest, ok := strings.CutPrefix(k, prefix); ok {
+				if child, _, isFolder := strings.Cut(rest, "/"); isFolder {
+					names[child+"/"] = true
+				} else {
+					names[child] = true
+				}
+			}
+		}
+		if len(names) == 0 {
+			writeVaultResponse(w, http.StatusNotFound, map[string]interface{}{"errors": []string{}})
+			return
+		}
+		var keys []string
+		for name := range names {
+			keys = append(keys, name)
+		}
+		sort.Strings(keys)
+		writeVaultResponse(w, http.StatusOK, map[string]interface{}{"data": map[string]interface{}{"keys": keys}})
+
+	default:
+		writeVaultResponse(w, http.StatusMethodNotAllowed, map[string]interface{}{"errors": []string{"unsupported operation"}})
+	}
+}
+
+func (v *fakeVaultVersion) metadata(version int) map[string]interface{} {
+	deletionTime := ""
+	if !v.deleted.IsZero() {
+		deletionTime = v.deleted.Format(time.RFC3339Nano)
+	}
+	return map[string]interface{}{
+		"version":       version,
+		"created_time":  v.created.Format(time.RFC3339Nano),
+		"deletion_time": deletionTime,
+		"destroyed":     false,
+	}
+}
+
+func writeVaultResponse(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func TestVaultPath(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(&fakeVault{secrets: map[string]*fakeVaultSecret{}})
+	defer server.Close()
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "test-token")
+
+	vfsContext := NewVFSContext()
+	base, err := vfsContext.BuildVfsPath("vault://secret/clusters/example.com/pki")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	if base.Path() != "vault://secret/clusters/example.com/pki" {
+		t.Errorf("unexpected path %q", base.Path())
+	}
+
+	// Nodes can only read the path if they can log in to Vault
+	if IsClusterReadable(base) {
+		t.Errorf("expected path not to be cluster readable without an auth method")
+	}
+	t.Setenv(EnvVaultAuthMethod, "aws")
+	if !IsClusterReadable(base) {
+		t.Errorf("expected path to be cluster readable with an auth method")
+	}
+
+	keyset := base.Join("private", "kubernetes-ca", "keyset.yaml")
+	if _, err := keyset.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected not found reading missing file, got %v", err)
+	}
+
+	// CreateFile only creates files that do not exist
+	if err := keyset.CreateFile(ctx, bytes.NewReader([]byte("first")), nil); err != nil {
+		t.Fatalf("error creating file: %v", err)
+	}
+	if err := keyset.CreateFile(ctx, bytes.NewReader([]byte("second")), nil); !os.IsExist(err) {
+		t.Fatalf("expected file exists error, got %v", err)
+	}
+
+	// Writes are conditional on the version that was read
+	conditional := keyset.(HasConditionalWrite)
+	data, version, err := conditional.ReadFileVersion(ctx)
+	if err != nil {
+		t.Fatalf("error reading file: %v", err)
+	}
+	if string(data) != "first" || version != "1" {
+		t.Fatalf("unexpected contents %q at version %q", data, version)
+	}
+	if err := keyset.WriteFile(ctx, bytes.NewReader([]byte("\x00binary\xff")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	if _, err := conditional.WriteFileIfVersion(ctx, bytes.NewReader([]byte("stale")), nil, version); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("expected version conflict, got %v", err)
+	}
+	data, err = keyset.ReadFile(ctx)
+	if err != nil {
+		t.Fatalf("error reading file: %v", err)
+	}
+	if string(data) != "\x00binary\xff" {
+		t.Fatalf("unexpected contents %q", data)
+	}
+
+	// Listing
+	secret := base.Join("..", "secrets", "admin")
+	if err := secret.WriteFile(ctx, bytes.NewReader([]byte("secret")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	sshKey := base.Join("ssh", "public", "admin", "0123")
+	if err := sshKey.WriteFile(ctx, bytes.NewReader([]byte("ssh-rsa")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	tree, err := base.ReadTree(ctx)
+	if err != nil {
+		t.Fatalf("error reading tree: %v", err)
+	}
+	if got := pathsToStrings(tree); strings.Join(got, ",") != keyset.Path()+","+sshKey.Path() {
+		t.Errorf("unexpected tree %v", got)
+	}
+	dir, err := base.Join("private", "kubernetes-ca").ReadDir()
+	if err != nil {
+		t.Fatalf("error reading dir: %v", err)
+	}
+	if got := pathsToStrings(dir); strings.Join(got, ",") != keyset.Path() {
+		t.Errorf("unexpected dir %v", got)
+	}
+
+	// Removed files are not listed, and can be created again
+	if err := sshKey.Remove(ctx); err != nil {
+		t.Fatalf("error removing file: %v", err)
+	}
+	if _, err := sshKey.ReadFile(ctx); !os.IsNotExist(err) {
+		t.Fatalf("expected not found reading removed file, got %v", err)
+	}
+	tree, err = base.ReadTree(ctx)
+	if err != nil {
+		t.Fatalf("error reading tree: %v", err)
+	}
+	if got := pathsToStrings(tree); strings.Join(got, ",") != keyset.Path() {
+		t.Errorf("unexpected tree after remove %v", got)
+	}
+	if err := sshKey.CreateFile(ctx, bytes.NewReader([]byte("ssh-ed25519")), nil); err != nil {
+		t.Fatalf("error creating removed file: %v", err)
+	}
+
+	// RemoveAllVersions deletes every secret under the path
+	if err := base.RemoveAllVersions(ctx); err != nil {
+		t.Fatalf("error removing all versions: %v", err)
+	}
+	keys, err := base.(*VaultPath).listKeys(ctx, base.(*VaultPath).Key())
+	if err != nil {
+		t.Fatalf("error listing keys: %v", err)
+	}
+	if len(keys) != 0 {
+		t.Errorf("expected all versions to be removed, got %v", keys)
+	}
+	if _, err := secret.ReadFile(ctx); err != nil {
+		t.Errorf("expected files outside the path to be kept, got %v", err)
+	}
+}
+
+func pathsToStrings(paths []Path) []string {
+	var s []string
+	for _, p := range paths {
+		s = append(s, p.Path())
+	}
+	sort.Strings(s)
+	return s
+}
@@ -18,6 +18,7 @@ package vfs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -92,6 +93,33 @@ type HasHash interface {
 	Hash(algorithm hashing.HashAlgorithm) (*hashing.Hash, error)
 }
 
+// ErrVersionConflict is returned by a conditional write when the file has changed since the expected version was read.
+var ErrVersionConflict = errors.New("file has been modified since it was read")
+
+// HasConditionalWrite is implemented by paths that support optimistic concurrency,
+// where a file is only replaced if nobody else has written it since it was read.
+type HasConditionalWrite interface {
+	// ReadFileVersion returns the contents of the file along with an opaque token identifying the version that was read.
+	// If the file did not exist, err = os.ErrNotExist
+	ReadFileVersion(ctx context.Context) ([]byte, string, error)
+
+	// WriteFileIfVersion replaces the file contents only if the file is still at the given version, and returns the new version.
+	// If the file has since been modified or removed, err = ErrVersionConflict
+	WriteFileIfVersion(ctx context.Context, data io.ReadSeeker, acl ACL, version string) (string, error)
+}
+
+// ErrNotModified is returned by a conditional read when the file is still at the expected version.
+var ErrNotModified = errors.New("file has not been modified")
+
+// HasConditionalRead is implemented by paths that can check whether a file has changed without downloading it again.
+// Versions are the tokens returned by HasConditionalWrite::ReadFileVersion.
+type HasConditionalRead interface {
+	// ReadFileIfModified returns the contents of the file and its new version, if the file is no longer at the given version.
+	// If the file is still at the given version, err = ErrNotModified
+	// If the file did not exist, err = os.ErrNotExist
+	ReadFileIfModified(ctx context.Context, version string) ([]byte, string, error)
+}
+
 func RelativePath(base Path, child Path) (string, error) {
 	basePath := base.Path()
 	childPath := child.Path()
@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/klog/v2"
+	"k8s.io/kops/util/pkg/hashing"
+)
+
+// WatchEventType is the kind of change reported by Watch.
+type WatchEventType string
+
+const (
+	// WatchEventAdded is reported for a file that did not exist when it was last checked.
+	WatchEventAdded WatchEventType = "Added"
+	// WatchEventModified is reported for a file whose contents changed.
+	WatchEventModified WatchEventType = "Modified"
+	// WatchEventDeleted is reported for a file that no longer exists.
+	WatchEventDeleted WatchEventType = "Deleted"
+)
+
+// WatchEvent is a change to a file under a watched path.
+type WatchEvent struct {
+	Type WatchEventType
+	Path Path
+}
+
+// DefaultWatchInterval is how often Watch lists the tree when the backend does not notify us of changes.
+const DefaultWatchInterval = time.Minute
+
+// HasChangeNotifications is implemented by paths whose backend can tell us when files may have changed,
+// so that Watch does not have to wait for its next poll.
+type HasChangeNotifications interface {
+	// NotifyChanges returns a channel that receives a value whenever files under the path may have changed,
+	// and is closed when ctx is done.
+	// It returns a nil channel if notifications are not configured for the path.
+	NotifyChanges(ctx context.Context) (<-chan struct{}, error)
+}
+
+// Watch reports changes to the files under prefix until ctx is done, when the returned channel is closed.
+// Changes are found by listing the tree and comparing the hashes of the files (the ETags, for object stores),
+// so a file is only downloaded if its backend does not list a hash.
+// The tree is listed every interval, and also as soon as the backend notifies us of a change, if it can.
+// Only changes after Watch returns are reported.
+func Watch(ctx context.Context, prefix Path, interval time.Duration) (<-chan WatchEvent, error) {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	versions, err := watchSnapshot(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications <-chan struct{}
+	if notifier, ok := prefix.(HasChangeNotifications); ok {
+		notifications, err = notifier.NotifyChanges(ctx)
+		if err != nil {
+			// Polling still finds every change, just later
+			klog.Warningf("unable to receive change notifications for %s, falling back to polling: %v", prefix, err)
+			notifications = nil
+		}
+	}
+
+	events := make(chan WatchEvent)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case _, ok := <-notifications:
+				if !ok {
+					notifications = nil
+					continue
+				}
+			}
+
+			current, err := watchSnapshot(ctx, prefix)
+			if err != nil {
+				klog.Warningf("error checking %s for changes: %v", prefix, err)
+				continue
+			}
+			for _, event := range diffWatchSnapshots(versions, current) {
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+			versions = current
+		}
+	}()
+
+	return events, nil
+}
+
+// watchEntry records the version of a file seen by Watch.
+type watchEntry struct {
+	path    Path
+	version string
+}
+
+// watchSnapshot lists the files under prefix, along with a string that changes whenever their contents do.
+func watchSnapshot(ctx context.Context, prefix Path) (map[string]watchEntry, error) {
+	files, err := prefix.ReadTree(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s: %w", prefix, err)
+	}
+
+	snapshot := make(map[string]watchEntry)
+	for _, file := range files {
+		version, err := watchVersion(ctx, file)
+		if err != nil {
+			// The file may have been removed since it was listed; the next poll will tell
+			klog.V(2).Infof("unable to determine version of %s: %v", file, err)
+			continue
+		}
+		snapshot[file.Path()] = watchEntry{path: file, version: version}
+	}
+	return snapshot, nil
+}
+
+func watchVersion(ctx context.Context, p Path) (string, error) {
+	if hasHash, ok := p.(HasHash); ok {
+		hash, err := hasHash.PreferredHash()
+		if err == nil && hash != nil {
+			return hash.String(), nil
+		}
+	}
+
+	b, err := p.ReadFile(ctx)
+	if err != nil {
+		return "", err
+	}
+	hash, err := hashing.HashAlgorithmSHA256.Hash(bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	return hash.String(), nil
+}
+
+// diffWatchSnapshots returns the events that take previous to current, ordered by path.
+func diffWatchSnapshots(previous, current map[string]watchEntry) []WatchEvent {
+	var events []WatchEvent
+	for key, entry := range current {
+		old, found := previous[key]
+		if !found {
+			events = append(events, WatchEvent{Type: WatchEventAdded, Path: entry.path})
+		} else if old.version != entry.version {
+			events = append(events, WatchEvent{Type: WatchEventModified, Path: entry.path})
+		}
+	}
+	for key, entry := range previous {
+		if _, found := current[key]; !found {
+			events = append(events, WatchEvent{Type: WatchEventDeleted, Path: entry.path})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Path.Path() < events[j].Path.Path()
+	})
+	return events
+}
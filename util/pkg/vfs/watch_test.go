@@ -0,0 +1,200 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vfs
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func expectWatchEvent(t *testing.T, events <-chan WatchEvent, eventType WatchEventType, p Path) {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatalf("watch closed while waiting for %s %s", eventType, p)
+		}
+		if event.Type != eventType || event.Path.Path() != p.Path() {
+			t.Fatalf("expected %s %s, got %s %s", eventType, p, event.Type, event.Path)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("timed out waiting for %s %s", eventType, p)
+	}
+}
+
+func TestWatchPolling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vfsContext := NewTestingVFSContext()
+	base, err := vfsContext.BuildVfsPath("memfs://tests/watch")
+	if err != nil {
+		t.Fatalf("error building path: %v", err)
+	}
+	existing := base.Join("private", "kubernetes-ca", "keyset.yaml")
+	if err := existing.WriteFile(ctx, bytes.NewReader([]byte("v1")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	events, err := Watch(ctx, base, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("error starting watch: %v", err)
+	}
+
+	added := base.Join("private", "etcd-clients-ca", "keyset.yaml")
+	if err := added.WriteFile(ctx, bytes.NewReader([]byte("v1")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	expectWatchEvent(t, events, WatchEventAdded, added)
+
+	if err := existing.WriteFile(ctx, bytes.NewReader([]byte("v2")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	expectWatchEvent(t, events, WatchEventModified, existing)
+
+	// Rewriting the same contents is not a change
+	if err := existing.WriteFile(ctx, bytes.NewReader([]byte("v2")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	if err := added.WriteFile(ctx, bytes.NewReader([]byte("v2")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	expectWatchEvent(t, events, WatchEventModified, added)
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchFSNotifications(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	base := NewFSPath(t.TempDir())
+	existing := base.Join("private", "kubernetes-ca", "keyset.yaml")
+	if err := existing.WriteFile(ctx, bytes.NewReader([]byte("v1")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+
+	// The poll interval is long enough that only a notification can produce the events in time
+	events, err := Watch(ctx, base, time.Hour)
+	if err != nil {
+		t.Fatalf("error starting watch: %v", err)
+	}
+
+	if err := existing.WriteFile(ctx, bytes.NewReader([]byte("v2")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	expectWatchEvent(t, events, WatchEventModified, existing)
+
+	// Files in directories created after the watch started are also seen
+	added := base.Join("private", "etcd-clients-ca", "keyset.yaml")
+	if err := added.WriteFile(ctx, bytes.NewReader([]byte("v1")), nil); err != nil {
+		t.Fatalf("error writing file: %v", err)
+	}
+	expectWatchEvent(t, events, WatchEventAdded, added)
+
+	if err := existing.Remove(ctx); err != nil {
+		t.Fatalf("error removing file: %v", err)
+	}
+	expectWatchEvent(t, events, WatchEventDeleted, existing)
+}
+
+func TestS3PathIsChangeEvent(t *testing.T) {
+	p := newS3Path(nil, "s3", "state-store", "cluster.example.com/pki", false)
+
+	grid := []struct {
+		body     string
+		expected bool
+	}{
+		{
+			body:     `{"source":"aws.s3","detail-type":"Object Created","detail":{"bucket":{"name":"state-store"},"object":{"key":"cluster.example.com/pki/private/kubernetes-ca/keyset.yaml"}}}`,
+			expected: true,
+		},
+		{
+			body:     `{"source":"aws.s3","detail-type":"Object Deleted","detail":{"bucket":{"name":"state-store"},"object":{"key":"cluster.example.com/pki/private/kubernetes-ca/keyset.yaml"}}}`,
+			expected: true,
+		},
+		{
+			body:     `{"source":"aws.s3","detail-type":"Object Created","detail":{"bucket":{"name":"state-store"},"object":{"key":"cluster.example.com/pki-backup/keyset.yaml"}}}`,
+			expected: false,
+		},
+		{
+			body:     `{"source":"aws.s3","detail-type":"Object Created","detail":{"bucket":{"name":"other-bucket"},"object":{"key":"cluster.example.com/pki/private/kubernetes-ca/keyset.yaml"}}}`,
+			expected: false,
+		},
+		{
+			body:     `{"source":"aws.s3","detail-type":"Object Restore Completed","detail":{"bucket":{"name":"state-store"},"object":{"key":"cluster.example.com/pki/private/kubernetes-ca/keyset.yaml"}}}`,
+			expected: false,
+		},
+		{
+			body:     `not json`,
+			expected: false,
+		},
+	}
+	for _, g := range grid {
+		if actual := p.isChangeEvent(g.body); actual != g.expected {
+			t.Errorf("isChangeEvent(%s) = %v, expected %v", g.body, actual, g.expected)
+		}
+	}
+
+	if region, err := sqsQueueRegion("https://sqs.eu-west-1.amazonaws.com/123456789012/kops-state-store"); err != nil || region != "eu-west-1" {
+		t.Errorf("unexpected region %q for queue url: %v", region, err)
+	}
+}
+
+func TestGSPathIsChangeNotification(t *testing.T) {
+	p := NewGSPath(nil, "state-store", "cluster.example.com/pki")
+
+	grid := []struct {
+		attributes map[string]string
+		expected   bool
+	}{
+		{
+			attributes: map[string]string{"bucketId": "state-store", "eventType": "OBJECT_FINALIZE", "objectId": "cluster.example.com/pki/private/kubernetes-ca/keyset.yaml"},
+			expected:   true,
+		},
+		{
+			attributes: map[string]string{"bucketId": "state-store", "eventType": "OBJECT_DELETE", "objectId": "cluster.example.com/pki/private/kubernetes-ca/keyset.yaml"},
+			expected:   true,
+		},
+		{
+			attributes: map[string]string{"bucketId": "state-store", "eventType": "OBJECT_FINALIZE", "objectId": "cluster.example.com/pki-backup/keyset.yaml"},
+			expected:   false,
+		},
+		{
+			attributes: map[string]string{"bucketId": "other-bucket", "eventType": "OBJECT_FINALIZE", "objectId": "cluster.example.com/pki/private/kubernetes-ca/keyset.yaml"},
+			expected:   false,
+		},
+		{
+			attributes: map[string]string{"bucketId": "state-store", "eventType": "OBJECT_METADATA_UPDATE", "objectId": "cluster.example.com/pki/private/kubernetes-ca/keyset.yaml"},
+			expected:   false,
+		},
+		{
+			attributes: nil,
+			expected:   false,
+		},
+	}
+	for _, g := range grid {
+		if actual := p.isChangeNotification(g.attributes); actual != g.expected {
+			t.Errorf("isChangeNotification(%v) = %v, expected %v", g.attributes, actual, g.expected)
+		}
+	}
+}